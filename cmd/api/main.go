@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/gin-gonic/gin"
@@ -8,13 +9,25 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/devleo-m/go-zero/internal/infrastructure"
+	"github.com/devleo-m/go-zero/internal/infrastructure/auth"
 	"github.com/devleo-m/go-zero/internal/infrastructure/config"
+	"github.com/devleo-m/go-zero/internal/infrastructure/http/health"
 	"github.com/devleo-m/go-zero/internal/infrastructure/http/middleware"
 	"github.com/devleo-m/go-zero/internal/infrastructure/http/routes"
 	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
 	userApp "github.com/devleo-m/go-zero/internal/modules/user/application"
+	userDomain "github.com/devleo-m/go-zero/internal/modules/user/domain"
+	userEmail "github.com/devleo-m/go-zero/internal/modules/user/infrastructure/email"
 	userHttp "github.com/devleo-m/go-zero/internal/modules/user/infrastructure/http"
+	userNotification "github.com/devleo-m/go-zero/internal/modules/user/infrastructure/notification"
 	userRepo "github.com/devleo-m/go-zero/internal/modules/user/infrastructure/postgres"
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+	"github.com/devleo-m/go-zero/internal/shared/circuitbreaker"
+	"github.com/devleo-m/go-zero/internal/shared/featureflag"
+	"github.com/devleo-m/go-zero/internal/shared/jsonnaming"
+	"github.com/devleo-m/go-zero/internal/shared/pagination"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+	"github.com/devleo-m/go-zero/internal/shared/validation"
 )
 
 func main() {
@@ -38,6 +51,14 @@ func main() {
 	// Configurar Gin mode
 	configureGinMode(cfg.App.Env)
 
+	// Limites de paginação compartilhados por todas as listagens
+	pagination.Configure(cfg.Pagination.DefaultLimit, cfg.Pagination.MaxLimit)
+	pagination.ConfigureMaxOffset(cfg.Pagination.MaxOffset)
+
+	// Convenção dos nomes de campos JSON (já validada em config.Load)
+	jsonNaming, _ := jsonnaming.Parse(cfg.App.JSONNaming)
+	jsonnaming.Configure(jsonNaming)
+
 	// Conectar ao banco de dados
 	db := setupDatabase(cfg, appLogger)
 	defer closeDatabase(db, appLogger)
@@ -47,7 +68,7 @@ func main() {
 	)
 
 	// Configurar handlers e rotas
-	router := setupRouter(cfg, db)
+	router := setupRouter(cfg, db, appLogger)
 
 	// Iniciar servidor
 	startServer(router, cfg.App.Port, appLogger)
@@ -95,7 +116,18 @@ func setupDatabase(cfg *config.Config, appLogger *logger.Logger) *infrastructure
 		dsn = buildDatabaseURL(cfg.Database)
 	}
 
-	db, err := infrastructure.NewDatabase(dsn)
+	dsn, err := infrastructure.WithStatementTimeout(dsn, cfg.Database.StatementTimeout)
+	if err != nil {
+		appLogger.Fatal("Invalid database configuration",
+			zap.Error(err),
+			zap.String("component", "database"),
+		)
+	}
+
+	db, err := infrastructure.NewDatabaseWithRetry(context.Background(), dsn, appLogger, infrastructure.ConnectRetryConfig{
+		Attempts: cfg.Database.ConnectAttempts,
+		Interval: cfg.Database.ConnectRetryInterval,
+	})
 	if err != nil {
 		appLogger.Fatal("Failed to connect to database",
 			zap.Error(err),
@@ -103,6 +135,36 @@ func setupDatabase(cfg *config.Config, appLogger *logger.Logger) *infrastructure
 		)
 	}
 
+	db.SetSlowQueryThreshold(cfg.Database.SlowQueryThreshold)
+
+	if err := db.ConfigurePool(infrastructure.PoolConfig{
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+	}); err != nil {
+		appLogger.Fatal("Failed to configure database connection pool",
+			zap.Error(err),
+			zap.String("component", "database"),
+		)
+	}
+
+	if cfg.Database.CircuitBreakerThreshold > 0 {
+		breaker := circuitbreaker.New(circuitbreaker.Config{
+			FailureThreshold: cfg.Database.CircuitBreakerThreshold,
+			Cooldown:         cfg.Database.CircuitBreakerCooldown,
+			IsFailure:        infrastructure.IsDatabaseFailure,
+			IsNeutral:        infrastructure.IsDatabaseNeutral,
+		})
+
+		if err := db.UseCircuitBreaker(breaker); err != nil {
+			appLogger.Fatal("Failed to enable database circuit breaker",
+				zap.Error(err),
+				zap.String("component", "database"),
+			)
+		}
+	}
+
 	return db
 }
 
@@ -116,17 +178,133 @@ func closeDatabase(db *infrastructure.Database, appLogger *logger.Logger) {
 	}
 }
 
+// setupCache escolhe a implementação de cache (contadores de rate limit,
+// totais de paginação etc.) e registra a escolha.
+func setupCache(cfg *config.Config, appLogger *logger.Logger) cache.Service {
+	if cfg.Redis.URL != "" {
+		// Ainda não há cliente Redis neste build: manter o comportamento
+		// funcional em memória e deixar explícito que o Redis foi ignorado
+		appLogger.Warn("Redis cache is not available in this build, falling back to in-memory cache",
+			zap.String("component", "cache"),
+			zap.String("backend", "memory"),
+		)
+
+		return cache.NewMemoryCache()
+	}
+
+	appLogger.Info("Using in-memory cache (REDIS_URL not configured)",
+		zap.String("component", "cache"),
+		zap.String("backend", "memory"),
+	)
+
+	return cache.NewMemoryCache()
+}
+
+// setupNotifications escolhe o serviço de notificações de segurança (SMS/push).
+// Sem provedor configurado, as notificações habilitadas são apenas registradas no log.
+func setupNotifications(cfg *config.Config, appLogger *logger.Logger) userApp.NotificationService {
+	if !cfg.Notification.Enabled {
+		return userApp.NullNotificationService{}
+	}
+
+	return userNotification.NewDispatcher(userNotification.Config{
+		SMS:    userNotification.NewLogSender(appLogger.Logger, "sms"),
+		Events: cfg.Notification.SecurityEvents,
+	})
+}
+
+// setupEmail escolhe como os emails transacionais são entregues: via SMTP ou,
+// com o driver noop (padrão), apenas registrados no log.
+func setupEmail(cfg *config.Config, appLogger *logger.Logger) userApp.EmailService {
+	emailLogger := appLogger.WithComponent("email").Logger
+
+	var sender userEmail.Sender = userEmail.NewNoopSender(emailLogger)
+	if cfg.SMTP.Driver == "smtp" {
+		sender = userEmail.NewSMTPSender(userEmail.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			User:     cfg.SMTP.User,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		})
+	}
+
+	return userEmail.NewService(sender, userEmail.NewRenderer(cfg.SMTP.TemplatesPath), emailLogger, cfg.App.Name)
+}
+
 // setupRouter configura e retorna o router com todas as rotas.
-func setupRouter(cfg *config.Config, db *infrastructure.Database) *gin.Engine {
+func setupRouter(cfg *config.Config, db *infrastructure.Database, appLogger *logger.Logger) *gin.Engine {
+	// Configurar cache
+	cacheService := setupCache(cfg, appLogger)
+
 	// Configurar repositórios
-	userRepository := userRepo.NewRepository(db.DB)
+	userRepository := userRepo.NewRepository(db.DB).
+		WithCountCache(cacheService, cfg.Pagination.CountCacheTTL).
+		WithQueryGuard(repository.QueryGuard{
+			MaxRows: cfg.Database.MaxQueryRows,
+			Strict:  cfg.Database.StrictQueryLimits,
+		}).
+		WithLogger(appLogger.Logger)
+
+	passwordHistoryRepository := userRepo.NewPasswordHistoryRepository(db.DB)
+	profileRepository := userRepo.NewProfileRepository(db.DB)
+	filterPresetRepository := userRepo.NewFilterPresetRepository(db.DB)
+
+	// Configurar serviços de domínio
+	passwordService := userDomain.NewPasswordService(cfg.Password.BcryptCost).
+		WithPolicy(validation.PasswordPolicy{
+			MinLength:       cfg.Password.MinLength,
+			RequiredClasses: cfg.Password.RequiredClasses,
+		})
+	notificationService := setupNotifications(cfg, appLogger)
 
 	// Configurar use cases
-	createUserUseCase := userApp.NewCreateUserUseCase(userRepository)
+	useCaseLogger := appLogger.WithComponent("user").Logger
+	createUserUseCase := userApp.NewCreateUserUseCase(userRepository, passwordService).
+		WithEmailDomainPolicy(userDomain.NewEmailDomainPolicy(cfg.App.AllowedEmailDomains, cfg.App.BlockedEmailDomains)).
+		WithProfiles(profileRepository).
+		WithLogger(useCaseLogger)
 	getUserUseCase := userApp.NewGetUserUseCase(userRepository)
+	getUsersBatchUseCase := userApp.NewGetUsersBatchUseCase(userRepository)
 	listUsersUseCase := userApp.NewListUsersUseCase(userRepository)
-	updateUserUseCase := userApp.NewUpdateUserUseCase(userRepository)
-	deleteUserUseCase := userApp.NewDeleteUserUseCase(userRepository)
+	updateUserUseCase := userApp.NewUpdateUserUseCase(userRepository).WithLogger(useCaseLogger)
+	deleteUserUseCase := userApp.NewDeleteUserUseCase(userRepository).WithLogger(useCaseLogger)
+	userMetadataUseCase := userApp.NewUserMetadataUseCase(userRepository).WithLogger(useCaseLogger)
+	userProfileUseCase := userApp.NewUserProfileUseCase(userRepository, profileRepository).WithLogger(useCaseLogger)
+	filterPresetUseCase := userApp.NewFilterPresetUseCase(filterPresetRepository, listUsersUseCase).WithLogger(useCaseLogger)
+	listInactiveUsersUseCase := userApp.NewListInactiveUsersUseCase(userRepository)
+	bulkUpdateStatusUseCase := userApp.NewBulkUpdateStatusUseCase(userRepository).WithLogger(useCaseLogger)
+	bulkUpdateRoleUseCase := userApp.NewBulkUpdateRoleUseCase(userRepository).WithLogger(useCaseLogger)
+	bulkDeleteUsersUseCase := userApp.NewBulkDeleteUsersUseCase(userRepository).WithLogger(useCaseLogger)
+	listUsersByEmailDomainUseCase := userApp.NewListUsersByEmailDomainUseCase(userRepository)
+	mergeUsersUseCase := userApp.NewMergeUsersUseCase(userRepository).WithLogger(useCaseLogger)
+	setUserStatusUseCase := userApp.NewSetUserStatusUseCase(userRepository).
+		WithNotifier(notificationService).
+		WithLogger(useCaseLogger)
+	changeRoleUseCase := userApp.NewChangeRoleUseCase(userRepository).
+		WithNotifier(notificationService).
+		WithLogger(useCaseLogger)
+	resendEmailUseCase := userApp.NewResendEmailUseCase(userRepository, setupEmail(cfg, appLogger)).
+		WithRateLimit(cacheService, cfg.SMTP.ResendLimit, cfg.SMTP.ResendWindow).
+		WithLogger(useCaseLogger)
+	jwtService := auth.NewJWTService(auth.Config{
+		Secret:           cfg.JWT.Secret,
+		Issuer:           cfg.JWT.Issuer,
+		Audience:         cfg.JWT.Audience,
+		AccessTokenTTL:   cfg.JWT.AccessTokenTTL,
+		RefreshTokenTTL:  cfg.JWT.RefreshTokenTTL,
+		Leeway:           cfg.JWT.Leeway,
+		ImpersonationTTL: cfg.Impersonation.TokenTTL,
+	})
+	impersonateUserUseCase := userApp.NewImpersonateUserUseCase(userRepository, jwtService).
+		WithAllowAdminTargets(cfg.Impersonation.AllowAdminTargets).
+		WithLogger(useCaseLogger)
+	changePasswordUseCase := userApp.NewChangePasswordUseCase(
+		userRepository,
+		passwordHistoryRepository,
+		passwordService,
+		cfg.Password.HistorySize,
+	).WithNotifier(notificationService).WithLogger(useCaseLogger)
 
 	// Configurar handlers
 	userHandler := userHttp.NewHandler(
@@ -135,24 +313,128 @@ func setupRouter(cfg *config.Config, db *infrastructure.Database) *gin.Engine {
 		listUsersUseCase,
 		updateUserUseCase,
 		deleteUserUseCase,
+		changePasswordUseCase,
+		userMetadataUseCase,
+		getUsersBatchUseCase,
+		userProfileUseCase,
+	)
+	userAdminHandler := userHttp.NewAdminHandler(
+		listInactiveUsersUseCase,
+		bulkUpdateStatusUseCase,
+		bulkUpdateRoleUseCase,
+		bulkDeleteUsersUseCase,
+		listUsersByEmailDomainUseCase,
+		mergeUsersUseCase,
+		setUserStatusUseCase,
+		resendEmailUseCase,
+		impersonateUserUseCase,
+		filterPresetUseCase,
+		changeRoleUseCase,
 	)
 
+	// Configurar health checks
+	healthCheckers := map[string]health.Checker{
+		"database": health.NewDatabaseChecker(db),
+		"schema":   health.NewSchemaChecker(db),
+	}
+	if db.Breaker != nil {
+		healthCheckers["database_circuit_breaker"] = health.NewCircuitBreakerChecker(db.Breaker)
+	}
+
+	// Fora dos componentes críticos, falhas do cache e o pool de conexões
+	// saturado deixam o serviço degraded
+	healthCheckers["cache"] = health.NewCacheChecker(cacheService)
+	healthCheckers["database_pool"] = health.NewDatabasePoolChecker(db.PoolStats)
+
+	healthHandler := health.NewHealthHandler(health.HealthHandlerConfig{
+		Checkers:           healthCheckers,
+		CriticalComponents: cfg.Health.CriticalComponents,
+		CheckTimeout:       cfg.Health.CheckTimeout,
+		CheckInterval:      cfg.Health.CheckInterval,
+		Version:            cfg.App.Version,
+	})
+	healthHandler.Start(context.Background())
+
 	// Configurar rate limiter
-	rateLimiter := middleware.NewRateLimiter(cfg.RateLimit.Requests, cfg.RateLimit.Window)
+	rateLimitFailureMode, err := cache.ParseFailureMode(cfg.RateLimit.CacheFailureMode)
+	if err != nil {
+		appLogger.Fatal("Invalid rate limit configuration", zap.Error(err))
+	}
+
+	rateLimiter := middleware.NewRateLimiter(cfg.RateLimit.Requests, cfg.RateLimit.Window).
+		WithStore(cacheService).
+		WithFailureMode(rateLimitFailureMode).
+		WithLogger(appLogger.WithComponent("rate_limit").Logger)
+
+	routeRateLimits, err := middleware.ParseRouteRateLimits(cfg.RateLimit.Routes)
+	if err != nil {
+		appLogger.Fatal("Invalid rate limit configuration", zap.Error(err))
+	}
+
+	for _, rule := range routeRateLimits {
+		rule.Limiter.
+			WithStore(cacheService).
+			WithFailureMode(rateLimitFailureMode).
+			WithLogger(appLogger.WithComponent("rate_limit").Logger)
+	}
 
 	// Configurar rotas
 	router := gin.New()
+
+	// Confiar apenas nos proxies configurados para que ClientIP() (usado no
+	// rate limiting e nos logs) não possa ser forjado via X-Forwarded-For
+	if err := router.SetTrustedProxies(cfg.App.TrustedProxies); err != nil {
+		appLogger.Fatal("Invalid trusted proxies configuration",
+			zap.Error(err),
+			zap.Strings("trusted_proxies", cfg.App.TrustedProxies),
+		)
+	}
+
 	routesConfig := &routes.Config{
 		JWT: routes.JWTConfig{
-			Secret: cfg.JWT.Secret,
+			Secret:           cfg.JWT.Secret,
+			Issuer:           cfg.JWT.Issuer,
+			Audience:         cfg.JWT.Audience,
+			AccessTokenTTL:   cfg.JWT.AccessTokenTTL,
+			RefreshTokenTTL:  cfg.JWT.RefreshTokenTTL,
+			Leeway:           cfg.JWT.Leeway,
+			ImpersonationTTL: cfg.Impersonation.TokenTTL,
 		},
+		JWTService: jwtService,
 		CORS: routes.CORSConfig{
-			AllowedOrigins: cfg.CORS.AllowedOrigins,
-			AllowedMethods: cfg.CORS.AllowedMethods,
-			AllowedHeaders: cfg.CORS.AllowedHeaders,
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			MaxAge:           cfg.CORS.MaxAge,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+		},
+		SecurityHeaders: middleware.SecurityHeadersConfig{
+			ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+			FrameOptions:          cfg.Security.FrameOptions,
+			ReferrerPolicy:        cfg.Security.ReferrerPolicy,
+			HSTSMaxAge:            cfg.Security.HSTSMaxAge,
+			HSTSEnabled:           cfg.Security.HSTSEnabled,
+			HSTSIncludeSubdomains: cfg.Security.HSTSIncludeSubdomains,
+			HSTSPreload:           cfg.Security.HSTSPreload,
+		},
+		Compression: middleware.CompressionConfig{
+			Enabled: cfg.Compression.Enabled,
+			MinSize: cfg.Compression.MinSize,
+			Level:   cfg.Compression.Level,
 		},
-		RateLimiter: rateLimiter,
-		UserHandler: userHandler,
+		RateLimiter:      rateLimiter,
+		RouteRateLimits:  routeRateLimits,
+		UserHandler:      userHandler,
+		UserAdminHandler: userAdminHandler,
+		HealthHandler:    healthHandler,
+		Logger:           appLogger,
+		DatabaseStats:    db.PoolStats,
+		RequestTimeout:   cfg.App.RequestTimeout,
+		FeatureFlags: featureflag.NewStore(map[string]bool{
+			featureflag.UserRegistration: cfg.App.RegistrationEnabled,
+			featureflag.MaintenanceMode:  cfg.App.MaintenanceMode,
+		}),
+		MaintenanceRetryAfter: cfg.App.MaintenanceRetryAfter,
 	}
 
 	routes.SetupRoutes(router, routesConfig)
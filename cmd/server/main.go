@@ -7,10 +7,12 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/devleo-m/go-zero/internal/infrastructure"
 	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
 	userApp "github.com/devleo-m/go-zero/internal/modules/user/application"
+	userDomain "github.com/devleo-m/go-zero/internal/modules/user/domain"
 	userHttp "github.com/devleo-m/go-zero/internal/modules/user/infrastructure/http"
 	userRepo "github.com/devleo-m/go-zero/internal/modules/user/infrastructure/postgres"
 )
@@ -37,10 +39,15 @@ func main() {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
 
+	// Sem proxies confiáveis, X-Forwarded-For é ignorado em ClientIP()
+	if err := router.SetTrustedProxies(nil); err != nil {
+		appLogger.Fatal("Failed to configure trusted proxies", zap.Error(err))
+	}
+
 	// Conectar ao banco de dados
 	dsn := getDatabaseDSN()
 
-	db, err := infrastructure.NewDatabase(dsn)
+	db, err := infrastructure.NewDatabase(dsn, appLogger)
 	if err != nil {
 		appLogger.Fatal("Failed to connect to database", zap.Error(err))
 	}
@@ -90,11 +97,24 @@ func getServerPort() string {
 func setupUserModule(router *gin.Engine, db *infrastructure.Database) {
 	userRepository := userRepo.NewRepository(db.DB)
 
-	createUserUseCase := userApp.NewCreateUserUseCase(userRepository)
+	profileRepository := userRepo.NewProfileRepository(db.DB)
+
+	passwordService := userDomain.NewPasswordService(bcrypt.DefaultCost)
+
+	createUserUseCase := userApp.NewCreateUserUseCase(userRepository, passwordService).WithProfiles(profileRepository)
 	getUserUseCase := userApp.NewGetUserUseCase(userRepository)
+	getUsersBatchUseCase := userApp.NewGetUsersBatchUseCase(userRepository)
 	listUsersUseCase := userApp.NewListUsersUseCase(userRepository)
 	updateUserUseCase := userApp.NewUpdateUserUseCase(userRepository)
 	deleteUserUseCase := userApp.NewDeleteUserUseCase(userRepository)
+	userMetadataUseCase := userApp.NewUserMetadataUseCase(userRepository)
+	userProfileUseCase := userApp.NewUserProfileUseCase(userRepository, profileRepository)
+	changePasswordUseCase := userApp.NewChangePasswordUseCase(
+		userRepository,
+		userRepo.NewPasswordHistoryRepository(db.DB),
+		passwordService,
+		userDomain.DefaultPasswordHistorySize,
+	)
 
 	userHandler := userHttp.NewHandler(
 		createUserUseCase,
@@ -102,6 +122,10 @@ func setupUserModule(router *gin.Engine, db *infrastructure.Database) {
 		listUsersUseCase,
 		updateUserUseCase,
 		deleteUserUseCase,
+		changePasswordUseCase,
+		userMetadataUseCase,
+		getUsersBatchUseCase,
+		userProfileUseCase,
 	)
 
 	userHttp.SetupRoutes(router, userHandler)
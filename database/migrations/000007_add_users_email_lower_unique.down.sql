@@ -0,0 +1,5 @@
+-- Migration Rollback: Remove case-insensitive unique email
+-- Description: Drops the LOWER(email) unique index
+-- Author: devleo-m
+
+DROP INDEX IF EXISTS idx_users_email_lower;
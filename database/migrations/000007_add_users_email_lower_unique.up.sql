@@ -0,0 +1,11 @@
+-- Migration: Add case-insensitive unique email
+-- Description: Canonicalize existing emails and enforce uniqueness on LOWER(email)
+-- Author: devleo-m
+
+-- Canonicalize existing emails (trim + lowercase)
+-- Note: fails if the table already holds emails that differ only by case;
+-- those duplicates must be resolved manually before running this migration.
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+
+-- Case-insensitive unique index (only for non-deleted users)
+CREATE UNIQUE INDEX idx_users_email_lower ON users (LOWER(email)) WHERE deleted_at IS NULL;
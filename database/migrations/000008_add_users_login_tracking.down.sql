@@ -0,0 +1,9 @@
+-- Migration Rollback: Remove login tracking from users
+-- Description: Drops last_login_at and login_count columns
+-- Author: devleo-m
+
+DROP INDEX IF EXISTS idx_users_last_login_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS last_login_at,
+    DROP COLUMN IF EXISTS login_count;
@@ -0,0 +1,10 @@
+-- Migration: Add login tracking to users
+-- Description: Adds last_login_at and login_count columns used by activity reports
+-- Author: devleo-m
+
+ALTER TABLE users
+    ADD COLUMN last_login_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN login_count INTEGER NOT NULL DEFAULT 0;
+
+-- Index for inactivity cohorts (users that never logged in or are inactive since a date)
+CREATE INDEX idx_users_last_login_at ON users(last_login_at);
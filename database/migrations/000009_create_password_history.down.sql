@@ -0,0 +1,5 @@
+-- Migration Rollback: Drop password history table
+-- Description: Removes the password_history table
+-- Author: devleo-m
+
+DROP TABLE IF EXISTS password_history;
@@ -0,0 +1,15 @@
+-- Migration: Create password history table
+-- Description: Stores previous password hashes per user to block password reuse
+-- Author: devleo-m
+
+CREATE TABLE password_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_password_history_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Index for fetching/pruning the most recent entries of a user
+CREATE INDEX idx_password_history_user_created ON password_history(user_id, created_at DESC);
@@ -0,0 +1,7 @@
+-- Migration Rollback: Remove created_by/updated_by from users
+-- Description: Drops created_by and updated_by columns
+-- Author: devleo-m
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS updated_by;
@@ -0,0 +1,8 @@
+-- Migration: Add created_by/updated_by to users
+-- Description: Records which actor (user ID or the "system" sentinel) created and last modified each user
+-- Author: devleo-m
+
+-- Existing rows have no known actor and are attributed to the system sentinel
+ALTER TABLE users
+    ADD COLUMN created_by VARCHAR(64) NOT NULL DEFAULT 'system',
+    ADD COLUMN updated_by VARCHAR(64) NOT NULL DEFAULT 'system';
@@ -0,0 +1,8 @@
+-- Migration Rollback: Remove metadata from users
+-- Description: Drops the metadata column and its index
+-- Author: devleo-m
+
+DROP INDEX IF EXISTS idx_users_metadata;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS metadata;
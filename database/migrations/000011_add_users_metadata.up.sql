@@ -0,0 +1,9 @@
+-- Migration: Add metadata to users
+-- Description: Stores free-form key/value attributes per user as JSONB, queryable by containment
+-- Author: devleo-m
+
+ALTER TABLE users
+    ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- jsonb_path_ops serves the @> containment queries used to filter by metadata
+CREATE INDEX idx_users_metadata ON users USING GIN (metadata jsonb_path_ops);
@@ -0,0 +1,5 @@
+-- Migration Rollback: Drop user profiles table
+-- Description: Removes the user_profiles table
+-- Author: devleo-m
+
+DROP TABLE IF EXISTS user_profiles;
@@ -0,0 +1,19 @@
+-- Migration: Create user profiles table
+-- Description: Stores presentation data (avatar, bio, locale) per user, one row per user
+-- Author: devleo-m
+
+CREATE TABLE user_profiles (
+    user_id UUID PRIMARY KEY,
+    avatar_url VARCHAR(2048) NOT NULL DEFAULT '',
+    bio VARCHAR(500) NOT NULL DEFAULT '',
+    locale VARCHAR(16) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_user_profiles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Empty profiles for users created before this table existed
+INSERT INTO user_profiles (user_id)
+SELECT id FROM users
+ON CONFLICT (user_id) DO NOTHING;
@@ -0,0 +1,5 @@
+-- Migration Rollback: Drop user filter presets table
+-- Description: Removes the user_filter_presets table
+-- Author: devleo-m
+
+DROP TABLE IF EXISTS user_filter_presets;
@@ -0,0 +1,15 @@
+-- Migration: Create user filter presets table
+-- Description: Stores named user listing filters (role, status, dates, search) saved by each admin
+-- Author: devleo-m
+
+CREATE TABLE user_filter_presets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id UUID NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    criteria JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_user_filter_presets_admin FOREIGN KEY (admin_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_user_filter_presets_admin_name UNIQUE (admin_id, name)
+);
@@ -0,0 +1,294 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// Tipos de token emitidos pelo serviço.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// TTLs padrão usados quando a configuração não informa valores.
+const (
+	DefaultAccessTokenTTL  = 24 * time.Hour
+	DefaultRefreshTokenTTL = 168 * time.Hour
+)
+
+// DefaultLeeway é a tolerância padrão a diferenças de relógio entre serviços.
+const DefaultLeeway = 30 * time.Second
+
+// DefaultImpersonationTTL é o tempo de vida padrão dos tokens de personificação.
+const DefaultImpersonationTTL = 15 * time.Minute
+
+// Erros de validação de token.
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrTokenExpired     = errors.New("token has expired")
+	ErrInvalidTokenType = errors.New("invalid token type")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("invalid token audience")
+	ErrNotRefreshable   = errors.New("token cannot be refreshed")
+)
+
+// Claims representa as claims do JWT.
+type Claims struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type"`
+	// ImpersonatorID é o ID do administrador que personifica UserID;
+	// vazio em tokens emitidos ao próprio usuário.
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Config representa a configuração do serviço de JWT.
+type Config struct {
+	// Clock é opcional; quando nil, o relógio do sistema é usado.
+	Clock  clock.Clock
+	Secret string
+	// Issuer e Audience, quando informados, são gravados nos tokens (iss/aud)
+	// e exigidos na validação, restringindo os tokens a este serviço.
+	Issuer          string
+	Audience        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// Leeway é a tolerância aplicada a exp, nbf e iat na validação.
+	// Zero usa DefaultLeeway; um valor negativo exige horários exatos.
+	Leeway time.Duration
+	// ImpersonationTTL é o tempo de vida dos tokens de personificação.
+	// Valores <= 0 usam DefaultImpersonationTTL.
+	ImpersonationTTL time.Duration
+}
+
+// TokenPair representa o par de tokens retornado na autenticação.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// JWTService gera e valida tokens JWT.
+type JWTService struct {
+	clock            clock.Clock
+	secret           []byte
+	issuer           string
+	audience         string
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
+	leeway           time.Duration
+	impersonationTTL time.Duration
+}
+
+// NewJWTService cria uma nova instância do serviço de JWT.
+func NewJWTService(config Config) *JWTService {
+	accessTokenTTL := config.AccessTokenTTL
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = DefaultAccessTokenTTL
+	}
+
+	refreshTokenTTL := config.RefreshTokenTTL
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = DefaultRefreshTokenTTL
+	}
+
+	leeway := config.Leeway
+	if leeway == 0 {
+		leeway = DefaultLeeway
+	}
+
+	impersonationTTL := config.ImpersonationTTL
+	if impersonationTTL <= 0 {
+		impersonationTTL = DefaultImpersonationTTL
+	}
+
+	return &JWTService{
+		clock:            clock.OrReal(config.Clock),
+		secret:           []byte(config.Secret),
+		issuer:           config.Issuer,
+		audience:         config.Audience,
+		accessTokenTTL:   accessTokenTTL,
+		refreshTokenTTL:  refreshTokenTTL,
+		leeway:           max(leeway, 0),
+		impersonationTTL: impersonationTTL,
+	}
+}
+
+// AccessTokenTTL retorna o tempo de vida do access token.
+func (s *JWTService) AccessTokenTTL() time.Duration {
+	return s.accessTokenTTL
+}
+
+// RefreshTokenTTL retorna o tempo de vida do refresh token.
+func (s *JWTService) RefreshTokenTTL() time.Duration {
+	return s.refreshTokenTTL
+}
+
+// ImpersonationTTL retorna o tempo de vida dos tokens de personificação.
+func (s *JWTService) ImpersonationTTL() time.Duration {
+	return s.impersonationTTL
+}
+
+// GenerateAccessToken gera um access token e retorna sua validade em segundos.
+func (s *JWTService) GenerateAccessToken(userID, email, role string) (string, int64, error) {
+	token, err := s.generate(&Claims{UserID: userID, Email: email, Role: role, TokenType: TokenTypeAccess}, s.accessTokenTTL)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return token, int64(s.accessTokenTTL.Seconds()), nil
+}
+
+// GenerateImpersonationToken gera um access token de curta duração em nome de
+// userID, registrando impersonatorID como o administrador responsável.
+// Nenhum refresh token acompanha a personificação, que expira sem renovação.
+func (s *JWTService) GenerateImpersonationToken(userID, email, role, impersonatorID string) (string, int64, error) {
+	token, err := s.generate(&Claims{
+		UserID:         userID,
+		Email:          email,
+		Role:           role,
+		TokenType:      TokenTypeAccess,
+		ImpersonatorID: impersonatorID,
+	}, s.impersonationTTL)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return token, int64(s.impersonationTTL.Seconds()), nil
+}
+
+// GenerateRefreshToken gera um refresh token.
+func (s *JWTService) GenerateRefreshToken(userID, email, role string) (string, error) {
+	return s.generate(&Claims{UserID: userID, Email: email, Role: role, TokenType: TokenTypeRefresh}, s.refreshTokenTTL)
+}
+
+// GenerateTokenPair gera um access token e um refresh token.
+func (s *JWTService) GenerateTokenPair(userID, email, role string) (*TokenPair, error) {
+	accessToken, expiresIn, err := s.GenerateAccessToken(userID, email, role)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.GenerateRefreshToken(userID, email, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    expiresIn,
+	}, nil
+}
+
+// RefreshTokens valida um refresh token e emite um novo par de tokens.
+func (s *JWTService) RefreshTokens(refreshToken string) (*TokenPair, error) {
+	claims, err := s.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Personificações nunca emitem refresh tokens; a checagem protege contra
+	// um token forjado ou emitido por outra versão do serviço
+	if claims.ImpersonatorID != "" {
+		return nil, ErrNotRefreshable
+	}
+
+	return s.GenerateTokenPair(claims.UserID, claims.Email, claims.Role)
+}
+
+// ValidateToken valida um access token e retorna suas claims.
+func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
+	return s.validate(tokenString, TokenTypeAccess)
+}
+
+// ValidateRefreshToken valida um refresh token e retorna suas claims.
+func (s *JWTService) ValidateRefreshToken(tokenString string) (*Claims, error) {
+	return s.validate(tokenString, TokenTypeRefresh)
+}
+
+// generate completa as claims registradas e assina o token com o tempo de vida informado.
+func (s *JWTService) generate(claims *Claims, ttl time.Duration) (string, error) {
+	now := s.clock.Now()
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Subject:   claims.UserID,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+
+	if s.issuer != "" {
+		claims.Issuer = s.issuer
+	}
+
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return token, nil
+}
+
+// validate faz o parse do token, verifica assinatura, expiração, emissor,
+// audiência e tipo. exp, nbf e iat (emitido no futuro) toleram o leeway.
+func (s *JWTService) validate(tokenString, tokenType string) (*Claims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithTimeFunc(s.clock.Now),
+		jwt.WithLeeway(s.leeway),
+		jwt.WithIssuedAt(),
+	}
+
+	if s.issuer != "" {
+		options = append(options, jwt.WithIssuer(s.issuer))
+	}
+
+	if s.audience != "" {
+		options = append(options, jwt.WithAudience(s.audience))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return s.secret, nil
+	}, options...)
+	if err != nil {
+		switch {
+		case errors.Is(err, jwt.ErrTokenExpired):
+			return nil, ErrTokenExpired
+		case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+			return nil, ErrInvalidIssuer
+		case errors.Is(err, jwt.ErrTokenInvalidAudience):
+			return nil, ErrInvalidAudience
+		}
+
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, ErrInvalidTokenType
+	}
+
+	return claims, nil
+}
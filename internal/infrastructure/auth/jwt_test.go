@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// newTestService cria um serviço com relógio fake e segredo fixo.
+func newTestService(config Config) (*JWTService, *clock.FakeClock) {
+	fake := clock.NewFakeClock(time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC))
+
+	config.Clock = fake
+	if config.Secret == "" {
+		config.Secret = "test-secret"
+	}
+
+	return NewJWTService(config), fake
+}
+
+func TestImpersonationTokenCarriesBothIdentities(t *testing.T) {
+	service, fake := newTestService(Config{ImpersonationTTL: 10 * time.Minute})
+
+	token, expiresIn, err := service.GenerateImpersonationToken("user-1", "user@example.com", "user", "admin-1")
+	if err != nil {
+		t.Fatalf("GenerateImpersonationToken: %v", err)
+	}
+
+	if expiresIn != 600 {
+		t.Errorf("expiresIn = %d, want 600", expiresIn)
+	}
+
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+
+	if claims.UserID != "user-1" || claims.Subject != "user-1" || claims.ImpersonatorID != "admin-1" {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	// Sem renovação: o token expira com o TTL de personificação
+	fake.Advance(10*time.Minute + DefaultLeeway + time.Second)
+
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken after TTL = %v, want %v", err, ErrTokenExpired)
+	}
+}
+
+func TestRefreshRejectsImpersonation(t *testing.T) {
+	service, _ := newTestService(Config{})
+
+	// Um refresh token com impersonator_id não é emitido pelo serviço, mas
+	// precisa ser recusado caso apareça
+	refresh, err := service.generate(&Claims{
+		UserID:         "user-1",
+		Role:           "user",
+		TokenType:      TokenTypeRefresh,
+		ImpersonatorID: "admin-1",
+	}, time.Hour)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	if _, err := service.RefreshTokens(refresh); !errors.Is(err, ErrNotRefreshable) {
+		t.Errorf("RefreshTokens = %v, want %v", err, ErrNotRefreshable)
+	}
+
+	access, _, err := service.GenerateImpersonationToken("user-1", "user@example.com", "user", "admin-1")
+	if err != nil {
+		t.Fatalf("GenerateImpersonationToken: %v", err)
+	}
+
+	if _, err := service.RefreshTokens(access); !errors.Is(err, ErrInvalidTokenType) {
+		t.Errorf("RefreshTokens with access token = %v, want %v", err, ErrInvalidTokenType)
+	}
+}
+
+func TestAccessTokenTTL(t *testing.T) {
+	// Leeway negativo exige horários exatos, isolando o efeito do TTL
+	service, fake := newTestService(Config{AccessTokenTTL: time.Second, Leeway: -1})
+
+	token, expiresIn, err := service.GenerateAccessToken("user-1", "user@example.com", "user")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken: %v", err)
+	}
+
+	if expiresIn != 1 {
+		t.Errorf("expiresIn = %d, want 1", expiresIn)
+	}
+
+	if _, err := service.ValidateToken(token); err != nil {
+		t.Fatalf("ValidateToken before expiry: %v", err)
+	}
+
+	fake.Advance(time.Second)
+
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken after TTL = %v, want %v", err, ErrTokenExpired)
+	}
+}
+
+func TestRefreshTokenTTL(t *testing.T) {
+	service, fake := newTestService(Config{AccessTokenTTL: time.Second, RefreshTokenTTL: time.Minute, Leeway: -1})
+
+	pair, err := service.GenerateTokenPair("user-1", "user@example.com", "user")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair: %v", err)
+	}
+
+	// O refresh continua válido depois que o access token expira
+	fake.Advance(30 * time.Second)
+
+	refreshed, err := service.RefreshTokens(pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshTokens within TTL: %v", err)
+	}
+
+	if refreshed.ExpiresIn != 1 {
+		t.Errorf("refreshed ExpiresIn = %d, want 1", refreshed.ExpiresIn)
+	}
+
+	fake.Advance(30 * time.Second)
+
+	if _, err := service.RefreshTokens(pair.RefreshToken); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("RefreshTokens after TTL = %v, want %v", err, ErrTokenExpired)
+	}
+}
+
+func TestValidateTokenIssuerAndAudience(t *testing.T) {
+	service, _ := newTestService(Config{Issuer: "go-zero", Audience: "go-zero-api"})
+
+	tests := []struct {
+		name   string
+		minter Config
+		want   error
+	}{
+		{name: "matching claims", minter: Config{Issuer: "go-zero", Audience: "go-zero-api"}},
+		{name: "different audience", minter: Config{Issuer: "go-zero", Audience: "billing-api"}, want: ErrInvalidAudience},
+		{name: "different issuer", minter: Config{Issuer: "billing", Audience: "go-zero-api"}, want: ErrInvalidIssuer},
+		// Sem aud, a claim exigida falta e o token é apenas inválido
+		{name: "missing audience", minter: Config{Issuer: "go-zero"}, want: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Serviço irmão com o mesmo segredo, emitindo tokens com outras claims
+			minter, _ := newTestService(tt.minter)
+
+			token, _, err := minter.GenerateAccessToken("user-1", "user@example.com", "user")
+			if err != nil {
+				t.Fatalf("GenerateAccessToken: %v", err)
+			}
+
+			if _, err := service.ValidateToken(token); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateToken = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTokenLeeway(t *testing.T) {
+	tests := []struct {
+		name   string
+		leeway time.Duration
+		// skew é quanto o relógio de quem valida está à frente (positivo, após
+		// a expiração) ou atrás (negativo, antes da emissão) de quem emitiu
+		skew time.Duration
+		want error
+	}{
+		{name: "expired a few seconds ago, within default leeway", skew: time.Minute + 5*time.Second},
+		{name: "expired beyond default leeway", skew: time.Minute + DefaultLeeway + time.Second, want: ErrTokenExpired},
+		{name: "expired within configured leeway", leeway: 10 * time.Second, skew: time.Minute + 9*time.Second},
+		{name: "expired beyond configured leeway", leeway: 10 * time.Second, skew: time.Minute + 11*time.Second, want: ErrTokenExpired},
+		{name: "exact validation", leeway: -1, skew: time.Minute + time.Second, want: ErrTokenExpired},
+		{name: "issued slightly in the future", skew: -20 * time.Second},
+		{name: "issued too far in the future", skew: -DefaultLeeway - time.Second, want: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minter, _ := newTestService(Config{AccessTokenTTL: time.Minute})
+
+			token, _, err := minter.GenerateAccessToken("user-1", "user@example.com", "user")
+			if err != nil {
+				t.Fatalf("GenerateAccessToken: %v", err)
+			}
+
+			// Mesmo segredo, outro relógio
+			validator, fake := newTestService(Config{AccessTokenTTL: time.Minute, Leeway: tt.leeway})
+			fake.Advance(tt.skew)
+
+			if _, err := validator.ValidateToken(token); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateToken = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
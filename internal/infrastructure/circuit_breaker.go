@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"github.com/devleo-m/go-zero/internal/shared/circuitbreaker"
+)
+
+// circuitBreakerAllowedKey marca, na instância da consulta, que o breaker liberou a execução.
+const circuitBreakerAllowedKey = "circuit_breaker:allowed"
+
+// Código do Postgres para consulta cancelada (statement_timeout ou cancelamento).
+const pgQueryCanceled = "57014"
+
+// IsDatabaseFailure indica se um erro do GORM representa indisponibilidade
+// do banco de dados. Só falhas de conexão e erros do servidor que indicam
+// indisponibilidade abrem o circuito; erros que o banco respondeu
+// normalmente (registro não encontrado, violação de unicidade, SQL inválido)
+// não contam.
+func IsDatabaseFailure(err error) bool {
+	if err == nil || IsDatabaseNeutral(err) {
+		return false
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound),
+		errors.Is(err, gorm.ErrDuplicatedKey),
+		errors.Is(err, gorm.ErrForeignKeyViolated),
+		errors.Is(err, gorm.ErrCheckConstraintViolated),
+		errors.Is(err, circuitbreaker.ErrOpen):
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return isUnavailableClass(pgErr.Code)
+	}
+
+	// Sem resposta do servidor: falha de conexão, rede ou driver
+	return true
+}
+
+// IsDatabaseNeutral indica se o erro veio do próprio chamador: contexto
+// cancelado, prazo esgotado ou statement_timeout. Esses erros não dizem
+// nada sobre a saúde do banco e não contam nem como falha nem como sucesso.
+func IsDatabaseNeutral(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgQueryCanceled {
+		return true
+	}
+
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// isUnavailableClass indica se o SQLSTATE pertence a uma classe de
+// indisponibilidade: conexão (08), recursos insuficientes (53), intervenção
+// do operador (57, exceto 57014), erro de sistema (58) ou erro interno (XX).
+func isUnavailableClass(code string) bool {
+	if len(code) < 2 || code == pgQueryCanceled {
+		return false
+	}
+
+	switch code[:2] {
+	case "08", "53", "57", "58", "XX":
+		return true
+	default:
+		return false
+	}
+}
+
+// UseCircuitBreaker registra callbacks no GORM para que todas as operações
+// passem pelo breaker: com o circuito aberto a consulta não é executada e
+// retorna circuitbreaker.ErrOpen.
+func (d *Database) UseCircuitBreaker(breaker *circuitbreaker.Breaker) error {
+	before := func(db *gorm.DB) {
+		if err := breaker.Allow(); err != nil {
+			_ = db.AddError(err)
+			return
+		}
+
+		db.InstanceSet(circuitBreakerAllowedKey, true)
+	}
+
+	after := func(db *gorm.DB) {
+		if allowed, ok := db.InstanceGet(circuitBreakerAllowedKey); ok && allowed == true {
+			breaker.Record(db.Error)
+		}
+	}
+
+	callbacks := d.DB.Callback()
+
+	err := errors.Join(
+		callbacks.Create().Before("gorm:create").Register("circuit_breaker:before_create", before),
+		callbacks.Create().After("gorm:create").Register("circuit_breaker:after_create", after),
+		callbacks.Query().Before("gorm:query").Register("circuit_breaker:before_query", before),
+		callbacks.Query().After("gorm:query").Register("circuit_breaker:after_query", after),
+		callbacks.Update().Before("gorm:update").Register("circuit_breaker:before_update", before),
+		callbacks.Update().After("gorm:update").Register("circuit_breaker:after_update", after),
+		callbacks.Delete().Before("gorm:delete").Register("circuit_breaker:before_delete", before),
+		callbacks.Delete().After("gorm:delete").Register("circuit_breaker:after_delete", after),
+		callbacks.Row().Before("gorm:row").Register("circuit_breaker:before_row", before),
+		callbacks.Row().After("gorm:row").Register("circuit_breaker:after_row", after),
+		callbacks.Raw().Before("gorm:raw").Register("circuit_breaker:before_raw", before),
+		callbacks.Raw().After("gorm:raw").Register("circuit_breaker:after_raw", after),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register circuit breaker callbacks: %w", err)
+	}
+
+	d.Breaker = breaker
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"github.com/devleo-m/go-zero/internal/shared/circuitbreaker"
+)
+
+func TestIsDatabaseFailure(t *testing.T) {
+	tests := []struct {
+		err     error
+		name    string
+		failure bool
+		neutral bool
+	}{
+		{name: "nil"},
+		{name: "record not found", err: gorm.ErrRecordNotFound},
+		{name: "duplicated key", err: gorm.ErrDuplicatedKey},
+		{name: "circuit open", err: circuitbreaker.ErrOpen},
+		{name: "syntax error", err: &pgconn.PgError{Code: "42601"}},
+		{name: "context canceled", err: fmt.Errorf("query: %w", context.Canceled), neutral: true},
+		{name: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), neutral: true},
+		{name: "statement timeout", err: fmt.Errorf("query: %w", &pgconn.PgError{Code: "57014"}), neutral: true},
+		{name: "bad connection", err: driver.ErrBadConn, failure: true},
+		{name: "network error", err: errors.New("dial tcp 127.0.0.1:5432: connect: connection refused"), failure: true},
+		{name: "connection failure", err: &pgconn.PgError{Code: "08006"}, failure: true},
+		{name: "too many connections", err: &pgconn.PgError{Code: "53300"}, failure: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, failure: true},
+		{name: "cannot connect now", err: &pgconn.PgError{Code: "57P03"}, failure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDatabaseFailure(tt.err); got != tt.failure {
+				t.Errorf("IsDatabaseFailure(%v) = %v, want %v", tt.err, got, tt.failure)
+			}
+
+			if got := tt.err != nil && IsDatabaseNeutral(tt.err); got != tt.neutral {
+				t.Errorf("IsDatabaseNeutral(%v) = %v, want %v", tt.err, got, tt.neutral)
+			}
+		})
+	}
+}
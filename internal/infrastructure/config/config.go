@@ -1,24 +1,33 @@
 package config
 
 import (
+	"errors"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 )
 
 type Config struct {
-	Database  DatabaseConfig
-	MongoDB   MongoDBConfig
-	Redis     RedisConfig
-	MinIO     MinIOConfig
-	SMTP      SMTPConfig
-	App       AppConfig
-	Stripe    StripeConfig
-	Logger    LoggerConfig
-	CORS      CORSConfig
-	JWT       JWTConfig
-	RateLimit RateLimitConfig
+	Database      DatabaseConfig
+	MongoDB       MongoDBConfig
+	Redis         RedisConfig
+	MinIO         MinIOConfig
+	SMTP          SMTPConfig
+	App           AppConfig
+	Stripe        StripeConfig
+	Logger        LoggerConfig
+	CORS          CORSConfig
+	JWT           JWTConfig
+	RateLimit     RateLimitConfig
+	Health        HealthConfig
+	Password      PasswordConfig
+	Pagination    PaginationConfig
+	Notification  NotificationConfig
+	Security      SecurityHeadersConfig
+	Compression   CompressionConfig
+	Impersonation ImpersonationConfig
 }
 
 type AppConfig struct {
@@ -26,6 +35,28 @@ type AppConfig struct {
 	Env     string
 	Port    string
 	Version string
+	// TrustedProxies lista IPs/CIDRs dos proxies confiáveis. Somente requisições
+	// vindas deles têm X-Forwarded-For/X-Real-IP considerados em ClientIP();
+	// vazio desativa a confiança, evitando que o cliente forje o próprio IP.
+	TrustedProxies []string
+	// RegistrationEnabled é o valor inicial da flag user_registration, que pode
+	// ser alterada em tempo de execução via PUT /api/v1/admin/system/config.
+	RegistrationEnabled bool
+	// MaintenanceMode é o valor inicial da flag maintenance_mode, que recusa
+	// escritas com 503 e também pode ser alterada pelo endpoint de configuração.
+	MaintenanceMode bool
+	// MaintenanceRetryAfter é o Retry-After sugerido durante a manutenção.
+	MaintenanceRetryAfter time.Duration
+	// AllowedEmailDomains restringe o cadastro a esses domínios (vazio aceita todos);
+	// BlockedEmailDomains é sempre recusado, ex.: provedores descartáveis.
+	AllowedEmailDomains []string
+	BlockedEmailDomains []string
+	// RequestTimeout é o tempo máximo de processamento das rotas da API;
+	// ao estourar, as queries em andamento são canceladas e o cliente recebe 504.
+	// Zero desativa o limite.
+	RequestTimeout time.Duration
+	// JSONNaming é a convenção dos nomes de campos JSON da API: snake ou camel.
+	JSONNaming string
 }
 
 type DatabaseConfig struct {
@@ -36,6 +67,48 @@ type DatabaseConfig struct {
 	Name     string
 	SSLMode  string
 	URL      string
+	// CircuitBreakerThreshold é o número de falhas consecutivas que abre o
+	// circuito do banco; 0 desativa o circuit breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+	// MaxQueryRows limita consultas de listagem sem paginação; com
+	// StrictQueryLimits essas consultas são rejeitadas.
+	MaxQueryRows      int
+	StrictQueryLimits bool
+	// Pool de conexões; MaxIdleConns acima de MaxOpenConns é reduzido pelo database/sql.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// StatementTimeout é o statement_timeout de cada conexão: o Postgres cancela
+	// consultas mais longas, independente do timeout HTTP. Zero desativa.
+	StatementTimeout time.Duration
+	// ConnectAttempts e ConnectRetryInterval controlam as tentativas de conexão
+	// na inicialização; a espera dobra a cada falha.
+	ConnectAttempts      int
+	ConnectRetryInterval time.Duration
+	// SlowQueryThreshold é a duração a partir da qual uma consulta é registrada
+	// como lenta; zero desativa o registro.
+	SlowQueryThreshold time.Duration
+}
+
+// SecurityHeadersConfig configura os cabeçalhos de segurança das respostas.
+// HSTS é habilitado por padrão apenas em produção.
+type SecurityHeadersConfig struct {
+	ContentSecurityPolicy string
+	FrameOptions          string
+	ReferrerPolicy        string
+	HSTSMaxAge            time.Duration
+	HSTSEnabled           bool
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+}
+
+// CompressionConfig configura a compressão gzip das respostas.
+type CompressionConfig struct {
+	Enabled bool
+	MinSize int
+	Level   int
 }
 
 type RedisConfig struct {
@@ -47,9 +120,13 @@ type RedisConfig struct {
 }
 
 type JWTConfig struct {
-	Secret                string
-	ExpiresIn             time.Duration
-	RefreshTokenExpiresIn time.Duration
+	Secret          string
+	Issuer          string
+	Audience        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// Leeway tolera diferenças de relógio na validação de exp, nbf e iat.
+	Leeway time.Duration
 }
 
 type MinIOConfig struct {
@@ -65,7 +142,15 @@ type SMTPConfig struct {
 	User     string
 	Password string
 	From     string
-	Port     int
+	// Driver define o envio de emails: "smtp" ou "noop" (apenas log).
+	Driver string
+	// TemplatesPath é um diretório opcional com templates que substituem os padrões.
+	TemplatesPath string
+	Port          int
+	// ResendLimit e ResendWindow limitam os reenvios de email feitos por
+	// administradores para um mesmo usuário.
+	ResendLimit  int
+	ResendWindow time.Duration
 }
 
 type StripeConfig struct {
@@ -84,6 +169,12 @@ type MongoDBConfig struct {
 }
 
 type RateLimitConfig struct {
+	// CacheFailureMode define se o rate limiting libera ("open") ou bloqueia
+	// ("closed") as requisições quando o cache está indisponível.
+	CacheFailureMode string
+	// Routes lista limites adicionais por grupo de rotas no formato
+	// "path=requests/window", aplicados além do limite global.
+	Routes   []string
 	Requests int
 	Window   time.Duration
 }
@@ -92,6 +183,49 @@ type CORSConfig struct {
 	AllowedOrigins []string
 	AllowedMethods []string
 	AllowedHeaders []string
+	// MaxAge é o tempo de cache do preflight (Access-Control-Max-Age).
+	MaxAge time.Duration
+	// AllowCredentials não pode ser combinado com a origem "*".
+	AllowCredentials bool
+}
+
+type NotificationConfig struct {
+	SecurityEvents []string
+	Enabled        bool
+}
+
+type PaginationConfig struct {
+	CountCacheTTL time.Duration
+	// DefaultLimit e MaxLimit valem para todas as listagens paginadas.
+	DefaultLimit int
+	MaxLimit     int
+	// MaxOffset é quantos registros uma listagem pode pular (0 = sem limite).
+	MaxOffset int
+}
+
+type PasswordConfig struct {
+	BcryptCost int
+	// HistorySize é a quantidade de senhas anteriores que não podem ser reutilizadas.
+	HistorySize int
+	// MinLength e RequiredClasses formam a política de força de senhas,
+	// aplicada igualmente no cadastro e na troca de senha.
+	MinLength       int
+	RequiredClasses int
+}
+
+// ImpersonationConfig controla a personificação de usuários por administradores.
+type ImpersonationConfig struct {
+	// TokenTTL é o tempo de vida do token de personificação, que não é renovável.
+	TokenTTL time.Duration
+	// AllowAdminTargets permite personificar administradores; o alvo ainda precisa
+	// ter papel inferior ao de quem personifica.
+	AllowAdminTargets bool
+}
+
+type HealthConfig struct {
+	CriticalComponents []string
+	CheckTimeout       time.Duration
+	CheckInterval      time.Duration
 }
 
 type LoggerConfig struct {
@@ -99,13 +233,26 @@ type LoggerConfig struct {
 	Format string
 }
 
+// ErrCORSWildcardWithCredentials indica a combinação inválida de origem "*" com credenciais.
+var ErrCORSWildcardWithCredentials = errors.New("wildcard CORS origin cannot be combined with credentials")
+
 func Load() (*Config, error) {
-	return &Config{
+	env := getEnv("APP_ENV", "development")
+
+	cfg := &Config{
 		App: AppConfig{
-			Name:    getEnv("APP_NAME", "go-zero"),
-			Env:     getEnv("APP_ENV", "development"),
-			Port:    getEnv("APP_PORT", "8080"),
-			Version: getEnv("APP_VERSION", "1.0.0"),
+			Name:                  getEnv("APP_NAME", "go-zero"),
+			Env:                   env,
+			Port:                  getEnv("APP_PORT", "8080"),
+			Version:               getEnv("APP_VERSION", "1.0.0"),
+			RegistrationEnabled:   getEnvAsBool("USER_REGISTRATION_ENABLED", true),
+			MaintenanceMode:       getEnvAsBool("APP_MAINTENANCE_MODE", false),
+			MaintenanceRetryAfter: getEnvAsDuration("APP_MAINTENANCE_RETRY_AFTER", 5*time.Minute),
+			TrustedProxies:        getEnvAsSlice("TRUSTED_PROXIES", nil),
+			AllowedEmailDomains:   getEnvAsSlice("USER_ALLOWED_EMAIL_DOMAINS", nil),
+			BlockedEmailDomains:   getEnvAsSlice("USER_BLOCKED_EMAIL_DOMAINS", nil),
+			RequestTimeout:        getEnvAsDuration("APP_REQUEST_TIMEOUT", 30*time.Second),
+			JSONNaming:            getEnv("API_JSON_NAMING", "snake"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -115,6 +262,19 @@ func Load() (*Config, error) {
 			Name:     getEnv("DB_NAME", "go_zero"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 			URL:      getEnv("DATABASE_URL", ""),
+
+			CircuitBreakerThreshold: getEnvAsInt("DB_CIRCUIT_BREAKER_THRESHOLD", 5),
+			CircuitBreakerCooldown:  getEnvAsDuration("DB_CIRCUIT_BREAKER_COOLDOWN", 30*time.Second),
+			MaxQueryRows:            getEnvAsInt("DB_MAX_QUERY_ROWS", 1000),
+			StrictQueryLimits:       getEnvAsBool("DB_STRICT_QUERY_LIMITS", false),
+			MaxOpenConns:            getEnvAsInt("DB_MAX_OPEN_CONNS", 100),
+			MaxIdleConns:            getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime:         getEnvAsDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime:         getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			StatementTimeout:        getEnvAsDuration("DB_STATEMENT_TIMEOUT", 30*time.Second),
+			ConnectAttempts:         getEnvAsInt("DB_CONNECT_ATTEMPTS", 10),
+			ConnectRetryInterval:    getEnvAsDuration("DB_CONNECT_RETRY_INTERVAL", time.Second),
+			SlowQueryThreshold:      getEnvAsDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
@@ -124,9 +284,12 @@ func Load() (*Config, error) {
 			URL:      getEnv("REDIS_URL", ""),
 		},
 		JWT: JWTConfig{
-			Secret:                getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-in-production"),
-			ExpiresIn:             getEnvAsDuration("JWT_EXPIRES_IN", 24*time.Hour),
-			RefreshTokenExpiresIn: getEnvAsDuration("REFRESH_TOKEN_EXPIRES_IN", 168*time.Hour),
+			Secret:          getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-in-production"),
+			Issuer:          getEnv("JWT_ISSUER", "go-zero"),
+			Audience:        getEnv("JWT_AUDIENCE", "go-zero-api"),
+			AccessTokenTTL:  getEnvAsDuration("JWT_ACCESS_TOKEN_TTL", getEnvAsDuration("JWT_EXPIRES_IN", 24*time.Hour)),
+			RefreshTokenTTL: getEnvAsDuration("JWT_REFRESH_TOKEN_TTL", getEnvAsDuration("REFRESH_TOKEN_EXPIRES_IN", 168*time.Hour)),
+			Leeway:          getEnvAsDuration("JWT_LEEWAY", 30*time.Second),
 		},
 		MinIO: MinIOConfig{
 			Endpoint:  getEnv("MINIO_ENDPOINT", "localhost:9000"),
@@ -136,11 +299,15 @@ func Load() (*Config, error) {
 			Bucket:    getEnv("MINIO_BUCKET", "go-zero"),
 		},
 		SMTP: SMTPConfig{
-			Host:     getEnv("SMTP_HOST", "localhost"),
-			Port:     getEnvAsInt("SMTP_PORT", 1025),
-			User:     getEnv("SMTP_USER", ""),
-			Password: getEnv("SMTP_PASSWORD", ""),
-			From:     getEnv("SMTP_FROM", "noreply@go-zero.dev"),
+			Host:          getEnv("SMTP_HOST", "localhost"),
+			Port:          getEnvAsInt("SMTP_PORT", 1025),
+			User:          getEnv("SMTP_USER", ""),
+			Password:      getEnv("SMTP_PASSWORD", ""),
+			From:          getEnv("SMTP_FROM", "noreply@go-zero.dev"),
+			Driver:        getEnv("EMAIL_DRIVER", "noop"),
+			TemplatesPath: getEnv("EMAIL_TEMPLATES_PATH", ""),
+			ResendLimit:   getEnvAsInt("EMAIL_RESEND_LIMIT", 3),
+			ResendWindow:  getEnvAsDuration("EMAIL_RESEND_WINDOW", time.Hour),
 		},
 		Stripe: StripeConfig{
 			SecretKey:      getEnv("STRIPE_SECRET_KEY", ""),
@@ -158,17 +325,71 @@ func Load() (*Config, error) {
 		RateLimit: RateLimitConfig{
 			Requests: getEnvAsInt("RATE_LIMIT_REQUESTS", 100),
 			Window:   getEnvAsDuration("RATE_LIMIT_WINDOW", time.Minute),
+
+			CacheFailureMode: getEnv("RATE_LIMIT_CACHE_FAILURE_MODE", "open"),
+			Routes:           getEnvAsSlice("RATE_LIMIT_ROUTES", []string{"/api/v1/admin/stats=10/1m"}),
 		},
 		CORS: CORSConfig{
-			AllowedOrigins: getEnvAsSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:8080"}),
-			AllowedMethods: getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-			AllowedHeaders: getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization", "X-Requested-With"}),
+			AllowedOrigins:   getEnvAsSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:8080"}),
+			AllowedMethods:   getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
+			AllowedHeaders:   getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization", "X-Requested-With"}),
+			MaxAge:           getEnvAsDuration("CORS_MAX_AGE", time.Hour),
+			AllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", true),
+		},
+		Security: SecurityHeadersConfig{
+			ContentSecurityPolicy: getEnv("SECURITY_CSP", "default-src 'self'; frame-ancestors 'none'"),
+			FrameOptions:          getEnv("SECURITY_FRAME_OPTIONS", "DENY"),
+			ReferrerPolicy:        getEnv("SECURITY_REFERRER_POLICY", "strict-origin-when-cross-origin"),
+			HSTSEnabled:           getEnvAsBool("SECURITY_HSTS_ENABLED", env == "production"),
+			HSTSMaxAge:            getEnvAsDuration("SECURITY_HSTS_MAX_AGE", 365*24*time.Hour),
+			HSTSIncludeSubdomains: getEnvAsBool("SECURITY_HSTS_INCLUDE_SUBDOMAINS", true),
+			HSTSPreload:           getEnvAsBool("SECURITY_HSTS_PRELOAD", false),
+		},
+		Compression: CompressionConfig{
+			Enabled: getEnvAsBool("COMPRESSION_ENABLED", true),
+			MinSize: getEnvAsInt("COMPRESSION_MIN_SIZE", 1024),
+			Level:   getEnvAsInt("COMPRESSION_LEVEL", -1),
 		},
 		Logger: LoggerConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
-	}, nil
+		Notification: NotificationConfig{
+			Enabled:        getEnvAsBool("NOTIFICATION_ENABLED", false),
+			SecurityEvents: getEnvAsSlice("NOTIFICATION_SECURITY_EVENTS", []string{"new_device_login", "password_changed", "role_elevated", "status_changed"}),
+		},
+		Pagination: PaginationConfig{
+			CountCacheTTL: getEnvAsDuration("PAGINATION_COUNT_CACHE_TTL", 0),
+			DefaultLimit:  getEnvAsInt("PAGINATION_DEFAULT_LIMIT", 10),
+			MaxLimit:      getEnvAsInt("PAGINATION_MAX_LIMIT", 100),
+			MaxOffset:     getEnvAsInt("PAGINATION_MAX_OFFSET", 10000),
+		},
+		Password: PasswordConfig{
+			BcryptCost:      getEnvAsInt("BCRYPT_COST", 10),
+			HistorySize:     getEnvAsInt("PASSWORD_HISTORY_SIZE", 5),
+			MinLength:       getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+			RequiredClasses: getEnvAsInt("PASSWORD_REQUIRED_CLASSES", 3),
+		},
+		Health: HealthConfig{
+			CriticalComponents: getEnvAsSlice("HEALTH_CRITICAL_COMPONENTS", []string{"database", "schema"}),
+			CheckTimeout:       getEnvAsDuration("HEALTH_CHECK_TIMEOUT", 5*time.Second),
+			CheckInterval:      getEnvAsDuration("HEALTH_CHECK_INTERVAL", 30*time.Second),
+		},
+		Impersonation: ImpersonationConfig{
+			TokenTTL:          getEnvAsDuration("IMPERSONATION_TOKEN_TTL", 15*time.Minute),
+			AllowAdminTargets: getEnvAsBool("IMPERSONATION_ALLOW_ADMIN_TARGETS", false),
+		},
+	}
+
+	if cfg.CORS.AllowCredentials && slices.Contains(cfg.CORS.AllowedOrigins, "*") {
+		return nil, ErrCORSWildcardWithCredentials
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadRejectsWildcardCORSWithCredentials(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "*")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+	if _, err := Load(); !errors.Is(err, ErrCORSWildcardWithCredentials) {
+		t.Errorf("Load = %v, want %v", err, ErrCORSWildcardWithCredentials)
+	}
+}
+
+const strongSecret = "0123456789abcdef0123456789abcdef"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		mutate     func(*Config)
+		wantFields []string
+	}{
+		{name: "development defaults", mutate: func(*Config) {}},
+		{
+			name: "production with real secrets",
+			mutate: func(c *Config) {
+				c.App.Env = "production"
+				c.JWT.Secret = strongSecret
+				c.Database.Password = "s3cr3t-db-password"
+			},
+		},
+		{name: "missing JWT secret", mutate: func(c *Config) { c.JWT.Secret = "" }, wantFields: []string{"JWT_SECRET"}},
+		{name: "short JWT secret", mutate: func(c *Config) { c.JWT.Secret = "short" }, wantFields: []string{"JWT_SECRET"}},
+		{name: "invalid port", mutate: func(c *Config) { c.App.Port = "70000" }, wantFields: []string{"APP_PORT"}},
+		{name: "non-positive token TTL", mutate: func(c *Config) { c.JWT.AccessTokenTTL = 0 }, wantFields: []string{"JWT_ACCESS_TOKEN_TTL"}},
+		{
+			name: "missing database fields",
+			mutate: func(c *Config) {
+				c.Database.Host, c.Database.User, c.Database.Name = "", "", ""
+			},
+			wantFields: []string{"DB_HOST", "DB_USER", "DB_NAME"},
+		},
+		{
+			name: "DATABASE_URL replaces the individual fields",
+			mutate: func(c *Config) {
+				c.Database.URL = "postgres://app@db/app"
+				c.Database.Host, c.Database.User, c.Database.Name = "", "", ""
+			},
+		},
+		{
+			name:       "production with example defaults",
+			mutate:     func(c *Config) { c.App.Env = "production" },
+			wantFields: []string{"JWT_SECRET", "DB_PASSWORD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			tt.mutate(cfg)
+
+			err = cfg.Validate()
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Errorf("Validate = %v, want nil", err)
+				}
+
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("Validate = %v, want *ValidationError", err)
+			}
+
+			got := make([]string, len(validationErr.Fields))
+			for i, field := range validationErr.Fields {
+				got[i] = field.Field
+			}
+
+			// Todos os problemas aparecem juntos, na ordem em que são verificados
+			if !slices.Equal(got, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestLoadFailsFastOnInvalidEnvironment(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("JWT_SECRET", "short")
+	t.Setenv("DB_PASSWORD", "postgres")
+
+	_, err := Load()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Load = %v, want %v", err, ErrInvalidConfig)
+	}
+
+	for _, field := range []string{"JWT_SECRET", "DB_PASSWORD"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error %q does not mention %s", err, field)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/devleo-m/go-zero/internal/shared/jsonnaming"
+)
+
+// MinJWTSecretLength é o tamanho mínimo do segredo usado para assinar os tokens.
+const MinJWTSecretLength = 32
+
+// ErrInvalidConfig indica uma configuração ausente ou inválida.
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// insecureJWTSecrets são os segredos de exemplo, recusados em produção.
+var insecureJWTSecrets = []string{
+	"your-super-secret-jwt-key-change-in-production",
+	"your-super-secret-jwt-key-change-in-production-123456789",
+}
+
+// insecureDatabasePasswords são senhas padrão recusadas em produção.
+var insecureDatabasePasswords = []string{"", "postgres", "password"}
+
+// FieldError descreve um problema em uma variável de ambiente.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError reúne todos os problemas encontrados na configuração.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", ErrInvalidConfig, strings.Join(messages, "; "))
+}
+
+// Unwrap permite comparar com errors.Is(err, ErrInvalidConfig).
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// Validate verifica os campos obrigatórios e, em produção, recusa os valores
+// padrão inseguros. Todos os problemas são retornados juntos em *ValidationError.
+func (c *Config) Validate() error {
+	var fields []FieldError
+
+	invalid := func(field, message string) {
+		fields = append(fields, FieldError{Field: field, Message: message})
+	}
+
+	if port, err := strconv.Atoi(c.App.Port); err != nil || port < 1 || port > 65535 {
+		invalid("APP_PORT", "must be a port number between 1 and 65535")
+	}
+
+	if _, err := jsonnaming.Parse(c.App.JSONNaming); err != nil {
+		invalid("API_JSON_NAMING", "must be snake or camel")
+	}
+
+	switch {
+	case c.JWT.Secret == "":
+		invalid("JWT_SECRET", "is required")
+	case len(c.JWT.Secret) < MinJWTSecretLength:
+		invalid("JWT_SECRET", fmt.Sprintf("must have at least %d characters", MinJWTSecretLength))
+	}
+
+	if c.JWT.AccessTokenTTL <= 0 {
+		invalid("JWT_ACCESS_TOKEN_TTL", "must be a positive duration")
+	}
+
+	if c.JWT.RefreshTokenTTL <= 0 {
+		invalid("JWT_REFRESH_TOKEN_TTL", "must be a positive duration")
+	}
+
+	if c.Database.StatementTimeout < 0 {
+		invalid("DB_STATEMENT_TIMEOUT", "must not be negative")
+	}
+
+	if c.Database.ConnectAttempts < 1 {
+		invalid("DB_CONNECT_ATTEMPTS", "must be at least 1")
+	}
+
+	if c.Database.ConnectRetryInterval < 0 {
+		invalid("DB_CONNECT_RETRY_INTERVAL", "must not be negative")
+	}
+
+	if c.Database.SlowQueryThreshold < 0 {
+		invalid("DB_SLOW_QUERY_THRESHOLD", "must not be negative")
+	}
+
+	if c.Impersonation.TokenTTL <= 0 {
+		invalid("IMPERSONATION_TOKEN_TTL", "must be a positive duration")
+	}
+
+	// DATABASE_URL, quando definida, substitui as variáveis individuais
+	if c.Database.URL == "" {
+		if c.Database.Host == "" {
+			invalid("DB_HOST", "is required when DATABASE_URL is not set")
+		}
+
+		if c.Database.User == "" {
+			invalid("DB_USER", "is required when DATABASE_URL is not set")
+		}
+
+		if c.Database.Name == "" {
+			invalid("DB_NAME", "is required when DATABASE_URL is not set")
+		}
+	}
+
+	if c.App.Env == "production" {
+		if slices.Contains(insecureJWTSecrets, c.JWT.Secret) {
+			invalid("JWT_SECRET", "must not use the example value in production")
+		}
+
+		if c.Database.URL == "" && slices.Contains(insecureDatabasePasswords, c.Database.Password) {
+			invalid("DB_PASSWORD", "must be set to a non-default value in production")
+		}
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+)
+
+// MaxConnectRetryInterval limita a espera entre tentativas de conexão, que
+// dobra a cada falha.
+const MaxConnectRetryInterval = 30 * time.Second
+
+// ConnectRetryConfig configura as tentativas de conexão na inicialização, para
+// que o serviço aguarde o banco subir (compose, Kubernetes) em vez de reiniciar
+// em loop. Attempts < 1 equivale a uma única tentativa.
+type ConnectRetryConfig struct {
+	Attempts int
+	// Interval é a espera após a primeira falha; dobra a cada nova falha,
+	// até MaxConnectRetryInterval.
+	Interval time.Duration
+}
+
+// NewDatabaseWithRetry conecta como NewDatabase, repetindo a tentativa com
+// backoff exponencial enquanto o banco não estiver acessível.
+func NewDatabaseWithRetry(ctx context.Context, dsn string, appLogger *logger.Logger, retry ConnectRetryConfig) (*Database, error) {
+	return connectWithRetry(ctx, retry, appLogger, func() (*Database, error) {
+		return NewDatabase(dsn, appLogger)
+	})
+}
+
+// connectWithRetry chama connect até que ele tenha sucesso, as tentativas se
+// esgotem ou ctx seja cancelado. Cada falha é registrada em appLogger.
+func connectWithRetry(
+	ctx context.Context,
+	retry ConnectRetryConfig,
+	appLogger *logger.Logger,
+	connect func() (*Database, error),
+) (*Database, error) {
+	attempts := max(retry.Attempts, 1)
+	wait := retry.Interval
+
+	for attempt := 1; ; attempt++ {
+		database, err := connect()
+		if err == nil {
+			return database, nil
+		}
+
+		if attempt == attempts {
+			return nil, fmt.Errorf("database unavailable after %d attempts: %w", attempts, err)
+		}
+
+		if appLogger != nil {
+			appLogger.Warn("Database connection attempt failed, retrying",
+				zap.Error(err),
+				zap.Int("attempt", attempt),
+				zap.Int("max_attempts", attempts),
+				zap.Duration("retry_in", wait),
+				zap.String("component", "database"),
+			)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("database connection aborted: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		wait = min(wait*2, MaxConnectRetryInterval)
+	}
+}
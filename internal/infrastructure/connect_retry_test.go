@@ -0,0 +1,112 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+)
+
+var errConnectionRefused = errors.New("connection refused")
+
+// fakeConnector falha nas primeiras failures chamadas e depois conecta.
+type fakeConnector struct {
+	database *Database
+	failures int
+	calls    int
+}
+
+func (f *fakeConnector) connect() (*Database, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errConnectionRefused
+	}
+
+	return f.database, nil
+}
+
+func TestConnectWithRetryWaitsForDatabase(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	connector := &fakeConnector{database: &Database{}, failures: 2}
+
+	database, err := connectWithRetry(context.Background(),
+		ConnectRetryConfig{Attempts: 5, Interval: time.Millisecond},
+		&logger.Logger{Logger: zap.New(core)},
+		connector.connect,
+	)
+	if err != nil {
+		t.Fatalf("connectWithRetry: %v", err)
+	}
+
+	if database != connector.database || connector.calls != 3 {
+		t.Errorf("connected after %d calls, want 3", connector.calls)
+	}
+
+	// Uma entrada por falha, com a espera dobrando a cada tentativa
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("got %d log entries, want 2", len(entries))
+	}
+
+	for i, entry := range entries {
+		fields := entry.ContextMap()
+		wantWait := time.Millisecond << i
+
+		if fields["attempt"] != int64(i+1) || fields["max_attempts"] != int64(5) || fields["retry_in"] != wantWait {
+			t.Errorf("entry %d fields = %v, want attempt %d retrying in %v", i, fields, i+1, wantWait)
+		}
+	}
+}
+
+func TestConnectWithRetryGivesUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    ConnectRetryConfig
+		wantCalls int
+	}{
+		{name: "attempts exhausted", config: ConnectRetryConfig{Attempts: 3, Interval: time.Millisecond}, wantCalls: 3},
+		{name: "zero attempts try once", config: ConnectRetryConfig{}, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &fakeConnector{failures: 10}
+
+			_, err := connectWithRetry(context.Background(), tt.config, nil, connector.connect)
+			if !errors.Is(err, errConnectionRefused) {
+				t.Errorf("connectWithRetry = %v, want %v", err, errConnectionRefused)
+			}
+
+			if connector.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", connector.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestConnectWithRetryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	connector := &fakeConnector{failures: 10}
+
+	// A primeira falha cancela o contexto; a espera de uma hora não acontece
+	connect := func() (*Database, error) {
+		cancel()
+
+		return connector.connect()
+	}
+
+	_, err := connectWithRetry(ctx, ConnectRetryConfig{Attempts: 5, Interval: time.Hour}, nil, connect)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("connectWithRetry = %v, want %v", err, context.Canceled)
+	}
+
+	if connector.calls != 1 {
+		t.Errorf("calls = %d, want 1", connector.calls)
+	}
+}
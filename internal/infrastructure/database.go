@@ -1,37 +1,202 @@
 package infrastructure
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	migrateDatabase "github.com/golang-migrate/migrate/v4/database"
+	migratePostgres "github.com/golang-migrate/migrate/v4/database/postgres"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormLogger "gorm.io/gorm/logger"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/http/health"
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/circuitbreaker"
 )
 
 // Database representa a conexão com o banco de dados.
 type Database struct {
 	DB *gorm.DB
+	// Breaker é o circuit breaker das consultas, quando habilitado via UseCircuitBreaker.
+	Breaker *circuitbreaker.Breaker
+}
+
+// PoolConfig configura o pool de conexões do database/sql.
+// Valores zero em ConnMaxLifetime e ConnMaxIdleTime mantêm as conexões sem limite de tempo.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultPoolConfig retorna os limites aplicados por NewDatabase.
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    100,
+		MaxIdleConns:    10,
+		ConnMaxLifetime: 30 * time.Minute,
+		ConnMaxIdleTime: 5 * time.Minute,
+	}
 }
 
 // NewDatabase cria uma nova conexão com o banco de dados.
-func NewDatabase(dsn string) (*Database, error) {
+// Quando appLogger é informado, as consultas são registradas por ele com os
+// IDs de correlação da requisição; caso contrário, usa o logger padrão do GORM.
+func NewDatabase(dsn string, appLogger *logger.Logger) (*Database, error) {
+	queryLogger := gormLogger.Default.LogMode(gormLogger.Info)
+	if appLogger != nil {
+		queryLogger = newGormLogger(appLogger)
+	}
+
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: queryLogger,
+		// Traduzir erros do driver (ex.: unique violation -> gorm.ErrDuplicatedKey)
+		TranslateError: true,
+		// Timestamps automáticos sempre em UTC, independente do fuso do servidor
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// Configurar pool de conexões
-	sqlDB, err := db.DB()
+	database := &Database{DB: db}
+	if err := database.ConfigurePool(DefaultPoolConfig()); err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+// WithStatementTimeout acrescenta à DSN o statement_timeout do Postgres, enviado
+// como parâmetro de sessão em cada conexão do pool. O banco cancela consultas que
+// passem do limite mesmo que o contexto da requisição não tenha expirado,
+// evitando que uma consulta descontrolada prenda a conexão. Aceita DSNs em URL
+// (postgres://) e no formato chave=valor; timeout <= 0 retorna a DSN inalterada.
+func WithStatementTimeout(dsn string, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		return dsn, nil
+	}
+
+	// O Postgres recebe o valor em milissegundos; frações abaixo disso viram 1ms
+	milliseconds := strconv.FormatInt(max(timeout.Milliseconds(), 1), 10)
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		parsed, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("invalid database URL: %w", err)
+		}
+
+		query := parsed.Query()
+		query.Set("statement_timeout", milliseconds)
+		parsed.RawQuery = query.Encode()
+
+		return parsed.String(), nil
+	}
+
+	return strings.TrimSpace(dsn + " statement_timeout=" + milliseconds), nil
+}
+
+// ConfigurePool aplica os limites do pool de conexões ao sql.DB subjacente.
+func (d *Database) ConfigurePool(pool PoolConfig) error {
+	sqlDB, err := d.DB.DB()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	return nil
+}
+
+// SetSlowQueryThreshold define a partir de quanto tempo uma consulta é
+// registrada como lenta (em warn, com SQL, duração e request_id); 0 desativa.
+// Só tem efeito com o logger da aplicação (NewDatabase com appLogger) e deve
+// ser chamado logo após a conexão, antes de as sessões serem derivadas.
+func (d *Database) SetSlowQueryThreshold(threshold time.Duration) {
+	queryLogger, ok := d.DB.Logger.(*gormZapLogger)
+	if !ok {
+		return
+	}
+
+	clone := *queryLogger
+	clone.slowThreshold = max(threshold, 0)
+	d.DB.Logger = &clone
+}
+
+// PoolStats retorna as estatísticas atuais do pool de conexões.
+func (d *Database) PoolStats() (sql.DBStats, error) {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return sql.DBStats{}, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	return sqlDB.Stats(), nil
+}
+
+// Ping verifica a conexão com o banco dentro do prazo de ctx. Com todas as
+// conexões do pool em uso, o ping espera na fila por uma delas; se o prazo
+// acabar nessa espera, retorna health.ErrPoolExhausted, que o verificador do
+// banco deixa para o verificador do pool reportar como degraded. Qualquer
+// outra falha, inclusive a do ping feito com uma conexão liberada, é retornada.
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	stats := sqlDB.Stats()
+	saturated := stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		if saturated && errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("%w: no connection freed before the ping deadline (%d of %d in use)",
+				health.ErrPoolExhausted, stats.InUse, stats.MaxOpenConnections)
+		}
+
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return nil
+}
+
+// ErrNoMigrations indica que nenhuma migration foi aplicada ao banco.
+var ErrNoMigrations = errors.New("no migrations applied")
+
+// SchemaVersion lê, sem alterar nada, a versão das migrations registrada pelo
+// golang-migrate (cmd/migrate) e se a última migration parou no meio (dirty).
+func (d *Database) SchemaVersion(ctx context.Context) (int64, bool, error) {
+	var state struct {
+		Version int64
+		Dirty   bool
+	}
+
+	result := d.DB.WithContext(ctx).
+		Table(migratePostgres.DefaultMigrationsTable).
+		Select("version", "dirty").
+		Limit(1).
+		Scan(&state)
+	if result.Error != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 || state.Version == int64(migrateDatabase.NilVersion) {
+		return 0, false, ErrNoMigrations
+	}
 
-	return &Database{DB: db}, nil
+	return state.Version, state.Dirty, nil
 }
 
 // Close fecha a conexão com o banco de dados.
@@ -0,0 +1,57 @@
+//go:build integration
+
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestStatementTimeoutCancelsSlowQuery(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	dsn, err := WithStatementTimeout(dsn, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WithStatementTimeout: %v", err)
+	}
+
+	database, err := NewDatabase(dsn, nil)
+	if err != nil {
+		t.Fatalf("NewDatabase: %v", err)
+	}
+
+	t.Cleanup(func() { _ = database.Close() })
+
+	// O contexto tem folga: quem cancela a consulta é o próprio Postgres
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	err = database.DB.WithContext(ctx).Exec("SELECT pg_sleep(5)").Error
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgQueryCanceled {
+		t.Fatalf("slow query error = %v, want SQLSTATE %s", err, pgQueryCanceled)
+	}
+
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Errorf("query ran for %v before being canceled", elapsed)
+	}
+
+	// O erro não diz nada sobre a saúde do banco
+	if !IsDatabaseNeutral(err) {
+		t.Error("statement timeout is not neutral for the circuit breaker")
+	}
+
+	if err := database.DB.WithContext(ctx).Exec("SELECT pg_sleep(0.01)").Error; err != nil {
+		t.Errorf("fast query: %v", err)
+	}
+}
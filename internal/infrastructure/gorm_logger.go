@@ -0,0 +1,111 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// DefaultSlowQueryThreshold é a duração padrão a partir da qual uma consulta é
+// registrada como lenta.
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// placeholderMarker casa os placeholders que o GORM reescreve como "$1$" quando
+// não recebe os parâmetros (ver ParamsFilter).
+var placeholderMarker = regexp.MustCompile(`\$(\d+)\$`)
+
+// gormZapLogger adapta o logger da aplicação para o GORM, incluindo em cada
+// consulta os IDs de correlação (request_id, trace_id) presentes no contexto.
+// Os parâmetros das consultas nunca são registrados (ver ParamsFilter).
+type gormZapLogger struct {
+	logger        *logger.Logger
+	level         gormLogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// newGormLogger cria o logger do GORM baseado no logger da aplicação.
+func newGormLogger(appLogger *logger.Logger) *gormZapLogger {
+	return &gormZapLogger{
+		logger:        appLogger.WithComponent("database"),
+		level:         gormLogger.Info,
+		slowThreshold: DefaultSlowQueryThreshold,
+	}
+}
+
+// ParamsFilter descarta os parâmetros antes de o GORM montar o SQL registrado,
+// que mantém os placeholders ($1, $2...). Valores como emails e hashes de
+// senha não chegam aos logs.
+func (l *gormZapLogger) ParamsFilter(_ context.Context, sql string, _ ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// LogMode altera o nível de log do GORM.
+func (l *gormZapLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	clone := *l
+	clone.level = level
+
+	return &clone
+}
+
+// Info registra uma mensagem informativa.
+func (l *gormZapLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Info {
+		l.logger.WithContext(ctx).Sugar().Infof(msg, args...)
+	}
+}
+
+// Warn registra uma mensagem de aviso.
+func (l *gormZapLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Warn {
+		l.logger.WithContext(ctx).Sugar().Warnf(msg, args...)
+	}
+}
+
+// Error registra uma mensagem de erro.
+func (l *gormZapLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Error {
+		l.logger.WithContext(ctx).Sugar().Errorf(msg, args...)
+	}
+}
+
+// Trace registra a execução de uma consulta SQL.
+func (l *gormZapLogger) Trace(
+	ctx context.Context,
+	begin time.Time,
+	fc func() (sql string, rowsAffected int64),
+	err error,
+) {
+	if l.level <= gormLogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	sql = placeholderMarker.ReplaceAllString(sql, "$$$1")
+	fields := append(requestctx.LogFields(ctx),
+		zap.String("db_query", sql),
+		zap.Duration("db_duration", elapsed),
+		zap.Int64("rows_affected", rows),
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled) && l.level >= gormLogger.Info:
+		// Cancelada porque o cliente desconectou: não é uma falha do banco
+		l.logger.Info("Database query cancelled", append(fields, zap.Error(err))...)
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, context.Canceled) &&
+		l.level >= gormLogger.Error:
+		l.logger.Error("Database query failed", append(fields, zap.Error(err))...)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormLogger.Warn:
+		l.logger.Warn("Slow database query", append(fields, zap.Duration("slow_threshold", l.slowThreshold))...)
+	case l.level >= gormLogger.Info:
+		l.logger.Debug("Database query", fields...)
+	}
+}
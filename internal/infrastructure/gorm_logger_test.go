@@ -0,0 +1,172 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/http/middleware"
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+func TestRequestIDReachesHandlerAndQueryLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	appLogger := &logger.Logger{Logger: zap.New(core)}
+	queryLogger := newGormLogger(appLogger)
+
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
+	router.GET("/users", func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		appLogger.WithContext(ctx).Info("Listing users")
+
+		// Mesmo caminho de uma consulta do repositório, que recebe o contexto da requisição
+		queryLogger.Trace(ctx, time.Now(), func() (string, int64) {
+			return `SELECT * FROM "users" WHERE email = $1$`, 1
+		}, nil)
+
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	req.Header.Set("X-Trace-ID", "trace-456")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	for _, message := range []string{"Listing users", "Database query"} {
+		entries := logs.FilterMessage(message).All()
+		if len(entries) != 1 {
+			t.Fatalf("got %d %q entries, want 1", len(entries), message)
+		}
+
+		fields := entries[0].ContextMap()
+		if fields["request_id"] != "req-123" || fields["trace_id"] != "trace-456" {
+			t.Errorf("%q fields = %v, want request_id req-123 and trace_id trace-456", message, fields)
+		}
+	}
+
+	// Os parâmetros nunca chegam ao log, apenas os placeholders
+	query := logs.FilterMessage("Database query").All()[0].ContextMap()["db_query"]
+	if query != `SELECT * FROM "users" WHERE email = $1` {
+		t.Errorf("db_query = %v", query)
+	}
+}
+
+func TestCancelledQueryIsNotLoggedAsFailure(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	queryLogger := newGormLogger(&logger.Logger{Logger: zap.New(core)})
+
+	tests := []struct {
+		err         error
+		name        string
+		wantMessage string
+		wantLevel   zapcore.Level
+	}{
+		{name: "client disconnected", err: context.Canceled, wantMessage: "Database query cancelled", wantLevel: zapcore.InfoLevel},
+		{name: "wrapped cancellation", err: fmt.Errorf("scan: %w", context.Canceled), wantMessage: "Database query cancelled", wantLevel: zapcore.InfoLevel},
+		{name: "database failure", err: errors.New("connection reset"), wantMessage: "Database query failed", wantLevel: zapcore.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.TakeAll()
+
+			queryLogger.Trace(context.Background(), time.Now(), func() (string, int64) {
+				return `SELECT * FROM "users"`, 0
+			}, tt.err)
+
+			entries := logs.All()
+			if len(entries) != 1 || entries[0].Message != tt.wantMessage || entries[0].Level != tt.wantLevel {
+				t.Errorf("entries = %v, want one %q at %s", entries, tt.wantMessage, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestSlowQueryIsLoggedAtWarn(t *testing.T) {
+	const threshold = 20 * time.Millisecond
+
+	tests := []struct {
+		name        string
+		threshold   time.Duration
+		delay       time.Duration
+		wantMessage string
+		wantLevel   zapcore.Level
+	}{
+		{name: "slow query", threshold: threshold, delay: 3 * threshold, wantMessage: "Slow database query", wantLevel: zapcore.WarnLevel},
+		{name: "fast query", threshold: time.Hour, wantMessage: "Database query", wantLevel: zapcore.DebugLevel},
+		{name: "threshold disabled", delay: 3 * threshold, wantMessage: "Database query", wantLevel: zapcore.DebugLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			gormDB, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+				DryRun:               true,
+				DisableAutomaticPing: true,
+				Logger:               newGormLogger(&logger.Logger{Logger: zap.New(core)}),
+			})
+			if err != nil {
+				t.Fatalf("open dry-run db: %v", err)
+			}
+
+			// Consulta artificialmente lenta: o callback atrasa a execução
+			err = gormDB.Callback().Query().Before("gorm:query").Register("test:delay", func(*gorm.DB) {
+				time.Sleep(tt.delay)
+			})
+			if err != nil {
+				t.Fatalf("register callback: %v", err)
+			}
+
+			database := &Database{DB: gormDB}
+			database.SetSlowQueryThreshold(tt.threshold)
+
+			ctx := requestctx.WithRequestID(context.Background(), "req-slow")
+
+			var count int64
+			database.DB.WithContext(ctx).Table("users").Where("email = ?", "ana@example.com").Count(&count)
+
+			entries := logs.All()
+			if len(entries) != 1 || entries[0].Message != tt.wantMessage || entries[0].Level != tt.wantLevel {
+				t.Fatalf("entries = %v, want one %q at %s", entries, tt.wantMessage, tt.wantLevel)
+			}
+
+			fields := entries[0].ContextMap()
+			query, _ := fields["db_query"].(string)
+
+			// O SQL mantém o placeholder, sem o email consultado
+			if !strings.Contains(query, "email = $1") || strings.Contains(query, "ana@example.com") {
+				t.Errorf("db_query = %q, want the placeholder and no parameter", query)
+			}
+
+			if fields["request_id"] != "req-slow" {
+				t.Errorf("request_id = %v, want req-slow", fields["request_id"])
+			}
+
+			if duration, _ := fields["db_duration"].(time.Duration); duration < tt.delay {
+				t.Errorf("db_duration = %v, want at least %v", duration, tt.delay)
+			}
+
+			if tt.wantLevel == zapcore.WarnLevel && fields["slow_threshold"] != tt.threshold {
+				t.Errorf("slow_threshold = %v, want %v", fields["slow_threshold"], tt.threshold)
+			}
+		})
+	}
+}
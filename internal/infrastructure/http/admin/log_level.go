@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/response"
+)
+
+// LogLevelHandler permite consultar e alterar o nível de log em tempo de execução.
+type LogLevelHandler struct {
+	logger *logger.Logger
+}
+
+// NewLogLevelHandler cria uma nova instância do handler de nível de log.
+func NewLogLevelHandler(appLogger *logger.Logger) *LogLevelHandler {
+	return &LogLevelHandler{logger: appLogger}
+}
+
+// SetLogLevelRequest representa a requisição de alteração do nível de log.
+type SetLogLevelRequest struct {
+	Level string `json:"level" binding:"required,oneof=debug info warn error"`
+}
+
+// GetLogLevel retorna o nível de log atual.
+func (h *LogLevelHandler) GetLogLevel(c *gin.Context) {
+	response.Success(c, gin.H{"level": h.logger.Level()})
+}
+
+// SetLogLevel altera o nível de log atual.
+func (h *LogLevelHandler) SetLogLevel(c *gin.Context) {
+	var req SetLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	previous := h.logger.Level()
+
+	if err := h.logger.SetLevel(req.Level); err != nil {
+		response.BadRequest(c, "INVALID_LOG_LEVEL", err.Error())
+		return
+	}
+
+	changedBy, _ := c.Get("user_id")
+	h.logger.Warn("Log level changed",
+		zap.String("component", "logger"),
+		zap.String("from", previous),
+		zap.String("to", req.Level),
+		zap.Any("changed_by", changedBy),
+	)
+
+	response.Success(c, gin.H{"level": h.logger.Level()}, "Log level updated")
+}
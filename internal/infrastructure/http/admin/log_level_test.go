@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestLogLevelHandler(t *testing.T) {
+	appLogger, err := logger.New(logger.Config{Level: "error", Format: "json"})
+	if err != nil {
+		t.Fatalf("logger.New: %v", err)
+	}
+
+	handler := NewLogLevelHandler(appLogger)
+	router := gin.New()
+	router.GET("/log-level", handler.GetLogLevel)
+	router.PUT("/log-level", handler.SetLogLevel)
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		wantCode  int
+		wantLevel string
+	}{
+		{name: "read current level", method: http.MethodGet, wantCode: http.StatusOK, wantLevel: "error"},
+		{name: "unknown level", method: http.MethodPut, body: `{"level":"verbose"}`, wantCode: http.StatusBadRequest},
+		{name: "missing level", method: http.MethodPut, body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "lower to debug", method: http.MethodPut, body: `{"level":"debug"}`, wantCode: http.StatusOK, wantLevel: "debug"},
+		{name: "read updated level", method: http.MethodGet, wantCode: http.StatusOK, wantLevel: "debug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, "/log-level", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			if tt.wantLevel == "" {
+				return
+			}
+
+			var body struct {
+				Data struct {
+					Level string `json:"level"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if body.Data.Level != tt.wantLevel || appLogger.Level() != tt.wantLevel {
+				t.Errorf("level = %s (logger %s), want %s", body.Data.Level, appLogger.Level(), tt.wantLevel)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/featureflag"
+	"github.com/devleo-m/go-zero/internal/shared/response"
+)
+
+// SystemConfigHandler expõe e altera as flags de funcionalidade em tempo de execução.
+type SystemConfigHandler struct {
+	flags  *featureflag.Store
+	logger *logger.Logger
+}
+
+// NewSystemConfigHandler cria uma nova instância do handler de configuração do sistema.
+func NewSystemConfigHandler(flags *featureflag.Store, appLogger *logger.Logger) *SystemConfigHandler {
+	return &SystemConfigHandler{flags: flags, logger: appLogger}
+}
+
+// UpdateSystemConfigRequest representa a requisição de alteração das flags.
+// Apenas as flags informadas são alteradas.
+type UpdateSystemConfigRequest struct {
+	Features map[string]bool `json:"features" binding:"required"`
+}
+
+// GetSystemConfig retorna o estado atual das flags.
+func (h *SystemConfigHandler) GetSystemConfig(c *gin.Context) {
+	response.Success(c, gin.H{"features": h.flags.All()})
+}
+
+// UpdateSystemConfig altera as flags informadas. Se alguma flag for
+// desconhecida, nenhuma alteração é aplicada.
+func (h *SystemConfigHandler) UpdateSystemConfig(c *gin.Context) {
+	var req UpdateSystemConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	current := h.flags.All()
+	for name := range req.Features {
+		if _, ok := current[name]; !ok {
+			response.BadRequest(c, "UNKNOWN_FEATURE_FLAG", "Unknown feature flag: "+name)
+			return
+		}
+	}
+
+	changedBy, _ := c.Get("user_id")
+
+	for name, enabled := range req.Features {
+		previous, err := h.flags.Set(name, enabled)
+		if errors.Is(err, featureflag.ErrUnknownFlag) {
+			response.BadRequest(c, "UNKNOWN_FEATURE_FLAG", "Unknown feature flag: "+name)
+			return
+		}
+
+		if previous == enabled {
+			continue
+		}
+
+		h.logger.WithContext(c.Request.Context()).Warn("Feature flag changed",
+			zap.String("component", "feature_flags"),
+			zap.String("flag", name),
+			zap.Bool("from", previous),
+			zap.Bool("to", enabled),
+			zap.Any("changed_by", changedBy),
+		)
+	}
+
+	response.Success(c, gin.H{"features": h.flags.All()}, "System configuration updated")
+}
@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/http/middleware"
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/featureflag"
+)
+
+func TestUpdateSystemConfigTogglesRegistration(t *testing.T) {
+	flags := featureflag.NewStore(map[string]bool{
+		featureflag.UserRegistration: true,
+		featureflag.MaintenanceMode:  false,
+	})
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	handler := NewSystemConfigHandler(flags, &logger.Logger{Logger: zap.New(core)})
+
+	// Como nas rotas: o gate consulta a flag a cada requisição
+	router := gin.New()
+	router.PUT("/admin/system/config", func(c *gin.Context) { c.Set("user_id", "admin-1") }, handler.UpdateSystemConfig)
+	router.POST("/users", middleware.RegistrationGate(func() bool {
+		return flags.Enabled(featureflag.UserRegistration)
+	}), func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	serve := func(method, path, body string) int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+		return rec.Code
+	}
+
+	tests := []struct {
+		name         string
+		config       string
+		wantConfig   int
+		wantRegister int
+	}{
+		{name: "disable registration", config: `{"features":{"user_registration":false}}`, wantConfig: http.StatusOK, wantRegister: http.StatusForbidden},
+		{
+			name:         "unknown flag changes nothing",
+			config:       `{"features":{"user_registration":true,"two_factor_auth":true}}`,
+			wantConfig:   http.StatusBadRequest,
+			wantRegister: http.StatusForbidden,
+		},
+		{name: "enable registration", config: `{"features":{"user_registration":true}}`, wantConfig: http.StatusOK, wantRegister: http.StatusCreated},
+		{name: "missing features", config: `{}`, wantConfig: http.StatusBadRequest, wantRegister: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := serve(http.MethodPut, "/admin/system/config", tt.config); code != tt.wantConfig {
+				t.Fatalf("PUT config = %d, want %d", code, tt.wantConfig)
+			}
+
+			if code := serve(http.MethodPost, "/users", ""); code != tt.wantRegister {
+				t.Errorf("POST /users = %d, want %d", code, tt.wantRegister)
+			}
+		})
+	}
+
+	// Cada alteração efetiva é auditada com o administrador
+	entries := logs.FilterMessage("Feature flag changed").All()
+	if len(entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields["flag"] != featureflag.UserRegistration || fields["to"] != false || fields["changed_by"] != "admin-1" {
+		t.Errorf("audit fields = %v", fields)
+	}
+}
+
+func TestUpdateSystemConfigTogglesMaintenance(t *testing.T) {
+	flags := featureflag.NewStore(map[string]bool{featureflag.MaintenanceMode: false})
+	handler := NewSystemConfigHandler(flags, &logger.Logger{Logger: zap.NewNop()})
+
+	// Como nas rotas: o gate é global e a rota de configuração fica isenta
+	router := gin.New()
+	router.Use(middleware.MaintenanceGate(func() bool {
+		return flags.Enabled(featureflag.MaintenanceMode)
+	}, time.Minute, "/admin/system/config"))
+	router.PUT("/admin/system/config", handler.UpdateSystemConfig)
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/users", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+		return rec
+	}
+
+	tests := []struct {
+		name        string
+		maintenance bool
+		wantWrite   int
+	}{
+		{name: "maintenance on", maintenance: true, wantWrite: http.StatusServiceUnavailable},
+		{name: "maintenance off", wantWrite: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := fmt.Sprintf(`{"features":{"maintenance_mode":%t}}`, tt.maintenance)
+			if rec := serve(http.MethodPut, "/admin/system/config", config); rec.Code != http.StatusOK {
+				t.Fatalf("PUT config = %d, want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+			}
+
+			write := serve(http.MethodPost, "/users", "")
+			if write.Code != tt.wantWrite {
+				t.Errorf("POST /users = %d, want %d", write.Code, tt.wantWrite)
+			}
+
+			if tt.maintenance && write.Header().Get("Retry-After") != "60" {
+				t.Errorf("Retry-After = %q, want 60", write.Header().Get("Retry-After"))
+			}
+
+			for _, path := range []string{"/health", "/users"} {
+				if rec := serve(http.MethodGet, path, ""); rec.Code != http.StatusOK {
+					t.Errorf("GET %s = %d, want %d", path, rec.Code, http.StatusOK)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+)
+
+// cacheProbeKey é a chave gravada pelo verificador de cache.
+const cacheProbeKey = "health:probe"
+
+// NewCacheChecker cria um verificador que grava e lê uma chave de teste no cache.
+// Fora de CriticalComponents, uma falha deixa o serviço como degraded, não unhealthy.
+func NewCacheChecker(service cache.Service) CheckerFunc {
+	return func(ctx context.Context) error {
+		if err := service.Set(ctx, cacheProbeKey, "ok", time.Minute); err != nil {
+			return fmt.Errorf("cache write failed: %w", err)
+		}
+
+		if _, err := service.Get(ctx, cacheProbeKey); err != nil {
+			return fmt.Errorf("cache read failed: %w", err)
+		}
+
+		return nil
+	}
+}
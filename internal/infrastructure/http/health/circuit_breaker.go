@@ -0,0 +1,19 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devleo-m/go-zero/internal/shared/circuitbreaker"
+)
+
+// NewCircuitBreakerChecker cria um verificador que falha enquanto o circuito não estiver fechado.
+func NewCircuitBreakerChecker(breaker *circuitbreaker.Breaker) CheckerFunc {
+	return func(_ context.Context) error {
+		if state := breaker.State(); state != circuitbreaker.StateClosed {
+			return fmt.Errorf("circuit breaker is %s", state)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrPoolExhausted indica que todas as conexões permitidas pelo pool estão em uso.
+var ErrPoolExhausted = errors.New("database connection pool exhausted")
+
+// DatabasePinger é implementado por conexões que verificam a própria saúde,
+// como infrastructure.Database.
+type DatabasePinger interface {
+	Ping(ctx context.Context) error
+}
+
+// NewDatabaseChecker cria um verificador que faz ping no banco de dados.
+// ErrPoolExhausted (o ping não conseguiu uma conexão no prazo porque o pool
+// está cheio) não é falha do banco: NewDatabasePoolChecker a reporta.
+func NewDatabaseChecker(db DatabasePinger) CheckerFunc {
+	return func(ctx context.Context) error {
+		if err := db.Ping(ctx); err != nil && !errors.Is(err, ErrPoolExhausted) {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// NewDatabasePoolChecker cria um verificador que falha com ErrPoolExhausted
+// enquanto todas as conexões permitidas pelo pool estão em uso. Pool cheio é
+// sinal de carga, não de falha: fora de CriticalComponents, deixa o serviço
+// degraded sem tirá-lo do balanceador.
+func NewDatabasePoolChecker(stats func() (sql.DBStats, error)) CheckerFunc {
+	return func(_ context.Context) error {
+		current, err := stats()
+		if err != nil {
+			return err
+		}
+
+		if current.MaxOpenConnections > 0 && current.InUse >= current.MaxOpenConnections {
+			return fmt.Errorf("%w: %d of %d connections in use, %d waits",
+				ErrPoolExhausted, current.InUse, current.MaxOpenConnections, current.WaitCount)
+		}
+
+		return nil
+	}
+}
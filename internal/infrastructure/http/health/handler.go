@@ -0,0 +1,266 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/response"
+)
+
+// Status representa o estado de saúde de um componente ou da aplicação.
+type Status string
+
+// Estados de saúde possíveis.
+const (
+	StatusHealthy   Status = "healthy"
+	StatusDegraded  Status = "degraded"
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// Valores padrão da configuração do health check.
+const (
+	DefaultCheckTimeout  = 5 * time.Second
+	DefaultCheckInterval = 30 * time.Second
+)
+
+// Checker verifica a saúde de um componente.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapta uma função para a interface Checker.
+type CheckerFunc func(ctx context.Context) error
+
+// Check executa a função de verificação.
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// DetailedChecker é um Checker que também informa dados do componente,
+// exibidos em details (ex.: a versão do schema). Os detalhes acompanham
+// inclusive as falhas.
+type DetailedChecker interface {
+	Checker
+	CheckDetails(ctx context.Context) (map[string]interface{}, error)
+}
+
+// ComponentStatus representa o resultado da verificação de um componente.
+type ComponentStatus struct {
+	CheckedAt time.Time              `json:"checked_at"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Status    Status                 `json:"status"`
+	Error     string                 `json:"error,omitempty"`
+	Latency   string                 `json:"latency"`
+	Critical  bool                   `json:"critical"`
+}
+
+// HealthHandlerConfig representa a configuração do HealthHandler.
+type HealthHandlerConfig struct {
+	// Clock é opcional; quando nil, o relógio do sistema é usado.
+	Clock clock.Clock
+	// Checkers mapeia o nome do componente para o seu verificador.
+	Checkers map[string]Checker
+	// ComponentTimeouts sobrescreve o timeout padrão por componente.
+	ComponentTimeouts map[string]time.Duration
+	// CriticalComponents lista os componentes que tornam a aplicação "not ready".
+	CriticalComponents []string
+	Version            string
+	CheckTimeout       time.Duration
+	CheckInterval      time.Duration
+}
+
+// HealthHandler expõe os endpoints de liveness e readiness.
+type HealthHandler struct {
+	startedAt time.Time
+	results   map[string]ComponentStatus
+	critical  map[string]bool
+	config    HealthHandlerConfig
+	mutex     sync.RWMutex
+}
+
+// NewHealthHandler cria uma nova instância do handler de saúde.
+func NewHealthHandler(config HealthHandlerConfig) *HealthHandler {
+	if config.CheckTimeout <= 0 {
+		config.CheckTimeout = DefaultCheckTimeout
+	}
+
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = DefaultCheckInterval
+	}
+
+	if config.CriticalComponents == nil {
+		config.CriticalComponents = []string{"database"}
+	}
+
+	critical := make(map[string]bool, len(config.CriticalComponents))
+	for _, name := range config.CriticalComponents {
+		critical[name] = true
+	}
+
+	config.Clock = clock.OrReal(config.Clock)
+
+	return &HealthHandler{
+		startedAt: config.Clock.Now(),
+		results:   make(map[string]ComponentStatus),
+		critical:  critical,
+		config:    config,
+	}
+}
+
+// Start executa as verificações periodicamente até o contexto ser cancelado.
+func (h *HealthHandler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(h.config.CheckInterval)
+		defer ticker.Stop()
+
+		h.runChecks(ctx)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				h.runChecks(ctx)
+			}
+		}
+	}()
+}
+
+// HealthCheck retorna o último estado conhecido dos componentes (liveness).
+func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	h.mutex.RLock()
+	components := make(map[string]ComponentStatus, len(h.results))
+	for name, result := range h.results {
+		components[name] = result
+	}
+	h.mutex.RUnlock()
+
+	response.Success(c, gin.H{
+		"status":     h.overallStatus(components),
+		"timestamp":  clock.UTC(h.config.Clock.Now()),
+		"uptime":     h.config.Clock.Now().Sub(h.startedAt).Round(time.Second).String(),
+		"version":    h.config.Version,
+		"components": components,
+	}, "Service is alive")
+}
+
+// ReadinessCheck verifica os componentes e retorna 503 se algum crítico falhar.
+func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
+	components := h.runChecks(c.Request.Context())
+	status := h.overallStatus(components)
+
+	data := gin.H{
+		"status":     status,
+		"timestamp":  clock.UTC(h.config.Clock.Now()),
+		"components": components,
+	}
+
+	if status == StatusUnhealthy {
+		response.JSON(c, http.StatusServiceUnavailable, response.Response{
+			Success: false,
+			Error:   "SERVICE_NOT_READY",
+			Message: "Service is not ready",
+			Data:    data,
+		})
+
+		return
+	}
+
+	response.Success(c, data, "Service is ready")
+}
+
+// runChecks executa todas as verificações em paralelo e armazena os resultados.
+func (h *HealthHandler) runChecks(ctx context.Context) map[string]ComponentStatus {
+	results := make(map[string]ComponentStatus, len(h.config.Checkers))
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+	)
+
+	for name, checker := range h.config.Checkers {
+		wg.Add(1)
+
+		go func(name string, checker Checker) {
+			defer wg.Done()
+
+			result := h.check(ctx, name, checker)
+
+			mutex.Lock()
+			results[name] = result
+			mutex.Unlock()
+		}(name, checker)
+	}
+
+	wg.Wait()
+
+	h.mutex.Lock()
+	h.results = results
+	h.mutex.Unlock()
+
+	return results
+}
+
+// check executa a verificação de um componente respeitando o seu timeout.
+func (h *HealthHandler) check(ctx context.Context, name string, checker Checker) ComponentStatus {
+	timeout := h.config.CheckTimeout
+	if componentTimeout, ok := h.config.ComponentTimeouts[name]; ok && componentTimeout > 0 {
+		timeout = componentTimeout
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	var (
+		details map[string]interface{}
+		err     error
+	)
+
+	if detailed, ok := checker.(DetailedChecker); ok {
+		details, err = detailed.CheckDetails(checkCtx)
+	} else {
+		err = checker.Check(checkCtx)
+	}
+
+	result := ComponentStatus{
+		CheckedAt: clock.UTC(h.config.Clock.Now()),
+		Details:   details,
+		Status:    StatusHealthy,
+		Latency:   time.Since(start).String(),
+		Critical:  h.critical[name],
+	}
+
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// overallStatus calcula o estado geral: unhealthy se algum componente crítico
+// falhar, degraded se apenas componentes não críticos falharem.
+func (h *HealthHandler) overallStatus(components map[string]ComponentStatus) Status {
+	status := StatusHealthy
+
+	for _, component := range components {
+		if component.Status == StatusHealthy {
+			continue
+		}
+
+		if component.Critical {
+			return StatusUnhealthy
+		}
+
+		status = StatusDegraded
+	}
+
+	return status
+}
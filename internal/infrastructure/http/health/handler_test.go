@@ -0,0 +1,151 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// healthBody é o envelope das respostas de saúde.
+type healthBody struct {
+	Data struct {
+		Components map[string]ComponentStatus `json:"components"`
+		Status     Status                     `json:"status"`
+		Uptime     string                     `json:"uptime"`
+	} `json:"data"`
+	Error   string `json:"error"`
+	Success bool   `json:"success"`
+}
+
+var (
+	healthy = CheckerFunc(func(context.Context) error { return nil })
+	failing = CheckerFunc(func(context.Context) error { return errors.New("connection refused") })
+	// hanging só retorna quando o timeout do componente cancela o contexto
+	hanging = CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+)
+
+// serveHealth executa endpoint no handler e decodifica a resposta.
+func serveHealth(t *testing.T, handler *HealthHandler, endpoint gin.HandlerFunc) (int, healthBody) {
+	t.Helper()
+
+	router := gin.New()
+	router.GET("/health", endpoint)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var body healthBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v (%q)", err, rec.Body.String())
+	}
+
+	return rec.Code, body
+}
+
+func TestReadinessCheck(t *testing.T) {
+	tests := []struct {
+		checkers   map[string]Checker
+		name       string
+		critical   []string
+		wantStatus Status
+		wantCode   int
+	}{
+		{
+			name:       "all healthy",
+			checkers:   map[string]Checker{"database": healthy, "cache": healthy},
+			wantCode:   http.StatusOK,
+			wantStatus: StatusHealthy,
+		},
+		{
+			name:       "critical component unhealthy",
+			checkers:   map[string]Checker{"database": failing, "cache": healthy},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusUnhealthy,
+		},
+		{
+			name:       "non-critical component degraded",
+			checkers:   map[string]Checker{"database": healthy, "cache": failing},
+			wantCode:   http.StatusOK,
+			wantStatus: StatusDegraded,
+		},
+		{
+			name:       "configured critical component unhealthy",
+			checkers:   map[string]Checker{"database": healthy, "cache": failing},
+			critical:   []string{"database", "cache"},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusUnhealthy,
+		},
+		{
+			name:       "critical component times out",
+			checkers:   map[string]Checker{"database": hanging},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(HealthHandlerConfig{
+				Checkers:           tt.checkers,
+				CriticalComponents: tt.critical,
+				ComponentTimeouts:  map[string]time.Duration{"database": 10 * time.Millisecond},
+			})
+
+			code, body := serveHealth(t, handler, handler.ReadinessCheck)
+			if code != tt.wantCode || body.Data.Status != tt.wantStatus {
+				t.Errorf("got %d %s, want %d %s", code, body.Data.Status, tt.wantCode, tt.wantStatus)
+			}
+
+			if code == http.StatusServiceUnavailable && body.Error != "SERVICE_NOT_READY" {
+				t.Errorf("error = %q, want SERVICE_NOT_READY", body.Error)
+			}
+		})
+	}
+}
+
+// downCache simula um cache fora do ar.
+type downCache struct{ cache.Service }
+
+func (downCache) Set(context.Context, string, string, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func TestCacheCheckerDegradesReadiness(t *testing.T) {
+	tests := []struct {
+		cache      cache.Service
+		name       string
+		wantStatus Status
+	}{
+		{name: "cache available", cache: cache.NewMemoryCache(), wantStatus: StatusHealthy},
+		{name: "cache down", cache: downCache{}, wantStatus: StatusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(HealthHandlerConfig{
+				Checkers: map[string]Checker{"database": healthy, "cache": NewCacheChecker(tt.cache)},
+			})
+
+			// Com o banco de pé, a queda do cache não tira o serviço de rotação
+			code, body := serveHealth(t, handler, handler.ReadinessCheck)
+			if code != http.StatusOK || body.Data.Status != tt.wantStatus {
+				t.Errorf("got %d %s, want %d %s", code, body.Data.Status, http.StatusOK, tt.wantStatus)
+			}
+		})
+	}
+}
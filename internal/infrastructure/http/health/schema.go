@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrDirtySchema indica que uma migration falhou no meio e o schema precisa
+// de intervenção manual (cmd/migrate -direction force).
+var ErrDirtySchema = errors.New("database schema is dirty")
+
+// SchemaVersionReader é implementado por conexões que informam a versão das
+// migrations aplicadas, como infrastructure.Database.
+type SchemaVersionReader interface {
+	SchemaVersion(ctx context.Context) (version int64, dirty bool, err error)
+}
+
+// SchemaChecker verifica a versão do schema do banco e informa version e
+// dirty nos detalhes do componente. Um schema dirty é reportado como falha.
+type SchemaChecker struct {
+	reader SchemaVersionReader
+}
+
+// NewSchemaChecker cria um verificador da versão do schema.
+func NewSchemaChecker(reader SchemaVersionReader) *SchemaChecker {
+	return &SchemaChecker{reader: reader}
+}
+
+// Check verifica se o schema tem uma versão aplicada e não está dirty.
+func (s *SchemaChecker) Check(ctx context.Context) error {
+	_, err := s.CheckDetails(ctx)
+
+	return err
+}
+
+// CheckDetails verifica o schema e retorna a versão e o estado dirty.
+func (s *SchemaChecker) CheckDetails(ctx context.Context) (map[string]interface{}, error) {
+	version, dirty, err := s.reader.SchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"version": version,
+		"dirty":   dirty,
+	}
+
+	if dirty {
+		return details, fmt.Errorf("%w at version %d", ErrDirtySchema, version)
+	}
+
+	return details, nil
+}
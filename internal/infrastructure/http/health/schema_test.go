@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeSchema informa uma versão fixa das migrations.
+type fakeSchema struct {
+	err     error
+	version int64
+	dirty   bool
+}
+
+func (f fakeSchema) SchemaVersion(context.Context) (int64, bool, error) {
+	return f.version, f.dirty, f.err
+}
+
+func TestSchemaCheckerReportsVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     fakeSchema
+		wantStatus Status
+		wantCode   int
+		wantError  string
+	}{
+		{
+			name:       "clean schema",
+			schema:     fakeSchema{version: 7},
+			wantStatus: StatusHealthy,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:       "dirty schema",
+			schema:     fakeSchema{version: 7, dirty: true},
+			wantStatus: StatusUnhealthy,
+			wantCode:   http.StatusServiceUnavailable,
+			wantError:  "database schema is dirty at version 7",
+		},
+		{
+			name:       "unreadable schema",
+			schema:     fakeSchema{err: errors.New("no migrations applied")},
+			wantStatus: StatusUnhealthy,
+			wantCode:   http.StatusServiceUnavailable,
+			wantError:  "no migrations applied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(HealthHandlerConfig{
+				Checkers:           map[string]Checker{"database": healthy, "schema": NewSchemaChecker(tt.schema)},
+				CriticalComponents: []string{"database", "schema"},
+			})
+
+			code, body := serveHealth(t, handler, handler.ReadinessCheck)
+			if code != tt.wantCode || body.Data.Status != tt.wantStatus {
+				t.Errorf("got %d %s, want %d %s", code, body.Data.Status, tt.wantCode, tt.wantStatus)
+			}
+
+			schema := body.Data.Components["schema"]
+			if !strings.Contains(schema.Error, tt.wantError) || (tt.wantError == "") != (schema.Error == "") {
+				t.Errorf("schema error = %q, want %q", schema.Error, tt.wantError)
+			}
+
+			if tt.schema.err != nil {
+				return
+			}
+
+			// Os detalhes acompanham inclusive o schema dirty
+			if schema.Details["version"] != float64(tt.schema.version) || schema.Details["dirty"] != tt.schema.dirty {
+				t.Errorf("schema details = %v", schema.Details)
+			}
+		})
+	}
+}
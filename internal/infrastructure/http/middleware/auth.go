@@ -1,24 +1,20 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-)
+	"github.com/google/uuid"
 
-// Claims representa as claims do JWT.
-type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
-	jwt.RegisteredClaims
-}
+	"github.com/devleo-m/go-zero/internal/infrastructure/auth"
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
 
 // AuthMiddleware cria um middleware de autenticação JWT.
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
+func AuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -44,49 +40,23 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
+		claims, err := jwtService.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   "INVALID_TOKEN",
-				"message": "Invalid or expired token",
-			})
-			c.Abort()
-
-			return
-		}
+			if errors.Is(err, auth.ErrTokenExpired) {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"success": false,
+					"error":   "TOKEN_EXPIRED",
+					"message": "Token has expired",
+				})
+				c.Abort()
+
+				return
+			}
 
-		if !token.Valid {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"success": false,
 				"error":   "INVALID_TOKEN",
-				"message": "Invalid token",
-			})
-			c.Abort()
-
-			return
-		}
-
-		claims, ok := token.Claims.(*Claims)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   "INVALID_TOKEN_CLAIMS",
-				"message": "Invalid token claims",
-			})
-			c.Abort()
-
-			return
-		}
-
-		// Verificar se o token não expirou
-		if claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(time.Now()) {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   "TOKEN_EXPIRED",
-				"message": "Token has expired",
+				"message": "Invalid or expired token",
 			})
 			c.Abort()
 
@@ -94,17 +64,14 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		}
 
 		// Adicionar informações do usuário ao contexto
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
-		c.Set("token_claims", claims)
+		setAuthContext(c, claims)
 
 		c.Next()
 	}
 }
 
 // OptionalAuthMiddleware cria um middleware de autenticação opcional.
-func OptionalAuthMiddleware(jwtSecret string) gin.HandlerFunc {
+func OptionalAuthMiddleware(jwtService *auth.JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -118,37 +85,39 @@ func OptionalAuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
-			c.Next()
-			return
-		}
-
-		claims, ok := token.Claims.(*Claims)
-		if !ok {
-			c.Next()
-			return
-		}
-
-		// Verificar se o token não expirou
-		if claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(time.Now()) {
+		claims, err := jwtService.ValidateToken(tokenString)
+		if err != nil {
 			c.Next()
 			return
 		}
 
 		// Adicionar informações do usuário ao contexto se o token for válido
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
-		c.Set("token_claims", claims)
+		setAuthContext(c, claims)
 
 		c.Next()
 	}
 }
 
+// setAuthContext adiciona as informações do token ao contexto.
+func setAuthContext(c *gin.Context, claims *auth.Claims) {
+	c.Set("user_id", claims.UserID)
+	c.Set("user_email", claims.Email)
+	c.Set("user_role", claims.Role)
+	c.Set("token_claims", claims)
+
+	// Casos de uso recebem apenas o context.Context; o ator identifica quem fez a alteração
+	ctx := requestctx.WithActor(c.Request.Context(), claims.UserID)
+
+	// Em uma personificação, o ator é o usuário personificado e o administrador
+	// real segue junto para a auditoria
+	if claims.ImpersonatorID != "" {
+		c.Set("impersonator_id", claims.ImpersonatorID)
+		ctx = requestctx.WithImpersonator(ctx, claims.ImpersonatorID)
+	}
+
+	c.Request = c.Request.WithContext(ctx)
+}
+
 // RequireRole cria um middleware que requer um role específico.
 func RequireRole(requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -244,25 +213,102 @@ func RequireAnyRole(requiredRoles ...string) gin.HandlerFunc {
 	}
 }
 
-// hasRequiredRole verifica se o usuário tem o role necessário.
-func hasRequiredRole(userRole, requiredRole string) bool {
-	// Hierarquia de roles (do menor para o maior)
-	roleHierarchy := map[string]int{
-		"user":        1,
-		"moderator":   2,
-		"admin":       3,
-		"super_admin": 4,
+// RequirePermission cria um middleware que requer permissão para executar
+// uma ação sobre um recurso, consultando as regras da entidade User.
+func RequirePermission(resource, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, ok := GetUserRole(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "AUTHENTICATION_REQUIRED",
+				"message": "Authentication is required",
+			})
+			c.Abort()
+
+			return
+		}
+
+		caller := &domain.User{Role: domain.Role(role)}
+		if !caller.CanAccess(resource, action) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error":   "INSUFFICIENT_PERMISSIONS",
+				"message": "Insufficient permissions",
+			})
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
 	}
+}
+
+// RequireSelfOrRole cria um middleware que permite o acesso quando o usuário
+// autenticado é o dono do recurso (parâmetro :id) ou possui algum dos roles informados.
+func RequireSelfOrRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, hasID := GetUserID(c)
+		role, hasRole := GetUserRole(c)
+
+		if !hasID || !hasRole {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "AUTHENTICATION_REQUIRED",
+				"message": "Authentication is required",
+			})
+			c.Abort()
 
-	userLevel, userExists := roleHierarchy[userRole]
-	requiredLevel, requiredExists := roleHierarchy[requiredRole]
+			return
+		}
 
-	if !userExists || !requiredExists {
+		// O próprio usuário pode agir sobre o seu recurso
+		if isSelf(userID, c.Param("id")) {
+			c.Next()
+			return
+		}
+
+		for _, requiredRole := range roles {
+			if hasRequiredRole(role, requiredRole) {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   "INSUFFICIENT_PERMISSIONS",
+			"message": "Insufficient permissions",
+		})
+		c.Abort()
+	}
+}
+
+// isSelf compara o ID do usuário autenticado com o parâmetro :id como UUIDs,
+// para que a grafia (maiúsculas, chaves, prefixo urn:uuid:) não negue o acesso
+// ao próprio recurso. IDs que não são UUIDs nunca identificam o usuário.
+func isSelf(userID, paramID string) bool {
+	actor, err := uuid.Parse(userID)
+	if err != nil {
 		return false
 	}
 
-	// O usuário deve ter pelo menos o nível necessário
-	return userLevel >= requiredLevel
+	target, err := uuid.Parse(paramID)
+	if err != nil {
+		return false
+	}
+
+	return actor == target
+}
+
+// hasRequiredRole verifica se o usuário tem o role necessário ou superior,
+// segundo a hierarquia do domínio. Roles desconhecidos nunca satisfazem.
+func hasRequiredRole(userRole, requiredRole string) bool {
+	userLevel := domain.RoleLevel(domain.Role(userRole))
+	requiredLevel := domain.RoleLevel(domain.Role(requiredRole))
+
+	return userLevel > 0 && requiredLevel > 0 && userLevel >= requiredLevel
 }
 
 // GetUserID extrai o ID do usuário do contexto.
@@ -277,6 +323,19 @@ func GetUserID(c *gin.Context) (string, bool) {
 	return id, ok
 }
 
+// GetImpersonatorID extrai o ID do administrador que personifica o usuário
+// autenticado; ok é false quando a requisição não é uma personificação.
+func GetImpersonatorID(c *gin.Context) (string, bool) {
+	impersonatorID, exists := c.Get("impersonator_id")
+	if !exists {
+		return "", false
+	}
+
+	id, ok := impersonatorID.(string)
+
+	return id, ok
+}
+
 // GetUserRole extrai o role do usuário do contexto.
 func GetUserRole(c *gin.Context) (string, bool) {
 	userRole, exists := c.Get("user_role")
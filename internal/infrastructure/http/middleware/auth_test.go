@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/auth"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// authenticatedAs simula o AuthMiddleware; um role vazio deixa a requisição
+// sem autenticação.
+func authenticatedAs(userID, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if role != "" {
+			c.Set("user_id", userID)
+			c.Set("user_role", role)
+		}
+
+		c.Next()
+	}
+}
+
+// authorize executa guard em GET /users/:id e retorna o status e o código de erro.
+func authorize(t *testing.T, guard gin.HandlerFunc, userID, role, targetID string) (int, string) {
+	t.Helper()
+
+	router := gin.New()
+	router.GET("/users/:id", authenticatedAs(userID, role), guard, func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/"+targetID, nil))
+
+	if rec.Code == http.StatusNoContent {
+		return rec.Code, ""
+	}
+
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v (%q)", err, rec.Body.String())
+	}
+
+	return rec.Code, body.Error
+}
+
+func TestRequireSelfOrRole(t *testing.T) {
+	const (
+		self  = "0b7e8a52-3c1d-4f6e-9a2b-5d4c3b2a1f00"
+		other = "7f3d2c1b-0a9e-4d8c-b7a6-1e2f3a4b5c6d"
+	)
+
+	tests := []struct {
+		name     string
+		roles    []string
+		role     string
+		target   string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "self access", roles: []string{"admin"}, role: "user", target: self, wantCode: http.StatusNoContent},
+		{name: "self access ignores case", roles: []string{"admin"}, role: "user", target: strings.ToUpper(self), wantCode: http.StatusNoContent},
+		{
+			name: "malformed id is not self", roles: []string{"admin"}, role: "user", target: "not-a-uuid",
+			wantCode: http.StatusForbidden, wantErr: "INSUFFICIENT_PERMISSIONS",
+		},
+		{name: "elevated access", roles: []string{"admin"}, role: "admin", target: other, wantCode: http.StatusNoContent},
+		{name: "higher role inherits", roles: []string{"admin"}, role: "super_admin", target: other, wantCode: http.StatusNoContent},
+		{
+			name: "other user denied", roles: []string{"admin"}, role: "moderator", target: other,
+			wantCode: http.StatusForbidden, wantErr: "INSUFFICIENT_PERMISSIONS",
+		},
+		{
+			name: "self only denies admins", role: "super_admin", target: other,
+			wantCode: http.StatusForbidden, wantErr: "INSUFFICIENT_PERMISSIONS",
+		},
+		{
+			name: "unknown role denied", roles: []string{"admin"}, role: "root", target: other,
+			wantCode: http.StatusForbidden, wantErr: "INSUFFICIENT_PERMISSIONS",
+		},
+		{
+			name: "unauthenticated", roles: []string{"admin"}, target: self,
+			wantCode: http.StatusUnauthorized, wantErr: "AUTHENTICATION_REQUIRED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, errCode := authorize(t, RequireSelfOrRole(tt.roles...), self, tt.role, tt.target)
+			if code != tt.wantCode || errCode != tt.wantErr {
+				t.Errorf("got %d %q, want %d %q", code, errCode, tt.wantCode, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		action   string
+		role     string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "own profile", resource: "profile", action: "update", role: "user", wantCode: http.StatusNoContent},
+		{name: "inherited permission", resource: "users", action: "read", role: "admin", wantCode: http.StatusNoContent},
+		{name: "wildcard", resource: "system", action: "configure", role: "super_admin", wantCode: http.StatusNoContent},
+		{
+			name: "moderator cannot delete", resource: "users", action: "delete", role: "moderator",
+			wantCode: http.StatusForbidden, wantErr: "INSUFFICIENT_PERMISSIONS",
+		},
+		{
+			name: "admin lacks unlisted permission", resource: "system", action: "configure", role: "admin",
+			wantCode: http.StatusForbidden, wantErr: "INSUFFICIENT_PERMISSIONS",
+		},
+		{
+			name: "unauthenticated", resource: "users", action: "read",
+			wantCode: http.StatusUnauthorized, wantErr: "AUTHENTICATION_REQUIRED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, errCode := authorize(t, RequirePermission(tt.resource, tt.action), "user-1", tt.role, "user-1")
+			if code != tt.wantCode || errCode != tt.wantErr {
+				t.Errorf("got %d %q, want %d %q", code, errCode, tt.wantCode, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHasRequiredRoleFollowsDomainHierarchy(t *testing.T) {
+	tests := []struct {
+		user     string
+		required string
+		want     bool
+	}{
+		{user: "super_admin", required: "admin", want: true},
+		{user: "admin", required: "admin", want: true},
+		{user: "moderator", required: "user", want: true},
+		{user: "moderator", required: "admin", want: false},
+		{user: "root", required: "user", want: false},
+		{user: "admin", required: "root", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := hasRequiredRole(tt.user, tt.required); got != tt.want {
+			t.Errorf("hasRequiredRole(%q, %q) = %v, want %v", tt.user, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestAuthMiddlewarePutsActorOnRequestContext(t *testing.T) {
+	jwtService := auth.NewJWTService(auth.Config{Secret: "test-secret"})
+
+	token, _, err := jwtService.GenerateAccessToken("user-1", "user@example.com", "user")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken: %v", err)
+	}
+
+	var actor string
+
+	router := gin.New()
+	router.GET("/", AuthMiddleware(jwtService), func(c *gin.Context) {
+		actor = requestctx.Actor(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent || actor != "user-1" {
+		t.Errorf("got %d with actor %q, want %d with actor user-1", rec.Code, actor, http.StatusNoContent)
+	}
+}
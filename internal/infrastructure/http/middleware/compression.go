@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCompressionMinSize é o tamanho mínimo de corpo comprimido por padrão.
+const DefaultCompressionMinSize = 1024
+
+// CompressionConfig representa a configuração da compressão de respostas.
+type CompressionConfig struct {
+	Enabled bool
+	// MinSize é o tamanho mínimo do corpo, em bytes, para que seja comprimido;
+	// respostas menores são enviadas como vieram. Valores < 1 usam o padrão.
+	MinSize int
+	// Level é o nível do gzip (gzip.BestSpeed a gzip.BestCompression);
+	// zero e valores inválidos usam gzip.DefaultCompression.
+	Level int
+}
+
+// incompressibleTypes lista prefixos de Content-Type que já são comprimidos.
+var incompressibleTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/pdf",
+	"application/octet-stream",
+}
+
+// CompressionMiddleware comprime com gzip as respostas de clientes que aceitam
+// gzip em Accept-Encoding. O corpo é acumulado até MinSize antes de decidir; um
+// Flush do handler (respostas em streaming) decide na hora e libera o que já foi
+// escrito, comprimido se o tipo de conteúdo permitir.
+func CompressionMiddleware(config CompressionConfig) gin.HandlerFunc {
+	if config.MinSize < 1 {
+		config.MinSize = DefaultCompressionMinSize
+	}
+
+	// Zero (gzip.NoCompression) não comprime nada; é tratado como não configurado
+	if config.Level == gzip.NoCompression || config.Level < gzip.HuffmanOnly || config.Level > gzip.BestCompression {
+		config.Level = gzip.DefaultCompression
+	}
+
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) ||
+			c.Request.Method == http.MethodHead ||
+			c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, config: config}
+		c.Writer = writer
+
+		defer func() {
+			writer.finish()
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// acceptsGzip informa se o cabeçalho Accept-Encoding aceita gzip (q > 0).
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if name != "gzip" && name != "*" {
+			continue
+		}
+
+		// q=0 recusa explicitamente a codificação
+		if encodingWeight(params) == 0 {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// encodingWeight lê o peso q dos parâmetros de uma codificação (1 se ausente).
+func encodingWeight(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.TrimSpace(key) != "q" {
+			continue
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0
+		}
+
+		return weight
+	}
+
+	return 1
+}
+
+// compressWriter acumula o início do corpo para decidir se comprime a resposta.
+type compressWriter struct {
+	gin.ResponseWriter
+	gzip     *gzip.Writer
+	buffer   bytes.Buffer
+	config   CompressionConfig
+	decided  bool
+	finished bool
+}
+
+// Write acumula o corpo até MinSize e depois escreve, comprimido ou não.
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer.Write(data)
+
+		if w.buffer.Len() < w.config.MinSize {
+			return len(data), nil
+		}
+
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+
+		return len(data), nil
+	}
+
+	if w.gzip != nil {
+		return w.gzip.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString acumula o corpo da mesma forma que Write.
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written informa se algo já foi escrito, inclusive no buffer.
+func (w *compressWriter) Written() bool {
+	return w.buffer.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// Flush decide a compressão com o que foi escrito até agora e envia ao cliente.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(true); err != nil {
+			return
+		}
+	}
+
+	if w.gzip != nil {
+		_ = w.gzip.Flush()
+	}
+
+	w.ResponseWriter.Flush()
+}
+
+// decide escolhe entre comprimir ou não e libera o buffer.
+// Com sizeReached false, o corpo é menor que MinSize e segue sem compressão.
+func (w *compressWriter) decide(sizeReached bool) error {
+	w.decided = true
+
+	if sizeReached && w.compressible() {
+		header := w.Header()
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+
+		// O nível já foi validado, então NewWriterLevel não falha
+		w.gzip, _ = gzip.NewWriterLevel(w.ResponseWriter, w.config.Level)
+	}
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if w.gzip != nil {
+		_, err = w.gzip.Write(w.buffer.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buffer.Bytes())
+	}
+
+	w.buffer.Reset()
+
+	return err
+}
+
+// compressible informa se a resposta pode ser comprimida.
+func (w *compressWriter) compressible() bool {
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(w.buffer.Bytes())
+	}
+
+	contentType = strings.ToLower(contentType)
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// finish libera o buffer restante e fecha o gzip ao fim da requisição.
+func (w *compressWriter) finish() {
+	if w.finished {
+		return
+	}
+
+	w.finished = true
+
+	if !w.decided {
+		_ = w.decide(false)
+	}
+
+	if w.gzip != nil {
+		_ = w.gzip.Close()
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// largeJSON é um corpo JSON bem acima do tamanho mínimo de compressão.
+var largeJSON = `{"users":[` + strings.Repeat(`{"name":"Ana","email":"ana@example.com"},`, 100) + `{}]}`
+
+// compressedRouter monta um router com a compressão e rotas de tipos variados.
+func compressedRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(CompressionMiddleware(CompressionConfig{Enabled: true}))
+	router.GET("/large", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(largeJSON))
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(`{"ok":true}`))
+	})
+	router.GET("/image", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", []byte(largeJSON))
+	})
+	router.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/csv")
+
+		// Exportação em streaming: cada linha é liberada com Flush
+		for range 3 {
+			_, _ = c.Writer.WriteString("id,name\n")
+			c.Writer.Flush()
+		}
+	})
+
+	return router
+}
+
+// readBody retorna o corpo da resposta, descomprimindo-o se estiver em gzip.
+func readBody(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		return rec.Body.String()
+	}
+
+	reader, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read gzip body: %v", err)
+	}
+
+	return string(body)
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	router := compressedRouter()
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantGzip       bool
+		wantBody       string
+	}{
+		{name: "large JSON with gzip", path: "/large", acceptEncoding: "gzip, deflate, br", wantGzip: true, wantBody: largeJSON},
+		{name: "large JSON without Accept-Encoding", path: "/large", wantBody: largeJSON},
+		{name: "gzip refused with q=0", path: "/large", acceptEncoding: "gzip;q=0, br", wantBody: largeJSON},
+		{name: "wildcard encoding", path: "/large", acceptEncoding: "*", wantGzip: true, wantBody: largeJSON},
+		{name: "small body", path: "/small", acceptEncoding: "gzip", wantBody: `{"ok":true}`},
+		{name: "already compressed type", path: "/image", acceptEncoding: "gzip", wantBody: largeJSON},
+		{name: "streamed export", path: "/stream", acceptEncoding: "gzip", wantGzip: true, wantBody: strings.Repeat("id,name\n", 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			if gzipped := rec.Header().Get("Content-Encoding") == "gzip"; gzipped != tt.wantGzip {
+				t.Errorf("Content-Encoding = %q, want gzip %v", rec.Header().Get("Content-Encoding"), tt.wantGzip)
+			}
+
+			// Corpos grandes e repetitivos precisam encolher de fato
+			if tt.wantGzip && len(tt.wantBody) > DefaultCompressionMinSize && rec.Body.Len() >= len(tt.wantBody) {
+				t.Errorf("compressed body has %d bytes, original %d", rec.Body.Len(), len(tt.wantBody))
+			}
+
+			if got := readBody(t, rec); got != tt.wantBody {
+				t.Errorf("body = %.80q, want %.80q", got, tt.wantBody)
+			}
+
+			if rec.Header().Get("Vary") != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", rec.Header().Get("Vary"))
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/shared/response"
+)
+
+// RequireJSON recusa com 415 as requisições POST, PUT e PATCH cujo corpo não
+// seja declarado como application/json (ou um tipo +json, como
+// application/merge-patch+json); parâmetros como charset são aceitos.
+// Requisições sem corpo passam, e exemptPaths (templates do gin), como rotas
+// de importação multipart, ficam de fora da verificação.
+func RequireJSON(exemptPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasJSONBodyMethod(c.Request.Method) || !hasBody(c.Request) ||
+			slices.Contains(exemptPaths, c.FullPath()) || isJSONContentType(c.GetHeader("Content-Type")) {
+			c.Next()
+			return
+		}
+
+		response.Error(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE",
+			"Content-Type must be application/json")
+		c.Abort()
+	}
+}
+
+// hasJSONBodyMethod informa se o método envia um corpo JSON nesta API.
+func hasJSONBodyMethod(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+// hasBody informa se a requisição tem corpo; tamanho desconhecido (-1, como
+// em chunked) conta como corpo.
+func hasBody(request *http.Request) bool {
+	return request.ContentLength != 0 || len(request.TransferEncoding) > 0
+}
+
+// isJSONContentType informa se o Content-Type declara JSON.
+func isJSONContentType(header string) bool {
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" ||
+		(strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json"))
+}
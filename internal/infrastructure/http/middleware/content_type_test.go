@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantCode    int
+	}{
+		{name: "json", method: http.MethodPost, path: "/users", contentType: "application/json", body: `{}`, wantCode: http.StatusNoContent},
+		{name: "json with charset", method: http.MethodPut, path: "/users", contentType: "application/json; charset=utf-8", body: `{}`, wantCode: http.StatusNoContent},
+		{name: "merge patch", method: http.MethodPatch, path: "/users", contentType: "application/merge-patch+json", body: `{}`, wantCode: http.StatusNoContent},
+		{name: "missing content type", method: http.MethodPost, path: "/users", body: `{}`, wantCode: http.StatusUnsupportedMediaType},
+		{name: "form", method: http.MethodPost, path: "/users", contentType: "application/x-www-form-urlencoded", body: "name=Ana", wantCode: http.StatusUnsupportedMediaType},
+		{name: "text", method: http.MethodPatch, path: "/users", contentType: "text/plain", body: `{}`, wantCode: http.StatusUnsupportedMediaType},
+		{name: "malformed content type", method: http.MethodPost, path: "/users", contentType: "application/", body: `{}`, wantCode: http.StatusUnsupportedMediaType},
+		// Sem corpo, ações como POST /:id/impersonate não precisam declarar tipo
+		{name: "post without body", method: http.MethodPost, path: "/users", wantCode: http.StatusNoContent},
+		{name: "delete with body", method: http.MethodDelete, path: "/users", contentType: "text/plain", body: "x", wantCode: http.StatusNoContent},
+		{name: "exempt multipart import", method: http.MethodPost, path: "/import", contentType: "multipart/form-data; boundary=x", body: "--x--", wantCode: http.StatusNoContent},
+	}
+
+	router := gin.New()
+	router.Use(RequireJSON("/import"))
+	router.Any("/users", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.POST("/import", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			if tt.wantCode != http.StatusUnsupportedMediaType {
+				return
+			}
+
+			var body struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error != "UNSUPPORTED_MEDIA_TYPE" {
+				t.Errorf("body = %s, want error UNSUPPORTED_MEDIA_TYPE", rec.Body.String())
+			}
+		})
+	}
+}
@@ -1,35 +1,62 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"slices"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ErrWildcardWithCredentials indica que a origem "*" foi combinada com credenciais,
+// o que os navegadores rejeitam e abriria o acesso credenciado a qualquer origem.
+var ErrWildcardWithCredentials = errors.New("wildcard CORS origin cannot be combined with credentials")
+
 // CORSConfig representa a configuração do CORS.
 type CORSConfig struct {
-	AllowedOrigins   []string
-	AllowedMethods   []string
-	AllowedHeaders   []string
-	ExposedHeaders   []string
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposedHeaders []string
+	// MaxAge é o tempo, em segundos, de cache do preflight.
 	MaxAge           int
 	AllowCredentials bool
 }
 
+// Validate verifica se a configuração é válida.
+func (config CORSConfig) Validate() error {
+	if config.AllowCredentials && slices.Contains(config.AllowedOrigins, "*") {
+		return ErrWildcardWithCredentials
+	}
+
+	return nil
+}
+
 // CORS cria um middleware de CORS.
+// Entra em pânico se a configuração for inválida (ver CORSConfig.Validate).
 func CORS(config CORSConfig) gin.HandlerFunc {
+	if err := config.Validate(); err != nil {
+		panic(err)
+	}
+
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
 
-		// Verificar se a origem é permitida
-		if isOriginAllowed(origin, config.AllowedOrigins) {
-			c.Header("Access-Control-Allow-Origin", origin)
-		} else if len(config.AllowedOrigins) > 0 {
-			// Se não for permitida e não for wildcard, usar a primeira origem permitida
-			c.Header("Access-Control-Allow-Origin", config.AllowedOrigins[0])
-		} else {
-			// Se não houver origens específicas, permitir todas
+		// A resposta depende da origem, então caches não devem compartilhá-la
+		c.Header("Vary", "Origin")
+
+		switch {
+		case len(config.AllowedOrigins) == 0 && !config.AllowCredentials:
+			// Sem origens específicas, permitir todas
 			c.Header("Access-Control-Allow-Origin", "*")
+		case isOriginAllowed(origin, config.AllowedOrigins):
+			if slices.Contains(config.AllowedOrigins, "*") {
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else {
+				// Com credenciais a origem precisa ser ecoada, nunca "*"
+				c.Header("Access-Control-Allow-Origin", origin)
+			}
 		}
 
 		// Configurar outros headers
@@ -50,10 +77,10 @@ func CORS(config CORSConfig) gin.HandlerFunc {
 		}
 
 		if config.MaxAge > 0 {
-			c.Header("Access-Control-Max-Age", string(rune(config.MaxAge)))
+			c.Header("Access-Control-Max-Age", strconv.Itoa(config.MaxAge))
 		}
 
-		if config.AllowCredentials {
+		if config.AllowCredentials && c.Writer.Header().Get("Access-Control-Allow-Origin") != "" {
 			c.Header("Access-Control-Allow-Credentials", "true")
 		}
 
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSCredentialedOrigin(t *testing.T) {
+	router := gin.New()
+	router.Use(CORS(CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}))
+	router.GET("/users", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	tests := []struct {
+		name            string
+		method          string
+		origin          string
+		wantOrigin      string
+		wantCredentials string
+		wantMaxAge      string
+		wantCode        int
+	}{
+		{
+			name:            "allowed origin is echoed",
+			method:          http.MethodGet,
+			origin:          "https://app.example.com",
+			wantOrigin:      "https://app.example.com",
+			wantCredentials: "true",
+			wantMaxAge:      "600",
+			wantCode:        http.StatusNoContent,
+		},
+		{
+			name:            "preflight is cached",
+			method:          http.MethodOptions,
+			origin:          "https://app.example.com",
+			wantOrigin:      "https://app.example.com",
+			wantCredentials: "true",
+			wantMaxAge:      "600",
+			wantCode:        http.StatusNoContent,
+		},
+		{
+			name:       "unknown origin gets no credentials",
+			method:     http.MethodGet,
+			origin:     "https://evil.example.com",
+			wantMaxAge: "600",
+			wantCode:   http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/users", nil)
+			req.Header.Set("Origin", tt.origin)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			header := rec.Header()
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			if got := header.Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+
+			if got := header.Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("Allow-Credentials = %q, want %q", got, tt.wantCredentials)
+			}
+
+			if got := header.Get("Access-Control-Max-Age"); got != tt.wantMaxAge {
+				t.Errorf("Max-Age = %q, want %q", got, tt.wantMaxAge)
+			}
+
+			if got := header.Get("Vary"); got != "Origin" {
+				t.Errorf("Vary = %q, want Origin", got)
+			}
+		})
+	}
+}
+
+func TestCORSRejectsWildcardWithCredentials(t *testing.T) {
+	config := CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}
+
+	if err := config.Validate(); !errors.Is(err, ErrWildcardWithCredentials) {
+		t.Fatalf("Validate = %v, want %v", err, ErrWildcardWithCredentials)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("CORS did not panic on an invalid config")
+		}
+	}()
+
+	CORS(config)
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatusClientClosedRequest é o status (convenção do nginx) registrado quando o
+// cliente desconecta antes da resposta. Ele aparece apenas nos logs: não há
+// mais ninguém para recebê-lo.
+const StatusClientClosedRequest = 499
+
+// ClientGone informa se ctx foi cancelado porque o cliente desconectou. O
+// net/http cancela o contexto da requisição ao perder a conexão; prazos
+// estourados (context.DeadlineExceeded) não contam.
+func ClientGone(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
+
+// ClientDisconnectMiddleware interrompe a cadeia quando o cliente desconectou
+// antes do handler começar, evitando trabalho e consultas que ninguém lerá.
+// Depois que o handler começa, o cancelamento chega às consultas pelo contexto
+// (o GORM recebe o contexto da requisição com WithContext).
+func ClientDisconnectMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ClientGone(c.Request.Context()) {
+			c.AbortWithStatus(StatusClientClosedRequest)
+			return
+		}
+
+		c.Next()
+	}
+}
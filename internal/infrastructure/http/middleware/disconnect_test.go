@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+)
+
+func TestClientGone(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	tests := []struct {
+		ctx  context.Context
+		name string
+		want bool
+	}{
+		{name: "client disconnected", ctx: canceled, want: true},
+		{name: "deadline exceeded", ctx: expired},
+		{name: "live request", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClientGone(tt.ctx); got != tt.want {
+				t.Errorf("ClientGone = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientDisconnectMiddlewareSkipsHandler(t *testing.T) {
+	reached := false
+
+	router := gin.New()
+	router.Use(ClientDisconnectMiddleware())
+	router.GET("/users", func(c *gin.Context) {
+		reached = true
+		c.Status(http.StatusOK)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil).WithContext(ctx))
+
+	if reached || rec.Code != StatusClientClosedRequest {
+		t.Errorf("handler reached = %v, status = %d; want skipped with %d", reached, rec.Code, StatusClientClosedRequest)
+	}
+}
+
+func TestLoggingMiddlewareRecordsClientClosedRequest(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	appLogger := &logger.Logger{Logger: zap.New(core)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), LoggingMiddleware(appLogger))
+	router.GET("/users", func(c *gin.Context) {
+		// O cliente desconecta durante a consulta e o handler responde 500
+		cancel()
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil).WithContext(ctx))
+
+	if n := len(logs.FilterMessage("HTTP Request").All()); n != 0 {
+		t.Errorf("got %d access entries with the handler's status, want none", n)
+	}
+
+	entries := logs.FilterMessage("Client closed request").All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d client-closed entries, want 1", len(entries))
+	}
+
+	// Não é um erro do servidor: nível info, com o status 499
+	fields := entries[0].ContextMap()
+	if entries[0].Level != zapcore.InfoLevel || fields["status"] != int64(StatusClientClosedRequest) {
+		t.Errorf("entry at %s with status %v, want info with %d", entries[0].Level, fields["status"], StatusClientClosedRequest)
+	}
+
+	if fields["request_id"] == nil || fields["request_id"] == "" {
+		t.Errorf("entry has no request id: %v", fields)
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+)
+
+// ImpersonationAuditMiddleware registra no log de auditoria cada requisição feita
+// com um token de personificação, identificando o usuário personificado e o
+// administrador real. Deve ser registrado depois de AuthMiddleware.
+func ImpersonationAuditMiddleware(appLogger *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if _, ok := GetImpersonatorID(c); !ok || appLogger == nil {
+			return
+		}
+
+		userID, _ := GetUserID(c)
+
+		// O contexto da requisição já carrega impersonator_id junto dos IDs de correlação
+		appLogger.WithComponent("audit").WithContext(c.Request.Context()).Info("Impersonated request",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.String("user_id", userID),
+		)
+	}
+}
@@ -1,102 +1,107 @@
 package middleware
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+	"github.com/devleo-m/go-zero/internal/shared/response"
 )
 
-// LoggingMiddleware cria um middleware de logging.
-func LoggingMiddleware(logger interface{}) gin.HandlerFunc {
+// LoggingMiddleware cria o middleware de log de acesso.
+// Deve ser registrado depois de RequestIDMiddleware, para compartilhar o request ID
+// com os demais logs da requisição, e antes de RecoveryMiddleware, para registrar
+// o status final mesmo quando a resposta foi escrita pelo tratamento de erro.
+func LoggingMiddleware(appLogger *logger.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Gerar request ID único
-		requestID := uuid.New().String()
-		c.Set("request_id", requestID)
-
-		// Adicionar request ID ao header de resposta
-		c.Header("X-Request-ID", requestID)
-
-		// Registrar início da requisição
 		start := time.Now()
+		// Contexto original da conexão: middlewares internos podem trocá-lo
+		// por contextos derivados, que são cancelados ao terminar
+		clientCtx := c.Request.Context()
 
-		// Processar requisição
 		c.Next()
 
-		// Calculator duração
-		duration := time.Since(start)
+		if ClientGone(clientCtx) {
+			logClientClosed(c, appLogger, time.Since(start))
+			return
+		}
+
+		logRequest(c, appLogger, time.Since(start))
+	}
+}
+
+// logClientClosed registra, com status 499, uma requisição abandonada pelo
+// cliente. O que o handler respondeu (em geral um 500 causado pela consulta
+// cancelada) nunca chegou ao cliente e não gera um log de erro.
+func logClientClosed(c *gin.Context, appLogger *logger.Logger, duration time.Duration) {
+	if appLogger == nil {
+		fmt.Fprintf(gin.DefaultWriter, "HTTP Request (request_id=%s): %s %s %d %s (client closed request)\n",
+			c.GetString("request_id"), c.Request.Method, c.Request.URL.Path, StatusClientClosedRequest, duration)
 
-		// Registrar fim da requisição
-		logRequest(c, start, duration)
+		return
 	}
+
+	appLogger.WithContext(c.Request.Context()).Info("Client closed request",
+		zap.String("method", c.Request.Method),
+		zap.String("path", c.Request.URL.Path),
+		zap.Int("status", StatusClientClosedRequest),
+		zap.Duration("duration", duration),
+		zap.String("client_ip", c.ClientIP()),
+	)
 }
 
-// logRequest registra informações da requisição.
-func logRequest(c *gin.Context, start time.Time, duration time.Duration) {
-	// Obter informações da requisição
-	method := c.Request.Method
-	path := c.Request.URL.Path
+// logRequest registra a requisição concluída, com nível baseado no status.
+func logRequest(c *gin.Context, appLogger *logger.Logger, duration time.Duration) {
 	statusCode := c.Writer.Status()
-	clientIP := c.ClientIP()
-	userAgent := c.Request.UserAgent()
-
-	// Obter request ID
-	requestID, _ := c.Get("request_id")
-
-	// Obter informações do usuário se autenticado
-	userRole, _ := c.Get("user_role")
-
-	// Criar campos de log
-	fields := []interface{}{
-		"method", method,
-		"path", path,
-		"status", statusCode,
-		"duration", duration.String(),
-		"client_ip", clientIP,
-		"user_agent", userAgent,
-		"request_id", requestID,
+
+	fields := []zap.Field{
+		zap.String("method", c.Request.Method),
+		zap.String("path", c.Request.URL.Path),
+		zap.Int("status", statusCode),
+		zap.Duration("duration", duration),
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_agent", c.Request.UserAgent()),
+		zap.Int("response_size", c.Writer.Size()),
 	}
 
-	// Adicionar informações do usuário se disponíveis
-	if userRole != nil {
-		fields = append(fields, "user_role", userRole)
+	if userRole, exists := c.Get("user_role"); exists {
+		fields = append(fields, zap.Any("user_role", userRole))
 	}
 
-	// Adicionar tamanho da resposta
-	fields = append(fields, "response_size", c.Writer.Size())
-
-	// Log baseado no status code
-	if statusCode >= 500 {
-		// Erro do servidor
-		logError(c, "HTTP Request", fields...)
-	} else if statusCode >= 400 {
-		// Erro do cliente
-		logWarn(c, "HTTP Request", fields...)
-	} else {
-		// Sucesso
-		logInfo(c, "HTTP Request", fields...)
+	if len(c.Errors) > 0 {
+		fields = append(fields, zap.String("errors", c.Errors.String()))
 	}
-}
 
-// logInfo registra um log de informação.
-func logInfo(c *gin.Context, msg string, fields ...interface{}) {
-	// Aqui você pode integrar com seu sistema de logging
-	// Por exemplo, usando zap, logrus, etc.
-	// Por enquanto, vamos usar o logger padrão do Gin
-	gin.DefaultWriter.Write([]byte(msg + "\n"))
-}
+	if appLogger == nil {
+		fmt.Fprintf(gin.DefaultWriter, "HTTP Request (request_id=%s): %s %s %d %s\n",
+			c.GetString("request_id"), c.Request.Method, c.Request.URL.Path, statusCode, duration)
 
-// logWarn registra um log de aviso.
-func logWarn(c *gin.Context, msg string, fields ...interface{}) {
-	gin.DefaultErrorWriter.Write([]byte(msg + "\n"))
-}
+		return
+	}
+
+	// O contexto da requisição carrega request_id e trace_id
+	requestLogger := appLogger.WithContext(c.Request.Context())
 
-// logError registra um log de erro.
-func logError(c *gin.Context, msg string, fields ...interface{}) {
-	gin.DefaultErrorWriter.Write([]byte(msg + "\n"))
+	switch {
+	case statusCode >= http.StatusInternalServerError:
+		requestLogger.Error("HTTP Request", fields...)
+	case statusCode >= http.StatusBadRequest:
+		requestLogger.Warn("HTTP Request", fields...)
+	default:
+		requestLogger.Info("HTTP Request", fields...)
+	}
 }
 
-// RequestIDMiddleware adiciona um request ID único a cada requisição.
+// RequestIDMiddleware adiciona um request ID único a cada requisição e
+// propaga o request ID e o trace ID pelo context.Context da requisição.
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
@@ -104,23 +109,45 @@ func RequestIDMiddleware() gin.HandlerFunc {
 			requestID = uuid.New().String()
 		}
 
+		// Sem um trace ID externo, o próprio request ID correlaciona os logs
+		traceID := c.GetHeader("X-Trace-ID")
+		if traceID == "" {
+			traceID = requestID
+		}
+
 		c.Set("request_id", requestID)
+		c.Set("trace_id", traceID)
 		c.Header("X-Request-ID", requestID)
+
+		ctx := requestctx.WithRequestID(c.Request.Context(), requestID)
+		ctx = requestctx.WithTraceID(ctx, traceID)
+		c.Request = c.Request.WithContext(ctx)
+
 		c.Next()
 	}
 }
 
 // RecoveryMiddleware cria um middleware de recovery personalizado.
-func RecoveryMiddleware() gin.HandlerFunc {
-	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		// Log do erro
-		requestID, _ := c.Get("request_id")
-
-		// Aqui você pode integrar com seu sistema de logging
-		gin.DefaultErrorWriter.Write([]byte("Panic recovered: " + recovered.(error).Error() + "\n"))
+// O valor do panic e a stack são registrados apenas no log, junto do request ID;
+// o cliente recebe um envelope estável contendo somente o request ID para correlação.
+func RecoveryMiddleware(appLogger *logger.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered interface{}) {
+		requestID := c.GetString("request_id")
+		stack := debug.Stack()
+
+		if appLogger != nil {
+			appLogger.WithContext(c.Request.Context()).Error("Panic recovered",
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("panic", fmt.Sprint(recovered)),
+				zap.ByteString("stack", stack),
+			)
+		} else {
+			fmt.Fprintf(gin.DefaultErrorWriter, "Panic recovered (request_id=%s): %v\n%s\n", requestID, recovered, stack)
+		}
 
-		// Responder com erro interno do servidor
-		c.JSON(500, gin.H{
+		c.Abort()
+		response.JSON(c, http.StatusInternalServerError, gin.H{
 			"success":    false,
 			"error":      "INTERNAL_SERVER_ERROR",
 			"message":    "An internal error occurred",
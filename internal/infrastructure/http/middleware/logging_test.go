@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+)
+
+func TestRecoveryMiddlewareReturnsOnlyCorrelationID(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RecoveryMiddleware(&logger.Logger{Logger: zap.New(core)}))
+	router.GET("/panic", func(*gin.Context) {
+		panic("db password is hunter2")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	if strings.Contains(rec.Body.String(), "hunter2") {
+		t.Errorf("response leaks the panic value: %s", rec.Body.String())
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v (%q)", err, rec.Body.String())
+	}
+
+	if body["error"] != "INTERNAL_SERVER_ERROR" || body["request_id"] != "req-123" || body["success"] != false {
+		t.Errorf("body = %v, want INTERNAL_SERVER_ERROR with request_id req-123", body)
+	}
+
+	entries := logs.FilterMessage("Panic recovered").All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d panic log entries, want 1", len(entries))
+	}
+
+	entry := entries[0]
+	fields := entry.ContextMap()
+
+	if entry.Level != zapcore.ErrorLevel || fields["request_id"] != "req-123" {
+		t.Errorf("log level %s, request_id %v; want error level with req-123", entry.Level, fields["request_id"])
+	}
+
+	if fields["panic"] != "db password is hunter2" || !strings.Contains(fields["stack"].(string), "goroutine") {
+		t.Errorf("log is missing the panic value or stack: %v", fields)
+	}
+}
+
+func TestAccessLogSharesRequestIDWithErrorLog(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	appLogger := &logger.Logger{Logger: zap.New(core)}
+
+	// Mesma ordem de SetupRoutes
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), LoggingMiddleware(appLogger), RecoveryMiddleware(appLogger))
+	router.GET("/panic", func(*gin.Context) {
+		panic("boom")
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		appLogger.WithContext(c.Request.Context()).Warn("User lookup failed")
+		c.AbortWithStatus(http.StatusNotFound)
+	})
+
+	tests := []struct {
+		path       string
+		errorMsg   string
+		wantStatus int
+		wantLevel  zapcore.Level
+	}{
+		{path: "/panic", errorMsg: "Panic recovered", wantStatus: http.StatusInternalServerError, wantLevel: zapcore.ErrorLevel},
+		{path: "/missing", errorMsg: "User lookup failed", wantStatus: http.StatusNotFound, wantLevel: zapcore.WarnLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			logs.TakeAll()
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			requestID := rec.Header().Get("X-Request-ID")
+			if rec.Code != tt.wantStatus || requestID == "" {
+				t.Fatalf("status = %d, request id %q; want %d with a request id", rec.Code, requestID, tt.wantStatus)
+			}
+
+			errorEntries := logs.FilterMessage(tt.errorMsg).All()
+			accessEntries := logs.FilterMessage("HTTP Request").All()
+
+			if len(errorEntries) != 1 || len(accessEntries) != 1 {
+				t.Fatalf("got %d error and %d access entries, want 1 each", len(errorEntries), len(accessEntries))
+			}
+
+			errorFields := errorEntries[0].ContextMap()
+			accessFields := accessEntries[0].ContextMap()
+
+			if errorFields["request_id"] != requestID || accessFields["request_id"] != requestID {
+				t.Errorf("request ids: error %v, access %v; want %s", errorFields["request_id"], accessFields["request_id"], requestID)
+			}
+
+			// O access log registra o status final, escrito depois do handler
+			if accessFields["status"] != int64(tt.wantStatus) || accessEntries[0].Level != tt.wantLevel {
+				t.Errorf("access log status %v at %s, want %d at %s",
+					accessFields["status"], accessEntries[0].Level, tt.wantStatus, tt.wantLevel)
+			}
+
+			if _, ok := accessFields["duration"]; !ok {
+				t.Errorf("access log has no duration: %v", accessFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaintenanceRetryAfter é o Retry-After sugerido durante a manutenção.
+const DefaultMaintenanceRetryAfter = 5 * time.Minute
+
+// MaintenanceGate recusa com 503 as requisições de escrita enquanto o modo de
+// manutenção está ativo; leituras (GET, HEAD, OPTIONS), como os health checks,
+// continuam atendidas. A função enabled é consultada a cada requisição.
+// exemptPaths (templates do gin) continuam aceitando escrita, para que o modo
+// possa ser desligado pela API.
+func MaintenanceGate(enabled func() bool, retryAfter time.Duration, exemptPaths ...string) gin.HandlerFunc {
+	if retryAfter <= 0 {
+		retryAfter = DefaultMaintenanceRetryAfter
+	}
+
+	retryAfterSeconds := strconv.Itoa(max(1, int(retryAfter.Seconds())))
+
+	return func(c *gin.Context) {
+		if !enabled() || isReadMethod(c.Request.Method) || slices.Contains(exemptPaths, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", retryAfterSeconds)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error":   "MAINTENANCE",
+			"message": "Service is under maintenance, try again later",
+		})
+		c.Abort()
+	}
+}
+
+// isReadMethod informa se o método HTTP não altera estado.
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
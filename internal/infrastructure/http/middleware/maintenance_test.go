@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMaintenanceGate(t *testing.T) {
+	enabled := false
+
+	router := gin.New()
+	router.Use(MaintenanceGate(func() bool { return enabled }, 0, "/admin/system/config"))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/health", ok)
+	router.GET("/users", ok)
+	router.HEAD("/users", ok)
+	router.OPTIONS("/users", ok)
+	router.POST("/users", ok)
+	router.DELETE("/users/:id", ok)
+	router.PUT("/admin/system/config", ok)
+
+	tests := []struct {
+		method      string
+		path        string
+		maintenance bool
+		wantCode    int
+	}{
+		{method: http.MethodPost, path: "/users", wantCode: http.StatusOK},
+		{method: http.MethodPost, path: "/users", maintenance: true, wantCode: http.StatusServiceUnavailable},
+		{method: http.MethodDelete, path: "/users/1", maintenance: true, wantCode: http.StatusServiceUnavailable},
+		{method: http.MethodGet, path: "/users", maintenance: true, wantCode: http.StatusOK},
+		{method: http.MethodHead, path: "/users", maintenance: true, wantCode: http.StatusOK},
+		{method: http.MethodOptions, path: "/users", maintenance: true, wantCode: http.StatusOK},
+		{method: http.MethodGet, path: "/health", maintenance: true, wantCode: http.StatusOK},
+		{method: http.MethodPut, path: "/admin/system/config", maintenance: true, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			// A flag é lida a cada requisição, sem recriar o middleware
+			enabled = tt.maintenance
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			// Sem retryAfter configurado, vale DefaultMaintenanceRetryAfter
+			retryAfter := rec.Header().Get("Retry-After")
+			if tt.wantCode == http.StatusServiceUnavailable {
+				want := strconv.Itoa(int(DefaultMaintenanceRetryAfter.Seconds()))
+				if retryAfter != want || !strings.Contains(rec.Body.String(), `"error":"MAINTENANCE"`) {
+					t.Errorf("Retry-After = %q, body %s; want %s and MAINTENANCE", retryAfter, rec.Body.String(), want)
+				}
+			} else if retryAfter != "" {
+				t.Errorf("Retry-After = %q on an allowed request", retryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/shared/response"
+	"github.com/devleo-m/go-zero/internal/shared/validation"
+)
+
+// uuidParamKeyPrefix prefixa a chave do contexto do gin onde cada parâmetro de
+// rota já convertido para UUID é guardado.
+const uuidParamKeyPrefix = "uuid_param:"
+
+// UUIDParams valida, uma única vez por requisição, os parâmetros de rota
+// informados (ex.: "id") e guarda o uuid.UUID no contexto para os handlers.
+// IDs malformados recebem 400 INVALID_ID; rotas do grupo sem o parâmetro passam.
+func UUIDParams(names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, name := range names {
+			if c.Param(name) == "" {
+				continue
+			}
+
+			if _, ok := UUIDParam(c, name); !ok {
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// UUIDParam retorna o parâmetro de rota name como uuid.UUID, reutilizando o
+// valor já validado por UUIDParams. Sem o middleware, o parâmetro é convertido
+// aqui; quando malformado, responde 400 INVALID_ID e retorna false.
+func UUIDParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	if value, exists := c.Get(uuidParamKeyPrefix + name); exists {
+		if id, ok := value.(uuid.UUID); ok {
+			return id, true
+		}
+	}
+
+	raw := c.Param(name)
+	if err := validation.ValidateUUID(raw); err != nil {
+		response.BadRequest(c, "INVALID_ID", err.Error())
+		return uuid.Nil, false
+	}
+
+	// ValidateUUID aceita apenas o formato canônico, que uuid.Parse sempre converte
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		response.BadRequest(c, "INVALID_ID", "Invalid ID format")
+		return uuid.Nil, false
+	}
+
+	c.Set(uuidParamKeyPrefix+name, id)
+
+	return id, true
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestUUIDParams(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantID   uuid.UUID
+	}{
+		{name: "valid id", path: "/users/" + id.String(), wantCode: http.StatusOK, wantID: id},
+		{name: "malformed id", path: "/users/not-a-uuid", wantCode: http.StatusBadRequest},
+		{name: "uppercase id", path: "/users/" + strings.ToUpper(id.String()), wantCode: http.StatusBadRequest},
+		{name: "route without the param", path: "/users", wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached := false
+
+			// O handler lê o valor guardado pelo middleware
+			handler := func(c *gin.Context) {
+				reached = true
+
+				if c.Param("id") == "" {
+					c.Status(http.StatusOK)
+					return
+				}
+
+				got, ok := UUIDParam(c, "id")
+				if !ok || got != tt.wantID {
+					t.Errorf("UUIDParam = %s, %v, want %s", got, ok, tt.wantID)
+				}
+
+				c.Status(http.StatusOK)
+			}
+
+			router := gin.New()
+			router.Use(UUIDParams("id"))
+			router.GET("/users/:id", handler)
+			router.GET("/users", handler)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			if tt.wantCode != http.StatusBadRequest {
+				return
+			}
+
+			// Um id malformado não chega ao handler
+			if reached {
+				t.Error("handler ran for a malformed id")
+			}
+
+			var body struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error != "INVALID_ID" {
+				t.Errorf("body = %s, want error INVALID_ID", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestUUIDParamWithoutMiddleware(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name     string
+		param    string
+		wantCode int
+		wantOK   bool
+	}{
+		{name: "valid id", param: id.String(), wantCode: http.StatusNoContent, wantOK: true},
+		{name: "malformed id", param: "123", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/users/:id", func(c *gin.Context) {
+				got, ok := UUIDParam(c, "id")
+				if ok != tt.wantOK || (ok && got != id) {
+					t.Errorf("UUIDParam = %s, %v, want %s, %v", got, ok, id, tt.wantOK)
+				}
+
+				if ok {
+					c.Status(http.StatusNoContent)
+				}
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/"+tt.param, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
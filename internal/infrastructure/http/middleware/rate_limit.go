@@ -1,30 +1,105 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
 )
 
 // RateLimiter representa um limitador de taxa.
+// Por padrão os contadores ficam em memória no próprio processo; com WithStore
+// eles passam a ser mantidos no cache (janela fixa), compartilhado entre instâncias.
 type RateLimiter struct {
-	requests map[string][]time.Time
-	mutex    sync.RWMutex
-	limit    int
-	window   time.Duration
+	clock       clock.Clock
+	store       cache.Service
+	logger      *zap.Logger
+	requests    map[string][]time.Time
+	failureMode cache.FailureMode
+	scope       string
+	mutex       sync.RWMutex
+	limit       int
+	window      time.Duration
 }
 
 // NewRateLimiter cria um novo limitador de taxa.
 func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
 	return &RateLimiter{
-		requests: make(map[string][]time.Time),
-		limit:    limit,
-		window:   window,
+		clock:       clock.RealClock{},
+		logger:      zap.NewNop(),
+		requests:    make(map[string][]time.Time),
+		failureMode: cache.FailOpen,
+		limit:       limit,
+		window:      window,
 	}
 }
 
+// WithClock define o relógio usado pelo limitador (útil em testes).
+func (rl *RateLimiter) WithClock(c clock.Clock) *RateLimiter {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.clock = clock.OrReal(c)
+
+	return rl
+}
+
+// WithStore define o cache usado para armazenar os contadores.
+func (rl *RateLimiter) WithStore(store cache.Service) *RateLimiter {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.store = store
+
+	return rl
+}
+
+// WithFailureMode define o comportamento quando o cache falha: cache.FailOpen
+// (padrão) permite a requisição e cache.FailClosed a recusa.
+func (rl *RateLimiter) WithFailureMode(mode cache.FailureMode) *RateLimiter {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.failureMode = mode
+
+	return rl
+}
+
+// WithLogger define o logger usado para registrar falhas do cache.
+func (rl *RateLimiter) WithLogger(logger *zap.Logger) *RateLimiter {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	if logger != nil {
+		rl.logger = logger
+	}
+
+	return rl
+}
+
+// WithScope separa os contadores do limitador no cache, permitindo que
+// limitadores de rotas diferentes compartilhem o mesmo store.
+func (rl *RateLimiter) WithScope(scope string) *RateLimiter {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.scope = scope
+
+	return rl
+}
+
 // RateLimit cria um middleware de rate limiting.
 func RateLimit(limiter *RateLimiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -32,14 +107,73 @@ func RateLimit(limiter *RateLimiter) gin.HandlerFunc {
 		clientID := getClientIdentifier(c)
 
 		// Verificar se o cliente excedeu o limite
-		if !limiter.Allow(clientID) {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"success": false,
-				"error":   "RATE_LIMIT_EXCEEDED",
-				"message": "Too many requests",
-			})
-			c.Abort()
+		if !applyRateLimit(c, limiter, clientID) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RouteRateLimit associa um limitador às rotas sob Path (template do gin,
+// ex.: /api/v1/admin/stats), aplicado além do limitador global.
+type RouteRateLimit struct {
+	Limiter *RateLimiter
+	Path    string
+}
 
+// ErrInvalidRouteRateLimit indica uma regra de rate limit por rota malformada.
+var ErrInvalidRouteRateLimit = errors.New("invalid route rate limit")
+
+// ParseRouteRateLimits converte regras no formato "path=requests/window"
+// (ex.: "/api/v1/admin/stats=10/1m") em limitadores por rota.
+func ParseRouteRateLimits(specs []string) ([]RouteRateLimit, error) {
+	rules := make([]RouteRateLimit, 0, len(specs))
+
+	for _, spec := range specs {
+		path, limit, found := strings.Cut(strings.TrimSpace(spec), "=")
+		if !found || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRouteRateLimit, spec)
+		}
+
+		requestsValue, windowValue, found := strings.Cut(limit, "/")
+		if !found {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRouteRateLimit, spec)
+		}
+
+		requests, err := strconv.Atoi(requestsValue)
+		if err != nil || requests < 1 {
+			return nil, fmt.Errorf("%w: %q: requests must be a positive integer", ErrInvalidRouteRateLimit, spec)
+		}
+
+		window, err := time.ParseDuration(windowValue)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("%w: %q: window must be a positive duration", ErrInvalidRouteRateLimit, spec)
+		}
+
+		path = strings.TrimSuffix(path, "/")
+		rules = append(rules, RouteRateLimit{
+			Path:    path,
+			Limiter: NewRateLimiter(requests, window).WithScope(path),
+		})
+	}
+
+	return rules, nil
+}
+
+// RouteRateLimitMiddleware aplica a regra cujo Path corresponde à rota da
+// requisição (a própria rota ou qualquer sub-rota); a regra mais longa vence.
+// Requisições sem regra correspondente seguem apenas o limitador global.
+// Os cabeçalhos X-RateLimit-* passam a refletir o limite da regra.
+func RouteRateLimitMiddleware(rules []RouteRateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rule := matchRouteRateLimit(rules, c.FullPath())
+		if rule == nil {
+			c.Next()
+			return
+		}
+
+		if !applyRateLimit(c, rule.Limiter, getClientIdentifier(c)) {
 			return
 		}
 
@@ -47,25 +181,157 @@ func RateLimit(limiter *RateLimiter) gin.HandlerFunc {
 	}
 }
 
-// Allow verifica se uma requisição é permitida.
+// matchRouteRateLimit retorna a regra mais específica para a rota (nil se nenhuma).
+func matchRouteRateLimit(rules []RouteRateLimit, route string) *RouteRateLimit {
+	var matched *RouteRateLimit
+
+	for i := range rules {
+		rule := &rules[i]
+		if route != rule.Path && !strings.HasPrefix(route, rule.Path+"/") {
+			continue
+		}
+
+		if matched == nil || len(rule.Path) > len(matched.Path) {
+			matched = rule
+		}
+	}
+
+	return matched
+}
+
+// applyRateLimit consome uma requisição do limitador e informa o estado nos
+// cabeçalhos X-RateLimit-*. Excedido o limite, responde 429 com Retry-After
+// e retorna false.
+func applyRateLimit(c *gin.Context, limiter *RateLimiter, clientID string) bool {
+	status := limiter.Check(c.Request.Context(), clientID)
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(status.Reset.Unix(), 10))
+
+	if status.Allowed {
+		return true
+	}
+
+	retryAfter := status.Reset.Sub(limiter.now())
+	c.Header("Retry-After", strconv.Itoa(max(1, int(math.Ceil(retryAfter.Seconds())))))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"success": false,
+		"error":   "RATE_LIMIT_EXCEEDED",
+		"message": "Too many requests",
+	})
+	c.Abort()
+
+	return false
+}
+
+// RateLimitStatus descreve o limite de um cliente após uma verificação.
+type RateLimitStatus struct {
+	// Reset é quando a janela atual termina e o cliente volta a ter Limit requisições.
+	Reset     time.Time
+	Limit     int
+	Remaining int
+	Allowed   bool
+}
+
+// now retorna o instante atual segundo o relógio do limitador.
+func (rl *RateLimiter) now() time.Time {
+	rl.mutex.RLock()
+	defer rl.mutex.RUnlock()
+
+	return rl.clock.Now()
+}
+
+// AllowContext verifica se uma requisição é permitida, usando o cache quando configurado.
+// Em falhas do cache, o modo de falha decide se o cliente é liberado ou bloqueado.
+func (rl *RateLimiter) AllowContext(ctx context.Context, clientID string) bool {
+	return rl.Check(ctx, clientID).Allowed
+}
+
+// Check consome uma requisição do cliente e retorna o estado do limite.
+// Com o cache, Reset é a expiração da chave da janela atual. Em falhas do
+// cache, o modo de falha decide se o cliente é liberado ou bloqueado.
+func (rl *RateLimiter) Check(ctx context.Context, clientID string) RateLimitStatus {
+	rl.mutex.RLock()
+	store, logger, failureMode, scope := rl.store, rl.logger, rl.failureMode, rl.scope
+	rl.mutex.RUnlock()
+
+	if store == nil {
+		return rl.check(clientID)
+	}
+
+	key := "ratelimit:" + clientID
+	if scope != "" {
+		key = "ratelimit:" + scope + ":" + clientID
+	}
+
+	now := rl.now()
+	status := RateLimitStatus{Limit: rl.limit, Reset: now.Add(rl.window)}
+
+	// A janela começa na primeira requisição e termina quando a chave expira
+	count, ttl, err := store.Increment(ctx, key, rl.window)
+	if err != nil {
+		logger.Warn("Rate limit cache unavailable",
+			append(requestctx.LogFields(ctx),
+				zap.Error(err),
+				zap.String("failure_mode", string(failureMode)),
+			)...,
+		)
+
+		status.Allowed = failureMode != cache.FailClosed
+		if status.Allowed {
+			status.Remaining = rl.limit
+		}
+
+		return status
+	}
+
+	// Sem expiração informada (chave sem TTL), mantém a estimativa de uma
+	// janela completa
+	if ttl > 0 {
+		status.Reset = now.Add(ttl)
+	}
+
+	status.Allowed = count <= int64(rl.limit)
+	status.Remaining = max(0, rl.limit-int(count))
+
+	return status
+}
+
+// Allow verifica se uma requisição é permitida (contadores em memória).
 func (rl *RateLimiter) Allow(clientID string) bool {
+	return rl.check(clientID).Allowed
+}
+
+// check consome uma requisição dos contadores em memória.
+func (rl *RateLimiter) check(clientID string) RateLimitStatus {
 	rl.mutex.Lock()
 	defer rl.mutex.Unlock()
 
-	now := time.Now()
+	now := rl.clock.Now()
 
 	// Limpar requisições antigas
 	rl.cleanup(clientID, now)
 
+	status := RateLimitStatus{Limit: rl.limit}
+
 	// Verificar se ainda há espaço para mais requisições
-	if len(rl.requests[clientID]) >= rl.limit {
-		return false
+	if len(rl.requests[clientID]) < rl.limit {
+		// Adicionar nova requisição
+		rl.requests[clientID] = append(rl.requests[clientID], now)
+		status.Allowed = true
 	}
 
-	// Adicionar nova requisição
-	rl.requests[clientID] = append(rl.requests[clientID], now)
+	requests := rl.requests[clientID]
+	status.Remaining = max(0, rl.limit-len(requests))
+
+	// A vaga mais próxima é liberada quando a requisição mais antiga sai da janela
+	status.Reset = now.Add(rl.window)
+	if len(requests) > 0 {
+		status.Reset = requests[0].Add(rl.window)
+	}
 
-	return true
+	return status
 }
 
 // cleanup remove requisições antigas.
@@ -74,7 +340,8 @@ func (rl *RateLimiter) cleanup(clientID string, now time.Time) {
 	requests := rl.requests[clientID]
 
 	// Encontrar o primeiro índice que não deve ser removido
-	start := 0
+	// (se nenhuma requisição estiver na janela, todas são removidas)
+	start := len(requests)
 
 	for i, reqTime := range requests {
 		if reqTime.After(cutoff) {
@@ -105,7 +372,7 @@ func (rl *RateLimiter) GetRemainingRequests(clientID string) int {
 	rl.mutex.RLock()
 	defer rl.mutex.RUnlock()
 
-	now := time.Now()
+	now := rl.clock.Now()
 	cutoff := now.Add(-rl.window)
 	requests := rl.requests[clientID]
 
@@ -128,7 +395,7 @@ func (rl *RateLimiter) GetResetTime(clientID string) time.Time {
 
 	requests := rl.requests[clientID]
 	if len(requests) == 0 {
-		return time.Now()
+		return rl.clock.Now()
 	}
 
 	// Retornar o tempo da requisição mais antiga + janela
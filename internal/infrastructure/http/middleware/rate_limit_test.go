@@ -0,0 +1,317 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// rateLimitedRouter monta um router com o limitador aplicado em GET /.
+func rateLimitedRouter(limiter *RateLimiter) *gin.Engine {
+	router := gin.New()
+	router.Use(RateLimit(limiter))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	return router
+}
+
+// unavailableCache simula um cache fora do ar: toda operação falha.
+type unavailableCache struct{}
+
+var errCacheDown = errors.New("connection refused")
+
+func (unavailableCache) Get(context.Context, string) (string, error) {
+	return "", errCacheDown
+}
+
+func (unavailableCache) Set(context.Context, string, string, time.Duration) error {
+	return errCacheDown
+}
+
+func (unavailableCache) Delete(context.Context, string) error {
+	return errCacheDown
+}
+
+func (unavailableCache) Increment(context.Context, string, time.Duration) (int64, time.Duration, error) {
+	return 0, 0, errCacheDown
+}
+
+func serve(router *gin.Engine) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	return rec
+}
+
+func TestRateLimitHeadersDecrement(t *testing.T) {
+	start := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		limiter func(*clock.FakeClock) *RateLimiter
+		name    string
+	}{
+		{
+			name: "in memory",
+			limiter: func(fake *clock.FakeClock) *RateLimiter {
+				return NewRateLimiter(2, time.Minute).WithClock(fake)
+			},
+		},
+		{
+			name: "cache store",
+			limiter: func(fake *clock.FakeClock) *RateLimiter {
+				return NewRateLimiter(2, time.Minute).WithClock(fake).WithStore(cache.NewMemoryCache().WithClock(fake))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clock.NewFakeClock(start)
+			router := rateLimitedRouter(tt.limiter(fake))
+			reset := strconv.FormatInt(start.Add(time.Minute).Unix(), 10)
+
+			for i, want := range []string{"1", "0"} {
+				rec := serve(router)
+				if rec.Code != http.StatusNoContent {
+					t.Fatalf("request %d: status = %d, want %d", i+1, rec.Code, http.StatusNoContent)
+				}
+
+				if got := rec.Header().Get("X-RateLimit-Remaining"); got != want {
+					t.Errorf("request %d: X-RateLimit-Remaining = %s, want %s", i+1, got, want)
+				}
+
+				if got := rec.Header().Get("X-RateLimit-Limit"); got != "2" {
+					t.Errorf("request %d: X-RateLimit-Limit = %s, want 2", i+1, got)
+				}
+
+				if got := rec.Header().Get("X-RateLimit-Reset"); got != reset {
+					t.Errorf("request %d: X-RateLimit-Reset = %s, want %s", i+1, got, reset)
+				}
+
+				fake.Advance(20 * time.Second)
+			}
+
+			// A janela começou no primeiro pedido: restam 20s, não um minuto inteiro
+			rec := serve(router)
+			if rec.Code != http.StatusTooManyRequests {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+			}
+
+			if got := rec.Header().Get("X-RateLimit-Reset"); got != reset {
+				t.Errorf("X-RateLimit-Reset = %s, want %s", got, reset)
+			}
+
+			if got := rec.Header().Get("Retry-After"); got != "20" {
+				t.Errorf("Retry-After = %s, want 20", got)
+			}
+
+			fake.Advance(20 * time.Second)
+
+			if rec := serve(router); rec.Code != http.StatusNoContent {
+				t.Errorf("status after reset = %d, want %d", rec.Code, http.StatusNoContent)
+			}
+		})
+	}
+}
+
+func TestRateLimitKeysOnTrustedClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		trusted    []string
+		// wantSecond é o status do segundo pedido, enviado com outro X-Forwarded-For
+		wantSecond int
+	}{
+		{
+			name:       "forged header from an untrusted peer is ignored",
+			remoteAddr: "203.0.113.7:4321",
+			wantSecond: http.StatusTooManyRequests,
+		},
+		{
+			name:       "untrusted peer outside the proxy range",
+			remoteAddr: "203.0.113.7:4321",
+			trusted:    []string{"10.0.0.0/8"},
+			wantSecond: http.StatusTooManyRequests,
+		},
+		{
+			name:       "trusted proxy forwards distinct clients",
+			remoteAddr: "10.0.0.2:4321",
+			trusted:    []string{"10.0.0.0/8"},
+			wantSecond: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := rateLimitedRouter(NewRateLimiter(1, time.Minute))
+			if err := router.SetTrustedProxies(tt.trusted); err != nil {
+				t.Fatalf("SetTrustedProxies: %v", err)
+			}
+
+			for i, forwarded := range []string{"198.51.100.1", "198.51.100.2"} {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = tt.remoteAddr
+				req.Header.Set("X-Forwarded-For", forwarded)
+
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				want := http.StatusNoContent
+				if i == 1 {
+					want = tt.wantSecond
+				}
+
+				if rec.Code != want {
+					t.Errorf("request %d: status = %d, want %d", i+1, rec.Code, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitCacheOutage(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     cache.FailureMode
+		wantCode int
+	}{
+		{name: "fail open allows with a warning", mode: cache.FailOpen, wantCode: http.StatusNoContent},
+		{name: "fail closed rejects", mode: cache.FailClosed, wantCode: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.WarnLevel)
+			limiter := NewRateLimiter(1, time.Minute).
+				WithStore(unavailableCache{}).
+				WithFailureMode(tt.mode).
+				WithLogger(zap.New(core))
+			router := rateLimitedRouter(limiter)
+
+			// Sem contadores, o limite não é aplicado nem no segundo pedido
+			for i := range 2 {
+				if rec := serve(router); rec.Code != tt.wantCode {
+					t.Errorf("request %d: status = %d, want %d", i+1, rec.Code, tt.wantCode)
+				}
+			}
+
+			warnings := logs.FilterMessage("Rate limit cache unavailable").All()
+			if len(warnings) != 2 {
+				t.Fatalf("got %d warnings, want 2", len(warnings))
+			}
+
+			if got := warnings[0].ContextMap()["failure_mode"]; got != string(tt.mode) {
+				t.Errorf("failure_mode = %v, want %s", got, tt.mode)
+			}
+		})
+	}
+}
+
+func TestParseRouteRateLimits(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantPath   string
+		wantLimit  int
+		wantWindow time.Duration
+		wantErr    bool
+	}{
+		{spec: "/api/v1/admin/users/stats=10/1m", wantPath: "/api/v1/admin/users/stats", wantLimit: 10, wantWindow: time.Minute},
+		{spec: " /export/=5/30s ", wantPath: "/export", wantLimit: 5, wantWindow: 30 * time.Second},
+		{spec: "api/v1/search=5/1m", wantErr: true},
+		{spec: "/search", wantErr: true},
+		{spec: "/search=5", wantErr: true},
+		{spec: "/search=0/1m", wantErr: true},
+		{spec: "/search=ten/1m", wantErr: true},
+		{spec: "/search=5/0s", wantErr: true},
+		{spec: "/search=5/soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			rules, err := ParseRouteRateLimits([]string{tt.spec})
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRouteRateLimit) {
+					t.Errorf("ParseRouteRateLimits error = %v, want %v", err, ErrInvalidRouteRateLimit)
+				}
+
+				return
+			}
+
+			if err != nil || len(rules) != 1 {
+				t.Fatalf("ParseRouteRateLimits = %v, %v; want one rule", rules, err)
+			}
+
+			rule := rules[0]
+			if rule.Path != tt.wantPath || rule.Limiter.limit != tt.wantLimit || rule.Limiter.window != tt.wantWindow {
+				t.Errorf("rule = %s %d/%s, want %s %d/%s",
+					rule.Path, rule.Limiter.limit, rule.Limiter.window, tt.wantPath, tt.wantLimit, tt.wantWindow)
+			}
+		})
+	}
+}
+
+func TestRouteRateLimitThrottlesStatsButNotPointGets(t *testing.T) {
+	rules, err := ParseRouteRateLimits([]string{"/api/v1/admin/users/stats=2/1m"})
+	if err != nil {
+		t.Fatalf("ParseRouteRateLimits: %v", err)
+	}
+
+	// Mesma ordem de SetupRoutes: limitador global e, por cima, as regras por rota
+	router := gin.New()
+	router.Use(RateLimit(NewRateLimiter(100, time.Minute)), RouteRateLimitMiddleware(rules))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/api/v1/admin/users/stats", ok)
+	router.GET("/api/v1/admin/users/:id", ok)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return rec
+	}
+
+	for i := 1; i <= 2; i++ {
+		rec := get("/api/v1/admin/users/stats")
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("stats request %d: status = %d, want %d", i, rec.Code, http.StatusNoContent)
+		}
+
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "2" {
+			t.Errorf("stats request %d: X-RateLimit-Limit = %s, want 2", i, got)
+		}
+	}
+
+	rec := get("/api/v1/admin/users/stats")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("third stats request: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+
+	if retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After")); err != nil || retryAfter < 1 {
+		t.Errorf("Retry-After = %q, want a positive number of seconds", rec.Header().Get("Retry-After"))
+	}
+
+	// Buscas pontuais seguem só o limite global
+	for i := 1; i <= 5; i++ {
+		rec := get("/api/v1/admin/users/0b6c8f0e-5d4b-4c33-9f41-5a4f6b1d2e3c")
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("point GET %d: status = %d, want %d", i, rec.Code, http.StatusNoContent)
+		}
+
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "100" {
+			t.Errorf("point GET %d: X-RateLimit-Limit = %s, want 100", i, got)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegistrationGate bloqueia o auto-cadastro público quando o registro está fechado.
+// A função enabled é consultada a cada requisição, permitindo alternar em tempo de execução.
+func RegistrationGate(enabled func() bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled() {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error":   "REGISTRATION_DISABLED",
+				"message": "Public registration is disabled",
+			})
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
+	}
+}
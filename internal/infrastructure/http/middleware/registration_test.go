@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRegistrationGate(t *testing.T) {
+	var open atomic.Bool
+
+	created := func(c *gin.Context) { c.Status(http.StatusCreated) }
+
+	// Como nas rotas: o cadastro público passa pelo gate, o administrativo não
+	router := gin.New()
+	router.POST("/users", RegistrationGate(open.Load), created)
+	router.POST("/admin/users", created)
+
+	tests := []struct {
+		name     string
+		open     bool
+		path     string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "open registration", open: true, path: "/users", wantCode: http.StatusCreated},
+		{name: "closed registration", path: "/users", wantCode: http.StatusForbidden, wantErr: "REGISTRATION_DISABLED"},
+		{name: "admin creation while closed", path: "/admin/users", wantCode: http.StatusCreated},
+		{name: "reopened at runtime", open: true, path: "/users", wantCode: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open.Store(tt.open)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			if tt.wantErr == "" {
+				return
+			}
+
+			var body struct {
+				Error   string `json:"error"`
+				Success bool   `json:"success"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v (%q)", err, rec.Body.String())
+			}
+
+			if body.Success || body.Error != tt.wantErr {
+				t.Errorf("body = %+v, want error %s", body, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	production := DefaultSecurityHeadersConfig("production")
+	production.HSTSMaxAge = 2 * time.Hour
+	production.HSTSPreload = true
+
+	custom := DefaultSecurityHeadersConfig("development")
+	custom.ContentSecurityPolicy = "default-src 'none'"
+	custom.FrameOptions = ""
+
+	tests := []struct {
+		name        string
+		config      SecurityHeadersConfig
+		wantHSTS    string
+		wantCSP     string
+		wantFraming string
+	}{
+		{
+			name:        "development omits HSTS",
+			config:      DefaultSecurityHeadersConfig("development"),
+			wantCSP:     DefaultContentSecurityPolicy,
+			wantFraming: DefaultFrameOptions,
+		},
+		{
+			name:        "production sends the configured max-age",
+			config:      production,
+			wantHSTS:    "max-age=7200; includeSubDomains; preload",
+			wantCSP:     DefaultContentSecurityPolicy,
+			wantFraming: DefaultFrameOptions,
+		},
+		{
+			name:    "custom policy and omitted frame options",
+			config:  custom,
+			wantCSP: "default-src 'none'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(SecurityHeadersMiddleware(tt.config))
+			router.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			header := rec.Header()
+
+			if got := header.Get("Strict-Transport-Security"); got != tt.wantHSTS {
+				t.Errorf("Strict-Transport-Security = %q, want %q", got, tt.wantHSTS)
+			}
+
+			if got := header.Get("Content-Security-Policy"); got != tt.wantCSP {
+				t.Errorf("Content-Security-Policy = %q, want %q", got, tt.wantCSP)
+			}
+
+			if got := header.Get("X-Frame-Options"); got != tt.wantFraming {
+				t.Errorf("X-Frame-Options = %q, want %q", got, tt.wantFraming)
+			}
+
+			if got := header.Get("Referrer-Policy"); got != DefaultReferrerPolicy {
+				t.Errorf("Referrer-Policy = %q, want %q", got, DefaultReferrerPolicy)
+			}
+
+			if got := header.Get("X-Content-Type-Options"); got != "nosniff" {
+				t.Errorf("X-Content-Type-Options = %q, want nosniff", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/response"
+)
+
+// TimeoutMiddleware limita o tempo de processamento de cada requisição.
+//
+// O prazo é aplicado ao contexto da requisição, que os repositórios repassam ao
+// GORM com WithContext; ao estourar, a query em andamento é cancelada pelo
+// driver e a conexão volta ao pool. O handler roda na própria goroutine da
+// requisição e escreve num buffer: se o prazo estourou, o que ele escreveu é
+// descartado e o cliente recebe 504 TIMEOUT, sem escrita concorrente nem
+// "headers already written". Se o cliente desconectou, nada é enviado.
+func TimeoutMiddleware(timeout time.Duration, appLogger *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		original := c.Writer
+		buffered := &bufferedWriter{ResponseWriter: original}
+		c.Writer = buffered
+
+		// Se o handler entrar em panic, o RecoveryMiddleware precisa escrever o
+		// 500 no writer original, e não num buffer que nunca seria enviado
+		defer func() {
+			c.Writer = original
+		}()
+
+		c.Next()
+
+		c.Writer = original
+
+		// Cliente desconectado: não há para quem enviar a resposta acumulada
+		if ClientGone(ctx) {
+			return
+		}
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			if appLogger != nil {
+				appLogger.WithContext(ctx).Warn("Request timed out",
+					zap.String("method", c.Request.Method),
+					zap.String("path", c.Request.URL.Path),
+					zap.Duration("timeout", timeout),
+				)
+			}
+
+			// Descarta cabeçalhos de corpo definidos pelo handler
+			original.Header().Del("Content-Type")
+			original.Header().Del("Content-Length")
+
+			response.Error(c, http.StatusGatewayTimeout, "TIMEOUT", "The request took too long to complete")
+
+			return
+		}
+
+		buffered.flush()
+	}
+}
+
+// bufferedWriter retém status e corpo até que TimeoutMiddleware decida se
+// eles serão enviados ao cliente.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+// WriteHeader registra o status sem enviá-lo.
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && w.status == 0 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow não envia nada; o status é enviado em flush.
+func (w *bufferedWriter) WriteHeaderNow() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+}
+
+// Write acumula o corpo no buffer.
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+
+	return w.body.Write(data)
+}
+
+// WriteString acumula o corpo no buffer.
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+
+	return w.body.WriteString(s)
+}
+
+// Status retorna o status registrado, ou 200 se nenhum foi definido.
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+// Size retorna o tamanho do corpo acumulado, ou -1 se nada foi escrito.
+func (w *bufferedWriter) Size() int {
+	if w.status == 0 {
+		return -1
+	}
+
+	return w.body.Len()
+}
+
+// Written indica se o handler já produziu uma resposta.
+func (w *bufferedWriter) Written() bool {
+	return w.status != 0
+}
+
+// Flush é ignorado: a resposta só é enviada depois que o handler termina.
+func (w *bufferedWriter) Flush() {}
+
+// flush envia ao cliente o status e o corpo acumulados.
+func (w *bufferedWriter) flush() {
+	if w.status == 0 {
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if w.body.Len() == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestTimeoutMiddlewarePanicReturnsRecoveryEnvelope(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RecoveryMiddleware(nil), TimeoutMiddleware(time.Second, nil))
+	router.GET("/panic", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain")
+		_, _ = c.Writer.WriteString("partial")
+
+		panic("secret panic value")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d (body %q)", rec.Code, http.StatusInternalServerError, rec.Body.String())
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v (%q)", err, rec.Body.String())
+	}
+
+	if body["request_id"] != "req-123" {
+		t.Errorf("request_id = %v, want req-123", body["request_id"])
+	}
+
+	if body["error"] != "INTERNAL_SERVER_ERROR" {
+		t.Errorf("error = %v, want INTERNAL_SERVER_ERROR", body["error"])
+	}
+}
+
+func TestTimeoutMiddlewareCancelsSlowHandler(t *testing.T) {
+	cancelled := make(chan struct{})
+
+	router := gin.New()
+	router.Use(TimeoutMiddleware(20*time.Millisecond, nil))
+	router.GET("/slow", func(c *gin.Context) {
+		// Simula uma consulta lenta que respeita o contexto, como o GORM faz
+		select {
+		case <-c.Request.Context().Done():
+			close(cancelled)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "query cancelled"})
+		case <-time.After(time.Second):
+			c.JSON(http.StatusOK, gin.H{"ok": true})
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	select {
+	case <-cancelled:
+	default:
+		t.Fatal("handler context was not cancelled")
+	}
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusGatewayTimeout)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v (%q)", err, rec.Body.String())
+	}
+
+	if body["error"] != "TIMEOUT" {
+		t.Errorf("error = %v, want TIMEOUT", body["error"])
+	}
+}
+
+func TestTimeoutMiddlewareFlushesFastResponse(t *testing.T) {
+	router := gin.New()
+	router.Use(TimeoutMiddleware(time.Second, nil))
+	router.POST("/fast", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/fast", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+
+	if rec.Body.String() != `{"ok":true}` {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+}
@@ -1,25 +1,41 @@
 package routes
 
 import (
+	"database/sql"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
+	"github.com/devleo-m/go-zero/internal/infrastructure/auth"
+	adminHttp "github.com/devleo-m/go-zero/internal/infrastructure/http/admin"
+	"github.com/devleo-m/go-zero/internal/infrastructure/http/health"
 	"github.com/devleo-m/go-zero/internal/infrastructure/http/middleware"
+	"github.com/devleo-m/go-zero/internal/infrastructure/logger"
+	"github.com/devleo-m/go-zero/internal/shared/featureflag"
 	"github.com/devleo-m/go-zero/internal/shared/response"
 )
 
 // SetupRoutes configura todas as rotas da aplicação.
 func SetupRoutes(router *gin.Engine, config *Config) {
 	// Middleware global
-	router.Use(middleware.LoggingMiddleware(nil))
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.RecoveryMiddleware())
+	router.Use(middleware.LoggingMiddleware(config.Logger))
+	router.Use(middleware.RecoveryMiddleware(config.Logger))
 	router.Use(middleware.CORS(middleware.CORSConfig{
 		AllowedOrigins:   config.CORS.AllowedOrigins,
 		AllowedMethods:   config.CORS.AllowedMethods,
 		AllowedHeaders:   config.CORS.AllowedHeaders,
-		MaxAge:           3600,
-		AllowCredentials: true,
+		MaxAge:           int(config.CORS.MaxAge.Seconds()),
+		AllowCredentials: config.CORS.AllowCredentials,
 	}))
+	router.Use(middleware.SecurityHeadersMiddleware(config.SecurityHeaders))
+
+	if config.Compression.Enabled {
+		router.Use(middleware.CompressionMiddleware(config.Compression))
+	}
 
 	// Rate limiting
 	if config.RateLimiter != nil {
@@ -28,12 +44,62 @@ func SetupRoutes(router *gin.Engine, config *Config) {
 		}
 	}
 
+	if len(config.RouteRateLimits) > 0 {
+		router.Use(middleware.RouteRateLimitMiddleware(config.RouteRateLimits))
+	}
+
+	featureFlags := config.FeatureFlags
+	if featureFlags == nil {
+		featureFlags = featureflag.NewStore(map[string]bool{
+			featureflag.UserRegistration: true,
+			featureflag.MaintenanceMode:  false,
+		})
+	}
+
+	// Em manutenção, apenas leituras (inclusive a busca em lote, que usa POST)
+	// e o endpoint que desliga o modo aceitam requisições
+	router.Use(middleware.MaintenanceGate(func() bool {
+		return featureFlags.Enabled(featureflag.MaintenanceMode)
+	}, config.MaintenanceRetryAfter, "/api/v1/admin/system/config", "/api/v1/users/batch"))
+
 	// Health check
-	router.GET("/health", healthCheck)
-	router.GET("/metrics", metricsHandler)
+	if config.HealthHandler != nil {
+		router.GET("/health", config.HealthHandler.HealthCheck)
+		router.GET("/ready", config.HealthHandler.ReadinessCheck)
+	} else {
+		router.GET("/health", healthCheck)
+	}
+
+	router.GET("/metrics", metricsHandler(config.DatabaseStats))
+
+	userHandler, hasUserHandler := config.UserHandler.(userRoutesHandler)
+	userAdminHandler, hasUserAdminHandler := config.UserAdminHandler.(userAdminRoutesHandler)
+
+	jwtService := config.JWTService
+	if jwtService == nil {
+		jwtService = auth.NewJWTService(auth.Config{
+			Secret:           config.JWT.Secret,
+			Issuer:           config.JWT.Issuer,
+			Audience:         config.JWT.Audience,
+			AccessTokenTTL:   config.JWT.AccessTokenTTL,
+			RefreshTokenTTL:  config.JWT.RefreshTokenTTL,
+			Leeway:           config.JWT.Leeway,
+			ImpersonationTTL: config.JWT.ImpersonationTTL,
+		})
+	}
 
 	// API v1
 	v1 := router.Group("/api/v1")
+	if config.RequestTimeout > 0 {
+		v1.Use(middleware.TimeoutMiddleware(config.RequestTimeout, config.Logger))
+	}
+
+	// Corpos de POST/PUT/PATCH devem ser JSON; rotas de upload (multipart)
+	// devem ser incluídas em JSONExemptPaths
+	v1.Use(middleware.RequireJSON(config.JSONExemptPaths...))
+
+	// Clientes que desconectaram antes do handler não geram trabalho (log 499)
+	v1.Use(middleware.ClientDisconnectMiddleware())
 	{
 		// Rotas públicas (sem autenticação)
 		public := v1.Group("/")
@@ -42,36 +108,97 @@ func SetupRoutes(router *gin.Engine, config *Config) {
 			// public.POST("/auth/register", authHandler.Register)
 			// public.POST("/auth/login", authHandler.Login)
 			// User routes (públicas para desenvolvimento/aprendizado)
-			if config.UserHandler != nil {
-				if userHandler, ok := config.UserHandler.(interface {
-					CreateUser(*gin.Context)
-					ListUsers(*gin.Context)
-					GetUser(*gin.Context)
-					UpdateUser(*gin.Context)
-					DeleteUser(*gin.Context)
-				}); ok {
-					userRoutes := public.Group("/users")
-					{
-						userRoutes.POST("", userHandler.CreateUser)
-						userRoutes.GET("", userHandler.ListUsers)
-						userRoutes.GET("/:id", userHandler.GetUser)
-						userRoutes.PUT("/:id", userHandler.UpdateUser)
-						userRoutes.DELETE("/:id", userHandler.DeleteUser)
-					}
+			if hasUserHandler {
+				userRoutes := public.Group("/users")
+				userRoutes.Use(middleware.UUIDParams("id"))
+				{
+					userRoutes.POST("", middleware.RegistrationGate(func() bool {
+						return featureFlags.Enabled(featureflag.UserRegistration)
+					}), userHandler.CreateUser)
+					userRoutes.GET("", userHandler.ListUsers)
+					userRoutes.GET("/by-email", userHandler.GetUserByEmail)
+					userRoutes.POST("/batch", userHandler.GetUsersBatch)
+					userRoutes.GET("/:id", userHandler.GetUser)
+					userRoutes.DELETE("/:id", userHandler.DeleteUser)
 				}
 			}
 		}
 
-		// Rotas protegidas (com autenticação - para futuro)
+		// Rotas protegidas (com autenticação)
 		protected := v1.Group("/")
-		protected.Use(middleware.AuthMiddleware(config.JWT.Secret))
+		protected.Use(middleware.AuthMiddleware(jwtService))
+		protected.Use(middleware.ImpersonationAuditMiddleware(config.Logger))
 		{
+			// User routes que exigem ser o próprio usuário ou um admin
+			if hasUserHandler {
+				protected.POST("/auth/change-password", userHandler.ChangeOwnPassword)
+
+				// IDs malformados recebem 400 antes da checagem de permissão
+				userRoutes := protected.Group("/users")
+				userRoutes.Use(middleware.UUIDParams("id"), middleware.RequireSelfOrRole("admin"))
+				{
+					userRoutes.PUT("/:id", userHandler.UpdateUser)
+					userRoutes.GET("/:id/metadata", userHandler.GetUserMetadata)
+					userRoutes.PUT("/:id/metadata", userHandler.SetUserMetadata)
+					userRoutes.GET("/:id/profile", userHandler.GetUserProfile)
+					userRoutes.PUT("/:id/profile", userHandler.UpdateUserProfile)
+					// A troca com senha atual é só do próprio usuário; admins usam o reset
+					userRoutes.PUT("/:id/password", middleware.RequireSelfOrRole(), userHandler.ChangePassword)
+				}
+			}
+
 			// Admin routes
 			admin := protected.Group("/admin")
 			admin.Use(middleware.RequireRole("admin"))
 			{
 				// Admin-specific routes
-				admin.GET("/stats", adminStats)
+				admin.GET("/stats", middleware.RequirePermission("stats", "read"), adminStats)
+
+				if config.Logger != nil {
+					logLevelHandler := adminHttp.NewLogLevelHandler(config.Logger)
+					admin.GET("/log-level", logLevelHandler.GetLogLevel)
+					admin.PUT("/log-level", logLevelHandler.SetLogLevel)
+
+					systemConfigHandler := adminHttp.NewSystemConfigHandler(featureFlags, config.Logger)
+					admin.GET("/system/config", systemConfigHandler.GetSystemConfig)
+					admin.PUT("/system/config", systemConfigHandler.UpdateSystemConfig)
+				}
+
+				// Além do role, cada rota exige a permissão do domínio para a ação
+				adminUsers := admin.Group("/users")
+				adminUsers.Use(middleware.UUIDParams("id"), middleware.RequirePermission("users", "read"))
+				{
+					canCreate := middleware.RequirePermission("users", "create")
+					canUpdate := middleware.RequirePermission("users", "update")
+					canDelete := middleware.RequirePermission("users", "delete")
+
+					// Admins podem criar usuários mesmo com o registro público fechado
+					if hasUserHandler {
+						adminUsers.POST("", canCreate, userHandler.CreateUser)
+						adminUsers.POST("/:id/password-reset", canUpdate, userHandler.ResetPassword)
+					}
+
+					if hasUserAdminHandler {
+						adminUsers.GET("", userAdminHandler.ListUsersByEmailDomain)
+						adminUsers.GET("/inactive", userAdminHandler.ListInactiveUsers)
+						adminUsers.POST("/bulk-status", canUpdate, userAdminHandler.BulkUpdateStatus)
+						adminUsers.POST("/bulk-role", canUpdate, userAdminHandler.BulkUpdateRole)
+						adminUsers.POST("/bulk-role/preview", userAdminHandler.PreviewBulkUpdateRole)
+						adminUsers.POST("/bulk-delete", canDelete, userAdminHandler.BulkDeleteUsers)
+						adminUsers.POST("/:id/merge", canUpdate, canDelete, userAdminHandler.MergeUsers)
+						adminUsers.PUT("/:id/status", canUpdate, userAdminHandler.SetUserStatus)
+						adminUsers.PATCH("/:id/role", canUpdate, userAdminHandler.ChangeRole)
+						adminUsers.POST("/:id/resend-email", canUpdate, userAdminHandler.ResendEmail)
+						adminUsers.POST("/:id/impersonate", canUpdate, userAdminHandler.Impersonate)
+
+						// Presets de filtro salvos por cada administrador
+						adminUsers.GET("/filter-presets", userAdminHandler.ListFilterPresets)
+						adminUsers.GET("/filter-presets/:name", userAdminHandler.GetFilterPreset)
+						adminUsers.PUT("/filter-presets/:name", userAdminHandler.SaveFilterPreset)
+						adminUsers.DELETE("/filter-presets/:name", userAdminHandler.DeleteFilterPreset)
+						adminUsers.GET("/filter-presets/:name/users", userAdminHandler.ApplyFilterPreset)
+					}
+				}
 			}
 		}
 	}
@@ -84,28 +211,67 @@ func SetupRoutes(router *gin.Engine, config *Config) {
 
 	// Swagger documentation
 	router.GET("/swagger/*any", swaggerHandler)
+
+	router.NoRoute(handleNotFound)
+}
+
+// supportedAPIVersions lista as versões da API registradas em SetupRoutes.
+var supportedAPIVersions = []string{"v1"}
+
+// apiVersionPattern reconhece o prefixo de versão da API (ex.: /api/v2/...).
+var apiVersionPattern = regexp.MustCompile(`^/api/(v[0-9]+)(?:/|$)`)
+
+// handleNotFound responde às rotas inexistentes. Uma versão de API não suportada
+// recebe UNSUPPORTED_API_VERSION com as versões disponíveis; o resto, 404 genérico.
+func handleNotFound(c *gin.Context) {
+	if match := apiVersionPattern.FindStringSubmatch(c.Request.URL.Path); match != nil &&
+		!slices.Contains(supportedAPIVersions, match[1]) {
+		response.ErrorWithData(c, http.StatusNotFound, "UNSUPPORTED_API_VERSION",
+			"API version "+match[1]+" is not supported", gin.H{
+				"supported_versions": supportedAPIVersions,
+			})
+
+		return
+	}
+
+	response.NotFound(c, "ROUTE_NOT_FOUND", "Route not found")
 }
 
-// healthCheck retorna o status de saúde da aplicação.
+// healthCheck retorna um status de saúde simples quando não há HealthHandler.
 func healthCheck(c *gin.Context) {
 	response.Success(c, gin.H{
 		"status":    "ok",
-		"timestamp": gin.H{},
-		"services": gin.H{
-			"database": "ok",
-			"redis":    "ok",
-			"api":      "ok",
-		},
+		"timestamp": time.Now().UTC(),
 	}, "Service is healthy")
 }
 
-// metricsHandler retorna métricas da aplicação.
-func metricsHandler(c *gin.Context) {
-	// Aqui você pode implementar métricas customizadas
-	// Por enquanto, retornamos um placeholder
-	c.JSON(200, gin.H{
-		"message": "Metrics endpoint - implement Prometheus metrics here",
-	})
+// metricsHandler retorna métricas da aplicação, incluindo o pool de conexões
+// do banco quando databaseStats é informado.
+func metricsHandler(databaseStats func() (sql.DBStats, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Métricas customizadas (ex.: Prometheus) podem ser adicionadas aqui
+		metrics := gin.H{
+			"message": "Metrics endpoint - implement Prometheus metrics here",
+		}
+
+		if databaseStats != nil {
+			if stats, err := databaseStats(); err == nil {
+				metrics["database_pool"] = gin.H{
+					"max_open_connections": stats.MaxOpenConnections,
+					"open_connections":     stats.OpenConnections,
+					"in_use":               stats.InUse,
+					"idle":                 stats.Idle,
+					"wait_count":           stats.WaitCount,
+					"wait_duration_ms":     stats.WaitDuration.Milliseconds(),
+					"max_idle_closed":      stats.MaxIdleClosed,
+					"max_idle_time_closed": stats.MaxIdleTimeClosed,
+					"max_lifetime_closed":  stats.MaxLifetimeClosed,
+				}
+			}
+		}
+
+		response.JSON(c, http.StatusOK, metrics)
+	}
 }
 
 // adminStats retorna estatísticas administrativas.
@@ -138,20 +304,94 @@ func swaggerHandler(c *gin.Context) {
 	})
 }
 
+// userRoutesHandler define os handlers do módulo de usuários usados nas rotas.
+type userRoutesHandler interface {
+	CreateUser(*gin.Context)
+	ListUsers(*gin.Context)
+	GetUser(*gin.Context)
+	GetUserByEmail(*gin.Context)
+	GetUsersBatch(*gin.Context)
+	UpdateUser(*gin.Context)
+	ChangePassword(*gin.Context)
+	ChangeOwnPassword(*gin.Context)
+	ResetPassword(*gin.Context)
+	GetUserMetadata(*gin.Context)
+	SetUserMetadata(*gin.Context)
+	GetUserProfile(*gin.Context)
+	UpdateUserProfile(*gin.Context)
+	DeleteUser(*gin.Context)
+}
+
+// userAdminRoutesHandler define os handlers administrativos do módulo de usuários.
+type userAdminRoutesHandler interface {
+	ListInactiveUsers(*gin.Context)
+	ListUsersByEmailDomain(*gin.Context)
+	BulkUpdateStatus(*gin.Context)
+	BulkUpdateRole(*gin.Context)
+	PreviewBulkUpdateRole(*gin.Context)
+	BulkDeleteUsers(*gin.Context)
+	MergeUsers(*gin.Context)
+	SetUserStatus(*gin.Context)
+	ChangeRole(*gin.Context)
+	ResendEmail(*gin.Context)
+	Impersonate(*gin.Context)
+	ListFilterPresets(*gin.Context)
+	GetFilterPreset(*gin.Context)
+	SaveFilterPreset(*gin.Context)
+	DeleteFilterPreset(*gin.Context)
+	ApplyFilterPreset(*gin.Context)
+}
+
 // Config representa a configuração das rotas.
 type Config struct {
-	RateLimiter interface{}
-	UserHandler interface{}
-	JWT         JWTConfig
-	CORS        CORSConfig
+	RateLimiter      interface{}
+	UserHandler      interface{}
+	UserAdminHandler interface{}
+	HealthHandler    *health.HealthHandler
+	// RouteRateLimits são limites mais restritos para rotas caras, aplicados
+	// depois do limitador global.
+	RouteRateLimits []middleware.RouteRateLimit
+	Logger          *logger.Logger
+	// DatabaseStats expõe as estatísticas do pool de conexões em /metrics.
+	DatabaseStats func() (sql.DBStats, error)
+	// FeatureFlags é consultado a cada requisição pelas rotas condicionadas a flags.
+	// Sem Store, o auto-cadastro público fica habilitado.
+	FeatureFlags *featureflag.Store
+	// MaintenanceRetryAfter é o Retry-After das escritas recusadas em manutenção.
+	MaintenanceRetryAfter time.Duration
+	// RequestTimeout limita o processamento das rotas da API; zero desativa.
+	RequestTimeout time.Duration
+	// JSONExemptPaths são templates de rota (ex.: "/api/v1/admin/users/import")
+	// dispensados de enviar o corpo como application/json, como uploads multipart.
+	JSONExemptPaths []string
+	JWT             JWTConfig
+	// JWTService, quando informado, é usado no lugar de um serviço criado a partir
+	// de JWT, permitindo compartilhá-lo com os casos de uso que emitem tokens.
+	JWTService *auth.JWTService
+	CORS       CORSConfig
+	// SecurityHeaders é aplicado como veio; use middleware.DefaultSecurityHeadersConfig
+	// para obter os padrões de um ambiente.
+	SecurityHeaders middleware.SecurityHeadersConfig
+	// Compression habilita a compressão gzip das respostas quando Enabled.
+	Compression middleware.CompressionConfig
 }
 
 type JWTConfig struct {
-	Secret string
+	Secret          string
+	Issuer          string
+	Audience        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// Leeway tolera diferenças de relógio na validação de exp, nbf e iat.
+	Leeway time.Duration
+	// ImpersonationTTL é o tempo de vida dos tokens de personificação.
+	ImpersonationTTL time.Duration
 }
 
 type CORSConfig struct {
-	AllowedOrigins []string
-	AllowedMethods []string
-	AllowedHeaders []string
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	MaxAge           time.Duration
+	AllowCredentials bool
 }
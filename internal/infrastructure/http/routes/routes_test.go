@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandleNotFound(t *testing.T) {
+	router := gin.New()
+	router.GET("/api/v1/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.NoRoute(handleNotFound)
+
+	tests := []struct {
+		path          string
+		wantErr       string
+		wantSupported []string
+	}{
+		{path: "/api/v2/users", wantErr: "UNSUPPORTED_API_VERSION", wantSupported: []string{"v1"}},
+		{path: "/api/v10", wantErr: "UNSUPPORTED_API_VERSION", wantSupported: []string{"v1"}},
+		{path: "/api/v1/unknown", wantErr: "ROUTE_NOT_FOUND"},
+		{path: "/api/version", wantErr: "ROUTE_NOT_FOUND"},
+		{path: "/random/path", wantErr: "ROUTE_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+			}
+
+			var body struct {
+				Error string `json:"error"`
+				Data  struct {
+					SupportedVersions []string `json:"supported_versions"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if body.Error != tt.wantErr || !slices.Equal(body.Data.SupportedVersions, tt.wantSupported) {
+				t.Errorf("error = %s with versions %v, want %s with %v",
+					body.Error, body.Data.SupportedVersions, tt.wantErr, tt.wantSupported)
+			}
+		})
+	}
+}
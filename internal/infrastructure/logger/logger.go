@@ -1,37 +1,55 @@
 package logger
 
 import (
+	"context"
+	"errors"
 	"os"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
 )
 
 type Logger struct {
 	*zap.Logger
+	level zap.AtomicLevel
 }
 
 type Config struct {
 	Level  string
 	Format string
+	// SamplingInitial e SamplingThereafter configuram a amostragem por segundo:
+	// as primeiras N mensagens iguais são registradas e depois apenas 1 a cada M.
+	// SamplingInitial <= 0 desativa a amostragem.
+	SamplingInitial    int
+	SamplingThereafter int
 }
 
+// ErrInvalidLevel indica um nível de log desconhecido.
+var ErrInvalidLevel = errors.New("invalid log level")
+
 func New(config Config) (*Logger, error) {
 	zapConfig := zap.NewProductionConfig()
 
-	// Configurar nível de log
-	switch config.Level {
-	case "debug":
-		zapConfig.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
-	case "info":
-		zapConfig.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
-	case "warn":
-		zapConfig.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
-	case "error":
-		zapConfig.Level = zap.NewAtomicLevelAt(zap.ErrorLevel)
-	default:
-		zapConfig.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+	// Configurar nível de log (ajustável em tempo de execução via SetLevel)
+	level, err := parseLevel(config.Level)
+	if err != nil {
+		level = zap.InfoLevel
+	}
+
+	zapConfig.Level = zap.NewAtomicLevelAt(level)
+
+	// Configurar amostragem
+	if config.SamplingInitial > 0 {
+		zapConfig.Sampling = &zap.SamplingConfig{
+			Initial:    config.SamplingInitial,
+			Thereafter: config.SamplingThereafter,
+		}
+	} else {
+		zapConfig.Sampling = nil
 	}
 
 	// Configurar formato
@@ -52,7 +70,7 @@ func New(config Config) (*Logger, error) {
 		return nil, err
 	}
 
-	return &Logger{Logger: logger}, nil
+	return &Logger{Logger: logger, level: zapConfig.Level}, nil
 }
 
 func NewFromEnv() (*Logger, error) {
@@ -67,13 +85,59 @@ func NewFromEnv() (*Logger, error) {
 	}
 
 	return New(Config{
-		Level:  level,
-		Format: format,
+		Level:              level,
+		Format:             format,
+		SamplingInitial:    getEnvAsInt("LOG_SAMPLING_INITIAL", 100),
+		SamplingThereafter: getEnvAsInt("LOG_SAMPLING_THEREAFTER", 100),
 	})
 }
 
+// Level retorna o nível de log atual.
+func (l *Logger) Level() string {
+	return l.level.Level().String()
+}
+
+// SetLevel altera o nível de log em tempo de execução (seguro para uso concorrente).
+func (l *Logger) SetLevel(level string) error {
+	parsed, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	l.level.SetLevel(parsed)
+
+	return nil
+}
+
+// parseLevel converte o nome de um nível para zapcore.Level.
+func parseLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case "debug":
+		return zap.DebugLevel, nil
+	case "info":
+		return zap.InfoLevel, nil
+	case "warn":
+		return zap.WarnLevel, nil
+	case "error":
+		return zap.ErrorLevel, nil
+	default:
+		return zap.InfoLevel, ErrInvalidLevel
+	}
+}
+
+// getEnvAsInt lê uma variável de ambiente inteira com valor padrão.
+func getEnvAsInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+
+	return defaultValue
+}
+
 func (l *Logger) WithFields(fields ...zap.Field) *Logger {
-	return &Logger{Logger: l.Logger.With(fields...)}
+	return &Logger{Logger: l.Logger.With(fields...), level: l.level}
 }
 
 // WithFieldsFromMap cria campos zap a partir de um map.
@@ -83,13 +147,23 @@ func (l *Logger) WithFieldsFromMap(fields map[string]interface{}) *Logger {
 		zapFields = append(zapFields, zap.Any(k, v))
 	}
 
-	return &Logger{Logger: l.Logger.With(zapFields...)}
+	return &Logger{Logger: l.Logger.With(zapFields...), level: l.level}
 }
 
 func (l *Logger) WithRequestID(requestID string) *Logger {
 	return l.WithFields(zap.String("request_id", requestID))
 }
 
+// WithContext adiciona ao logger os IDs de correlação (request_id, trace_id) do contexto.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	fields := requestctx.LogFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+
+	return l.WithFields(fields...)
+}
+
 func (l *Logger) WithUserID(userID string) *Logger {
 	return l.WithFields(zap.String("user_id", userID))
 }
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newObservedLogger cria um Logger cujas entradas ficam em memória, com o
+// mesmo nível atômico usado por New.
+func newObservedLogger(level string) (*Logger, *observer.ObservedLogs) {
+	parsed, _ := parseLevel(level)
+	atomic := zap.NewAtomicLevelAt(parsed)
+	core, logs := observer.New(atomic)
+
+	return &Logger{Logger: zap.New(core), level: atomic}, logs
+}
+
+func TestSetLevelEmitsDebugOnlyAfterLowering(t *testing.T) {
+	log, logs := newObservedLogger("info")
+	child := log.WithComponent("users")
+
+	log.Debug("before")
+	child.Debug("before")
+
+	if logs.Len() != 0 {
+		t.Fatalf("debug emitted at info level: %v", logs.All())
+	}
+
+	if err := log.SetLevel("debug"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+
+	// Loggers derivados compartilham o nível do logger original
+	log.Debug("after")
+	child.Debug("after")
+
+	if got := logs.FilterMessage("after").Len(); got != 2 {
+		t.Errorf("got %d debug entries after lowering, want 2", got)
+	}
+
+	if err := log.SetLevel("info"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+
+	child.Debug("raised")
+
+	if got := logs.FilterMessage("raised").Len(); got != 0 {
+		t.Errorf("debug emitted after raising the level back to info")
+	}
+}
+
+func TestSetLevelRejectsUnknownLevel(t *testing.T) {
+	log, _ := newObservedLogger("warn")
+
+	if err := log.SetLevel("verbose"); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("SetLevel(verbose) = %v, want %v", err, ErrInvalidLevel)
+	}
+
+	if got := log.Level(); got != "warn" {
+		t.Errorf("Level() = %s, want warn", got)
+	}
+}
+
+func TestSetLevelConcurrent(t *testing.T) {
+	log, _ := newObservedLogger("info")
+	levels := []string{"debug", "info", "warn", "error"}
+
+	var wg sync.WaitGroup
+
+	for i := range 50 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_ = log.SetLevel(levels[i%len(levels)])
+			_ = log.Level()
+
+			log.Debug("concurrent")
+		}()
+	}
+
+	wg.Wait()
+
+	if got := log.Level(); got != "debug" && got != "info" && got != "warn" && got != "error" {
+		t.Errorf("Level() = %s after concurrent updates", got)
+	}
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+)
+
+// BulkDeleteUsersUseCase implementa o caso de uso de deletar vários usuários (soft delete).
+type BulkDeleteUsersUseCase struct {
+	userRepo domain.Repository
+	logger   *zap.Logger
+}
+
+// NewBulkDeleteUsersUseCase cria uma nova instância do caso de uso.
+func NewBulkDeleteUsersUseCase(userRepo domain.Repository) *BulkDeleteUsersUseCase {
+	return &BulkDeleteUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *BulkDeleteUsersUseCase) WithLogger(logger *zap.Logger) *BulkDeleteUsersUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// BulkDeleteUsersInput representa os dados de entrada.
+// Com DryRun, apenas a quantidade de usuários que seriam deletados é retornada.
+type BulkDeleteUsersInput struct {
+	BulkFilter
+	DryRun bool `json:"dry_run"`
+}
+
+// BulkDeleteUsersOutput representa os dados de saída.
+type BulkDeleteUsersOutput struct {
+	Affected int64 `json:"affected"`
+	DryRun   bool  `json:"dry_run"`
+}
+
+// Execute executa o caso de uso. Só são deletados os usuários que quem pede
+// pode gerenciar, nunca ele mesmo; os demais ficam de fora da seleção.
+func (uc *BulkDeleteUsersUseCase) Execute(
+	ctx context.Context,
+	input BulkDeleteUsersInput,
+) (*BulkDeleteUsersOutput, error) {
+	builder, err := input.queryBuilder()
+	if err != nil {
+		return nil, err
+	}
+
+	requester, err := loadRequester(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	scopeToRequester(builder, requester)
+	filter := builder.Build()
+	output := &BulkDeleteUsersOutput{DryRun: input.DryRun}
+
+	if input.DryRun {
+		output.Affected, err = uc.userRepo.Count(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count users: %w", err)
+		}
+
+		return output, nil
+	}
+
+	output.Affected, err = uc.userRepo.DeleteMany(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete users: %w", err)
+	}
+
+	contextLogger(ctx, uc.logger).Info("Users deleted", zap.Int64("affected", output.Affected))
+
+	return output, nil
+}
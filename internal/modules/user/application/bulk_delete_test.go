@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// seedBulkTargets cria cinco usuários, três deles moderadores a partir de
+// BaseTime + 1 minuto, e retorna o filtro que seleciona exatamente esses três.
+func seedBulkTargets(t *testing.T, repo domain.Repository) BulkFilter {
+	t.Helper()
+
+	users := make([]*domain.User, 5)
+	for i := range users {
+		users[i] = repositorytest.NewUser("Usuário", string(rune('a'+i))+"@example.com", i)
+	}
+
+	// O primeiro moderador foi criado antes do início do intervalo
+	for _, i := range []int{0, 1, 3, 4} {
+		users[i].Role = domain.RoleModerator
+	}
+
+	repositorytest.Seed(t, repo, users...)
+
+	from := repositorytest.BaseTime.Add(time.Minute)
+
+	return BulkFilter{Role: domain.RoleModerator, CreatedFrom: &from}
+}
+
+func TestBulkDeleteUsersDryRun(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	filter := seedBulkTargets(t, repo)
+	uc := NewBulkDeleteUsersUseCase(repo)
+
+	ctx = requestctx.WithActor(ctx, seedRoles(t, repo, domain.RoleAdmin)[0].ID.String())
+
+	preview, err := uc.Execute(ctx, BulkDeleteUsersInput{BulkFilter: filter, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+
+	if preview.Affected != 3 || !preview.DryRun {
+		t.Errorf("dry run = %+v, want 3 affected", preview)
+	}
+
+	if remaining, _ := repo.Count(ctx, repository.QueryFilter{}); remaining != 6 {
+		t.Fatalf("dry run deleted users: %d remaining, want 6", remaining)
+	}
+
+	// A execução real aplica o mesmo filtro do dry run
+	output, err := uc.Execute(ctx, BulkDeleteUsersInput{BulkFilter: filter})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if output.Affected != preview.Affected || output.DryRun {
+		t.Errorf("output = %+v, want %d affected", output, preview.Affected)
+	}
+
+	if remaining, _ := repo.Count(ctx, repository.QueryFilter{}); remaining != 3 {
+		t.Errorf("%d users remaining, want 3", remaining)
+	}
+}
+
+func TestBulkDeleteUsersScopesToRequester(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleSuperAdmin, domain.RoleAdmin, domain.RoleUser)
+	admin, user := users[0], users[3]
+
+	uc := NewBulkDeleteUsersUseCase(repo)
+	ctx = requestctx.WithActor(ctx, admin.ID.String())
+
+	for _, filter := range []BulkFilter{{Role: domain.RoleSuperAdmin}, {Role: domain.RoleAdmin}} {
+		output, err := uc.Execute(ctx, BulkDeleteUsersInput{BulkFilter: filter})
+		if err != nil {
+			t.Fatalf("Execute %+v: %v", filter, err)
+		}
+
+		if output.Affected != 0 {
+			t.Errorf("deleting %s users affected %d, want 0", filter.Role, output.Affected)
+		}
+	}
+
+	output, err := uc.Execute(ctx, BulkDeleteUsersInput{BulkFilter: BulkFilter{EmailDomain: "example.com"}})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if output.Affected != 1 {
+		t.Errorf("affected = %d, want 1", output.Affected)
+	}
+
+	remaining, err := repo.FindMany(ctx, repository.QueryFilter{})
+	if err != nil {
+		t.Fatalf("FindMany: %v", err)
+	}
+
+	for _, stored := range remaining {
+		if stored.ID == user.ID {
+			t.Errorf("user %s was not deleted", stored.Email)
+		}
+	}
+
+	if len(remaining) != 3 {
+		t.Errorf("%d users remaining, want 3", len(remaining))
+	}
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+	"github.com/devleo-m/go-zero/internal/shared/validation"
+)
+
+// BulkFilter representa os critérios de seleção das operações em massa.
+// Ao menos um critério (IDs, Role, Status, EmailDomain ou intervalo de datas) é obrigatório.
+type BulkFilter struct {
+	CreatedFrom *time.Time    `json:"created_from,omitempty"`
+	CreatedTo   *time.Time    `json:"created_to,omitempty"`
+	Role        domain.Role   `json:"role,omitempty"`
+	Status      domain.Status `json:"status,omitempty"`
+	EmailDomain string        `json:"email_domain,omitempty"`
+	IDs         []uuid.UUID   `json:"ids,omitempty"`
+}
+
+// queryBuilder converte os critérios em um QueryBuilder.
+func (f BulkFilter) queryBuilder() (*repository.QueryBuilder, error) {
+	if f.Role != "" && !f.Role.Valid() {
+		return nil, domain.ErrInvalidRole
+	}
+
+	if f.Status != "" && !f.Status.Valid() {
+		return nil, domain.ErrInvalidStatus
+	}
+
+	builder := repository.NewQueryBuilder()
+	criteria := 0
+
+	if len(f.IDs) > 0 {
+		builder.WhereIn("id", f.IDs)
+		criteria++
+	}
+
+	if f.Role != "" {
+		builder.WhereEqual("role", f.Role.String())
+		criteria++
+	}
+
+	if f.Status != "" {
+		builder.WhereEqual("status", f.Status.String())
+		criteria++
+	}
+
+	if emailDomain := strings.TrimPrefix(domain.NormalizeEmail(f.EmailDomain), "@"); emailDomain != "" {
+		// Um domínio válido não tem curingas do LIKE: o padrão casa exatamente
+		// a parte após o @, como em FindUsersByEmailDomain
+		if err := validation.ValidateDomain(emailDomain); err != nil {
+			return nil, fmt.Errorf("%w: email_domain: %v", domain.ErrInvalidFilter, err)
+		}
+
+		builder.Where("email", repository.OpILike, "%@"+emailDomain)
+		criteria++
+	}
+
+	if f.CreatedFrom != nil {
+		builder.Where("created_at", repository.OpGreaterOrEqual, *f.CreatedFrom)
+		criteria++
+	}
+
+	if f.CreatedTo != nil {
+		builder.Where("created_at", repository.OpLessOrEqual, *f.CreatedTo)
+		criteria++
+	}
+
+	// Evitar alterar todos os usuários por engano
+	if criteria == 0 {
+		return nil, domain.ErrEmptyBulkFilter
+	}
+
+	return builder, nil
+}
+
+// scopeToRequester restringe a seleção aos usuários que requester pode
+// gerenciar (domain.User.CanManage), deixando-o de fora.
+func scopeToRequester(builder *repository.QueryBuilder, requester *domain.User) {
+	manageable := domain.RolesBelow(requester.Role)
+
+	roles := make([]string, len(manageable))
+	for i, role := range manageable {
+		roles[i] = role.String()
+	}
+
+	builder.WhereIn("role", roles)
+	builder.Where("id", repository.OpNotEqual, requester.ID)
+}
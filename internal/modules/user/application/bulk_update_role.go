@@ -0,0 +1,170 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+// MaxBulkRolePreviewUsers limita quantos usuários a prévia lista individualmente;
+// Total sempre traz a quantidade completa.
+const MaxBulkRolePreviewUsers = 1000
+
+// BulkUpdateRoleUseCase implementa o caso de uso de alterar o role de vários
+// usuários, com uma prévia das mudanças antes de aplicá-las.
+type BulkUpdateRoleUseCase struct {
+	clock    clock.Clock
+	userRepo domain.Repository
+	logger   *zap.Logger
+}
+
+// NewBulkUpdateRoleUseCase cria uma nova instância do caso de uso.
+func NewBulkUpdateRoleUseCase(userRepo domain.Repository) *BulkUpdateRoleUseCase {
+	return &BulkUpdateRoleUseCase{
+		clock:    clock.RealClock{},
+		userRepo: userRepo,
+	}
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *BulkUpdateRoleUseCase) WithLogger(logger *zap.Logger) *BulkUpdateRoleUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *BulkUpdateRoleUseCase) WithClock(c clock.Clock) *BulkUpdateRoleUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// BulkUpdateRoleInput representa os dados de entrada.
+// Quem pede a mudança é o ator autenticado do contexto.
+type BulkUpdateRoleInput struct {
+	TargetRole domain.Role `json:"target_role" validate:"required"`
+	BulkFilter
+}
+
+// RoleChange descreve a mudança de role de um usuário.
+type RoleChange struct {
+	UserID uuid.UUID   `json:"user_id"`
+	Email  string      `json:"email"`
+	From   domain.Role `json:"from"`
+	To     domain.Role `json:"to"`
+}
+
+// BulkUpdateRolePreview representa as mudanças que Execute aplicaria.
+// Truncated indica que Changes lista apenas os primeiros MaxBulkRolePreviewUsers.
+type BulkUpdateRolePreview struct {
+	TargetRole domain.Role  `json:"target_role"`
+	Changes    []RoleChange `json:"changes"`
+	Total      int64        `json:"total"`
+	Truncated  bool         `json:"truncated"`
+}
+
+// BulkUpdateRoleOutput representa os dados de saída.
+type BulkUpdateRoleOutput struct {
+	TargetRole domain.Role `json:"target_role"`
+	Affected   int64       `json:"affected"`
+}
+
+// Preview lista, sem alterar nada, os usuários cujo role mudaria e para qual.
+// Para aplicar exatamente a prévia, envie os IDs listados em BulkFilter.IDs.
+func (uc *BulkUpdateRoleUseCase) Preview(ctx context.Context, input BulkUpdateRoleInput) (*BulkUpdateRolePreview, error) {
+	filter, err := uc.filter(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.userRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	listing := filter
+	listing.OrderBy = []string{"email ASC"}
+	listing.Limit = MaxBulkRolePreviewUsers
+
+	users, err := uc.userRepo.FindMany(ctx, listing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	changes := make([]RoleChange, len(users))
+	for i, user := range users {
+		changes[i] = RoleChange{UserID: user.ID, Email: user.Email, From: user.Role, To: input.TargetRole}
+	}
+
+	return &BulkUpdateRolePreview{
+		TargetRole: input.TargetRole,
+		Changes:    changes,
+		Total:      total,
+		Truncated:  total > int64(len(changes)),
+	}, nil
+}
+
+// Execute aplica, em uma transação (UpdateMany), as mudanças listadas por Preview.
+func (uc *BulkUpdateRoleUseCase) Execute(ctx context.Context, input BulkUpdateRoleInput) (*BulkUpdateRoleOutput, error) {
+	filter, err := uc.filter(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	actor := actorFrom(ctx)
+
+	affected, err := uc.userRepo.UpdateMany(ctx, filter, map[string]interface{}{
+		"role":       input.TargetRole.String(),
+		"updated_at": clock.UTC(uc.clock.Now()),
+		"updated_by": actor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update users role: %w", err)
+	}
+
+	contextLogger(ctx, uc.logger).Warn("Users role updated",
+		zap.String("component", "audit"),
+		zap.Stringer("target_role", input.TargetRole),
+		zap.Int64("affected", affected),
+		zap.String("changed_by", actor),
+	)
+
+	return &BulkUpdateRoleOutput{TargetRole: input.TargetRole, Affected: affected}, nil
+}
+
+// filter monta a seleção comum a Preview e Execute, com as mesmas regras de
+// domain.User.CanChangeRole: o role alvo deve ficar abaixo do role de quem
+// pede, que fica de fora da seleção, assim como os usuários que ele não pode
+// gerenciar. Usuários que já têm o role alvo não contam.
+func (uc *BulkUpdateRoleUseCase) filter(ctx context.Context, input BulkUpdateRoleInput) (repository.QueryFilter, error) {
+	if !input.TargetRole.Valid() {
+		return repository.QueryFilter{}, fmt.Errorf("%w: %q", domain.ErrInvalidRole, input.TargetRole)
+	}
+
+	builder, err := input.queryBuilder()
+	if err != nil {
+		return repository.QueryFilter{}, err
+	}
+
+	requester, err := loadRequester(ctx, uc.userRepo)
+	if err != nil {
+		return repository.QueryFilter{}, err
+	}
+
+	if domain.RoleLevel(input.TargetRole) >= domain.RoleLevel(requester.Role) {
+		return repository.QueryFilter{}, fmt.Errorf("%w: %s cannot grant the %s role",
+			domain.ErrRoleChangeForbidden, requester.Role, input.TargetRole)
+	}
+
+	builder.Where("role", repository.OpNotEqual, input.TargetRole.String())
+	scopeToRequester(builder, requester)
+
+	return builder.Build(), nil
+}
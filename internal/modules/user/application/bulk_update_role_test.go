@@ -0,0 +1,162 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+func TestBulkUpdateRolePreviewMatchesApply(t *testing.T) {
+	fake := clock.NewFakeClock(repositorytest.BaseTime.Add(24 * time.Hour))
+	repo := memory.NewRepository().WithClock(fake)
+
+	withRole := func(name, email string, offset int, role domain.Role) *domain.User {
+		user := repositorytest.NewUser(name, email, offset)
+		user.Role = role
+
+		return user
+	}
+
+	admin := withRole("Admin", "admin@acme.io", 0, domain.RoleAdmin)
+	users := []*domain.User{
+		admin,
+		withRole("Davi", "davi@acme.io", 1, domain.RoleUser),
+		withRole("Ana", "ana@acme.io", 2, domain.RoleUser),
+		// Já é moderador: não muda
+		withRole("Bruno", "bruno@acme.io", 3, domain.RoleModerator),
+		// Não pode ser gerenciada por um admin
+		withRole("Carla", "carla@acme.io", 4, domain.RoleAdmin),
+		// Fora do domínio filtrado
+		withRole("Eva", "eva@other.io", 5, domain.RoleUser),
+	}
+	repositorytest.Seed(t, repo, users...)
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	uc := NewBulkUpdateRoleUseCase(repo).WithClock(fake).WithLogger(zap.New(core))
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+	input := BulkUpdateRoleInput{
+		TargetRole: domain.RoleModerator,
+		BulkFilter: BulkFilter{EmailDomain: "acme.io"},
+	}
+
+	preview, err := uc.Preview(ctx, input)
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+
+	// Ordenada por email, user -> moderator
+	want := []RoleChange{
+		{UserID: users[2].ID, Email: "ana@acme.io", From: domain.RoleUser, To: domain.RoleModerator},
+		{UserID: users[1].ID, Email: "davi@acme.io", From: domain.RoleUser, To: domain.RoleModerator},
+	}
+
+	if preview.Total != 2 || preview.Truncated || len(preview.Changes) != len(want) {
+		t.Fatalf("preview = %+v, want 2 changes", preview)
+	}
+
+	for i, change := range preview.Changes {
+		if change != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, change, want[i])
+		}
+	}
+
+	// A prévia não altera nada
+	if stored, _ := repo.GetByID(ctx, users[2].ID); stored.Role != domain.RoleUser {
+		t.Errorf("preview changed %s to %s", stored.Email, stored.Role)
+	}
+
+	output, err := uc.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if output.Affected != preview.Total {
+		t.Errorf("affected = %d, want the previewed %d", output.Affected, preview.Total)
+	}
+
+	// Exatamente os usuários da prévia mudaram, e para o role previsto
+	previewed := map[string]RoleChange{}
+	for _, change := range preview.Changes {
+		previewed[change.UserID.String()] = change
+	}
+
+	for _, user := range users {
+		stored, err := repo.GetByID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("GetByID(%s): %v", user.Email, err)
+		}
+
+		wantRole := user.Role
+		if change, ok := previewed[user.ID.String()]; ok {
+			wantRole = change.To
+
+			if stored.UpdatedBy != admin.ID.String() || !stored.UpdatedAt.Equal(fake.Now()) {
+				t.Errorf("%s updated by %s at %v", stored.Email, stored.UpdatedBy, stored.UpdatedAt)
+			}
+		}
+
+		if stored.Role != wantRole {
+			t.Errorf("%s role = %s, want %s", stored.Email, stored.Role, wantRole)
+		}
+	}
+
+	if entries := logs.FilterMessage("Users role updated").All(); len(entries) != 1 || entries[0].ContextMap()["affected"] != int64(2) {
+		t.Errorf("audit entries = %v", entries)
+	}
+
+	// Depois de aplicada, a mesma seleção não tem mais o que mudar
+	again, err := uc.Preview(ctx, input)
+	if err != nil {
+		t.Fatalf("Preview after apply: %v", err)
+	}
+
+	if again.Total != 0 || len(again.Changes) != 0 {
+		t.Errorf("preview after apply = %+v, want no changes", again)
+	}
+}
+
+func TestBulkUpdateRoleRejectsForbiddenTargets(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleUser)
+	ctx := requestctx.WithActor(context.Background(), users[0].ID.String())
+	uc := NewBulkUpdateRoleUseCase(repo)
+
+	tests := []struct {
+		name string
+		role domain.Role
+		want error
+	}{
+		{name: "own role", role: domain.RoleAdmin, want: domain.ErrRoleChangeForbidden},
+		{name: "higher role", role: domain.RoleSuperAdmin, want: domain.ErrRoleChangeForbidden},
+		{name: "unknown role", role: "owner", want: domain.ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := BulkUpdateRoleInput{TargetRole: tt.role, BulkFilter: BulkFilter{EmailDomain: "example.com"}}
+
+			if _, err := uc.Preview(ctx, input); !errors.Is(err, tt.want) {
+				t.Errorf("Preview = %v, want %v", err, tt.want)
+			}
+
+			if _, err := uc.Execute(ctx, input); !errors.Is(err, tt.want) {
+				t.Errorf("Execute = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if stored, _ := repo.GetByID(ctx, users[1].ID); stored.Role != domain.RoleUser {
+		t.Errorf("rejected change stored role %s", stored.Role)
+	}
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// BulkUpdateStatusUseCase implementa o caso de uso de alterar o status de vários usuários.
+type BulkUpdateStatusUseCase struct {
+	clock    clock.Clock
+	userRepo domain.Repository
+	logger   *zap.Logger
+}
+
+// NewBulkUpdateStatusUseCase cria uma nova instância do caso de uso.
+func NewBulkUpdateStatusUseCase(userRepo domain.Repository) *BulkUpdateStatusUseCase {
+	return &BulkUpdateStatusUseCase{
+		clock:    clock.RealClock{},
+		userRepo: userRepo,
+	}
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *BulkUpdateStatusUseCase) WithLogger(logger *zap.Logger) *BulkUpdateStatusUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *BulkUpdateStatusUseCase) WithClock(c clock.Clock) *BulkUpdateStatusUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// BulkUpdateStatusInput representa os dados de entrada.
+// Com DryRun, apenas a quantidade de usuários que seriam afetados é retornada.
+type BulkUpdateStatusInput struct {
+	TargetStatus domain.Status `json:"target_status" validate:"required"`
+	BulkFilter
+	DryRun bool `json:"dry_run"`
+}
+
+// BulkUpdateStatusOutput representa os dados de saída.
+type BulkUpdateStatusOutput struct {
+	TargetStatus domain.Status `json:"target_status"`
+	Affected     int64         `json:"affected"`
+	DryRun       bool          `json:"dry_run"`
+}
+
+// Execute executa o caso de uso. Como em SetUserStatusUseCase, só são
+// alterados os usuários que quem pede pode gerenciar, nunca ele mesmo, e cujo
+// status atual permite a transição para o status alvo; os demais ficam de
+// fora da seleção.
+func (uc *BulkUpdateStatusUseCase) Execute(
+	ctx context.Context,
+	input BulkUpdateStatusInput,
+) (*BulkUpdateStatusOutput, error) {
+	if !input.TargetStatus.Valid() {
+		return nil, domain.ErrInvalidStatus
+	}
+
+	builder, err := input.queryBuilder()
+	if err != nil {
+		return nil, err
+	}
+
+	requester, err := loadRequester(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := domain.StatusesTransitioningTo(input.TargetStatus)
+
+	statuses := make([]string, len(sources))
+	for i, status := range sources {
+		statuses[i] = status.String()
+	}
+
+	// Usuários que já estão no status alvo não contam como afetados
+	builder.WhereIn("status", statuses)
+	scopeToRequester(builder, requester)
+	filter := builder.Build()
+
+	output := &BulkUpdateStatusOutput{
+		TargetStatus: input.TargetStatus,
+		DryRun:       input.DryRun,
+	}
+
+	if input.DryRun {
+		output.Affected, err = uc.userRepo.Count(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count users: %w", err)
+		}
+
+		return output, nil
+	}
+
+	output.Affected, err = uc.userRepo.UpdateMany(ctx, filter, map[string]interface{}{
+		"status":     input.TargetStatus.String(),
+		"updated_at": clock.UTC(uc.clock.Now()),
+		"updated_by": actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update users status: %w", err)
+	}
+
+	contextLogger(ctx, uc.logger).Info("Users status updated",
+		zap.Stringer("target_status", input.TargetStatus),
+		zap.Int64("affected", output.Affected),
+	)
+
+	return output, nil
+}
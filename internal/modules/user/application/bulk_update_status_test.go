@@ -0,0 +1,223 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+func TestBulkUpdateStatusByEmailDomain(t *testing.T) {
+	now := repositorytest.BaseTime.Add(24 * time.Hour)
+	repo := memory.NewRepository()
+
+	alreadySuspended := repositorytest.NewUser("Carla", "carla@compromised.io", 2)
+	alreadySuspended.Status = domain.StatusSuspended
+
+	repositorytest.Seed(t, repo,
+		repositorytest.NewUser("Ana", "ana@compromised.io", 0),
+		repositorytest.NewUser("Bruno", "Bruno@Compromised.IO", 1),
+		alreadySuspended,
+		repositorytest.NewUser("Davi", "davi@example.com", 3),
+		repositorytest.NewUser("Eva", "eva@notcompromised.io", 4),
+	)
+
+	admin := seedRoles(t, repo, domain.RoleAdmin)[0]
+
+	uc := NewBulkUpdateStatusUseCase(repo).WithClock(clock.NewFakeClock(now))
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+	input := BulkUpdateStatusInput{
+		TargetStatus: domain.StatusSuspended,
+		BulkFilter:   BulkFilter{EmailDomain: "@Compromised.io"},
+	}
+
+	// O dry run conta sem alterar
+	input.DryRun = true
+
+	preview, err := uc.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+
+	if preview.Affected != 2 {
+		t.Errorf("dry run affected = %d, want 2", preview.Affected)
+	}
+
+	input.DryRun = false
+
+	output, err := uc.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	// Quem já estava suspenso não conta como afetado
+	if output.Affected != 2 {
+		t.Errorf("affected = %d, want 2", output.Affected)
+	}
+
+	for _, email := range []string{"ana@compromised.io", "bruno@compromised.io", "davi@example.com", "eva@notcompromised.io"} {
+		user, err := repo.GetByEmail(ctx, email)
+		if err != nil {
+			t.Fatalf("GetByEmail(%s): %v", email, err)
+		}
+
+		suspended := user.Status == domain.StatusSuspended
+		if want := email != "davi@example.com" && email != "eva@notcompromised.io"; suspended != want {
+			t.Errorf("%s status = %s", email, user.Status)
+		}
+
+		if suspended && (user.UpdatedBy != admin.ID.String() || !user.UpdatedAt.Equal(now)) {
+			t.Errorf("%s updated by %s at %v", email, user.UpdatedBy, user.UpdatedAt)
+		}
+	}
+}
+
+func TestBulkUpdateStatusRejectsInvalidInput(t *testing.T) {
+	uc := NewBulkUpdateStatusUseCase(memory.NewRepository())
+
+	tests := []struct {
+		name  string
+		input BulkUpdateStatusInput
+		want  error
+	}{
+		{
+			name:  "invalid target status",
+			input: BulkUpdateStatusInput{TargetStatus: "banned", BulkFilter: BulkFilter{EmailDomain: "example.com"}},
+			want:  domain.ErrInvalidStatus,
+		},
+		{
+			name:  "empty filter",
+			input: BulkUpdateStatusInput{TargetStatus: domain.StatusInactive},
+			want:  domain.ErrEmptyBulkFilter,
+		},
+		{
+			name:  "wildcard email domain",
+			input: BulkUpdateStatusInput{TargetStatus: domain.StatusInactive, BulkFilter: BulkFilter{EmailDomain: "%"}},
+			want:  domain.ErrInvalidFilter,
+		},
+		{
+			name:  "unauthenticated",
+			input: BulkUpdateStatusInput{TargetStatus: domain.StatusInactive, BulkFilter: BulkFilter{EmailDomain: "example.com"}},
+			want:  domain.ErrRoleChangeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uc.Execute(context.Background(), tt.input); !errors.Is(err, tt.want) {
+				t.Errorf("Execute error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkUpdateStatusDryRunLeavesUsersUnchanged(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	filter := seedBulkTargets(t, repo)
+	uc := NewBulkUpdateStatusUseCase(repo)
+
+	ctx = requestctx.WithActor(ctx, seedRoles(t, repo, domain.RoleAdmin)[0].ID.String())
+
+	input := BulkUpdateStatusInput{TargetStatus: domain.StatusInactive, BulkFilter: filter, DryRun: true}
+
+	preview, err := uc.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+
+	inactive := repository.NewQueryBuilder().WhereEqual("status", domain.StatusInactive.String()).Build()
+
+	if count, _ := repo.Count(ctx, inactive); preview.Affected != 3 || count != 0 {
+		t.Fatalf("dry run affected %d and changed %d users, want 3 and 0", preview.Affected, count)
+	}
+
+	input.DryRun = false
+
+	output, err := uc.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if count, _ := repo.Count(ctx, inactive); output.Affected != preview.Affected || count != preview.Affected {
+		t.Errorf("affected %d and %d users inactive, want %d", output.Affected, count, preview.Affected)
+	}
+}
+
+func TestBulkUpdateStatusScopesToRequesterAndTransitions(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleSuperAdmin, domain.RoleAdmin, domain.RoleUser, domain.RoleUser)
+	admin, superAdmin, otherAdmin, user, pending := users[0], users[1], users[2], users[3], users[4]
+
+	pending.Status = domain.StatusPending
+	if err := repo.Update(context.Background(), pending); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	uc := NewBulkUpdateStatusUseCase(repo)
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+	// Nem o role do filtro amplia a seleção além de quem o admin gerencia
+	output, err := uc.Execute(ctx, BulkUpdateStatusInput{
+		TargetStatus: domain.StatusSuspended,
+		BulkFilter:   BulkFilter{Role: domain.RoleSuperAdmin},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if output.Affected != 0 {
+		t.Errorf("suspending super admins affected %d, want 0", output.Affected)
+	}
+
+	output, err = uc.Execute(ctx, BulkUpdateStatusInput{
+		TargetStatus: domain.StatusSuspended,
+		BulkFilter:   BulkFilter{EmailDomain: "example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if output.Affected != 2 {
+		t.Errorf("affected = %d, want 2", output.Affected)
+	}
+
+	// Ninguém volta a pending, nem em massa
+	output, err = uc.Execute(ctx, BulkUpdateStatusInput{
+		TargetStatus: domain.StatusPending,
+		BulkFilter:   BulkFilter{EmailDomain: "example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Execute pending: %v", err)
+	}
+
+	if output.Affected != 0 {
+		t.Errorf("moving back to pending affected %d, want 0", output.Affected)
+	}
+
+	want := map[*domain.User]domain.Status{
+		admin:      domain.StatusActive,
+		superAdmin: domain.StatusActive,
+		otherAdmin: domain.StatusActive,
+		user:       domain.StatusSuspended,
+		pending:    domain.StatusSuspended,
+	}
+
+	for target, status := range want {
+		stored, err := repo.GetByID(context.Background(), target.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+
+		if stored.Status != status {
+			t.Errorf("%s status = %s, want %s", stored.Email, stored.Status, status)
+		}
+	}
+}
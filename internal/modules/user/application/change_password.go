@@ -0,0 +1,209 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// ChangePasswordUseCase implementa o caso de uso de troca de senha.
+type ChangePasswordUseCase struct {
+	clock       clock.Clock
+	userRepo    domain.Repository
+	historyRepo domain.PasswordHistoryRepository
+	passwords   *domain.PasswordService
+	notifier    NotificationService
+	logger      *zap.Logger
+	historySize int
+}
+
+// NewChangePasswordUseCase cria uma nova instância do caso de uso.
+// historySize <= 0 usa domain.DefaultPasswordHistorySize.
+func NewChangePasswordUseCase(
+	userRepo domain.Repository,
+	historyRepo domain.PasswordHistoryRepository,
+	passwords *domain.PasswordService,
+	historySize int,
+) *ChangePasswordUseCase {
+	if historySize <= 0 {
+		historySize = domain.DefaultPasswordHistorySize
+	}
+
+	return &ChangePasswordUseCase{
+		clock:       clock.RealClock{},
+		userRepo:    userRepo,
+		historyRepo: historyRepo,
+		passwords:   passwords,
+		notifier:    NullNotificationService{},
+		historySize: historySize,
+	}
+}
+
+// WithNotifier define o serviço que notifica o usuário sobre a troca de senha.
+func (uc *ChangePasswordUseCase) WithNotifier(notifier NotificationService) *ChangePasswordUseCase {
+	if notifier != nil {
+		uc.notifier = notifier
+	}
+
+	return uc
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *ChangePasswordUseCase) WithLogger(logger *zap.Logger) *ChangePasswordUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *ChangePasswordUseCase) WithClock(c clock.Clock) *ChangePasswordUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// ChangePasswordInput representa os dados de entrada.
+type ChangePasswordInput struct {
+	CurrentPassword string    `json:"current_password" validate:"required"`
+	NewPassword     string    `json:"new_password" validate:"required"`
+	UserID          uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// ChangePasswordOutput representa os dados de saída.
+type ChangePasswordOutput struct {
+	Message string `json:"message"`
+}
+
+// Execute executa o caso de uso.
+func (uc *ChangePasswordUseCase) Execute(
+	ctx context.Context,
+	input ChangePasswordInput,
+) (*ChangePasswordOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := uc.passwords.Compare(user.Password, input.CurrentPassword); err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if err := uc.replacePassword(ctx, user, input.NewPassword); err != nil {
+		return nil, err
+	}
+
+	contextLogger(ctx, uc.logger).Info("User password changed", zap.String("user_id", user.ID.String()))
+
+	uc.notifyPasswordChanged(ctx, user)
+
+	return &ChangePasswordOutput{
+		Message: "Password changed successfully",
+	}, nil
+}
+
+// ResetPasswordInput representa os dados de entrada da redefinição administrativa.
+type ResetPasswordInput struct {
+	NewPassword string    `json:"new_password" validate:"required"`
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// Reset redefine a senha de um usuário sem exigir a senha atual. É restrito a
+// administradores na camada HTTP; o ator do contexto é registrado no log e em
+// updated_by. Quem pede precisa ter um role superior ao do alvo e não pode
+// redefinir a própria senha (domain.ErrRoleChangeForbidden). A política de
+// força e o histórico continuam valendo.
+func (uc *ChangePasswordUseCase) Reset(
+	ctx context.Context,
+	input ResetPasswordInput,
+) (*ChangePasswordOutput, error) {
+	requester, err := loadRequester(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := ensureCanManage(requester, user); err != nil {
+		return nil, err
+	}
+
+	if err := uc.replacePassword(ctx, user, input.NewPassword); err != nil {
+		return nil, err
+	}
+
+	contextLogger(ctx, uc.logger).Warn("User password reset by admin",
+		zap.String("user_id", user.ID.String()),
+		zap.String("reset_by", actorFrom(ctx)),
+	)
+
+	uc.notifyPasswordChanged(ctx, user)
+
+	return &ChangePasswordOutput{
+		Message: "Password reset successfully",
+	}, nil
+}
+
+// replacePassword aplica a nova senha ao usuário e registra a anterior no histórico.
+func (uc *ChangePasswordUseCase) replacePassword(ctx context.Context, user *domain.User, newPassword string) error {
+	// A nova senha não pode ser a atual nem uma das últimas N
+	if err := uc.ensureNotReused(ctx, user, newPassword); err != nil {
+		return err
+	}
+
+	previousHash := user.Password
+
+	if err := user.UpdatePassword(newPassword, uc.passwords, uc.clock.Now()); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	user.UpdatedBy = actorFrom(ctx)
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	if err := uc.historyRepo.Add(ctx, user.ID, previousHash, uc.historySize); err != nil {
+		return fmt.Errorf("failed to record password history: %w", err)
+	}
+
+	return nil
+}
+
+// notifyPasswordChanged avisa o usuário sobre a troca de senha.
+// Falhas na notificação não devem desfazer a troca de senha.
+func (uc *ChangePasswordUseCase) notifyPasswordChanged(ctx context.Context, user *domain.User) {
+	if err := uc.notifier.NotifySecurityEvent(ctx, user, SecurityEventPasswordChanged); err != nil {
+		contextLogger(ctx, uc.logger).Warn("Failed to send password change notification",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// ensureNotReused verifica a nova senha contra a senha atual e o histórico.
+func (uc *ChangePasswordUseCase) ensureNotReused(ctx context.Context, user *domain.User, password string) error {
+	if uc.passwords.Compare(user.Password, password) == nil {
+		return domain.ErrPasswordReused
+	}
+
+	hashes, err := uc.historyRepo.Recent(ctx, user.ID, uc.historySize)
+	if err != nil {
+		return fmt.Errorf("failed to check password history: %w", err)
+	}
+
+	for _, hash := range hashes {
+		if uc.passwords.Compare(hash, password) == nil {
+			return domain.ErrPasswordReused
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// fakePasswordHistory guarda o histórico de senhas em memória.
+type fakePasswordHistory struct {
+	hashes map[uuid.UUID][]string
+}
+
+func (f *fakePasswordHistory) Recent(_ context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	hashes := f.hashes[userID]
+
+	return hashes[:min(limit, len(hashes))], nil
+}
+
+func (f *fakePasswordHistory) Add(_ context.Context, userID uuid.UUID, passwordHash string, keep int) error {
+	if f.hashes == nil {
+		f.hashes = map[uuid.UUID][]string{}
+	}
+
+	hashes := append([]string{passwordHash}, f.hashes[userID]...)
+	f.hashes[userID] = hashes[:min(keep, len(hashes))]
+
+	return nil
+}
+
+// recordingNotifier registra os eventos de segurança notificados.
+type recordingNotifier struct {
+	events []SecurityEvent
+}
+
+func (r *recordingNotifier) NotifySecurityEvent(_ context.Context, _ *domain.User, event SecurityEvent) error {
+	r.events = append(r.events, event)
+
+	return nil
+}
+
+// seedWithPassword cria no repositório um usuário com hash real de testPassword.
+func seedWithPassword(t *testing.T, repo domain.Repository) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser("Ana", "ana@example.com", testPassword, testPasswords, repositorytest.BaseTime)
+	if err != nil {
+		t.Fatalf("NewUser: %v", err)
+	}
+
+	repositorytest.Seed(t, repo, user)
+
+	return user
+}
+
+func TestChangePasswordNotifiesSecurityEvent(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	user := seedWithPassword(t, repo)
+
+	notifier := &recordingNotifier{}
+	uc := NewChangePasswordUseCase(repo, &fakePasswordHistory{}, testPasswords, 0).WithNotifier(notifier)
+
+	// Leitura e tentativa recusada não notificam
+	if _, err := NewGetUserUseCase(repo).Execute(ctx, GetUserInput{ID: user.ID}); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+
+	_, err := uc.Execute(ctx, ChangePasswordInput{UserID: user.ID, CurrentPassword: "Senha-Errada-1", NewPassword: "Nova-Senha-456"})
+	if !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("Execute with wrong password = %v, want %v", err, domain.ErrInvalidCredentials)
+	}
+
+	if len(notifier.events) != 0 {
+		t.Fatalf("notified %v before any password change", notifier.events)
+	}
+
+	if _, err := uc.Execute(ctx, ChangePasswordInput{
+		UserID:          user.ID,
+		CurrentPassword: testPassword,
+		NewPassword:     "Nova-Senha-456",
+	}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if len(notifier.events) != 1 || notifier.events[0] != SecurityEventPasswordChanged {
+		t.Errorf("events = %v, want [%s]", notifier.events, SecurityEventPasswordChanged)
+	}
+}
+
+func TestChangePasswordRejectsRecentHistory(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	user := seedWithPassword(t, repo)
+
+	history := &fakePasswordHistory{}
+	uc := NewChangePasswordUseCase(repo, history, testPasswords, 2)
+
+	current := testPassword
+	change := func(next string) error {
+		_, err := uc.Execute(ctx, ChangePasswordInput{UserID: user.ID, CurrentPassword: current, NewPassword: next})
+		if err == nil {
+			current = next
+		}
+
+		return err
+	}
+
+	// testPassword -> Segunda -> Terceira -> Quarta: com N = 2, o histórico
+	// guarda Segunda e Terceira, e testPassword já foi descartada
+	for _, next := range []string{"Segunda-Senha-2", "Terceira-Senha-3", "Quarta-Senha-4"} {
+		if err := change(next); err != nil {
+			t.Fatalf("change to %s: %v", next, err)
+		}
+	}
+
+	if got := len(history.hashes[user.ID]); got != 2 {
+		t.Errorf("history keeps %d hashes, want 2", got)
+	}
+
+	for _, reused := range []string{"Quarta-Senha-4", "Terceira-Senha-3", "Segunda-Senha-2"} {
+		if err := change(reused); !errors.Is(err, domain.ErrPasswordReused) {
+			t.Errorf("reusing %s = %v, want %v", reused, err, domain.ErrPasswordReused)
+		}
+	}
+
+	if err := change(testPassword); err != nil {
+		t.Errorf("password pruned from history was rejected: %v", err)
+	}
+}
+
+func TestNewChangePasswordUseCaseDefaultHistorySize(t *testing.T) {
+	uc := NewChangePasswordUseCase(memory.NewRepository(), &fakePasswordHistory{}, testPasswords, 0)
+
+	if uc.historySize != domain.DefaultPasswordHistorySize || domain.DefaultPasswordHistorySize != 5 {
+		t.Errorf("historySize = %d, want 5", uc.historySize)
+	}
+}
+
+func TestResetPasswordSkipsCurrentPasswordAndAudits(t *testing.T) {
+	repo := memory.NewRepository()
+	user := seedWithPassword(t, repo)
+	admin := seedRoles(t, repo, domain.RoleAdmin)[0]
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	notifier := &recordingNotifier{}
+	uc := NewChangePasswordUseCase(repo, &fakePasswordHistory{}, testPasswords, 0).
+		WithNotifier(notifier).
+		WithLogger(zap.New(core))
+
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+	// A redefinição não conhece a senha atual, mas não pode repeti-la
+	if _, err := uc.Reset(ctx, ResetPasswordInput{UserID: user.ID, NewPassword: testPassword}); !errors.Is(err, domain.ErrPasswordReused) {
+		t.Fatalf("Reset to the current password = %v, want %v", err, domain.ErrPasswordReused)
+	}
+
+	if _, err := uc.Reset(ctx, ResetPasswordInput{UserID: user.ID, NewPassword: "Nova-Senha-456"}); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.ValidatePassword("Nova-Senha-456") != nil || stored.UpdatedBy != admin.ID.String() {
+		t.Errorf("stored user updated by %q does not accept the new password", stored.UpdatedBy)
+	}
+
+	entries := logs.FilterMessage("User password reset by admin").All()
+	if len(entries) != 1 || entries[0].Level != zapcore.WarnLevel || entries[0].ContextMap()["reset_by"] != admin.ID.String() {
+		t.Errorf("audit entries = %v", entries)
+	}
+
+	if len(notifier.events) != 1 {
+		t.Errorf("events = %v, want one notification", notifier.events)
+	}
+}
+
+func TestResetPasswordRequiresHigherRole(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleAdmin, domain.RoleSuperAdmin)
+	admin, otherAdmin, superAdmin := users[0], users[1], users[2]
+
+	notifier := &recordingNotifier{}
+	uc := NewChangePasswordUseCase(repo, &fakePasswordHistory{}, testPasswords, 0).WithNotifier(notifier)
+
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+	for _, target := range []*domain.User{superAdmin, otherAdmin, admin} {
+		_, err := uc.Reset(ctx, ResetPasswordInput{UserID: target.ID, NewPassword: "Nova-Senha-456"})
+		if !errors.Is(err, domain.ErrRoleChangeForbidden) {
+			t.Errorf("Reset %s = %v, want %v", target.Role, err, domain.ErrRoleChangeForbidden)
+		}
+
+		stored, err := repo.GetByID(context.Background(), target.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+
+		if stored.Password != target.Password {
+			t.Errorf("password of the %s changed", target.Role)
+		}
+	}
+
+	if len(notifier.events) != 0 {
+		t.Errorf("events = %v, want none", notifier.events)
+	}
+}
@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// ChangeRoleUseCase implementa o caso de uso de mudar o role de um usuário.
+type ChangeRoleUseCase struct {
+	clock    clock.Clock
+	userRepo domain.Repository
+	notifier NotificationService
+	logger   *zap.Logger
+}
+
+// NewChangeRoleUseCase cria uma nova instância do caso de uso.
+func NewChangeRoleUseCase(userRepo domain.Repository) *ChangeRoleUseCase {
+	return &ChangeRoleUseCase{
+		clock:    clock.RealClock{},
+		userRepo: userRepo,
+		notifier: NullNotificationService{},
+	}
+}
+
+// WithNotifier define o serviço que notifica o usuário quando seu role é elevado.
+func (uc *ChangeRoleUseCase) WithNotifier(notifier NotificationService) *ChangeRoleUseCase {
+	if notifier != nil {
+		uc.notifier = notifier
+	}
+
+	return uc
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *ChangeRoleUseCase) WithLogger(logger *zap.Logger) *ChangeRoleUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *ChangeRoleUseCase) WithClock(c clock.Clock) *ChangeRoleUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// ChangeRoleInput representa os dados de entrada.
+// Quem pede a mudança é o ator autenticado do contexto.
+type ChangeRoleInput struct {
+	Role   domain.Role `json:"role" validate:"required"`
+	UserID uuid.UUID   `json:"user_id" validate:"required"`
+}
+
+// ChangeRoleOutput representa os dados de saída.
+type ChangeRoleOutput struct {
+	User         *domain.User `json:"user"`
+	PreviousRole domain.Role  `json:"previous_role"`
+	Changed      bool         `json:"changed"`
+}
+
+// Execute executa o caso de uso. A permissão é verificada com
+// domain.User.CanChangeRole, independente do middleware de roles da rota.
+func (uc *ChangeRoleUseCase) Execute(ctx context.Context, input ChangeRoleInput) (*ChangeRoleOutput, error) {
+	if !input.Role.Valid() {
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidRole, input.Role)
+	}
+
+	requester, err := loadRequester(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := requester.CanChangeRole(user, input.Role); err != nil {
+		return nil, err
+	}
+
+	previous := user.Role
+
+	changed, err := user.ChangeRole(input.Role, uc.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ChangeRoleOutput{User: user, PreviousRole: previous, Changed: changed}
+	if !changed {
+		return output, nil
+	}
+
+	user.UpdatedBy = requester.ID.String()
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	log := contextLogger(ctx, uc.logger)
+	log.Warn("User role changed",
+		zap.String("component", "audit"),
+		zap.String("user_id", user.ID.String()),
+		zap.Stringer("from", previous),
+		zap.Stringer("to", user.Role),
+		zap.String("changed_by", user.UpdatedBy),
+		zap.Stringer("changed_by_role", requester.Role),
+	)
+
+	if domain.RoleLevel(user.Role) <= domain.RoleLevel(previous) {
+		return output, nil
+	}
+
+	// Falhas na notificação não devem desfazer a mudança de role
+	if err := uc.notifier.NotifySecurityEvent(ctx, user, SecurityEventRoleElevated); err != nil {
+		log.Warn("Failed to send role change notification",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err),
+		)
+	}
+
+	return output, nil
+}
+
+// loadRequester carrega o usuário que pede a mudança de role. Durante uma
+// personificação, é o administrador, como em actorFrom.
+func loadRequester(ctx context.Context, userRepo domain.Repository) (*domain.User, error) {
+	id, err := uuid.Parse(actorFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: no authenticated requester", domain.ErrRoleChangeForbidden)
+	}
+
+	requester, err := userRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, fmt.Errorf("%w: requester no longer exists", domain.ErrRoleChangeForbidden)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get requester: %w", err)
+	}
+
+	return requester, nil
+}
+
+// ensureCanManage verifica se requester pode agir sobre target: é preciso ter
+// um role estritamente superior ao do alvo (domain.User.CanManage), e ninguém
+// age sobre a própria conta por esta via.
+func ensureCanManage(requester, target *domain.User) error {
+	if requester.ID == target.ID {
+		return fmt.Errorf("%w: cannot act on your own account", domain.ErrRoleChangeForbidden)
+	}
+
+	if !requester.CanManage(target) {
+		return fmt.Errorf("%w: %s cannot manage a %s", domain.ErrRoleChangeForbidden, requester.Role, target.Role)
+	}
+
+	return nil
+}
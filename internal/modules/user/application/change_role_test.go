@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+func TestChangeRoleAuditsAndNotifies(t *testing.T) {
+	fake := clock.NewFakeClock(time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC))
+	repo := memory.NewRepository().WithClock(fake)
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleUser)
+	admin, target := users[0], users[1]
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	notifier := &recordingNotifier{}
+	uc := NewChangeRoleUseCase(repo).WithNotifier(notifier).WithLogger(zap.New(core)).WithClock(fake)
+
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+	output, err := uc.Execute(ctx, ChangeRoleInput{UserID: target.ID, Role: domain.RoleModerator})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if !output.Changed || output.PreviousRole != domain.RoleUser || output.User.Role != domain.RoleModerator {
+		t.Errorf("output = changed %v, previous %s, role %s", output.Changed, output.PreviousRole, output.User.Role)
+	}
+
+	stored, err := repo.GetByID(context.Background(), target.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Role != domain.RoleModerator || stored.UpdatedBy != admin.ID.String() || !stored.UpdatedAt.Equal(fake.Now()) {
+		t.Errorf("stored role %s, updated by %s at %v", stored.Role, stored.UpdatedBy, stored.UpdatedAt)
+	}
+
+	// A auditoria guarda o role anterior e quem fez a mudança
+	entries := logs.FilterMessage("User role changed").All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields["component"] != "audit" || fields["from"] != "user" || fields["to"] != "moderator" ||
+		fields["changed_by"] != admin.ID.String() || fields["changed_by_role"] != "admin" {
+		t.Errorf("audit fields = %v", fields)
+	}
+
+	if !slices.Equal(notifier.events, []SecurityEvent{SecurityEventRoleElevated}) {
+		t.Errorf("events = %v, want [%s]", notifier.events, SecurityEventRoleElevated)
+	}
+
+	// Rebaixar também é auditado, mas não é uma elevação
+	if _, err := uc.Execute(ctx, ChangeRoleInput{UserID: target.ID, Role: domain.RoleUser}); err != nil {
+		t.Fatalf("Execute demotion: %v", err)
+	}
+
+	if len(logs.FilterMessage("User role changed").All()) != 2 || len(notifier.events) != 1 {
+		t.Errorf("demotion: %d audit entries and %d events, want 2 and 1",
+			len(logs.FilterMessage("User role changed").All()), len(notifier.events))
+	}
+}
+
+func TestChangeRoleRejections(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleAdmin, domain.RoleUser)
+	admin, otherAdmin, user := users[0], users[1], users[2]
+
+	tests := []struct {
+		name   string
+		actor  string
+		target *domain.User
+		role   domain.Role
+		want   error
+	}{
+		{name: "self elevation", actor: admin.ID.String(), target: admin, role: domain.RoleSuperAdmin, want: domain.ErrSelfRoleChange},
+		{name: "user elevates itself", actor: user.ID.String(), target: user, role: domain.RoleAdmin, want: domain.ErrSelfRoleChange},
+		{name: "grant own role", actor: admin.ID.String(), target: user, role: domain.RoleAdmin, want: domain.ErrRoleChangeForbidden},
+		{name: "demote a peer", actor: admin.ID.String(), target: otherAdmin, role: domain.RoleUser, want: domain.ErrRoleChangeForbidden},
+		{name: "user changes another user", actor: user.ID.String(), target: admin, role: domain.RoleUser, want: domain.ErrRoleChangeForbidden},
+		{name: "unauthenticated", target: user, role: domain.RoleModerator, want: domain.ErrRoleChangeForbidden},
+		{name: "unknown role", actor: admin.ID.String(), target: user, role: "owner", want: domain.ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			notifier := &recordingNotifier{}
+			uc := NewChangeRoleUseCase(repo).WithNotifier(notifier).WithLogger(zap.New(core))
+
+			ctx := requestctx.WithActor(context.Background(), tt.actor)
+
+			if _, err := uc.Execute(ctx, ChangeRoleInput{UserID: tt.target.ID, Role: tt.role}); !errors.Is(err, tt.want) {
+				t.Fatalf("Execute error = %v, want %v", err, tt.want)
+			}
+
+			stored, err := repo.GetByID(context.Background(), tt.target.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+
+			if stored.Role != tt.target.Role {
+				t.Errorf("stored role = %s, want unchanged %s", stored.Role, tt.target.Role)
+			}
+
+			if logs.Len() != 0 || len(notifier.events) != 0 {
+				t.Errorf("rejected change logged %d entries and sent %v", logs.Len(), notifier.events)
+			}
+		})
+	}
+}
@@ -4,27 +4,65 @@ import (
 	"context"
 	"fmt"
 
+	"go.uber.org/zap"
+
 	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
 )
 
 // CreateUserUseCase implementa o caso de uso de criação de usuário.
 type CreateUserUseCase struct {
-	userRepo domain.Repository
+	clock     clock.Clock
+	userRepo  domain.Repository
+	passwords *domain.PasswordService
+	profiles  domain.ProfileRepository
+	logger    *zap.Logger
+	domains   domain.EmailDomainPolicy
 }
 
 // NewCreateUserUseCase cria uma nova instância do caso de uso.
-func NewCreateUserUseCase(userRepo domain.Repository) *CreateUserUseCase {
+func NewCreateUserUseCase(userRepo domain.Repository, passwords *domain.PasswordService) *CreateUserUseCase {
 	return &CreateUserUseCase{
-		userRepo: userRepo,
+		clock:     clock.RealClock{},
+		userRepo:  userRepo,
+		passwords: passwords,
 	}
 }
 
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *CreateUserUseCase) WithLogger(logger *zap.Logger) *CreateUserUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *CreateUserUseCase) WithClock(c clock.Clock) *CreateUserUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// WithEmailDomainPolicy define a política de domínios de email aceitos no cadastro.
+func (uc *CreateUserUseCase) WithEmailDomainPolicy(policy domain.EmailDomainPolicy) *CreateUserUseCase {
+	uc.domains = policy
+
+	return uc
+}
+
+// WithProfiles define o repositório em que o perfil vazio do novo usuário é criado.
+func (uc *CreateUserUseCase) WithProfiles(profiles domain.ProfileRepository) *CreateUserUseCase {
+	uc.profiles = profiles
+
+	return uc
+}
+
 // CreateUserInput representa os dados de entrada.
 type CreateUserInput struct {
 	Phone    *string `json:"phone,omitempty"`
 	Name     string  `json:"name" validate:"required,min=2,max=100"`
 	Email    string  `json:"email" validate:"required,email"`
-	Password string  `json:"password" validate:"required,min=8"`
+	Password string  `json:"password" validate:"required"`
 }
 
 // CreateUserOutput representa os dados de saída.
@@ -35,18 +73,15 @@ type CreateUserOutput struct {
 
 // Execute executa o caso de uso.
 func (uc *CreateUserUseCase) Execute(ctx context.Context, input CreateUserInput) (*CreateUserOutput, error) {
-	// Verificar se email já existe
-	existingUser, err := uc.userRepo.GetByEmail(ctx, input.Email)
-	if err != nil && err != domain.ErrUserNotFound {
-		return nil, fmt.Errorf("failed to check email: %w", err)
-	}
+	// Normalizar email para garantir unicidade case-insensitive
+	input.Email = domain.NormalizeEmail(input.Email)
 
-	if existingUser != nil {
-		return nil, domain.ErrEmailAlreadyInUse
+	if err := uc.domains.Check(input.Email); err != nil {
+		return nil, err
 	}
 
 	// Criar usuário
-	user, err := domain.NewUser(input.Name, input.Email, input.Password)
+	user, err := domain.NewUser(input.Name, input.Email, input.Password, uc.passwords, uc.clock.Now())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
@@ -56,13 +91,44 @@ func (uc *CreateUserUseCase) Execute(ctx context.Context, input CreateUserInput)
 		user.Phone = input.Phone
 	}
 
-	// Salvar no banco
-	if err := uc.userRepo.Create(ctx, user); err != nil {
+	user.CreatedBy = actorFrom(ctx)
+	user.UpdatedBy = user.CreatedBy
+
+	// Salvar no banco de forma atômica (a restrição única de email evita duplicatas concorrentes)
+	saved, created, err := uc.userRepo.FindOrCreate(ctx, input.Email, func() *domain.User {
+		return user
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to save user: %w", err)
 	}
 
+	if !created {
+		return nil, domain.ErrEmailAlreadyInUse
+	}
+
+	user = saved
+
+	uc.createProfile(ctx, user)
+
+	contextLogger(ctx, uc.logger).Info("User created", zap.String("user_id", user.ID.String()))
+
 	return &CreateUserOutput{
 		User:    user,
 		Message: "User created successfully",
 	}, nil
 }
+
+// createProfile cria o perfil vazio do usuário. Uma falha não desfaz o
+// cadastro: a leitura do perfil trata usuários sem perfil como perfil vazio.
+func (uc *CreateUserUseCase) createProfile(ctx context.Context, user *domain.User) {
+	if uc.profiles == nil {
+		return
+	}
+
+	if err := uc.profiles.Upsert(ctx, domain.NewUserProfile(user.ID, user.CreatedAt)); err != nil {
+		contextLogger(ctx, uc.logger).Warn("Failed to create user profile",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
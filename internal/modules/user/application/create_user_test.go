@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// testPasswords usa o custo mínimo do bcrypt para manter os testes rápidos.
+var testPasswords = domain.NewPasswordService(bcrypt.MinCost)
+
+// testPassword atende à política padrão de senhas.
+const testPassword = "Senha-Forte-123"
+
+func TestCreateUserRejectsCaseDifferingDuplicate(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	uc := NewCreateUserUseCase(repo, testPasswords)
+
+	output, err := uc.Execute(ctx, CreateUserInput{Name: "Ana", Email: " A@B.com ", Password: testPassword})
+	if err != nil {
+		t.Fatalf("first Execute: %v", err)
+	}
+
+	if output.User.Email != "a@b.com" {
+		t.Errorf("stored email = %q, want %q", output.User.Email, "a@b.com")
+	}
+
+	_, err = uc.Execute(ctx, CreateUserInput{Name: "Outra Ana", Email: "a@b.com", Password: testPassword})
+	if !errors.Is(err, domain.ErrEmailAlreadyInUse) {
+		t.Fatalf("second Execute error = %v, want %v", err, domain.ErrEmailAlreadyInUse)
+	}
+
+	found, err := repo.GetByEmail(ctx, "A@b.COM")
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+
+	if found.ID != output.User.ID {
+		t.Errorf("GetByEmail returned %s, want %s", found.ID, output.User.ID)
+	}
+}
+
+func TestCreateUserLogsCorrelationIDs(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	uc := NewCreateUserUseCase(memory.NewRepository(), testPasswords).WithLogger(zap.New(core))
+
+	ctx := requestctx.WithTraceID(requestctx.WithRequestID(context.Background(), "req-123"), "trace-456")
+
+	if _, err := uc.Execute(ctx, CreateUserInput{Name: "Ana", Email: "ana@example.com", Password: testPassword}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	entries := logs.FilterMessage("User created").All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+
+	if fields := entries[0].ContextMap(); fields["request_id"] != "req-123" || fields["trace_id"] != "trace-456" {
+		t.Errorf("fields = %v, want request_id req-123 and trace_id trace-456", fields)
+	}
+}
+
+func TestCreateUserEnforcesEmailDomainPolicy(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	uc := NewCreateUserUseCase(repo, testPasswords).
+		WithEmailDomainPolicy(domain.NewEmailDomainPolicy([]string{"acme.com"}, []string{"mailinator.com"}))
+
+	if _, err := uc.Execute(ctx, CreateUserInput{Name: "Ana", Email: "ana@acme.com", Password: testPassword}); err != nil {
+		t.Fatalf("Execute with allowed domain: %v", err)
+	}
+
+	for _, email := range []string{"bruno@mailinator.com", "carla@example.com"} {
+		if _, err := uc.Execute(ctx, CreateUserInput{Name: "Outro", Email: email, Password: testPassword}); !errors.Is(err, domain.ErrEmailDomainNotAllowed) {
+			t.Errorf("Execute(%s) error = %v, want %v", email, err, domain.ErrEmailDomainNotAllowed)
+		}
+
+		if _, err := repo.GetByEmail(ctx, email); err == nil {
+			t.Errorf("%s was stored", email)
+		}
+	}
+}
+
+func TestCreateUserRecordsActor(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor string
+		want  string
+	}{
+		{name: "authenticated admin", actor: "admin-1", want: "admin-1"},
+		{name: "public registration", want: domain.SystemActor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewRepository()
+			uc := NewCreateUserUseCase(repo, testPasswords)
+
+			ctx := context.Background()
+			if tt.actor != "" {
+				ctx = requestctx.WithActor(ctx, tt.actor)
+			}
+
+			output, err := uc.Execute(ctx, CreateUserInput{Name: "Ana", Email: "ana@example.com", Password: testPassword})
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+
+			stored, err := repo.GetByID(ctx, output.User.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+
+			if stored.CreatedBy != tt.want || stored.UpdatedBy != tt.want {
+				t.Errorf("created by %q, updated by %q, want %q", stored.CreatedBy, stored.UpdatedBy, tt.want)
+			}
+		})
+	}
+}
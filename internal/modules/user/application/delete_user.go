@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	"github.com/devleo-m/go-zero/internal/modules/user/domain"
 )
@@ -12,6 +13,7 @@ import (
 // DeleteUserUseCase implementa o caso de uso de deletar usuário.
 type DeleteUserUseCase struct {
 	userRepo domain.Repository
+	logger   *zap.Logger
 }
 
 // NewDeleteUserUseCase cria uma nova instância do caso de uso.
@@ -21,6 +23,13 @@ func NewDeleteUserUseCase(userRepo domain.Repository) *DeleteUserUseCase {
 	}
 }
 
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *DeleteUserUseCase) WithLogger(logger *zap.Logger) *DeleteUserUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
 // DeleteUserInput representa os dados de entrada.
 type DeleteUserInput struct {
 	ID uuid.UUID `json:"id" validate:"required"`
@@ -44,6 +53,8 @@ func (uc *DeleteUserUseCase) Execute(ctx context.Context, input DeleteUserInput)
 		return nil, fmt.Errorf("failed to delete user: %w", err)
 	}
 
+	contextLogger(ctx, uc.logger).Info("User deleted", zap.String("user_id", input.ID.String()))
+
 	return &DeleteUserOutput{
 		Message: "User deleted successfully",
 	}, nil
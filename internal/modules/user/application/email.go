@@ -0,0 +1,18 @@
+package application
+
+import (
+	"context"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+)
+
+// EmailService define o envio dos emails transacionais do usuário.
+type EmailService interface {
+	SendWelcomeEmail(ctx context.Context, user *domain.User) error
+	SendEmailVerification(ctx context.Context, user *domain.User, token string) error
+	SendPasswordReset(ctx context.Context, user *domain.User, token string) error
+	SendPasswordChanged(ctx context.Context, user *domain.User) error
+	SendAccountLocked(ctx context.Context, user *domain.User) error
+	SendLoginAlert(ctx context.Context, user *domain.User, ipAddress string) error
+	SendAccountDeleted(ctx context.Context, user *domain.User) error
+}
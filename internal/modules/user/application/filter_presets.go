@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// FilterPresetUseCase implementa o gerenciamento dos presets de filtro de cada
+// administrador e a listagem de usuários a partir de um preset.
+type FilterPresetUseCase struct {
+	clock     clock.Clock
+	presets   domain.FilterPresetRepository
+	listUsers *ListUsersUseCase
+	logger    *zap.Logger
+}
+
+// NewFilterPresetUseCase cria uma nova instância do caso de uso.
+// A aplicação de um preset delega a listagem para listUsers.
+func NewFilterPresetUseCase(presets domain.FilterPresetRepository, listUsers *ListUsersUseCase) *FilterPresetUseCase {
+	return &FilterPresetUseCase{
+		clock:     clock.RealClock{},
+		presets:   presets,
+		listUsers: listUsers,
+	}
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *FilterPresetUseCase) WithLogger(logger *zap.Logger) *FilterPresetUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *FilterPresetUseCase) WithClock(c clock.Clock) *FilterPresetUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// SaveFilterPresetInput representa os dados de entrada da gravação.
+// Um preset com o mesmo nome tem seus critérios substituídos.
+type SaveFilterPresetInput struct {
+	Criteria domain.FilterCriteria `json:"criteria"`
+	Name     string                `json:"name" validate:"required"`
+	AdminID  uuid.UUID             `json:"admin_id" validate:"required"`
+}
+
+// SaveFilterPresetOutput representa os dados de saída da gravação.
+type SaveFilterPresetOutput struct {
+	Preset  *domain.FilterPreset `json:"preset"`
+	Created bool                 `json:"created"`
+}
+
+// ApplyFilterPresetInput representa os dados de entrada da aplicação de um preset.
+type ApplyFilterPresetInput struct {
+	Name    string    `json:"name" validate:"required"`
+	AdminID uuid.UUID `json:"admin_id" validate:"required"`
+	Limit   int       `json:"limit" validate:"min=1,max=100"`
+	Offset  int       `json:"offset" validate:"min=0"`
+}
+
+// List retorna os presets do administrador.
+func (uc *FilterPresetUseCase) List(ctx context.Context, adminID uuid.UUID) ([]*domain.FilterPreset, error) {
+	return uc.presets.List(ctx, adminID)
+}
+
+// Get retorna um preset do administrador pelo nome.
+func (uc *FilterPresetUseCase) Get(ctx context.Context, adminID uuid.UUID, name string) (*domain.FilterPreset, error) {
+	return uc.presets.Get(ctx, adminID, name)
+}
+
+// Save cria ou substitui um preset do administrador.
+func (uc *FilterPresetUseCase) Save(ctx context.Context, input SaveFilterPresetInput) (*SaveFilterPresetOutput, error) {
+	preset, err := domain.NewFilterPreset(input.AdminID, input.Name, input.Criteria, uc.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.presets.Save(ctx, preset)
+	if err != nil {
+		return nil, err
+	}
+
+	contextLogger(ctx, uc.logger).Info("Filter preset saved",
+		zap.String("admin_id", input.AdminID.String()),
+		zap.String("name", preset.Name),
+		zap.Bool("created", created),
+	)
+
+	return &SaveFilterPresetOutput{Preset: preset, Created: created}, nil
+}
+
+// Delete remove um preset do administrador.
+func (uc *FilterPresetUseCase) Delete(ctx context.Context, adminID uuid.UUID, name string) error {
+	if err := uc.presets.Delete(ctx, adminID, name); err != nil {
+		return err
+	}
+
+	contextLogger(ctx, uc.logger).Info("Filter preset deleted",
+		zap.String("admin_id", adminID.String()),
+		zap.String("name", name),
+	)
+
+	return nil
+}
+
+// Apply lista os usuários que satisfazem os critérios do preset, com as mesmas
+// regras e a mesma paginação da listagem de usuários.
+func (uc *FilterPresetUseCase) Apply(ctx context.Context, input ApplyFilterPresetInput) (*ListUsersOutput, error) {
+	preset, err := uc.presets.Get(ctx, input.AdminID, input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := preset.Criteria
+
+	listInput := ListUsersInput{
+		CreatedFrom: criteria.CreatedFrom,
+		CreatedTo:   criteria.CreatedTo,
+		Search:      criteria.Search,
+		Roles:       make([]string, len(criteria.Roles)),
+		Statuses:    make([]string, len(criteria.Statuses)),
+		Limit:       input.Limit,
+		Offset:      input.Offset,
+	}
+
+	for i, role := range criteria.Roles {
+		listInput.Roles[i] = role.String()
+	}
+
+	for i, status := range criteria.Statuses {
+		listInput.Statuses[i] = status.String()
+	}
+
+	output, err := uc.listUsers.Execute(ctx, listInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply filter preset %q: %w", preset.Name, err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,188 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+)
+
+// presetKey identifica um preset: o nome é único entre os presets do administrador.
+type presetKey struct {
+	adminID uuid.UUID
+	name    string
+}
+
+// fakePresets guarda os presets em memória, como o upsert do Postgres.
+type fakePresets struct {
+	presets map[presetKey]domain.FilterPreset
+}
+
+func newFakePresets() *fakePresets {
+	return &fakePresets{presets: map[presetKey]domain.FilterPreset{}}
+}
+
+func (f *fakePresets) List(_ context.Context, adminID uuid.UUID) ([]*domain.FilterPreset, error) {
+	var presets []*domain.FilterPreset
+
+	for key, preset := range f.presets {
+		if key.adminID == adminID {
+			presets = append(presets, &preset)
+		}
+	}
+
+	return presets, nil
+}
+
+func (f *fakePresets) Get(_ context.Context, adminID uuid.UUID, name string) (*domain.FilterPreset, error) {
+	preset, ok := f.presets[presetKey{adminID, name}]
+	if !ok {
+		return nil, domain.ErrFilterPresetNotFound
+	}
+
+	return &preset, nil
+}
+
+func (f *fakePresets) Save(_ context.Context, preset *domain.FilterPreset) (bool, error) {
+	key := presetKey{preset.AdminID, preset.Name}
+
+	// Na substituição, o registro mantém a identidade original
+	existing, replaced := f.presets[key]
+	if replaced {
+		preset.ID, preset.CreatedAt = existing.ID, existing.CreatedAt
+	}
+
+	f.presets[key] = *preset
+
+	return !replaced, nil
+}
+
+func (f *fakePresets) Delete(_ context.Context, adminID uuid.UUID, name string) error {
+	key := presetKey{adminID, name}
+	if _, ok := f.presets[key]; !ok {
+		return domain.ErrFilterPresetNotFound
+	}
+
+	delete(f.presets, key)
+
+	return nil
+}
+
+func TestFilterPresetLifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+
+	users := []*domain.User{
+		repositorytest.NewUser("Ana Admin", "ana@example.com", 0),
+		repositorytest.NewUser("Bruno", "bruno@example.com", 1),
+		repositorytest.NewUser("Carla", "carla@example.com", 2),
+		repositorytest.NewUser("Ana Suspensa", "ana.s@example.com", 3),
+	}
+	users[0].Role = domain.RoleAdmin
+	users[2].Status = domain.StatusSuspended
+	users[3].Status = domain.StatusSuspended
+	repositorytest.Seed(t, repo, users...)
+
+	uc := NewFilterPresetUseCase(newFakePresets(), NewListUsersUseCase(repo))
+	adminID := uuid.New()
+
+	saved, err := uc.Save(ctx, SaveFilterPresetInput{
+		AdminID:  adminID,
+		Name:     "suspended",
+		Criteria: domain.FilterCriteria{Statuses: []domain.Status{domain.StatusSuspended}},
+	})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if !saved.Created {
+		t.Error("first save did not create the preset")
+	}
+
+	output, err := uc.Apply(ctx, ApplyFilterPresetInput{AdminID: adminID, Name: "suspended", Limit: 10})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	if output.Result.TotalItems != 2 {
+		t.Errorf("suspended preset matched %d users, want 2", output.Result.TotalItems)
+	}
+
+	// Gravar com o mesmo nome substitui os critérios: agora status e busca se somam
+	replaced, err := uc.Save(ctx, SaveFilterPresetInput{
+		AdminID: adminID,
+		Name:    "suspended",
+		Criteria: domain.FilterCriteria{
+			Statuses: []domain.Status{domain.StatusSuspended},
+			Search:   "ana",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Save replacement: %v", err)
+	}
+
+	if replaced.Created || replaced.Preset.ID != saved.Preset.ID {
+		t.Errorf("replacement created = %v, id %s, want the original %s", replaced.Created, replaced.Preset.ID, saved.Preset.ID)
+	}
+
+	output, err = uc.Apply(ctx, ApplyFilterPresetInput{AdminID: adminID, Name: "suspended", Limit: 10})
+	if err != nil {
+		t.Fatalf("Apply replacement: %v", err)
+	}
+
+	if output.Result.TotalItems != 1 || output.Result.Items[0].ID != users[3].ID {
+		t.Errorf("replaced preset matched %d users, want only %s", output.Result.TotalItems, users[3].Name)
+	}
+
+	// Os presets são de cada administrador
+	if _, err := uc.Apply(ctx, ApplyFilterPresetInput{AdminID: uuid.New(), Name: "suspended", Limit: 10}); !errors.Is(err, domain.ErrFilterPresetNotFound) {
+		t.Errorf("Apply by another admin = %v, want %v", err, domain.ErrFilterPresetNotFound)
+	}
+
+	if err := uc.Delete(ctx, adminID, "suspended"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := uc.Apply(ctx, ApplyFilterPresetInput{AdminID: adminID, Name: "suspended", Limit: 10}); !errors.Is(err, domain.ErrFilterPresetNotFound) {
+		t.Errorf("Apply after delete = %v, want %v", err, domain.ErrFilterPresetNotFound)
+	}
+
+	if err := uc.Delete(ctx, adminID, "suspended"); !errors.Is(err, domain.ErrFilterPresetNotFound) {
+		t.Errorf("second Delete = %v, want %v", err, domain.ErrFilterPresetNotFound)
+	}
+}
+
+func TestFilterPresetSaveValidates(t *testing.T) {
+	uc := NewFilterPresetUseCase(newFakePresets(), NewListUsersUseCase(memory.NewRepository()))
+	from, to := repositorytest.BaseTime, repositorytest.BaseTime.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name     string
+		preset   string
+		criteria domain.FilterCriteria
+		want     error
+	}{
+		{name: "name with spaces", preset: "my preset", want: domain.ErrInvalidFilterPreset},
+		{name: "uppercase name", preset: "Admins", want: domain.ErrInvalidFilterPreset},
+		{name: "unknown role", preset: "admins", criteria: domain.FilterCriteria{Roles: []domain.Role{"owner"}}, want: domain.ErrInvalidRole},
+		{name: "inverted range", preset: "range", criteria: domain.FilterCriteria{CreatedFrom: &from, CreatedTo: &to}, want: domain.ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Save(context.Background(), SaveFilterPresetInput{
+				AdminID:  uuid.New(),
+				Name:     tt.preset,
+				Criteria: tt.criteria,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Save = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -9,6 +10,9 @@ import (
 	"github.com/devleo-m/go-zero/internal/modules/user/domain"
 )
 
+// ErrInvalidLookup indica um GetUserInput sem ID e email ou com ambos informados.
+var ErrInvalidLookup = errors.New("exactly one of id or email must be provided")
+
 // GetUserUseCase implementa o caso de uso de buscar usuário.
 type GetUserUseCase struct {
 	userRepo domain.Repository
@@ -22,8 +26,23 @@ func NewGetUserUseCase(userRepo domain.Repository) *GetUserUseCase {
 }
 
 // GetUserInput representa os dados de entrada.
+// Exatamente um entre ID e Email deve ser informado; não há precedência entre
+// eles, e uma entrada ambígua é rejeitada com ErrInvalidLookup.
 type GetUserInput struct {
-	ID uuid.UUID `json:"id" validate:"required"`
+	Email string    `json:"email,omitempty"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// Validate verifica se exatamente um critério de busca foi informado.
+func (input GetUserInput) Validate() error {
+	hasID := input.ID != uuid.Nil
+	hasEmail := input.Email != ""
+
+	if hasID == hasEmail {
+		return ErrInvalidLookup
+	}
+
+	return nil
 }
 
 // GetUserOutput representa os dados de saída.
@@ -33,7 +52,21 @@ type GetUserOutput struct {
 
 // Execute executa o caso de uso.
 func (uc *GetUserUseCase) Execute(ctx context.Context, input GetUserInput) (*GetUserOutput, error) {
-	user, err := uc.userRepo.GetByID(ctx, input.ID)
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		user *domain.User
+		err  error
+	)
+
+	if input.Email != "" {
+		user, err = uc.userRepo.GetByEmail(ctx, domain.NormalizeEmail(input.Email))
+	} else {
+		user, err = uc.userRepo.GetByID(ctx, input.ID)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
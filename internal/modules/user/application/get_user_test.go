@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+)
+
+func TestGetUserNotFoundContract(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+
+	deleted := repositorytest.NewUser("Removida", "removed@example.com", 0)
+	repositorytest.Seed(t, repo, deleted)
+
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	uc := NewGetUserUseCase(repo)
+
+	tests := []struct {
+		name  string
+		input GetUserInput
+		want  error
+	}{
+		{name: "unknown id", input: GetUserInput{ID: uuid.New()}, want: domain.ErrUserNotFound},
+		{name: "unknown email", input: GetUserInput{Email: "nobody@example.com"}, want: domain.ErrUserNotFound},
+		{name: "soft deleted by id", input: GetUserInput{ID: deleted.ID}, want: domain.ErrUserNotFound},
+		{name: "soft deleted by email", input: GetUserInput{Email: deleted.Email}, want: domain.ErrUserNotFound},
+		{name: "no criteria", input: GetUserInput{}, want: ErrInvalidLookup},
+		{name: "both criteria", input: GetUserInput{ID: deleted.ID, Email: deleted.Email}, want: ErrInvalidLookup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Nunca (nil, nil): o chamador só precisa verificar o erro
+			output, err := uc.Execute(ctx, tt.input)
+			if output != nil || !errors.Is(err, tt.want) {
+				t.Errorf("Execute = %v, %v; want nil, %v", output, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetUserInputLookup(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+
+	ana := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, repo, ana)
+
+	uc := NewGetUserUseCase(repo)
+
+	tests := []struct {
+		name  string
+		input GetUserInput
+		want  error
+	}{
+		{name: "id only", input: GetUserInput{ID: ana.ID}},
+		{name: "email only", input: GetUserInput{Email: " ANA@example.com "}},
+		{name: "both set", input: GetUserInput{ID: ana.ID, Email: ana.Email}, want: ErrInvalidLookup},
+		{name: "neither set", input: GetUserInput{}, want: ErrInvalidLookup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); !errors.Is(err, tt.want) {
+				t.Fatalf("Validate = %v, want %v", err, tt.want)
+			}
+
+			output, err := uc.Execute(ctx, tt.input)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute error = %v, want %v", err, tt.want)
+			}
+
+			if tt.want == nil && output.User.ID != ana.ID {
+				t.Errorf("Execute returned %s, want %s", output.User.ID, ana.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+// MaxBatchSize é a quantidade máxima de IDs em uma busca em lote.
+const MaxBatchSize = 100
+
+// Erros da busca em lote.
+var (
+	ErrEmptyBatch    = errors.New("at least one id is required")
+	ErrBatchTooLarge = fmt.Errorf("batch exceeds the maximum of %d ids", MaxBatchSize)
+)
+
+// GetUsersBatchUseCase implementa o caso de uso de buscar vários usuários por ID.
+type GetUsersBatchUseCase struct {
+	userRepo domain.Repository
+}
+
+// NewGetUsersBatchUseCase cria uma nova instância do caso de uso.
+func NewGetUsersBatchUseCase(userRepo domain.Repository) *GetUsersBatchUseCase {
+	return &GetUsersBatchUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// GetUsersBatchInput representa os dados de entrada.
+// IDs repetidos são considerados uma única vez.
+type GetUsersBatchInput struct {
+	IDs []uuid.UUID `json:"ids" validate:"required"`
+}
+
+// GetUsersBatchOutput representa os dados de saída.
+// Users segue a ordem dos IDs pedidos; Missing lista os IDs não encontrados.
+type GetUsersBatchOutput struct {
+	Users   []*domain.User `json:"users"`
+	Missing []uuid.UUID    `json:"missing"`
+}
+
+// Execute executa o caso de uso com uma única consulta.
+func (uc *GetUsersBatchUseCase) Execute(ctx context.Context, input GetUsersBatchInput) (*GetUsersBatchOutput, error) {
+	ids := uniqueIDs(input.IDs)
+
+	if len(ids) == 0 {
+		return nil, ErrEmptyBatch
+	}
+
+	if len(ids) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	filter := repository.NewQueryBuilder().
+		WhereIn("id", ids).
+		Limit(len(ids)).
+		Build()
+
+	users, err := uc.userRepo.FindMany(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*domain.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	output := &GetUsersBatchOutput{
+		Users:   make([]*domain.User, 0, len(users)),
+		Missing: []uuid.UUID{},
+	}
+
+	for _, id := range ids {
+		if user, ok := byID[id]; ok {
+			output.Users = append(output.Users, user)
+		} else {
+			output.Missing = append(output.Missing, id)
+		}
+	}
+
+	return output, nil
+}
+
+// uniqueIDs remove IDs repetidos preservando a ordem da primeira ocorrência.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	return unique
+}
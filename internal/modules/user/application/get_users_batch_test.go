@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+)
+
+func TestGetUsersBatchMixesFoundAndMissing(t *testing.T) {
+	repo := memory.NewRepository()
+	ana := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	bruno := repositorytest.NewUser("Bruno", "bruno@example.com", 1)
+	carla := repositorytest.NewUser("Carla", "carla@example.com", 2)
+	repositorytest.Seed(t, repo, ana, bruno, carla)
+
+	missingA, missingB := uuid.New(), uuid.New()
+
+	output, err := NewGetUsersBatchUseCase(repo).Execute(context.Background(), GetUsersBatchInput{
+		IDs: []uuid.UUID{carla.ID, missingA, ana.ID, carla.ID, missingB},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	// A ordem do pedido é mantida e repetidos aparecem uma vez
+	got := make([]uuid.UUID, len(output.Users))
+	for i, user := range output.Users {
+		got[i] = user.ID
+	}
+
+	if want := []uuid.UUID{carla.ID, ana.ID}; !slices.Equal(got, want) {
+		t.Errorf("users = %v, want %v", got, want)
+	}
+
+	if want := []uuid.UUID{missingA, missingB}; !slices.Equal(output.Missing, want) {
+		t.Errorf("missing = %v, want %v", output.Missing, want)
+	}
+}
+
+func TestGetUsersBatchLimits(t *testing.T) {
+	oversized := make([]uuid.UUID, MaxBatchSize+1)
+	for i := range oversized {
+		oversized[i] = uuid.New()
+	}
+
+	// Repetidos não contam para o limite
+	repeated := slices.Repeat([]uuid.UUID{uuid.New()}, MaxBatchSize+1)
+
+	tests := []struct {
+		name    string
+		ids     []uuid.UUID
+		wantErr error
+	}{
+		{name: "empty", wantErr: ErrEmptyBatch},
+		{name: "over the cap", ids: oversized, wantErr: ErrBatchTooLarge},
+		{name: "exactly the cap", ids: oversized[:MaxBatchSize]},
+		{name: "repeated ids", ids: repeated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := NewGetUsersBatchUseCase(memory.NewRepository()).
+				Execute(context.Background(), GetUsersBatchInput{IDs: tt.ids})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && len(output.Users) != 0 {
+				t.Errorf("got %d users from an empty repository", len(output.Users))
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// Erros da personificação de usuários.
+var (
+	ErrImpersonatorRequired = errors.New("impersonation requires an authenticated administrator")
+	ErrSelfImpersonation    = errors.New("cannot impersonate yourself")
+	ErrNestedImpersonation  = errors.New("cannot impersonate while impersonating another user")
+	ErrAdminImpersonation   = errors.New("cannot impersonate an administrator")
+)
+
+// ImpersonationTokenIssuer emite o token de curta duração usado na personificação.
+type ImpersonationTokenIssuer interface {
+	GenerateImpersonationToken(userID, email, role, impersonatorID string) (string, int64, error)
+}
+
+// ImpersonateUserUseCase implementa o caso de uso de um administrador agir
+// em nome de outro usuário.
+type ImpersonateUserUseCase struct {
+	userRepo          domain.Repository
+	tokens            ImpersonationTokenIssuer
+	logger            *zap.Logger
+	allowAdminTargets bool
+}
+
+// NewImpersonateUserUseCase cria uma nova instância do caso de uso.
+// Por padrão, administradores não podem ser personificados.
+func NewImpersonateUserUseCase(userRepo domain.Repository, tokens ImpersonationTokenIssuer) *ImpersonateUserUseCase {
+	return &ImpersonateUserUseCase{
+		userRepo: userRepo,
+		tokens:   tokens,
+	}
+}
+
+// WithAllowAdminTargets permite personificar usuários com papel de administrador.
+// Mesmo assim, o alvo precisa ter papel inferior ao de quem personifica: na
+// prática, apenas um super_admin personifica administradores.
+func (uc *ImpersonateUserUseCase) WithAllowAdminTargets(allow bool) *ImpersonateUserUseCase {
+	uc.allowAdminTargets = allow
+
+	return uc
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *ImpersonateUserUseCase) WithLogger(logger *zap.Logger) *ImpersonateUserUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// ImpersonateUserInput representa os dados de entrada.
+// O administrador é o ator autenticado do contexto.
+type ImpersonateUserInput struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// ImpersonateUserOutput representa os dados de saída.
+// Não há refresh token: a personificação termina quando o token expira.
+type ImpersonateUserOutput struct {
+	AccessToken    string    `json:"access_token"`
+	TokenType      string    `json:"token_type"`
+	ExpiresIn      int64     `json:"expires_in"`
+	UserID         uuid.UUID `json:"user_id"`
+	ImpersonatorID string    `json:"impersonator_id"`
+}
+
+// Execute executa o caso de uso.
+func (uc *ImpersonateUserUseCase) Execute(ctx context.Context, input ImpersonateUserInput) (*ImpersonateUserOutput, error) {
+	if requestctx.Impersonator(ctx) != "" {
+		return nil, ErrNestedImpersonation
+	}
+
+	adminID := requestctx.Actor(ctx)
+	if adminID == "" {
+		return nil, ErrImpersonatorRequired
+	}
+
+	if adminID == input.UserID.String() {
+		return nil, ErrSelfImpersonation
+	}
+
+	admin, err := uc.loadImpersonator(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Personificar alguém de nível igual ou superior daria ao administrador
+	// privilégios que ele não tem, mesmo com WithAllowAdminTargets
+	if !admin.CanManage(user) {
+		return nil, fmt.Errorf("%w: %s cannot impersonate a %s", ErrAdminImpersonation, admin.Role, user.Role)
+	}
+
+	if !uc.allowAdminTargets && domain.RoleLevel(user.Role) >= domain.RoleLevel(domain.RoleAdmin) {
+		return nil, ErrAdminImpersonation
+	}
+
+	token, expiresIn, err := uc.tokens.GenerateImpersonationToken(user.ID.String(), user.Email, user.Role.String(), adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue impersonation token: %w", err)
+	}
+
+	contextLogger(ctx, uc.logger).Info("Impersonation started",
+		zap.String("user_id", user.ID.String()),
+		zap.String("admin_id", adminID),
+		zap.Int64("expires_in", expiresIn),
+	)
+
+	return &ImpersonateUserOutput{
+		AccessToken:    token,
+		TokenType:      "Bearer",
+		ExpiresIn:      expiresIn,
+		UserID:         user.ID,
+		ImpersonatorID: adminID,
+	}, nil
+}
+
+// loadImpersonator carrega o administrador autenticado. Um ID inválido ou de
+// usuário removido é tratado como ausência de autenticação.
+func (uc *ImpersonateUserUseCase) loadImpersonator(ctx context.Context, adminID string) (*domain.User, error) {
+	id, err := uuid.Parse(adminID)
+	if err != nil {
+		return nil, ErrImpersonatorRequired
+	}
+
+	admin, err := uc.userRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, ErrImpersonatorRequired
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get impersonator: %w", err)
+	}
+
+	return admin, nil
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// fakeImpersonationIssuer registra os dados recebidos para o token.
+type fakeImpersonationIssuer struct {
+	userID, role, impersonatorID string
+}
+
+func (f *fakeImpersonationIssuer) GenerateImpersonationToken(
+	userID, _, role, impersonatorID string,
+) (string, int64, error) {
+	f.userID, f.role, f.impersonatorID = userID, role, impersonatorID
+
+	return "impersonation-token", 900, nil
+}
+
+// seedRoles cria, no repositório, um usuário para cada papel informado.
+func seedRoles(t *testing.T, repo domain.Repository, roles ...domain.Role) []*domain.User {
+	t.Helper()
+
+	users := make([]*domain.User, len(roles))
+	for i, role := range roles {
+		users[i] = repositorytest.NewUser(string(role), fmt.Sprintf("%s%d@example.com", role, i), i)
+		users[i].Role = role
+	}
+
+	repositorytest.Seed(t, repo, users...)
+
+	return users
+}
+
+func TestImpersonateUserIssuesTokenAndAuditsUnderAdmin(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleUser)
+	admin, target := users[0], users[1]
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	tokens := &fakeImpersonationIssuer{}
+	uc := NewImpersonateUserUseCase(repo, tokens).WithLogger(zap.New(core))
+
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+	output, err := uc.Execute(ctx, ImpersonateUserInput{UserID: target.ID})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if output.UserID != target.ID || output.ImpersonatorID != admin.ID.String() || output.AccessToken == "" {
+		t.Errorf("output = %+v", output)
+	}
+
+	// O token identifica o usuário personificado e o administrador real
+	if tokens.userID != target.ID.String() || tokens.impersonatorID != admin.ID.String() || tokens.role != "user" {
+		t.Errorf("token issued for user %s by %s with role %s", tokens.userID, tokens.impersonatorID, tokens.role)
+	}
+
+	entries := logs.FilterMessage("Impersonation started").All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields["admin_id"] != admin.ID.String() || fields["user_id"] != target.ID.String() {
+		t.Errorf("audit fields = %v", fields)
+	}
+}
+
+func TestImpersonateUserRejections(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleSuperAdmin, domain.RoleAdmin, domain.RoleAdmin, domain.RoleUser)
+	superAdmin, admin, otherAdmin, user := users[0], users[1], users[2], users[3]
+
+	tests := []struct {
+		name         string
+		actor        string
+		impersonator string
+		target       *domain.User
+		allowAdmins  bool
+		want         error
+	}{
+		{
+			name:        "admin cannot impersonate a super admin even when admins are allowed",
+			actor:       admin.ID.String(),
+			target:      superAdmin,
+			allowAdmins: true,
+			want:        ErrAdminImpersonation,
+		},
+		{
+			name:        "admin cannot impersonate another admin even when admins are allowed",
+			actor:       admin.ID.String(),
+			target:      otherAdmin,
+			allowAdmins: true,
+			want:        ErrAdminImpersonation,
+		},
+		{
+			name:   "super admin cannot impersonate an admin by default",
+			actor:  superAdmin.ID.String(),
+			target: admin,
+			want:   ErrAdminImpersonation,
+		},
+		{
+			name:   "self impersonation",
+			actor:  admin.ID.String(),
+			target: admin,
+			want:   ErrSelfImpersonation,
+		},
+		{
+			name:         "nested impersonation",
+			actor:        user.ID.String(),
+			impersonator: admin.ID.String(),
+			target:       otherAdmin,
+			want:         ErrNestedImpersonation,
+		},
+		{
+			name:   "unauthenticated",
+			target: user,
+			want:   ErrImpersonatorRequired,
+		},
+		{
+			name:        "plain user cannot impersonate",
+			actor:       user.ID.String(),
+			target:      admin,
+			allowAdmins: true,
+			want:        ErrAdminImpersonation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := &fakeImpersonationIssuer{}
+			uc := NewImpersonateUserUseCase(repo, tokens).WithAllowAdminTargets(tt.allowAdmins)
+
+			ctx := requestctx.WithActor(context.Background(), tt.actor)
+			if tt.impersonator != "" {
+				ctx = requestctx.WithImpersonator(ctx, tt.impersonator)
+			}
+
+			_, err := uc.Execute(ctx, ImpersonateUserInput{UserID: tt.target.ID})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute error = %v, want %v", err, tt.want)
+			}
+
+			if tokens.userID != "" {
+				t.Errorf("a token was issued for %s", tokens.userID)
+			}
+		})
+	}
+}
+
+func TestImpersonateUserSuperAdminCanImpersonateAdminWhenAllowed(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleSuperAdmin, domain.RoleAdmin)
+
+	tokens := &fakeImpersonationIssuer{}
+	uc := NewImpersonateUserUseCase(repo, tokens).WithAllowAdminTargets(true)
+	ctx := requestctx.WithActor(context.Background(), users[0].ID.String())
+
+	if _, err := uc.Execute(ctx, ImpersonateUserInput{UserID: users[1].ID}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if tokens.impersonatorID != users[0].ID.String() {
+		t.Errorf("impersonator = %s, want %s", tokens.impersonatorID, users[0].ID)
+	}
+}
@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+// ListInactiveUsersUseCase implementa o caso de uso de listar usuários inativos.
+type ListInactiveUsersUseCase struct {
+	userRepo domain.Repository
+}
+
+// NewListInactiveUsersUseCase cria uma nova instância do caso de uso.
+func NewListInactiveUsersUseCase(userRepo domain.Repository) *ListInactiveUsersUseCase {
+	return &ListInactiveUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// ListInactiveUsersInput representa os dados de entrada.
+type ListInactiveUsersInput struct {
+	Days     int `json:"days" validate:"min=0"`
+	Page     int `json:"page" validate:"min=1"`
+	PageSize int `json:"page_size" validate:"min=1,max=100"`
+}
+
+// ListInactiveUsersOutput representa os dados de saída.
+type ListInactiveUsersOutput struct {
+	Result *repository.PaginatedResult[domain.User] `json:"result"`
+}
+
+// Execute executa o caso de uso.
+func (uc *ListInactiveUsersUseCase) Execute(
+	ctx context.Context,
+	input ListInactiveUsersInput,
+) (*ListInactiveUsersOutput, error) {
+	if input.Days < 0 {
+		input.Days = 0
+	}
+
+	result, err := uc.userRepo.FindUsersByLastLogin(ctx, input.Days, input.Page, input.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inactive users: %w", err)
+	}
+
+	return &ListInactiveUsersOutput{
+		Result: result,
+	}, nil
+}
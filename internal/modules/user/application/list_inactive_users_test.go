@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+func TestListInactiveUsersCohort(t *testing.T) {
+	now := repositorytest.BaseTime.AddDate(0, 3, 0)
+	repo := memory.NewRepository().WithClock(clock.NewFakeClock(now))
+
+	// lastLogin cria um usuário cujo último login foi há daysAgo dias
+	lastLogin := func(name string, offset int, daysAgo time.Duration) *domain.User {
+		user := repositorytest.NewUser(name, name+"@example.com", offset)
+		at := now.Add(-daysAgo * 24 * time.Hour)
+		user.LastLoginAt = &at
+
+		return user
+	}
+
+	repositorytest.Seed(t, repo,
+		lastLogin("yesterday", 0, 1),
+		lastLogin("cutoff", 1, 30),
+		lastLogin("stale", 2, 45),
+		lastLogin("ancient", 3, 80),
+		repositorytest.NewUser("never", "never@example.com", 4),
+	)
+
+	uc := NewListInactiveUsersUseCase(repo)
+
+	tests := []struct {
+		name      string
+		input     ListInactiveUsersInput
+		want      string
+		wantTotal int64
+	}{
+		{
+			name:      "never logged in comes first, then the oldest login",
+			input:     ListInactiveUsersInput{Days: 30, Page: 1, PageSize: 10},
+			want:      "[never@example.com ancient@example.com stale@example.com]",
+			wantTotal: 3,
+		},
+		{
+			name:      "second page",
+			input:     ListInactiveUsersInput{Days: 30, Page: 2, PageSize: 2},
+			want:      "[stale@example.com]",
+			wantTotal: 3,
+		},
+		{
+			name:      "longer window narrows the cohort",
+			input:     ListInactiveUsersInput{Days: 60, Page: 1, PageSize: 10},
+			want:      "[never@example.com ancient@example.com]",
+			wantTotal: 2,
+		},
+		{
+			name:      "negative days behaves like zero",
+			input:     ListInactiveUsersInput{Days: -5, Page: 1, PageSize: 10},
+			want:      "[never@example.com ancient@example.com stale@example.com cutoff@example.com yesterday@example.com]",
+			wantTotal: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := uc.Execute(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+
+			got := make([]string, len(output.Result.Items))
+			for i, user := range output.Result.Items {
+				got[i] = user.Email
+			}
+
+			if fmt.Sprint(got) != tt.want || output.Result.TotalItems != tt.wantTotal {
+				t.Errorf("cohort = %v (total %d), want %s (total %d)", got, output.Result.TotalItems, tt.want, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -3,8 +3,12 @@ package application
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/pagination"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
 )
 
 // ListUsersUseCase implementa o caso de uso de listar usuários.
@@ -20,42 +24,115 @@ func NewListUsersUseCase(userRepo domain.Repository) *ListUsersUseCase {
 }
 
 // ListUsersInput representa os dados de entrada.
+// Roles e Statuses filtram por qualquer um dos valores informados; Metadata
+// exige que cada chave tenha exatamente o valor (string) informado. Search
+// busca no nome e no email e CreatedFrom/CreatedTo limitam a data de criação.
 type ListUsersInput struct {
-	Limit  int `json:"limit" validate:"min=1,max=100"`
-	Offset int `json:"offset" validate:"min=0"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	CreatedFrom *time.Time        `json:"created_from,omitempty"`
+	CreatedTo   *time.Time        `json:"created_to,omitempty"`
+	Search      string            `json:"search,omitempty"`
+	Roles       []string          `json:"roles,omitempty"`
+	Statuses    []string          `json:"statuses,omitempty"`
+	Limit       int               `json:"limit" validate:"min=1,max=100"`
+	Offset      int               `json:"offset" validate:"min=0"`
 }
 
-// ListUsersOutput representa os dados de saída.
+// ListUsersOutput representa os dados de saída. Result é sempre preenchido:
+// uma listagem vazia traz página, tamanho e total zerado, nunca nil.
 type ListUsersOutput struct {
-	Users []*domain.User `json:"users"`
-	Total int            `json:"total"`
+	Result *repository.PaginatedResult[domain.User] `json:"result"`
 }
 
 // Execute executa o caso de uso.
 func (uc *ListUsersUseCase) Execute(ctx context.Context, input ListUsersInput) (*ListUsersOutput, error) {
 	// Definir valores padrão
-	if input.Limit <= 0 {
-		input.Limit = 10
-	}
+	input.Limit = pagination.ClampLimit(input.Limit)
 
 	if input.Offset < 0 {
 		input.Offset = 0
 	}
 
+	spec := repository.AllSpecification[domain.User]()
+
+	if len(input.Roles) > 0 {
+		roles := make([]domain.Role, len(input.Roles))
+
+		for i, value := range input.Roles {
+			role, err := domain.ParseRole(value)
+			if err != nil {
+				return nil, err
+			}
+
+			roles[i] = role
+		}
+
+		spec = spec.And(domain.RoleSpecification(roles...))
+	}
+
+	if len(input.Statuses) > 0 {
+		statuses := make([]domain.Status, len(input.Statuses))
+
+		for i, value := range input.Statuses {
+			status, err := domain.ParseStatus(value)
+			if err != nil {
+				return nil, err
+			}
+
+			statuses[i] = status
+		}
+
+		spec = spec.And(domain.StatusSpecification(statuses...))
+	}
+
+	criteria := domain.FilterCriteria{
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+		Search:      strings.TrimSpace(input.Search),
+	}
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+
+	if criteria.Search != "" {
+		spec = spec.And(domain.SearchSpecification(criteria.Search))
+	}
+
+	if criteria.CreatedFrom != nil {
+		spec = spec.And(domain.CreatedFromSpecification(*criteria.CreatedFrom))
+	}
+
+	if criteria.CreatedTo != nil {
+		spec = spec.And(domain.CreatedToSpecification(*criteria.CreatedTo))
+	}
+
+	for key, value := range input.Metadata {
+		if !domain.ValidMetadataKey(key) {
+			return nil, domain.ErrInvalidMetadataKey
+		}
+
+		spec = spec.And(domain.MetadataSpecification(key, value))
+	}
+
+	filter := spec.ToQueryFilter()
+	filter.Limit = input.Limit
+	filter.Offset = input.Offset
+
 	// Buscar usuários
-	users, err := uc.userRepo.List(ctx, input.Limit, input.Offset)
+	users, err := uc.userRepo.FindMany(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
 
-	// Buscar total real de usuários
-	total, err := uc.userRepo.Count(ctx)
+	// O total usa as mesmas condições da página, não a tabela inteira
+	total, err := uc.userRepo.Count(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count users: %w", err)
 	}
 
+	page := input.Offset/input.Limit + 1
+
 	return &ListUsersOutput{
-		Users: users,
-		Total: int(total), // Total real da tabela
+		Result: repository.NewPaginatedResult(users, total, page, input.Limit),
 	}, nil
 }
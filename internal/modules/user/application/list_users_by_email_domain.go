@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+// ListUsersByEmailDomainUseCase implementa o caso de uso de listar usuários de um domínio de email.
+type ListUsersByEmailDomainUseCase struct {
+	userRepo domain.Repository
+}
+
+// NewListUsersByEmailDomainUseCase cria uma nova instância do caso de uso.
+func NewListUsersByEmailDomainUseCase(userRepo domain.Repository) *ListUsersByEmailDomainUseCase {
+	return &ListUsersByEmailDomainUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// ListUsersByEmailDomainInput representa os dados de entrada.
+type ListUsersByEmailDomainInput struct {
+	EmailDomain string `json:"email_domain" validate:"required"`
+	Page        int    `json:"page" validate:"min=1"`
+	PageSize    int    `json:"page_size" validate:"min=1,max=100"`
+}
+
+// ListUsersByEmailDomainOutput representa os dados de saída.
+type ListUsersByEmailDomainOutput struct {
+	Result *repository.PaginatedResult[domain.User] `json:"result"`
+}
+
+// Execute executa o caso de uso.
+func (uc *ListUsersByEmailDomainUseCase) Execute(
+	ctx context.Context,
+	input ListUsersByEmailDomainInput,
+) (*ListUsersByEmailDomainOutput, error) {
+	emailDomain := strings.TrimPrefix(domain.NormalizeEmail(input.EmailDomain), "@")
+
+	result, err := uc.userRepo.FindUsersByEmailDomain(ctx, emailDomain, input.Page, input.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users by email domain: %w", err)
+	}
+
+	return &ListUsersByEmailDomainOutput{
+		Result: result,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+)
+
+func TestListUsersTotalCountsFilteredUsers(t *testing.T) {
+	repo := memory.NewRepository()
+
+	// 100 usuários: um a cada quatro é admin e um a cada cinco está suspenso
+	users := make([]*domain.User, 100)
+	for i := range users {
+		users[i] = repositorytest.NewUser(fmt.Sprintf("user-%03d", i), fmt.Sprintf("user%03d@example.com", i), i)
+		if i%4 == 0 {
+			users[i].Role = domain.RoleAdmin
+		}
+
+		if i%5 == 0 {
+			users[i].Status = domain.StatusSuspended
+		}
+	}
+
+	repositorytest.Seed(t, repo, users...)
+
+	uc := NewListUsersUseCase(repo)
+
+	tests := []struct {
+		name      string
+		input     ListUsersInput
+		wantTotal int64
+		wantItems int
+	}{
+		{name: "no filter", input: ListUsersInput{Limit: 10}, wantTotal: 100, wantItems: 10},
+		{name: "role", input: ListUsersInput{Roles: []string{"admin"}, Limit: 10}, wantTotal: 25, wantItems: 10},
+		{name: "status", input: ListUsersInput{Statuses: []string{"suspended"}, Limit: 10}, wantTotal: 20, wantItems: 10},
+		{name: "role and status", input: ListUsersInput{Roles: []string{"admin"}, Statuses: []string{"suspended"}, Limit: 10}, wantTotal: 5, wantItems: 5},
+		{name: "search", input: ListUsersInput{Search: "user-09", Limit: 5}, wantTotal: 10, wantItems: 5},
+		{name: "last page", input: ListUsersInput{Roles: []string{"admin"}, Limit: 10, Offset: 20}, wantTotal: 25, wantItems: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := uc.Execute(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+
+			if output.Result.TotalItems != tt.wantTotal || len(output.Result.Items) != tt.wantItems {
+				t.Errorf("got %d items of %d, want %d of %d",
+					len(output.Result.Items), output.Result.TotalItems, tt.wantItems, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestListUsersEmptyResultKeepsPagination(t *testing.T) {
+	uc := NewListUsersUseCase(memory.NewRepository())
+
+	output, err := uc.Execute(context.Background(), ListUsersInput{Limit: 10})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	page := output.Result
+	if page == nil {
+		t.Fatal("Result is nil")
+	}
+
+	if page.Page != 1 || page.PageSize != 10 || page.TotalItems != 0 || len(page.Items) != 0 {
+		t.Errorf("page = %+v, want page 1 of size 10 with no items", page)
+	}
+
+	if page.HasNext || page.HasPrev {
+		t.Errorf("HasNext = %v, HasPrev = %v, want both false", page.HasNext, page.HasPrev)
+	}
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// contextLogger retorna o logger com os IDs de correlação do contexto.
+// Um logger nil resulta em um logger que descarta as mensagens.
+func contextLogger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	if logger == nil {
+		return zap.NewNop()
+	}
+
+	return logger.With(requestctx.LogFields(ctx)...)
+}
+
+// actorFrom retorna o ator autenticado do contexto ou domain.SystemActor.
+// Durante uma personificação, as alterações são atribuídas ao administrador.
+func actorFrom(ctx context.Context) string {
+	if impersonator := requestctx.Impersonator(ctx); impersonator != "" {
+		return impersonator
+	}
+
+	if actor := requestctx.Actor(ctx); actor != "" {
+		return actor
+	}
+
+	return domain.SystemActor
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+)
+
+// MergeUsersUseCase implementa o caso de uso de mesclar usuários duplicados.
+type MergeUsersUseCase struct {
+	userRepo domain.Repository
+	logger   *zap.Logger
+}
+
+// NewMergeUsersUseCase cria uma nova instância do caso de uso.
+func NewMergeUsersUseCase(userRepo domain.Repository) *MergeUsersUseCase {
+	return &MergeUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *MergeUsersUseCase) WithLogger(logger *zap.Logger) *MergeUsersUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// MergeUsersInput representa os dados de entrada.
+// O usuário de origem é incorporado ao destino, que mantém seu ID.
+// Com DryRun, a mesclagem é simulada e nada é persistido.
+type MergeUsersInput struct {
+	SourceID uuid.UUID `json:"source_id"`
+	TargetID uuid.UUID `json:"target_id"`
+	DryRun   bool      `json:"dry_run"`
+}
+
+// MergeUsersOutput representa os dados de saída.
+type MergeUsersOutput struct {
+	*domain.MergeResult
+	DryRun bool `json:"dry_run"`
+}
+
+// Execute executa o caso de uso. Quem pede precisa poder gerenciar tanto a
+// origem quanto o destino, sem ser nenhum deles (domain.ErrRoleChangeForbidden).
+func (uc *MergeUsersUseCase) Execute(ctx context.Context, input MergeUsersInput) (*MergeUsersOutput, error) {
+	if input.SourceID == input.TargetID {
+		return nil, domain.ErrSelfMerge
+	}
+
+	requester, err := loadRequester(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range []uuid.UUID{input.SourceID, input.TargetID} {
+		user, err := uc.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+
+		if err := ensureCanManage(requester, user); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := uc.userRepo.Merge(ctx, input.SourceID, input.TargetID, input.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge users: %w", err)
+	}
+
+	if !input.DryRun {
+		contextLogger(ctx, uc.logger).Info("Users merged",
+			zap.String("source_id", input.SourceID.String()),
+			zap.String("target_id", input.TargetID.String()),
+			zap.Int64("password_history_moved", result.PasswordHistoryMoved),
+		)
+	}
+
+	return &MergeUsersOutput{
+		MergeResult: result,
+		DryRun:      input.DryRun,
+	}, nil
+}
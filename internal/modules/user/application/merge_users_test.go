@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// seedDuplicates cria um usuário de destino e uma conta duplicada com atividade própria.
+func seedDuplicates(t *testing.T, repo domain.Repository) (source, target *domain.User) {
+	t.Helper()
+
+	phone := "+5511999990000"
+	lastLogin := repositorytest.BaseTime.Add(time.Hour)
+
+	source = repositorytest.NewUser("Ana", "ana.dup@example.com", 0)
+	source.Phone, source.LastLoginAt, source.LoginCount = &phone, &lastLogin, 3
+	source.Metadata = domain.Metadata{"plan": "pro", "team": "source"}
+
+	target = repositorytest.NewUser("Ana", "ana@example.com", 1)
+	target.LoginCount = 2
+	target.Metadata = domain.Metadata{"team": "target"}
+
+	repositorytest.Seed(t, repo, source, target)
+
+	return source, target
+}
+
+// asAdmin cria um administrador no repositório e o autentica no contexto.
+func asAdmin(t *testing.T, repo domain.Repository) context.Context {
+	t.Helper()
+
+	admin := seedRoles(t, repo, domain.RoleAdmin)[0]
+
+	return requestctx.WithActor(context.Background(), admin.ID.String())
+}
+
+func TestMergeUsersFoldsSourceIntoTarget(t *testing.T) {
+	repo := memory.NewRepository()
+	source, target := seedDuplicates(t, repo)
+	ctx := asAdmin(t, repo)
+
+	output, err := NewMergeUsersUseCase(repo).Execute(ctx, MergeUsersInput{SourceID: source.ID, TargetID: target.ID})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if output.DryRun || output.TargetID != target.ID {
+		t.Errorf("output = %+v", output)
+	}
+
+	merged, err := repo.GetByID(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("GetByID(target): %v", err)
+	}
+
+	if merged.Email != target.Email || merged.LoginCount != 5 || merged.Phone == nil || !merged.LastLoginAt.Equal(*source.LastLoginAt) {
+		t.Errorf("merged target = %+v", merged)
+	}
+
+	// Em conflito, os metadados do destino prevalecem
+	if merged.Metadata["team"] != "target" || merged.Metadata["plan"] != "pro" {
+		t.Errorf("merged metadata = %v", merged.Metadata)
+	}
+
+	if _, err := repo.GetByID(ctx, source.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("GetByID(source) = %v, want %v", err, domain.ErrUserNotFound)
+	}
+}
+
+func TestMergeUsersDryRunPersistsNothing(t *testing.T) {
+	repo := memory.NewRepository()
+	source, target := seedDuplicates(t, repo)
+	ctx := asAdmin(t, repo)
+
+	output, err := NewMergeUsersUseCase(repo).Execute(ctx, MergeUsersInput{SourceID: source.ID, TargetID: target.ID, DryRun: true})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if !output.DryRun || output.Target.LoginCount != 5 {
+		t.Errorf("dry run output = %+v, want the would-be target", output)
+	}
+
+	stored, err := repo.GetByID(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("GetByID(target): %v", err)
+	}
+
+	if stored.LoginCount != 2 || stored.Phone != nil || len(stored.Metadata) != 1 {
+		t.Errorf("stored target changed: %+v", stored)
+	}
+
+	if _, err := repo.GetByID(ctx, source.ID); err != nil {
+		t.Errorf("GetByID(source) after dry run: %v", err)
+	}
+}
+
+func TestMergeUsersRejections(t *testing.T) {
+	repo := memory.NewRepository()
+	source, target := seedDuplicates(t, repo)
+	ctx := asAdmin(t, repo)
+
+	tests := []struct {
+		name  string
+		input MergeUsersInput
+		want  error
+	}{
+		{name: "self merge", input: MergeUsersInput{SourceID: target.ID, TargetID: target.ID}, want: domain.ErrSelfMerge},
+		{name: "unknown target", input: MergeUsersInput{SourceID: source.ID, TargetID: repositorytest.NewUser("X", "x@example.com", 9).ID}, want: domain.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMergeUsersUseCase(repo).Execute(ctx, tt.input); !errors.Is(err, tt.want) {
+				t.Errorf("Execute error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := repo.GetByID(ctx, source.ID); err != nil {
+		t.Errorf("source changed after rejected merges: %v", err)
+	}
+}
+
+func TestMergeUsersRequiresHigherRole(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleSuperAdmin, domain.RoleUser)
+	admin, superAdmin, user := users[0], users[1], users[2]
+
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+	tests := []struct {
+		name  string
+		input MergeUsersInput
+	}{
+		{name: "super admin source", input: MergeUsersInput{SourceID: superAdmin.ID, TargetID: user.ID}},
+		{name: "super admin target", input: MergeUsersInput{SourceID: user.ID, TargetID: superAdmin.ID}},
+		{name: "own account as source", input: MergeUsersInput{SourceID: admin.ID, TargetID: user.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMergeUsersUseCase(repo).Execute(ctx, tt.input); !errors.Is(err, domain.ErrRoleChangeForbidden) {
+				t.Errorf("Execute error = %v, want %v", err, domain.ErrRoleChangeForbidden)
+			}
+		})
+	}
+
+	for _, stored := range users {
+		if _, err := repo.GetByID(context.Background(), stored.ID); err != nil {
+			t.Errorf("%s removed after rejected merges: %v", stored.Email, err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package application
+
+import (
+	"context"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+)
+
+// SecurityEvent representa um evento de segurança que pode notificar o usuário.
+type SecurityEvent string
+
+// Eventos de segurança de alto risco.
+const (
+	SecurityEventNewDeviceLogin  SecurityEvent = "new_device_login"
+	SecurityEventPasswordChanged SecurityEvent = "password_changed"
+	SecurityEventRoleElevated    SecurityEvent = "role_elevated"
+	SecurityEventStatusChanged   SecurityEvent = "status_changed"
+)
+
+// NotificationService define o envio de notificações (SMS, push) ao usuário.
+type NotificationService interface {
+	NotifySecurityEvent(ctx context.Context, user *domain.User, event SecurityEvent) error
+}
+
+// NullNotificationService é a implementação padrão que não envia nada.
+type NullNotificationService struct{}
+
+// NotifySecurityEvent não faz nada.
+func (NullNotificationService) NotifySecurityEvent(context.Context, *domain.User, SecurityEvent) error {
+	return nil
+}
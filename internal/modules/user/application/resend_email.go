@@ -0,0 +1,220 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+)
+
+// Erros do reenvio de emails.
+var (
+	ErrInvalidEmailType     = errors.New("invalid email type")
+	ErrEmailNotApplicable   = errors.New("email type does not apply to the user's status")
+	ErrEmailTypeUnavailable = errors.New("email type is not available")
+	ErrResendRateLimited    = errors.New("too many email resends for this user")
+)
+
+// ResendRateLimitedError indica que o limite de reenvios do usuário foi
+// atingido; RetryAfter é o tempo até a janela atual terminar.
+type ResendRateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ResendRateLimitedError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrResendRateLimited, e.RetryAfter)
+}
+
+// Unwrap permite comparar com errors.Is(err, ErrResendRateLimited).
+func (e *ResendRateLimitedError) Unwrap() error {
+	return ErrResendRateLimited
+}
+
+// EmailType identifica um email que pode ser reenviado por um administrador.
+type EmailType string
+
+// Emails que podem ser reenviados.
+const (
+	EmailTypeWelcome    EmailType = "welcome"
+	EmailTypeActivation EmailType = "activation"
+)
+
+// emailTypeStatuses lista, por tipo de email, os status em que o reenvio faz sentido.
+var emailTypeStatuses = map[EmailType][]domain.Status{
+	EmailTypeWelcome:    {domain.StatusPending, domain.StatusActive},
+	EmailTypeActivation: {domain.StatusPending},
+}
+
+// ParseEmailType converte o texto em EmailType, retornando ErrInvalidEmailType
+// para tipos desconhecidos.
+func ParseEmailType(value string) (EmailType, error) {
+	emailType := EmailType(value)
+	if _, ok := emailTypeStatuses[emailType]; !ok {
+		return "", fmt.Errorf("%w: %q (expected welcome or activation)", ErrInvalidEmailType, value)
+	}
+
+	return emailType, nil
+}
+
+// VerificationTokenIssuer emite o código enviado no email de ativação.
+type VerificationTokenIssuer interface {
+	IssueVerificationToken(ctx context.Context, user *domain.User) (string, error)
+}
+
+// Limites padrão de reenvio por usuário.
+const (
+	DefaultResendLimit  = 3
+	DefaultResendWindow = time.Hour
+)
+
+// ResendEmailUseCase implementa o caso de uso de reenviar um email ao usuário.
+type ResendEmailUseCase struct {
+	userRepo domain.Repository
+	emails   EmailService
+	tokens   VerificationTokenIssuer
+	store    cache.Service
+	logger   *zap.Logger
+	limit    int
+	window   time.Duration
+}
+
+// NewResendEmailUseCase cria uma nova instância do caso de uso.
+// Os reenvios são limitados por usuário (DefaultResendLimit por DefaultResendWindow)
+// com contadores em memória até que WithRateLimit defina outro store.
+func NewResendEmailUseCase(userRepo domain.Repository, emails EmailService) *ResendEmailUseCase {
+	return &ResendEmailUseCase{
+		userRepo: userRepo,
+		emails:   emails,
+		store:    cache.NewMemoryCache(),
+		limit:    DefaultResendLimit,
+		window:   DefaultResendWindow,
+	}
+}
+
+// WithTokenIssuer define o emissor dos códigos de ativação.
+// Sem ele, o reenvio do email de ativação retorna ErrEmailTypeUnavailable.
+func (uc *ResendEmailUseCase) WithTokenIssuer(tokens VerificationTokenIssuer) *ResendEmailUseCase {
+	uc.tokens = tokens
+
+	return uc
+}
+
+// WithRateLimit define o store dos contadores e quantos reenvios cada usuário
+// pode receber por janela. limit < 1 ou window <= 0 mantêm os padrões.
+func (uc *ResendEmailUseCase) WithRateLimit(store cache.Service, limit int, window time.Duration) *ResendEmailUseCase {
+	if store != nil {
+		uc.store = store
+	}
+
+	if limit > 0 {
+		uc.limit = limit
+	}
+
+	if window > 0 {
+		uc.window = window
+	}
+
+	return uc
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *ResendEmailUseCase) WithLogger(logger *zap.Logger) *ResendEmailUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// ResendEmailInput representa os dados de entrada.
+type ResendEmailInput struct {
+	Type   EmailType `json:"type" validate:"required"`
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// ResendEmailOutput representa os dados de saída.
+type ResendEmailOutput struct {
+	Type   EmailType `json:"type"`
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+}
+
+// Execute executa o caso de uso. O solicitante precisa poder gerenciar o
+// usuário (domain.ErrRoleChangeForbidden caso contrário). Com o limite de
+// reenvios atingido, retorna *ResendRateLimitedError com o tempo até a janela
+// terminar.
+func (uc *ResendEmailUseCase) Execute(ctx context.Context, input ResendEmailInput) (*ResendEmailOutput, error) {
+	statuses, ok := emailTypeStatuses[input.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidEmailType, input.Type)
+	}
+
+	requester, err := loadRequester(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := ensureCanManage(requester, user); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(statuses, user.Status) {
+		return nil, fmt.Errorf("%w: %s email for %s user", ErrEmailNotApplicable, input.Type, user.Status)
+	}
+
+	if input.Type == EmailTypeActivation && uc.tokens == nil {
+		return nil, fmt.Errorf("%w: no verification token issuer configured", ErrEmailTypeUnavailable)
+	}
+
+	// O limite vale para todos os tipos somados, evitando inundar a caixa do usuário
+	count, ttl, err := uc.store.Increment(ctx, "email_resend:"+user.ID.String(), uc.window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check resend limit: %w", err)
+	}
+
+	if count > int64(uc.limit) {
+		// Sem expiração informada (chave sem TTL), orienta a esperar uma
+		// janela completa
+		if ttl <= 0 {
+			ttl = uc.window
+		}
+
+		return nil, &ResendRateLimitedError{RetryAfter: ttl}
+	}
+
+	if err := uc.send(ctx, input.Type, user); err != nil {
+		return nil, fmt.Errorf("failed to send %s email: %w", input.Type, err)
+	}
+
+	contextLogger(ctx, uc.logger).Info("Email resent",
+		zap.String("user_id", user.ID.String()),
+		zap.String("type", string(input.Type)),
+		zap.String("requested_by", requester.ID.String()),
+	)
+
+	return &ResendEmailOutput{Type: input.Type, UserID: user.ID, Email: user.Email}, nil
+}
+
+// send envia o email do tipo informado.
+func (uc *ResendEmailUseCase) send(ctx context.Context, emailType EmailType, user *domain.User) error {
+	if emailType == EmailTypeActivation {
+		token, err := uc.tokens.IssueVerificationToken(ctx, user)
+		if err != nil {
+			return fmt.Errorf("failed to issue verification token: %w", err)
+		}
+
+		return uc.emails.SendEmailVerification(ctx, user, token)
+	}
+
+	return uc.emails.SendWelcomeEmail(ctx, user)
+}
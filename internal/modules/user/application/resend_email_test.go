@@ -0,0 +1,181 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// recordingEmails registra os emails de boas-vindas e de ativação enviados.
+// Os demais métodos não são usados pelo reenvio.
+type recordingEmails struct {
+	EmailService
+	sent   []string
+	tokens []string
+}
+
+func (r *recordingEmails) SendWelcomeEmail(_ context.Context, user *domain.User) error {
+	r.sent = append(r.sent, "welcome:"+user.Email)
+
+	return nil
+}
+
+func (r *recordingEmails) SendEmailVerification(_ context.Context, user *domain.User, token string) error {
+	r.sent = append(r.sent, "activation:"+user.Email)
+	r.tokens = append(r.tokens, token)
+
+	return nil
+}
+
+// fixedTokenIssuer emite sempre o mesmo código de ativação.
+type fixedTokenIssuer string
+
+func (f fixedTokenIssuer) IssueVerificationToken(context.Context, *domain.User) (string, error) {
+	return string(f), nil
+}
+
+func TestResendEmail(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    domain.Status
+		emailType EmailType
+		noIssuer  bool
+		wantSent  string
+		wantErr   error
+	}{
+		{name: "welcome for active user", status: domain.StatusActive, emailType: EmailTypeWelcome, wantSent: "welcome:ana@example.com"},
+		{name: "welcome for pending user", status: domain.StatusPending, emailType: EmailTypeWelcome, wantSent: "welcome:ana@example.com"},
+		{name: "activation for pending user", status: domain.StatusPending, emailType: EmailTypeActivation, wantSent: "activation:ana@example.com"},
+		{name: "activation for active user", status: domain.StatusActive, emailType: EmailTypeActivation, wantErr: ErrEmailNotApplicable},
+		{name: "welcome for suspended user", status: domain.StatusSuspended, emailType: EmailTypeWelcome, wantErr: ErrEmailNotApplicable},
+		{name: "activation without token issuer", status: domain.StatusPending, emailType: EmailTypeActivation, noIssuer: true, wantErr: ErrEmailTypeUnavailable},
+		{name: "invalid type", status: domain.StatusActive, emailType: "newsletter", wantErr: ErrInvalidEmailType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewRepository()
+			user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+			user.Status = tt.status
+			repositorytest.Seed(t, repo, user)
+
+			emails := &recordingEmails{}
+			uc := NewResendEmailUseCase(repo, emails)
+
+			if !tt.noIssuer {
+				uc.WithTokenIssuer(fixedTokenIssuer("token-123"))
+			}
+
+			output, err := uc.Execute(asAdmin(t, repo), ResendEmailInput{UserID: user.ID, Type: tt.emailType})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if len(emails.sent) != 0 {
+					t.Errorf("sent = %v, want nothing", emails.sent)
+				}
+
+				return
+			}
+
+			if len(emails.sent) != 1 || emails.sent[0] != tt.wantSent {
+				t.Errorf("sent = %v, want [%s]", emails.sent, tt.wantSent)
+			}
+
+			if tt.emailType == EmailTypeActivation && (len(emails.tokens) != 1 || emails.tokens[0] != "token-123") {
+				t.Errorf("tokens = %v, want [token-123]", emails.tokens)
+			}
+
+			if output.Type != tt.emailType || output.UserID != user.ID || output.Email != user.Email {
+				t.Errorf("output = %+v", output)
+			}
+		})
+	}
+}
+
+func TestResendEmailRateLimitsPerUser(t *testing.T) {
+	repo := memory.NewRepository()
+	ana := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	bruno := repositorytest.NewUser("Bruno", "bruno@example.com", 1)
+	repositorytest.Seed(t, repo, ana, bruno)
+
+	fake := clock.NewFakeClock(repositorytest.BaseTime)
+	emails := &recordingEmails{}
+	uc := NewResendEmailUseCase(repo, emails).WithRateLimit(cache.NewMemoryCache().WithClock(fake), 2, time.Hour)
+	ctx := asAdmin(t, repo)
+
+	for i := 1; i <= 2; i++ {
+		if _, err := uc.Execute(ctx, ResendEmailInput{UserID: ana.ID, Type: EmailTypeWelcome}); err != nil {
+			t.Fatalf("resend %d: %v", i, err)
+		}
+	}
+
+	// A espera indicada é o que resta da janela, não a janela inteira
+	fake.Advance(59 * time.Minute)
+
+	_, err := uc.Execute(ctx, ResendEmailInput{UserID: ana.ID, Type: EmailTypeWelcome})
+
+	var limitErr *ResendRateLimitedError
+	if !errors.Is(err, ErrResendRateLimited) || !errors.As(err, &limitErr) {
+		t.Fatalf("third resend = %v, want %v", err, ErrResendRateLimited)
+	}
+
+	if limitErr.RetryAfter != time.Minute {
+		t.Errorf("RetryAfter = %s, want %s", limitErr.RetryAfter, time.Minute)
+	}
+
+	// O limite é de cada usuário
+	if _, err := uc.Execute(ctx, ResendEmailInput{UserID: bruno.ID, Type: EmailTypeWelcome}); err != nil {
+		t.Errorf("resend to another user: %v", err)
+	}
+
+	if len(emails.sent) != 3 {
+		t.Errorf("sent %d emails, want 3", len(emails.sent))
+	}
+}
+
+func TestResendEmailRequiresHigherRole(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleSuperAdmin)
+	admin, superAdmin := users[0], users[1]
+
+	emails := &recordingEmails{}
+	uc := NewResendEmailUseCase(repo, emails)
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+	tests := []struct {
+		name   string
+		target *domain.User
+	}{
+		{name: "higher role", target: superAdmin},
+		{name: "own account", target: admin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(ctx, ResendEmailInput{UserID: tt.target.ID, Type: EmailTypeWelcome})
+			if !errors.Is(err, domain.ErrRoleChangeForbidden) {
+				t.Fatalf("Execute error = %v, want %v", err, domain.ErrRoleChangeForbidden)
+			}
+		})
+	}
+
+	// Sem solicitante autenticado não há reenvio
+	_, err := uc.Execute(context.Background(), ResendEmailInput{UserID: superAdmin.ID, Type: EmailTypeWelcome})
+	if !errors.Is(err, domain.ErrRoleChangeForbidden) {
+		t.Errorf("anonymous Execute error = %v, want %v", err, domain.ErrRoleChangeForbidden)
+	}
+
+	if len(emails.sent) != 0 {
+		t.Errorf("sent = %v, want nothing", emails.sent)
+	}
+}
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// SetUserStatusUseCase implementa o caso de uso de definir o status de um usuário.
+type SetUserStatusUseCase struct {
+	clock    clock.Clock
+	userRepo domain.Repository
+	notifier NotificationService
+	logger   *zap.Logger
+}
+
+// NewSetUserStatusUseCase cria uma nova instância do caso de uso.
+func NewSetUserStatusUseCase(userRepo domain.Repository) *SetUserStatusUseCase {
+	return &SetUserStatusUseCase{
+		clock:    clock.RealClock{},
+		userRepo: userRepo,
+		notifier: NullNotificationService{},
+	}
+}
+
+// WithNotifier define o serviço que notifica o usuário sobre a mudança de status.
+func (uc *SetUserStatusUseCase) WithNotifier(notifier NotificationService) *SetUserStatusUseCase {
+	if notifier != nil {
+		uc.notifier = notifier
+	}
+
+	return uc
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *SetUserStatusUseCase) WithLogger(logger *zap.Logger) *SetUserStatusUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *SetUserStatusUseCase) WithClock(c clock.Clock) *SetUserStatusUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// SetUserStatusInput representa os dados de entrada.
+type SetUserStatusInput struct {
+	Status domain.Status `json:"status" validate:"required"`
+	UserID uuid.UUID     `json:"user_id" validate:"required"`
+}
+
+// SetUserStatusOutput representa os dados de saída.
+type SetUserStatusOutput struct {
+	User           *domain.User  `json:"user"`
+	PreviousStatus domain.Status `json:"previous_status"`
+	Changed        bool          `json:"changed"`
+}
+
+// Execute executa o caso de uso. Quem pede precisa ter um role superior ao do
+// alvo e não pode mudar o próprio status (domain.ErrRoleChangeForbidden).
+// Transições não permitidas retornam *domain.StatusTransitionError com os
+// status permitidos.
+func (uc *SetUserStatusUseCase) Execute(ctx context.Context, input SetUserStatusInput) (*SetUserStatusOutput, error) {
+	requester, err := loadRequester(ctx, uc.userRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := ensureCanManage(requester, user); err != nil {
+		return nil, err
+	}
+
+	previous := user.Status
+
+	changed, err := user.ChangeStatus(input.Status, uc.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	output := &SetUserStatusOutput{User: user, PreviousStatus: previous, Changed: changed}
+	if !changed {
+		return output, nil
+	}
+
+	user.UpdatedBy = actorFrom(ctx)
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	log := contextLogger(ctx, uc.logger)
+	log.Warn("User status changed",
+		zap.String("user_id", user.ID.String()),
+		zap.Stringer("from", previous),
+		zap.Stringer("to", user.Status),
+		zap.String("changed_by", user.UpdatedBy),
+	)
+
+	// Falhas na notificação não devem desfazer a mudança de status
+	if err := uc.notifier.NotifySecurityEvent(ctx, user, SecurityEventStatusChanged); err != nil {
+		log.Warn("Failed to send status change notification",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err),
+		)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+func TestSetUserStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    domain.Status
+		to      domain.Status
+		changed bool
+		wantErr error
+	}{
+		{name: "suspended back to active", from: domain.StatusSuspended, to: domain.StatusActive, changed: true},
+		{name: "active to inactive", from: domain.StatusActive, to: domain.StatusInactive, changed: true},
+		{name: "pending to suspended", from: domain.StatusPending, to: domain.StatusSuspended, changed: true},
+		{name: "same status", from: domain.StatusActive, to: domain.StatusActive},
+		{name: "back to pending", from: domain.StatusActive, to: domain.StatusPending, wantErr: domain.ErrInvalidStatusTransition},
+		{name: "unknown status", from: domain.StatusActive, to: "banned", wantErr: domain.ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := repositorytest.BaseTime.Add(time.Hour)
+			fake := clock.NewFakeClock(now)
+
+			repo := memory.NewRepository().WithClock(fake)
+			user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+			user.Status = tt.from
+			admin := repositorytest.NewUser("Admin", "admin@example.com", 1)
+			admin.Role = domain.RoleAdmin
+			repositorytest.Seed(t, repo, user, admin)
+
+			core, logs := observer.New(zapcore.InfoLevel)
+			notifier := &recordingNotifier{}
+			uc := NewSetUserStatusUseCase(repo).
+				WithNotifier(notifier).
+				WithLogger(zap.New(core)).
+				WithClock(fake)
+
+			ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+			output, err := uc.Execute(ctx, SetUserStatusInput{UserID: user.ID, Status: tt.to})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, getErr := repo.GetByID(context.Background(), user.ID)
+			if getErr != nil {
+				t.Fatalf("GetByID: %v", getErr)
+			}
+
+			if err != nil || !tt.changed {
+				// Nada é gravado, notificado ou auditado
+				if stored.Status != tt.from || len(notifier.events) != 0 || logs.Len() != 0 {
+					t.Errorf("status = %s, events = %v, logs = %d; want untouched", stored.Status, notifier.events, logs.Len())
+				}
+
+				if err == nil && (output.Changed || output.PreviousStatus != tt.from) {
+					t.Errorf("output = %+v, want unchanged", output)
+				}
+
+				return
+			}
+
+			if !output.Changed || output.PreviousStatus != tt.from || output.User.Status != tt.to {
+				t.Errorf("output = %+v", output)
+			}
+
+			if stored.Status != tt.to || stored.UpdatedBy != admin.ID.String() || !stored.UpdatedAt.Equal(now) {
+				t.Errorf("stored = %s by %s at %v", stored.Status, stored.UpdatedBy, stored.UpdatedAt)
+			}
+
+			if !slices.Equal(notifier.events, []SecurityEvent{SecurityEventStatusChanged}) {
+				t.Errorf("events = %v, want [%s]", notifier.events, SecurityEventStatusChanged)
+			}
+
+			entries := logs.FilterMessage("User status changed").All()
+			if len(entries) != 1 {
+				t.Fatalf("got %d audit entries, want 1", len(entries))
+			}
+
+			fields := entries[0].ContextMap()
+			if fields["from"] != string(tt.from) || fields["to"] != string(tt.to) || fields["changed_by"] != admin.ID.String() {
+				t.Errorf("audit fields = %v", fields)
+			}
+		})
+	}
+}
+
+func TestSetUserStatusRequiresHigherRole(t *testing.T) {
+	repo := memory.NewRepository()
+	users := seedRoles(t, repo, domain.RoleAdmin, domain.RoleAdmin, domain.RoleSuperAdmin)
+	admin, otherAdmin, superAdmin := users[0], users[1], users[2]
+
+	notifier := &recordingNotifier{}
+	uc := NewSetUserStatusUseCase(repo).WithNotifier(notifier)
+
+	ctx := requestctx.WithActor(context.Background(), admin.ID.String())
+
+	for _, target := range []*domain.User{superAdmin, otherAdmin, admin} {
+		_, err := uc.Execute(ctx, SetUserStatusInput{UserID: target.ID, Status: domain.StatusSuspended})
+		if !errors.Is(err, domain.ErrRoleChangeForbidden) {
+			t.Errorf("suspend %s = %v, want %v", target.Email, err, domain.ErrRoleChangeForbidden)
+		}
+
+		stored, err := repo.GetByID(context.Background(), target.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+
+		if stored.Status != target.Status {
+			t.Errorf("status of %s changed to %s", target.Email, stored.Status)
+		}
+	}
+
+	if len(notifier.events) != 0 {
+		t.Errorf("events = %v, want none", notifier.events)
+	}
+}
@@ -5,22 +5,41 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
 )
 
 // UpdateUserUseCase implementa o caso de uso de atualizar usuário.
 type UpdateUserUseCase struct {
+	clock    clock.Clock
 	userRepo domain.Repository
+	logger   *zap.Logger
 }
 
 // NewUpdateUserUseCase cria uma nova instância do caso de uso.
 func NewUpdateUserUseCase(userRepo domain.Repository) *UpdateUserUseCase {
 	return &UpdateUserUseCase{
+		clock:    clock.RealClock{},
 		userRepo: userRepo,
 	}
 }
 
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *UpdateUserUseCase) WithLogger(logger *zap.Logger) *UpdateUserUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *UpdateUserUseCase) WithClock(c clock.Clock) *UpdateUserUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
 // UpdateUserInput representa os dados de entrada.
 type UpdateUserInput struct {
 	Phone *string   `json:"phone,omitempty"`
@@ -43,15 +62,19 @@ func (uc *UpdateUserUseCase) Execute(ctx context.Context, input UpdateUserInput)
 	}
 
 	// Atualizar perfil
-	if err := user.UpdateProfile(input.Name, input.Phone); err != nil {
+	if err := user.UpdateProfile(input.Name, input.Phone, uc.clock.Now()); err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
 
+	user.UpdatedBy = actorFrom(ctx)
+
 	// Salvar no banco
 	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to save user: %w", err)
 	}
 
+	contextLogger(ctx, uc.logger).Info("User updated", zap.String("user_id", user.ID.String()))
+
 	return &UpdateUserOutput{
 		User:    user,
 		Message: "User updated successfully",
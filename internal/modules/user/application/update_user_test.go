@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+func TestUpdateUserStampsUpdateWithClock(t *testing.T) {
+	frozen := repositorytest.BaseTime.Add(48 * time.Hour)
+	fake := clock.NewFakeClock(frozen)
+
+	repo := memory.NewRepository().WithClock(fake)
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, repo, user)
+
+	uc := NewUpdateUserUseCase(repo).WithClock(fake)
+	ctx := requestctx.WithActor(context.Background(), "admin-1")
+
+	output, err := uc.Execute(ctx, UpdateUserInput{ID: user.ID, Name: "Ana Maria"})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if !output.User.UpdatedAt.Equal(frozen) || output.User.UpdatedBy != "admin-1" {
+		t.Errorf("updated at %s by %s, want %s by admin-1", output.User.UpdatedAt, output.User.UpdatedBy, frozen)
+	}
+
+	if !output.User.CreatedAt.Equal(repositorytest.BaseTime) {
+		t.Errorf("created at %s, want %s", output.User.CreatedAt, repositorytest.BaseTime)
+	}
+
+	stored, err := repo.GetByID(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Name != "Ana Maria" || !stored.UpdatedAt.Equal(frozen) {
+		t.Errorf("stored %q updated at %s, want %q at %s", stored.Name, stored.UpdatedAt, "Ana Maria", frozen)
+	}
+
+	// Quem criou o usuário não muda na atualização
+	if stored.UpdatedBy != "admin-1" || stored.CreatedBy != user.CreatedBy {
+		t.Errorf("stored created by %q, updated by %q, want %q and admin-1", stored.CreatedBy, stored.UpdatedBy, user.CreatedBy)
+	}
+}
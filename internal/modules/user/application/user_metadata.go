@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// UserMetadataUseCase implementa a leitura e a substituição dos metadados de um usuário.
+type UserMetadataUseCase struct {
+	clock    clock.Clock
+	userRepo domain.Repository
+	logger   *zap.Logger
+}
+
+// NewUserMetadataUseCase cria uma nova instância do caso de uso.
+func NewUserMetadataUseCase(userRepo domain.Repository) *UserMetadataUseCase {
+	return &UserMetadataUseCase{
+		clock:    clock.RealClock{},
+		userRepo: userRepo,
+	}
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *UserMetadataUseCase) WithLogger(logger *zap.Logger) *UserMetadataUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *UserMetadataUseCase) WithClock(c clock.Clock) *UserMetadataUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// SetUserMetadataInput representa os dados de entrada da substituição.
+type SetUserMetadataInput struct {
+	Metadata domain.Metadata `json:"metadata"`
+	UserID   uuid.UUID       `json:"user_id" validate:"required"`
+}
+
+// Get retorna os metadados do usuário.
+func (uc *UserMetadataUseCase) Get(ctx context.Context, userID uuid.UUID) (domain.Metadata, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.Metadata == nil {
+		return domain.Metadata{}, nil
+	}
+
+	return user.Metadata, nil
+}
+
+// Set substitui todos os metadados do usuário.
+func (uc *UserMetadataUseCase) Set(ctx context.Context, input SetUserMetadataInput) (domain.Metadata, error) {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := user.SetMetadata(input.Metadata, uc.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	user.UpdatedBy = actorFrom(ctx)
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	contextLogger(ctx, uc.logger).Info("User metadata updated",
+		zap.String("user_id", user.ID.String()),
+		zap.Int("keys", len(user.Metadata)),
+	)
+
+	return user.Metadata, nil
+}
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+)
+
+func TestUserMetadataSetAndQuery(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+
+	ana := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	bruno := repositorytest.NewUser("Bruno", "bruno@example.com", 1)
+	repositorytest.Seed(t, repo, ana, bruno)
+
+	uc := NewUserMetadataUseCase(repo)
+
+	for user, plan := range map[*domain.User]string{ana: "pro", bruno: "free"} {
+		if _, err := uc.Set(ctx, SetUserMetadataInput{UserID: user.ID, Metadata: domain.Metadata{"plan": plan}}); err != nil {
+			t.Fatalf("Set(%s): %v", user.Name, err)
+		}
+	}
+
+	metadata, err := uc.Get(ctx, ana.ID)
+	if err != nil || metadata["plan"] != "pro" {
+		t.Fatalf("Get = %v, %v; want plan pro", metadata, err)
+	}
+
+	list, err := NewListUsersUseCase(repo).Execute(ctx, ListUsersInput{Metadata: map[string]string{"plan": "pro"}, Limit: 10})
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+
+	if list.Result.TotalItems != 1 || list.Result.Items[0].ID != ana.ID {
+		t.Errorf("users with plan pro = %d, want only Ana", list.Result.TotalItems)
+	}
+
+	// Metadados inválidos são recusados sem alterar os atuais
+	if _, err := uc.Set(ctx, SetUserMetadataInput{UserID: ana.ID, Metadata: domain.Metadata{"plan name": "x"}}); !errors.Is(err, domain.ErrInvalidMetadataKey) {
+		t.Errorf("Set with invalid key = %v, want %v", err, domain.ErrInvalidMetadataKey)
+	}
+
+	if metadata, _ := uc.Get(ctx, ana.ID); metadata["plan"] != "pro" {
+		t.Errorf("metadata after rejected Set = %v", metadata)
+	}
+}
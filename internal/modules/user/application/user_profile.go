@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// UserProfileUseCase implementa a leitura e a atualização do perfil de um usuário.
+type UserProfileUseCase struct {
+	clock    clock.Clock
+	userRepo domain.Repository
+	profiles domain.ProfileRepository
+	logger   *zap.Logger
+}
+
+// NewUserProfileUseCase cria uma nova instância do caso de uso.
+func NewUserProfileUseCase(userRepo domain.Repository, profiles domain.ProfileRepository) *UserProfileUseCase {
+	return &UserProfileUseCase{
+		clock:    clock.RealClock{},
+		userRepo: userRepo,
+		profiles: profiles,
+	}
+}
+
+// WithLogger define o logger usado pelo caso de uso.
+func (uc *UserProfileUseCase) WithLogger(logger *zap.Logger) *UserProfileUseCase {
+	uc.logger = logger
+
+	return uc
+}
+
+// WithClock define o relógio usado pelo caso de uso.
+func (uc *UserProfileUseCase) WithClock(c clock.Clock) *UserProfileUseCase {
+	uc.clock = clock.OrReal(c)
+
+	return uc
+}
+
+// UpdateUserProfileInput representa os dados de entrada da atualização.
+// Todos os campos são substituídos; um campo vazio limpa o valor anterior.
+type UpdateUserProfileInput struct {
+	AvatarURL string    `json:"avatar_url"`
+	Bio       string    `json:"bio"`
+	Locale    string    `json:"locale"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// Get retorna o perfil do usuário. Usuários sem perfil recebem um perfil vazio.
+func (uc *UserProfileUseCase) Get(ctx context.Context, userID uuid.UUID) (*domain.UserProfile, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	profile, err := uc.profiles.Get(ctx, userID)
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return domain.NewUserProfile(userID, uc.clock.Now()), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// Update substitui o perfil do usuário, criando-o se ainda não existir.
+func (uc *UserProfileUseCase) Update(ctx context.Context, input UpdateUserProfileInput) (*domain.UserProfile, error) {
+	if _, err := uc.userRepo.GetByID(ctx, input.UserID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := uc.clock.Now()
+
+	profile := domain.NewUserProfile(input.UserID, now)
+	if err := profile.Update(input.AvatarURL, input.Bio, input.Locale, now); err != nil {
+		return nil, err
+	}
+
+	if err := uc.profiles.Upsert(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	contextLogger(ctx, uc.logger).Info("User profile updated",
+		zap.String("user_id", input.UserID.String()),
+		zap.String("updated_by", actorFrom(ctx)),
+	)
+
+	return profile, nil
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// fakeProfiles guarda os perfis em memória, como o upsert do Postgres.
+type fakeProfiles struct {
+	profiles map[uuid.UUID]domain.UserProfile
+	err      error
+}
+
+func newFakeProfiles() *fakeProfiles {
+	return &fakeProfiles{profiles: map[uuid.UUID]domain.UserProfile{}}
+}
+
+func (f *fakeProfiles) Get(_ context.Context, userID uuid.UUID) (*domain.UserProfile, error) {
+	profile, ok := f.profiles[userID]
+	if !ok {
+		return nil, domain.ErrProfileNotFound
+	}
+
+	return &profile, nil
+}
+
+func (f *fakeProfiles) Upsert(_ context.Context, profile *domain.UserProfile) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.profiles[profile.UserID] = *profile
+
+	return nil
+}
+
+func TestUserProfileUpsert(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, repo, user)
+
+	fake := clock.NewFakeClock(repositorytest.BaseTime)
+	profiles := newFakeProfiles()
+	uc := NewUserProfileUseCase(repo, profiles).WithClock(fake)
+
+	// Sem perfil gravado, a leitura devolve um perfil vazio
+	empty, err := uc.Get(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Get without profile: %v", err)
+	}
+
+	if empty.UserID != user.ID || empty.Bio != "" || len(profiles.profiles) != 0 {
+		t.Errorf("empty profile = %+v, stored %d profiles", empty, len(profiles.profiles))
+	}
+
+	steps := []struct {
+		input   UpdateUserProfileInput
+		wantErr error
+		wantBio string
+	}{
+		{input: UpdateUserProfileInput{Bio: "Olá", Locale: "pt-BR"}, wantBio: "Olá"},
+		{input: UpdateUserProfileInput{Bio: "Oi", AvatarURL: "https://cdn.example.com/ana.png"}, wantBio: "Oi"},
+		{input: UpdateUserProfileInput{Locale: "portuguese"}, wantErr: domain.ErrInvalidProfile, wantBio: "Oi"},
+	}
+
+	for i, step := range steps {
+		fake.Advance(time.Hour)
+		step.input.UserID = user.ID
+
+		if _, err := uc.Update(ctx, step.input); !errors.Is(err, step.wantErr) {
+			t.Fatalf("step %d: Update = %v, want %v", i+1, err, step.wantErr)
+		}
+
+		stored, err := uc.Get(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("step %d: Get: %v", i+1, err)
+		}
+
+		if stored.Bio != step.wantBio {
+			t.Errorf("step %d: bio = %q, want %q", i+1, stored.Bio, step.wantBio)
+		}
+	}
+
+	// Cada atualização substitui todos os campos: o locale do primeiro passo some
+	stored := profiles.profiles[user.ID]
+	if stored.Locale != "" || stored.AvatarURL != "https://cdn.example.com/ana.png" {
+		t.Errorf("stored = %+v, want the second update only", stored)
+	}
+
+	if _, err := uc.Update(ctx, UpdateUserProfileInput{UserID: uuid.New()}); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("Update unknown user = %v, want %v", err, domain.ErrUserNotFound)
+	}
+}
+
+func TestCreateUserCreatesEmptyProfile(t *testing.T) {
+	ctx := context.Background()
+	profiles := newFakeProfiles()
+	uc := NewCreateUserUseCase(memory.NewRepository(), testPasswords).WithProfiles(profiles)
+
+	output, err := uc.Execute(ctx, CreateUserInput{Name: "Ana", Email: "ana@example.com", Password: testPassword})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	profile, ok := profiles.profiles[output.User.ID]
+	if !ok || profile.Bio != "" || profile.AvatarURL != "" || profile.Locale != "" {
+		t.Errorf("profile = %+v (stored %v), want an empty profile", profile, ok)
+	}
+
+	// Uma falha ao criar o perfil não desfaz o cadastro
+	profiles.err = errors.New("connection refused")
+
+	if _, err := uc.Execute(ctx, CreateUserInput{Name: "Bruno", Email: "bruno@example.com", Password: testPassword}); err != nil {
+		t.Errorf("Execute with failing profiles = %v, want nil", err)
+	}
+}
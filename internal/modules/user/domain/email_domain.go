@@ -0,0 +1,55 @@
+package domain
+
+import "strings"
+
+// EmailDomainPolicy restringe os domínios de email aceitos no cadastro.
+// Uma allowlist vazia aceita qualquer domínio que não esteja na blocklist.
+type EmailDomainPolicy struct {
+	allowed map[string]bool
+	blocked map[string]bool
+}
+
+// NewEmailDomainPolicy cria uma política a partir das listas de domínios permitidos e bloqueados.
+func NewEmailDomainPolicy(allowed, blocked []string) EmailDomainPolicy {
+	return EmailDomainPolicy{
+		allowed: domainSet(allowed),
+		blocked: domainSet(blocked),
+	}
+}
+
+// Check retorna ErrEmailDomainNotAllowed se o domínio do email não for aceito.
+func (p EmailDomainPolicy) Check(email string) error {
+	// O domínio vem após o último @, já que partes locais entre aspas podem conter @
+	email = NormalizeEmail(email)
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 {
+		return ErrInvalidEmail
+	}
+
+	emailDomain := email[at+1:]
+
+	if p.blocked[emailDomain] {
+		return ErrEmailDomainNotAllowed
+	}
+
+	if len(p.allowed) > 0 && !p.allowed[emailDomain] {
+		return ErrEmailDomainNotAllowed
+	}
+
+	return nil
+}
+
+// domainSet normaliza a lista de domínios em um conjunto, ignorando entradas vazias.
+func domainSet(domains []string) map[string]bool {
+	set := make(map[string]bool, len(domains))
+
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d != "" {
+			set[d] = true
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEmailDomainPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		blocked []string
+		email   string
+		want    error
+	}{
+		{name: "any domain without lists", email: "ana@example.com"},
+		{name: "allowed domain", allowed: []string{"acme.com"}, email: "ana@acme.com"},
+		{name: "allowlist entry with @ and case", allowed: []string{" @ACME.com "}, email: "Ana@Acme.com"},
+		{name: "domain outside allowlist", allowed: []string{"acme.com"}, email: "ana@example.com", want: ErrEmailDomainNotAllowed},
+		{name: "subdomain outside allowlist", allowed: []string{"acme.com"}, email: "ana@mail.acme.com", want: ErrEmailDomainNotAllowed},
+		{name: "blocked disposable domain", blocked: []string{"mailinator.com"}, email: "ana@mailinator.com", want: ErrEmailDomainNotAllowed},
+		{name: "blocklist wins over allowlist", allowed: []string{"acme.com"}, blocked: []string{"acme.com"}, email: "ana@acme.com", want: ErrEmailDomainNotAllowed},
+		{name: "missing domain", email: "ana@", want: ErrInvalidEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := NewEmailDomainPolicy(tt.allowed, tt.blocked)
+
+			if err := policy.Check(tt.email); !errors.Is(err, tt.want) {
+				t.Errorf("Check(%q) = %v, want %v", tt.email, err, tt.want)
+			}
+		})
+	}
+}
@@ -2,13 +2,66 @@ package domain
 
 import "errors"
 
-// Erros do domínio.
+// Erros de entrada: o valor recebido tem formato inválido, é desconhecido ou
+// excede limites fixos, independente das regras configuradas e do estado dos
+// usuários. A API responde 400 Bad Request.
 var (
-	ErrUserNotFound       = errors.New("user not found")
-	ErrEmailAlreadyInUse  = errors.New("email already in use")
-	ErrInvalidName        = errors.New("invalid name")
-	ErrInvalidEmail       = errors.New("invalid email")
-	ErrInvalidPassword    = errors.New("invalid password")
-	ErrPasswordHash       = errors.New("failed to hash password")
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidName         = errors.New("invalid name")
+	ErrInvalidEmail        = errors.New("invalid email")
+	ErrInvalidStatus       = errors.New("invalid status")
+	ErrInvalidRole         = errors.New("invalid role")
+	ErrEmptyBulkFilter     = errors.New("bulk operation requires at least one filter")
+	ErrInvalidMetadata     = errors.New("invalid metadata")
+	ErrInvalidMetadataKey  = errors.New("invalid metadata key")
+	ErrMetadataTooLarge    = errors.New("metadata exceeds size limits")
+	ErrInvalidProfile      = errors.New("invalid user profile")
+	ErrInvalidFilterPreset = errors.New("invalid filter preset")
+	ErrInvalidFilter       = errors.New("invalid user filter")
 )
+
+// Violações de regras de negócio: a entrada é bem formada, mas as políticas do
+// domínio ou o estado atual do usuário a recusam. A API responde 422
+// Unprocessable Entity; veja IsBusinessRuleViolation.
+var (
+	ErrEmailDomainNotAllowed   = errors.New("email domain not allowed")
+	ErrInvalidPassword         = errors.New("invalid password")
+	ErrPasswordReused          = errors.New("password was used recently")
+	ErrInvalidStatusTransition = errors.New("status transition not allowed")
+	ErrSelfMerge               = errors.New("cannot merge a user into itself")
+	ErrSelfRoleChange          = errors.New("cannot change your own role")
+)
+
+// Demais erros do domínio, com status próprios: recursos ausentes (404),
+// conflitos com dados existentes (409), credenciais (401), permissões (403) e
+// falhas internas.
+var (
+	ErrUserNotFound         = errors.New("user not found")
+	ErrProfileNotFound      = errors.New("user profile not found")
+	ErrFilterPresetNotFound = errors.New("filter preset not found")
+	ErrEmailAlreadyInUse    = errors.New("email already in use")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrRoleChangeForbidden  = errors.New("role change not permitted")
+	ErrPasswordHash         = errors.New("failed to hash password")
+)
+
+// businessRuleErrors lista as violações de regras de negócio.
+var businessRuleErrors = []error{
+	ErrEmailDomainNotAllowed,
+	ErrInvalidPassword,
+	ErrPasswordReused,
+	ErrInvalidStatusTransition,
+	ErrSelfMerge,
+	ErrSelfRoleChange,
+}
+
+// IsBusinessRuleViolation informa se err (ou um erro encadeado a ele) é a
+// violação de uma regra de negócio, e não um erro no formato da entrada.
+func IsBusinessRuleViolation(err error) bool {
+	for _, target := range businessRuleErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsBusinessRuleViolation(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		want bool
+	}{
+		{name: "status transition", err: &StatusTransitionError{From: StatusActive, To: StatusPending}, want: true},
+		{name: "wrapped reused password", err: fmt.Errorf("change password: %w", ErrPasswordReused), want: true},
+		{name: "email domain", err: ErrEmailDomainNotAllowed, want: true},
+		{name: "self merge", err: ErrSelfMerge, want: true},
+		{name: "invalid name", err: ErrInvalidName},
+		{name: "invalid status", err: ErrInvalidStatus},
+		{name: "not found", err: ErrUserNotFound},
+		{name: "conflict", err: ErrEmailAlreadyInUse},
+		{name: "nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBusinessRuleViolation(tt.err); got != tt.want {
+				t.Errorf("IsBusinessRuleViolation(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
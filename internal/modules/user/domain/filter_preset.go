@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxFilterSearchLength é a quantidade máxima de caracteres do termo de busca.
+const MaxFilterSearchLength = 100
+
+// filterPresetNameRegex aceita nomes curtos usáveis na URL, como "pending-this-month".
+var filterPresetNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// FilterCriteria são os filtros combinados da listagem de usuários.
+// Papéis e status aceitam qualquer um dos valores; os demais critérios se somam.
+type FilterCriteria struct {
+	CreatedFrom *time.Time `json:"created_from,omitempty"`
+	CreatedTo   *time.Time `json:"created_to,omitempty"`
+	Search      string     `json:"search,omitempty"`
+	Roles       []Role     `json:"roles,omitempty"`
+	Statuses    []Status   `json:"statuses,omitempty"`
+}
+
+// Validate verifica papéis, status, o termo de busca e o intervalo de datas.
+func (c FilterCriteria) Validate() error {
+	for _, role := range c.Roles {
+		if !role.Valid() {
+			return fmt.Errorf("%w: %q", ErrInvalidRole, role)
+		}
+	}
+
+	for _, status := range c.Statuses {
+		if !status.Valid() {
+			return fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+		}
+	}
+
+	if utf8.RuneCountInString(c.Search) > MaxFilterSearchLength {
+		return fmt.Errorf("%w: search must have at most %d characters", ErrInvalidFilter, MaxFilterSearchLength)
+	}
+
+	if c.CreatedFrom != nil && c.CreatedTo != nil && c.CreatedFrom.After(*c.CreatedTo) {
+		return fmt.Errorf("%w: created_from must not be after created_to", ErrInvalidFilter)
+	}
+
+	return nil
+}
+
+// FilterPreset é um conjunto de filtros salvo por um administrador com um nome,
+// único entre os presets dele.
+type FilterPreset struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	Criteria  FilterCriteria
+	ID        uuid.UUID
+	AdminID   uuid.UUID
+}
+
+// NewFilterPreset cria um preset após validar o nome e os critérios; now é o
+// instante de criação.
+func NewFilterPreset(adminID uuid.UUID, name string, criteria FilterCriteria, now time.Time) (*FilterPreset, error) {
+	if !filterPresetNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("%w: name must have 1 to 64 lowercase letters, digits, '-' or '_'", ErrInvalidFilterPreset)
+	}
+
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &FilterPreset{
+		ID:        uuid.New(),
+		AdminID:   adminID,
+		Name:      name,
+		Criteria:  criteria,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// FilterPresetRepository define a persistência dos presets de filtro.
+type FilterPresetRepository interface {
+	// List retorna os presets do administrador ordenados por nome.
+	List(ctx context.Context, adminID uuid.UUID) ([]*FilterPreset, error)
+	// Get retorna o preset ou ErrFilterPresetNotFound.
+	Get(ctx context.Context, adminID uuid.UUID, name string) (*FilterPreset, error)
+	// Save cria o preset ou substitui os critérios do preset de mesmo nome.
+	// O booleano indica se houve criação.
+	Save(ctx context.Context, preset *FilterPreset) (bool, error)
+	// Delete remove o preset ou retorna ErrFilterPresetNotFound.
+	Delete(ctx context.Context, adminID uuid.UUID, name string) error
+}
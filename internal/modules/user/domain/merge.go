@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MergedEmail é o email gravado no usuário de origem de uma mesclagem antes do
+// soft delete. Como o email é único inclusive entre usuários deletados, trocar
+// o email da origem o libera para um novo cadastro; o domínio .invalid
+// (RFC 2606) garante que o endereço nunca receba mensagens.
+func MergedEmail(id uuid.UUID) string {
+	return "merged+" + id.String() + "@merged.invalid"
+}
+
+// MergeResult descreve o efeito da mesclagem de um usuário de origem em um destino.
+type MergeResult struct {
+	Target               *User     `json:"target"`
+	PasswordHistoryMoved int64     `json:"password_history_moved"`
+	SourceID             uuid.UUID `json:"source_id"`
+	TargetID             uuid.UUID `json:"target_id"`
+}
+
+// MergeFrom incorpora ao usuário os dados de atividade de source: contagem de
+// logins, último login mais recente, telefone, quando o destino não tiver um,
+// e as chaves de metadados que o destino não possui. Identidade, email, senha,
+// papel e status do destino são preservados; at é o instante da atualização.
+func (u *User) MergeFrom(source *User, at time.Time) {
+	u.LoginCount += source.LoginCount
+
+	if source.LastLoginAt != nil && (u.LastLoginAt == nil || source.LastLoginAt.After(*u.LastLoginAt)) {
+		lastLogin := *source.LastLoginAt
+		u.LastLoginAt = &lastLogin
+	}
+
+	if u.Phone == nil && source.Phone != nil {
+		phone := *source.Phone
+		u.Phone = &phone
+	}
+
+	for key, value := range source.Metadata {
+		if _, exists := u.Metadata[key]; !exists {
+			if u.Metadata == nil {
+				u.Metadata = Metadata{}
+			}
+
+			u.Metadata[key] = value
+		}
+	}
+
+	u.UpdatedAt = at
+}
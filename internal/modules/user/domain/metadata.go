@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+)
+
+// Limites dos metadados de um usuário.
+const (
+	// MaxMetadataBytes é o tamanho máximo dos metadados serializados em JSON.
+	MaxMetadataBytes = 16 * 1024
+	// MaxMetadataKeys é a quantidade máxima de chaves no primeiro nível.
+	MaxMetadataKeys = 50
+)
+
+// metadataKeyRegex restringe as chaves a identificadores simples, o que
+// também as mantém seguras para uso em filtros.
+var metadataKeyRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.-]{0,63}$`)
+
+// Metadata guarda atributos livres de um usuário, persistidos como JSONB.
+type Metadata map[string]interface{}
+
+// Validate verifica as chaves e os limites de tamanho dos metadados.
+func (m Metadata) Validate() error {
+	if len(m) > MaxMetadataKeys {
+		return ErrMetadataTooLarge
+	}
+
+	for key := range m {
+		if !ValidMetadataKey(key) {
+			return ErrInvalidMetadataKey
+		}
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return ErrInvalidMetadata
+	}
+
+	if len(raw) > MaxMetadataBytes {
+		return ErrMetadataTooLarge
+	}
+
+	return nil
+}
+
+// ValidMetadataKey indica se a chave pode ser usada nos metadados.
+func ValidMetadataKey(key string) bool {
+	return metadataKeyRegex.MatchString(key)
+}
+
+// SetMetadata substitui os metadados do usuário após validá-los, no instante informado.
+func (u *User) SetMetadata(metadata Metadata, at time.Time) error {
+	if metadata == nil {
+		metadata = Metadata{}
+	}
+
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
+
+	u.Metadata = metadata
+	u.UpdatedAt = at
+
+	return nil
+}
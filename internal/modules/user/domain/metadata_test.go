@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMetadataValidate(t *testing.T) {
+	tooManyKeys := Metadata{}
+	for i := range MaxMetadataKeys + 1 {
+		tooManyKeys[fmt.Sprintf("key_%d", i)] = i
+	}
+
+	tests := []struct {
+		name     string
+		metadata Metadata
+		want     error
+	}{
+		{name: "empty", metadata: Metadata{}},
+		{name: "nested values", metadata: Metadata{"plan": "pro", "billing.seats": 3, "flags": map[string]interface{}{"beta": true}}},
+		{name: "key with spaces", metadata: Metadata{"plan name": "pro"}, want: ErrInvalidMetadataKey},
+		{name: "key starting with a digit", metadata: Metadata{"1plan": "pro"}, want: ErrInvalidMetadataKey},
+		{name: "too many keys", metadata: tooManyKeys, want: ErrMetadataTooLarge},
+		{name: "too many bytes", metadata: Metadata{"notes": strings.Repeat("x", MaxMetadataBytes)}, want: ErrMetadataTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.metadata.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
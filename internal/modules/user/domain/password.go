@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/devleo-m/go-zero/internal/shared/validation"
+)
+
+// PasswordService centraliza o hash, a verificação e a política de força de senhas.
+type PasswordService struct {
+	cost   int
+	policy validation.PasswordPolicy
+}
+
+// NewPasswordService cria um serviço de senhas com o custo de bcrypt informado.
+// Valores fora do intervalo aceito pelo bcrypt usam bcrypt.DefaultCost.
+func NewPasswordService(cost int) *PasswordService {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &PasswordService{cost: cost, policy: validation.DefaultPasswordPolicy()}
+}
+
+// WithPolicy define a política de força aplicada a novas senhas.
+func (s *PasswordService) WithPolicy(policy validation.PasswordPolicy) *PasswordService {
+	s.policy = policy.Normalized()
+
+	return s
+}
+
+// Policy retorna a política de força de senhas em uso.
+func (s *PasswordService) Policy() validation.PasswordPolicy {
+	return s.policy
+}
+
+// CheckPolicy verifica se a senha atende à política, retornando ErrInvalidPassword
+// acompanhado do motivo quando não atende.
+func (s *PasswordService) CheckPolicy(password string) error {
+	if err := s.policy.Validate(password); err != nil {
+		var validationErr validation.ValidationError
+		if errors.As(err, &validationErr) {
+			return fmt.Errorf("%w: %s", ErrInvalidPassword, validationErr.Message)
+		}
+
+		return ErrInvalidPassword
+	}
+
+	return nil
+}
+
+// Cost retorna o custo alvo do bcrypt.
+func (s *PasswordService) Cost() int {
+	return s.cost
+}
+
+// Hash gera o hash de uma senha com o custo configurado.
+func (s *PasswordService) Hash(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
+	if err != nil {
+		return "", ErrPasswordHash
+	}
+
+	return string(hashedPassword), nil
+}
+
+// Compare verifica se a senha corresponde ao hash.
+func (s *PasswordService) Compare(hashedPassword, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+}
+
+// NeedsRehash indica se o hash foi gerado com custo inferior ao configurado.
+func (s *PasswordService) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return false
+	}
+
+	return cost < s.cost
+}
+
+// orDefaultPasswordService retorna o serviço informado ou um com custo padrão.
+func orDefaultPasswordService(passwords *PasswordService) *PasswordService {
+	if passwords == nil {
+		return NewPasswordService(bcrypt.DefaultCost)
+	}
+
+	return passwords
+}
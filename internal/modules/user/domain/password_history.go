@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPasswordHistorySize é a quantidade padrão de senhas anteriores mantidas por usuário.
+const DefaultPasswordHistorySize = 5
+
+// PasswordHistoryRepository define a persistência do histórico de senhas.
+type PasswordHistoryRepository interface {
+	// Recent retorna os hashes mais recentes do usuário (do mais novo para o mais antigo).
+	Recent(ctx context.Context, userID uuid.UUID, limit int) ([]string, error)
+	// Add registra um hash e mantém apenas os keep mais recentes do usuário.
+	Add(ctx context.Context, userID uuid.UUID, passwordHash string, keep int) error
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testPassword = "Senha-Forte-123"
+
+func TestRehashPasswordIfNeededUpgradesLowCostHash(t *testing.T) {
+	created := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	loggedIn := created.Add(time.Hour)
+
+	user, err := NewUser("Ana", "ana@example.com", testPassword, NewPasswordService(bcrypt.MinCost), created)
+	if err != nil {
+		t.Fatalf("NewUser: %v", err)
+	}
+
+	weakHash := user.Password
+	target := NewPasswordService(bcrypt.MinCost + 1)
+
+	// O rehash acontece depois de uma verificação de senha bem-sucedida
+	if err := user.ValidatePassword(testPassword); err != nil {
+		t.Fatalf("ValidatePassword: %v", err)
+	}
+
+	rehashed, err := user.RehashPasswordIfNeeded(testPassword, target, loggedIn)
+	if err != nil || !rehashed {
+		t.Fatalf("RehashPasswordIfNeeded = %v, %v; want true, nil", rehashed, err)
+	}
+
+	if cost, _ := bcrypt.Cost([]byte(user.Password)); cost != target.Cost() {
+		t.Errorf("cost after rehash = %d, want %d", cost, target.Cost())
+	}
+
+	if user.Password == weakHash || !user.UpdatedAt.Equal(loggedIn) {
+		t.Errorf("password or UpdatedAt not refreshed: UpdatedAt = %v", user.UpdatedAt)
+	}
+
+	if err := user.ValidatePassword(testPassword); err != nil {
+		t.Errorf("ValidatePassword after rehash: %v", err)
+	}
+
+	// Com o hash já no custo alvo, nada muda
+	upgraded := user.Password
+
+	rehashed, err = user.RehashPasswordIfNeeded(testPassword, target, loggedIn.Add(time.Hour))
+	if err != nil || rehashed || user.Password != upgraded || !user.UpdatedAt.Equal(loggedIn) {
+		t.Errorf("second RehashPasswordIfNeeded = %v, %v; hash or UpdatedAt changed", rehashed, err)
+	}
+}
+
+func TestNewPasswordServiceCost(t *testing.T) {
+	tests := []struct {
+		name string
+		cost int
+		want int
+	}{
+		{name: "configured", cost: 12, want: 12},
+		{name: "below minimum", cost: bcrypt.MinCost - 1, want: bcrypt.DefaultCost},
+		{name: "above maximum", cost: bcrypt.MaxCost + 1, want: bcrypt.DefaultCost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPasswordService(tt.cost).Cost(); got != tt.want {
+				t.Errorf("Cost() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
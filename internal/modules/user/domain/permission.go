@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Role representa o papel de um usuário.
+type Role string
+
+// Roles suportados pelo sistema.
+const (
+	RoleUser       Role = "user"
+	RoleModerator  Role = "moderator"
+	RoleAdmin      Role = "admin"
+	RoleSuperAdmin Role = "super_admin"
+)
+
+// ParseRole converte e valida um role.
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if !role.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRole, value)
+	}
+
+	return role, nil
+}
+
+// Valid verifica se o role é suportado.
+func (r Role) Valid() bool {
+	return roleLevels[r] > 0
+}
+
+// String retorna o role como texto.
+func (r Role) String() string {
+	return string(r)
+}
+
+// UnmarshalJSON rejeita roles desconhecidos já no bind da requisição.
+// A string vazia é aceita e representa um role não informado.
+func (r *Role) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: must be a string", ErrInvalidRole)
+	}
+
+	if value == "" {
+		*r = ""
+		return nil
+	}
+
+	role, err := ParseRole(value)
+	if err != nil {
+		return err
+	}
+
+	*r = role
+
+	return nil
+}
+
+// roleLevels define a hierarquia de roles (do menor para o maior).
+var roleLevels = map[Role]int{
+	RoleUser:       1,
+	RoleModerator:  2,
+	RoleAdmin:      3,
+	RoleSuperAdmin: 4,
+}
+
+// rolePermissions define as permissões (recurso:ação) concedidas a cada role.
+// Roles superiores herdam as permissões dos roles inferiores.
+var rolePermissions = map[Role][]string{
+	RoleUser: {
+		"profile:read",
+		"profile:update",
+	},
+	RoleModerator: {
+		"users:read",
+	},
+	RoleAdmin: {
+		"users:create",
+		"users:update",
+		"users:delete",
+		"stats:read",
+	},
+	RoleSuperAdmin: {
+		"*",
+	},
+}
+
+// RoleLevel retorna o nível hierárquico de um role (0 se desconhecido).
+func RoleLevel(role Role) int {
+	return roleLevels[role]
+}
+
+// RolesBelow retorna, do menor para o maior, os roles de nível inferior ao de role.
+func RolesBelow(role Role) []Role {
+	roles := make([]Role, 0, len(roleLevels))
+	for candidate, level := range roleLevels {
+		if level < RoleLevel(role) {
+			roles = append(roles, candidate)
+		}
+	}
+
+	slices.SortFunc(roles, func(a, b Role) int {
+		return roleLevels[a] - roleLevels[b]
+	})
+
+	return roles
+}
+
+// CanAccess verifica se o usuário pode executar uma ação sobre um recurso.
+func (u *User) CanAccess(resource, action string) bool {
+	level := RoleLevel(u.Role)
+	if level == 0 {
+		return false
+	}
+
+	permission := resource + ":" + action
+
+	for role, permissions := range rolePermissions {
+		if roleLevels[role] > level {
+			continue
+		}
+
+		for _, p := range permissions {
+			if p == "*" || p == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// CanManage verifica se o usuário pode gerenciar o usuário alvo.
+// Um usuário sempre pode gerenciar a si mesmo; para outros usuários é
+// necessário ter um role estritamente superior ao do alvo.
+func (u *User) CanManage(target *User) bool {
+	if target == nil {
+		return false
+	}
+
+	if u.ID == target.ID {
+		return true
+	}
+
+	return RoleLevel(u.Role) > RoleLevel(target.Role)
+}
+
+// CanChangeRole verifica se o usuário pode mudar o role do alvo para role.
+// Ninguém muda o próprio role (ErrSelfRoleChange), o que impede a autoelevação;
+// para os demais é preciso poder gerenciar o alvo (CanManage) e continuar acima
+// dele depois da mudança, de modo que ninguém conceda um role igual ou superior
+// ao seu (ErrRoleChangeForbidden).
+func (u *User) CanChangeRole(target *User, role Role) error {
+	if target == nil {
+		return ErrRoleChangeForbidden
+	}
+
+	if u.ID == target.ID {
+		return ErrSelfRoleChange
+	}
+
+	if !u.CanManage(target) {
+		return fmt.Errorf("%w: %s cannot manage a %s", ErrRoleChangeForbidden, u.Role, target.Role)
+	}
+
+	if RoleLevel(role) >= RoleLevel(u.Role) {
+		return fmt.Errorf("%w: %s cannot grant the %s role", ErrRoleChangeForbidden, u.Role, role)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRoleUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Role
+		wantErr error
+	}{
+		{input: `"user"`, want: RoleUser},
+		{input: `"super_admin"`, want: RoleSuperAdmin},
+		{input: `""`, want: ""},
+		{input: `"root"`, wantErr: ErrInvalidRole},
+		{input: `"ADMIN"`, wantErr: ErrInvalidRole},
+		{input: `null`, want: ""},
+		{input: `["admin"]`, wantErr: ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got Role
+
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("Unmarshal(%s) = %q, %v; want %q, %v", tt.input, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanChangeRole(t *testing.T) {
+	withRole := func(role Role) *User {
+		return &User{ID: uuid.New(), Role: role}
+	}
+
+	admin := withRole(RoleAdmin)
+
+	tests := []struct {
+		name      string
+		requester *User
+		target    *User
+		role      Role
+		want      error
+	}{
+		{name: "admin promotes user to moderator", requester: admin, target: withRole(RoleUser), role: RoleModerator},
+		{name: "admin demotes moderator", requester: admin, target: withRole(RoleModerator), role: RoleUser},
+		{name: "self elevation", requester: admin, target: admin, role: RoleSuperAdmin, want: ErrSelfRoleChange},
+		{name: "self demotion", requester: admin, target: admin, role: RoleUser, want: ErrSelfRoleChange},
+		{name: "grant own role", requester: admin, target: withRole(RoleUser), role: RoleAdmin, want: ErrRoleChangeForbidden},
+		{name: "grant higher role", requester: admin, target: withRole(RoleUser), role: RoleSuperAdmin, want: ErrRoleChangeForbidden},
+		{name: "peer target", requester: admin, target: withRole(RoleAdmin), role: RoleUser, want: ErrRoleChangeForbidden},
+		{name: "super admin grants admin", requester: withRole(RoleSuperAdmin), target: withRole(RoleUser), role: RoleAdmin},
+		{name: "missing target", requester: admin, role: RoleUser, want: ErrRoleChangeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.requester.CanChangeRole(tt.target, tt.role); !errors.Is(err, tt.want) {
+				t.Errorf("CanChangeRole = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Limites do perfil de um usuário.
+const (
+	// MaxProfileBioLength é a quantidade máxima de caracteres da biografia.
+	MaxProfileBioLength = 500
+	// MaxProfileAvatarURLLength é o tamanho máximo da URL do avatar.
+	MaxProfileAvatarURLLength = 2048
+)
+
+// localeRegex aceita tags de idioma simples, como "pt" ou "pt-BR".
+var localeRegex = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// UserProfile guarda os dados de apresentação de um usuário. Campos vazios
+// indicam que o usuário ainda não os preencheu.
+type UserProfile struct {
+	UpdatedAt time.Time
+	AvatarURL string
+	Bio       string
+	Locale    string
+	UserID    uuid.UUID
+}
+
+// NewUserProfile cria um perfil vazio para o usuário no instante informado.
+func NewUserProfile(userID uuid.UUID, at time.Time) *UserProfile {
+	return &UserProfile{
+		UserID:    userID,
+		UpdatedAt: at,
+	}
+}
+
+// Update substitui os campos do perfil após validá-los, no instante informado.
+func (p *UserProfile) Update(avatarURL, bio, locale string, at time.Time) error {
+	if err := validateAvatarURL(avatarURL); err != nil {
+		return err
+	}
+
+	if utf8.RuneCountInString(bio) > MaxProfileBioLength {
+		return fmt.Errorf("%w: bio must have at most %d characters", ErrInvalidProfile, MaxProfileBioLength)
+	}
+
+	if locale != "" && !localeRegex.MatchString(locale) {
+		return fmt.Errorf("%w: locale must be a language tag such as pt-BR", ErrInvalidProfile)
+	}
+
+	p.AvatarURL = avatarURL
+	p.Bio = bio
+	p.Locale = locale
+	p.UpdatedAt = at
+
+	return nil
+}
+
+// validateAvatarURL aceita uma URL http(s) absoluta ou vazio.
+func validateAvatarURL(avatarURL string) error {
+	if avatarURL == "" {
+		return nil
+	}
+
+	if len(avatarURL) > MaxProfileAvatarURLLength {
+		return fmt.Errorf("%w: avatar_url must have at most %d characters", ErrInvalidProfile, MaxProfileAvatarURLLength)
+	}
+
+	parsed, err := url.Parse(avatarURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: avatar_url must be an absolute http(s) URL", ErrInvalidProfile)
+	}
+
+	return nil
+}
+
+// ProfileRepository define a persistência dos perfis de usuário.
+type ProfileRepository interface {
+	// Get retorna o perfil do usuário ou ErrProfileNotFound se ele ainda não existir.
+	Get(ctx context.Context, userID uuid.UUID) (*UserProfile, error)
+	// Upsert cria o perfil do usuário ou substitui os campos do existente.
+	Upsert(ctx context.Context, profile *UserProfile) error
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUserProfileUpdate(t *testing.T) {
+	created := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		avatarURL string
+		bio       string
+		locale    string
+		wantErr   error
+	}{
+		{name: "all fields", avatarURL: "https://cdn.example.com/a.png", bio: "Olá", locale: "pt-BR"},
+		{name: "empty clears everything"},
+		{name: "language only locale", locale: "pt"},
+		{name: "bio at the limit in runes", bio: strings.Repeat("é", MaxProfileBioLength)},
+		{name: "bio over the limit", bio: strings.Repeat("a", MaxProfileBioLength+1), wantErr: ErrInvalidProfile},
+		{name: "relative avatar", avatarURL: "/avatars/a.png", wantErr: ErrInvalidProfile},
+		{name: "non http avatar", avatarURL: "javascript:alert(1)", wantErr: ErrInvalidProfile},
+		{name: "avatar too long", avatarURL: "https://example.com/" + strings.Repeat("a", MaxProfileAvatarURLLength), wantErr: ErrInvalidProfile},
+		{name: "malformed locale", locale: "pt_br", wantErr: ErrInvalidProfile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := NewUserProfile(uuid.New(), created)
+			profile.Bio = "anterior"
+
+			err := profile.Update(tt.avatarURL, tt.bio, tt.locale, updated)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				// Um perfil inválido não altera nada
+				if profile.Bio != "anterior" || !profile.UpdatedAt.Equal(created) {
+					t.Errorf("profile changed after a rejected update: %+v", profile)
+				}
+
+				return
+			}
+
+			if profile.AvatarURL != tt.avatarURL || profile.Bio != tt.bio || profile.Locale != tt.locale || !profile.UpdatedAt.Equal(updated) {
+				t.Errorf("profile = %+v", profile)
+			}
+		})
+	}
+}
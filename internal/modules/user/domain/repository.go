@@ -4,15 +4,32 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/shared/repository"
 )
 
 // Repository define as operações de persistência para User.
+//
+// Contrato de "não encontrado": buscas por um único usuário (GetByID,
+// GetByEmail, FindOne) retornam ErrUserNotFound e nunca (nil, nil);
+// buscas por listas retornam uma lista vazia sem erro.
 type Repository interface {
-	Create(ctx context.Context, user *User) error
+	repository.Repository[User]
+
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
+	// FindOrCreate insere o usuário construído por build ou, se o email já
+	// estiver em uso, retorna o usuário existente. O booleano indica se houve criação.
+	FindOrCreate(ctx context.Context, email string, build func() *User) (*User, bool, error)
+	// Merge mescla source em target em uma transação: reatribui os registros
+	// relacionados, atualiza o destino e remove (soft delete) a origem. Com
+	// dryRun, a transação é desfeita e apenas o resultado é retornado.
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID, dryRun bool) (*MergeResult, error)
 	List(ctx context.Context, limit, offset int) ([]*User, error)
-	Count(ctx context.Context) (int64, error)
-	Update(ctx context.Context, user *User) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	FindUsersByLastLogin(ctx context.Context, days, page, pageSize int) (*repository.PaginatedResult[User], error)
+	FindUsersByEmailDomain(
+		ctx context.Context,
+		emailDomain string,
+		page, pageSize int,
+	) (*repository.PaginatedResult[User], error)
 }
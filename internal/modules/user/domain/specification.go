@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+// ActiveSpecification seleciona usuários com status ativo.
+func ActiveSpecification() repository.Specification[User] {
+	return StatusSpecification(StatusActive)
+}
+
+// StatusSpecification seleciona usuários com algum dos status informados.
+func StatusSpecification(statuses ...Status) repository.Specification[User] {
+	return inSpecification("status", statuses)
+}
+
+// RoleSpecification seleciona usuários com algum dos papéis informados.
+func RoleSpecification(roles ...Role) repository.Specification[User] {
+	return inSpecification("role", roles)
+}
+
+// MetadataSpecification seleciona usuários cujo metadado key tem o valor informado.
+func MetadataSpecification(key string, value interface{}) repository.Specification[User] {
+	return repository.NewSpecification[User]("metadata", repository.OpJSONContains, map[string]interface{}{key: value})
+}
+
+// CreatedThisWeekSpecification seleciona usuários criados desde o início
+// (segunda-feira, 00:00 UTC) da semana de now.
+func CreatedThisWeekSpecification(now time.Time) repository.Specification[User] {
+	now = now.UTC()
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	weekStart := time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+
+	return repository.NewSpecification[User]("created_at", repository.OpGreaterOrEqual, weekStart)
+}
+
+// CreatedFromSpecification seleciona usuários criados a partir de from (inclusive).
+func CreatedFromSpecification(from time.Time) repository.Specification[User] {
+	return repository.NewSpecification[User]("created_at", repository.OpGreaterOrEqual, from)
+}
+
+// CreatedToSpecification seleciona usuários criados até to (inclusive).
+func CreatedToSpecification(to time.Time) repository.Specification[User] {
+	return repository.NewSpecification[User]("created_at", repository.OpLessOrEqual, to)
+}
+
+// likeEscaper escapa os curingas do LIKE para que o termo seja buscado literalmente.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// SearchSpecification seleciona usuários cujo nome ou email contém o termo,
+// sem diferenciar maiúsculas de minúsculas.
+func SearchSpecification(term string) repository.Specification[User] {
+	pattern := "%" + likeEscaper.Replace(term) + "%"
+
+	return repository.NewSpecification[User]("name", repository.OpILike, pattern).
+		Or(repository.NewSpecification[User]("email", repository.OpILike, pattern))
+}
+
+// inSpecification usa igualdade para um único valor e IN para vários.
+// Os valores são convertidos para string antes de chegar ao driver.
+func inSpecification[T ~string](field string, typed []T) repository.Specification[User] {
+	values := make([]string, len(typed))
+	for i, value := range typed {
+		values[i] = string(value)
+	}
+
+	if len(values) == 1 {
+		return repository.NewSpecification[User](field, repository.OpEqual, values[0])
+	}
+
+	return repository.NewSpecification[User](field, repository.OpIn, values)
+}
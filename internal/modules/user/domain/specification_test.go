@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+func TestCreatedThisWeekSpecificationBoundaries(t *testing.T) {
+	// Quarta-feira, 8 de janeiro de 2025; a semana começa na segunda, dia 6
+	wednesday := time.Date(2025, 1, 8, 15, 30, 0, 0, time.UTC)
+	monday := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		now  time.Time
+		name string
+		want []string
+	}{
+		{
+			name: "midweek",
+			now:  wednesday,
+			want: []string{"monday start", "wednesday"},
+		},
+		{
+			name: "monday midnight starts a new week",
+			now:  monday,
+			want: []string{"monday start"},
+		},
+		{
+			// Segunda 01:00 em UTC+3 ainda é domingo em UTC: semana anterior
+			name: "offset converted to UTC",
+			now:  time.Date(2025, 1, 6, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)),
+			want: []string{"previous monday", "sunday noon"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clock.NewFakeClock(tt.now)
+			repo := repository.NewMemoryRepository[User]()
+
+			for name, createdAt := range map[string]time.Time{
+				"previous monday": monday.AddDate(0, 0, -7),
+				"sunday noon":     monday.Add(-12 * time.Hour),
+				"sunday end":      monday.Add(-time.Second),
+				"monday start":    monday,
+				"wednesday":       wednesday,
+			} {
+				// Nada pode ter sido criado depois do instante congelado
+				if createdAt.After(fake.Now()) {
+					continue
+				}
+
+				if err := repo.Create(context.Background(), &User{Name: name, CreatedAt: createdAt}); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+			}
+
+			filter := CreatedThisWeekSpecification(fake.Now()).ToQueryFilter()
+			filter.OrderBy = []string{"created_at ASC"}
+
+			users, err := repo.FindMany(context.Background(), filter)
+			if err != nil {
+				t.Fatalf("FindMany: %v", err)
+			}
+
+			var got []string
+			for _, user := range users {
+				got = append(got, user.Name)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("created this week = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Status representa a situação da conta de um usuário.
+type Status string
+
+// Status suportados para um usuário.
+const (
+	StatusActive    Status = "active"
+	StatusInactive  Status = "inactive"
+	StatusPending   Status = "pending"
+	StatusSuspended Status = "suspended"
+)
+
+// validStatuses contém os status aceitos.
+var validStatuses = map[Status]bool{
+	StatusActive:    true,
+	StatusInactive:  true,
+	StatusPending:   true,
+	StatusSuspended: true,
+}
+
+// statusTransitions define as transições permitidas a partir de cada status.
+// Nenhum status volta a pending, que só existe antes da primeira ativação.
+var statusTransitions = map[Status][]Status{
+	StatusPending:   {StatusActive, StatusInactive, StatusSuspended},
+	StatusActive:    {StatusInactive, StatusSuspended},
+	StatusInactive:  {StatusActive, StatusSuspended},
+	StatusSuspended: {StatusActive, StatusInactive},
+}
+
+// StatusTransitionError descreve uma transição de status não permitida.
+type StatusTransitionError struct {
+	From    Status
+	To      Status
+	Allowed []Status
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", ErrInvalidStatusTransition, e.From, e.To)
+}
+
+// Unwrap permite comparar com errors.Is(err, ErrInvalidStatusTransition).
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
+
+// ParseStatus converte e valida um status.
+func ParseStatus(value string) (Status, error) {
+	status := Status(value)
+	if !status.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidStatus, value)
+	}
+
+	return status, nil
+}
+
+// IsValidStatus verifica se o status é suportado.
+func IsValidStatus(status string) bool {
+	return Status(status).Valid()
+}
+
+// Valid verifica se o status é suportado.
+func (s Status) Valid() bool {
+	return validStatuses[s]
+}
+
+// AllowedTransitions retorna os status para os quais é possível mudar a partir deste.
+func (s Status) AllowedTransitions() []Status {
+	return append([]Status(nil), statusTransitions[s]...)
+}
+
+// CanTransitionTo indica se a mudança para target é permitida.
+func (s Status) CanTransitionTo(target Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusesTransitioningTo retorna, em ordem alfabética, os status a partir dos
+// quais a mudança para target é permitida.
+func StatusesTransitioningTo(target Status) []Status {
+	statuses := make([]Status, 0, len(statusTransitions))
+	for from := range statusTransitions {
+		if from.CanTransitionTo(target) {
+			statuses = append(statuses, from)
+		}
+	}
+
+	slices.Sort(statuses)
+
+	return statuses
+}
+
+// String retorna o status como texto.
+func (s Status) String() string {
+	return string(s)
+}
+
+// UnmarshalJSON rejeita status desconhecidos já no bind da requisição.
+// A string vazia é aceita e representa um status não informado.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: must be a string", ErrInvalidStatus)
+	}
+
+	if value == "" {
+		*s = ""
+		return nil
+	}
+
+	status, err := ParseStatus(value)
+	if err != nil {
+		return err
+	}
+
+	*s = status
+
+	return nil
+}
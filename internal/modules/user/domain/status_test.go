@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestStatusUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Status
+		wantErr error
+	}{
+		{input: `"active"`, want: StatusActive},
+		{input: `"suspended"`, want: StatusSuspended},
+		{input: `""`, want: ""},
+		{input: `"banned"`, wantErr: ErrInvalidStatus},
+		{input: `"Active"`, wantErr: ErrInvalidStatus},
+		{input: `1`, wantErr: ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got Status
+
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("Unmarshal(%s) = %q, %v; want %q, %v", tt.input, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChangeStatusFollowsTransitions(t *testing.T) {
+	at := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		from    Status
+		to      Status
+		changed bool
+		wantErr error
+	}{
+		{from: StatusPending, to: StatusActive, changed: true},
+		{from: StatusActive, to: StatusSuspended, changed: true},
+		{from: StatusSuspended, to: StatusActive, changed: true},
+		{from: StatusInactive, to: StatusActive, changed: true},
+		{from: StatusActive, to: StatusActive},
+		{from: StatusActive, to: StatusPending, wantErr: ErrInvalidStatusTransition},
+		{from: StatusSuspended, to: StatusPending, wantErr: ErrInvalidStatusTransition},
+		{from: StatusActive, to: "banned", wantErr: ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			user := &User{Status: tt.from}
+
+			changed, err := user.ChangeStatus(tt.to, at)
+			if !errors.Is(err, tt.wantErr) || changed != tt.changed {
+				t.Fatalf("ChangeStatus = %v, %v; want %v, %v", changed, err, tt.changed, tt.wantErr)
+			}
+
+			want := tt.from
+			if tt.changed {
+				want = tt.to
+			}
+
+			if user.Status != want || user.UpdatedAt.Equal(at) != tt.changed {
+				t.Errorf("Status = %s, UpdatedAt = %v; want %s", user.Status, user.UpdatedAt, want)
+			}
+		})
+	}
+}
+
+func TestStatusTransitionErrorListsAllowed(t *testing.T) {
+	user := &User{Status: StatusActive}
+
+	_, err := user.ChangeStatus(StatusPending, time.Now())
+
+	var transitionErr *StatusTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("ChangeStatus error = %v, want *StatusTransitionError", err)
+	}
+
+	want := []Status{StatusInactive, StatusSuspended}
+	if transitionErr.From != StatusActive || transitionErr.To != StatusPending || !slices.Equal(transitionErr.Allowed, want) {
+		t.Errorf("error = %+v, want allowed %v", transitionErr, want)
+	}
+
+	// Alterar a lista devolvida não altera a máquina de estados
+	transitionErr.Allowed[0] = StatusPending
+	if !slices.Equal(StatusActive.AllowedTransitions(), want) {
+		t.Errorf("AllowedTransitions = %v, want %v", StatusActive.AllowedTransitions(), want)
+	}
+}
+
+func TestStatusesTransitioningTo(t *testing.T) {
+	tests := []struct {
+		target Status
+		want   []Status
+	}{
+		{target: StatusActive, want: []Status{StatusInactive, StatusPending, StatusSuspended}},
+		{target: StatusSuspended, want: []Status{StatusActive, StatusInactive, StatusPending}},
+		{target: StatusPending, want: []Status{}},
+	}
+
+	for _, tt := range tests {
+		if got := StatusesTransitioningTo(tt.target); !slices.Equal(got, tt.want) {
+			t.Errorf("StatusesTransitioningTo(%s) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
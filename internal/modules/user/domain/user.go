@@ -1,104 +1,190 @@
 package domain
 
 import (
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/devleo-m/go-zero/internal/shared/validation"
 )
 
 // User representa um usuário no domínio.
 type User struct {
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	Phone     *string    `json:"phone,omitempty"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
-	Name      string     `json:"name"`
-	Email     string     `json:"email"`
-	Password  string     `json:"-"`
-	Role      string     `json:"role"`
-	Status    string     `json:"status"`
-	ID        uuid.UUID  `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Phone       *string    `json:"phone,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	Metadata    Metadata   `json:"metadata,omitempty"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	Password    string     `json:"-"`
+	Role        Role       `json:"role"`
+	Status      Status     `json:"status"`
+	CreatedBy   string     `json:"created_by"`
+	UpdatedBy   string     `json:"updated_by"`
+	LoginCount  int        `json:"login_count"`
+	ID          uuid.UUID  `json:"id"`
 }
 
-// NewUser cria um novo usuário.
-func NewUser(name, email, password string) (*User, error) {
+// SystemActor identifica alterações feitas sem um usuário autenticado
+// (seeds, tarefas internas e o cadastro público).
+const SystemActor = "system"
+
+// NewUser cria um novo usuário, usando o PasswordService para gerar o hash da
+// senha; now é o instante de criação.
+func NewUser(name, email, password string, passwords *PasswordService, now time.Time) (*User, error) {
 	// Validações básicas
 	if name == "" || len(name) < 2 {
 		return nil, ErrInvalidName
 	}
 
-	// Validação de email
-	email = strings.TrimSpace(email)
-	if email == "" || !strings.Contains(email, "@") || !strings.Contains(email, ".") {
+	// Validação de email (mesma regra da camada HTTP)
+	email = NormalizeEmail(email)
+	if validation.ValidateEmail(email) != nil {
 		return nil, ErrInvalidEmail
 	}
 
-	if password == "" || len(password) < 8 {
-		return nil, ErrInvalidPassword
+	passwords = orDefaultPasswordService(passwords)
+	if err := passwords.CheckPolicy(password); err != nil {
+		return nil, err
 	}
 
 	// Hash da senha
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := passwords.Hash(password)
 	if err != nil {
-		return nil, ErrPasswordHash
+		return nil, err
 	}
 
-	now := time.Now()
-
 	return &User{
 		ID:        uuid.New(),
 		Name:      name,
 		Email:     email,
-		Password:  string(hashedPassword),
-		Role:      "user",
-		Status:    "active",
+		Password:  hashedPassword,
+		Role:      RoleUser,
+		Status:    StatusActive,
+		Metadata:  Metadata{},
+		CreatedBy: SystemActor,
+		UpdatedBy: SystemActor,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil
 }
 
+// NormalizeEmail retorna a forma canônica de um email (sem espaços e em minúsculas).
+func NormalizeEmail(email string) string {
+	return validation.NormalizeEmail(email)
+}
+
 // ValidatePassword verifica se a senha está correta.
 func (u *User) ValidatePassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 }
 
-// UpdatePassword atualiza a senha do usuário.
-func (u *User) UpdatePassword(newPassword string) error {
-	if newPassword == "" || len(newPassword) < 8 {
-		return ErrInvalidPassword
+// UpdatePassword atualiza a senha do usuário no instante informado.
+func (u *User) UpdatePassword(newPassword string, passwords *PasswordService, at time.Time) error {
+	passwords = orDefaultPasswordService(passwords)
+	if err := passwords.CheckPolicy(newPassword); err != nil {
+		return err
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	hashedPassword, err := passwords.Hash(newPassword)
 	if err != nil {
-		return ErrPasswordHash
+		return err
 	}
 
-	u.Password = string(hashedPassword)
-	u.UpdatedAt = time.Now()
+	u.Password = hashedPassword
+	u.UpdatedAt = at
 
 	return nil
 }
 
-// UpdateProfile atualiza informações do perfil.
-func (u *User) UpdateProfile(name string, phone *string) error {
+// RehashPasswordIfNeeded regera o hash da senha quando ele foi criado com um
+// custo inferior ao configurado. Deve ser chamado após uma autenticação
+// bem-sucedida, quando a senha em texto puro está disponível.
+func (u *User) RehashPasswordIfNeeded(password string, passwords *PasswordService, at time.Time) (bool, error) {
+	passwords = orDefaultPasswordService(passwords)
+	if !passwords.NeedsRehash(u.Password) {
+		return false, nil
+	}
+
+	hashedPassword, err := passwords.Hash(password)
+	if err != nil {
+		return false, err
+	}
+
+	u.Password = hashedPassword
+	u.UpdatedAt = at
+
+	return true, nil
+}
+
+// UpdateProfile atualiza informações do perfil no instante informado.
+func (u *User) UpdateProfile(name string, phone *string, at time.Time) error {
 	if name == "" || len(name) < 2 {
 		return ErrInvalidName
 	}
 
 	u.Name = name
 	u.Phone = phone
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = at
 
 	return nil
 }
 
-// SoftDelete marca o usuário como deletado.
-func (u *User) SoftDelete() {
-	now := time.Now()
-	u.DeletedAt = &now
-	u.UpdatedAt = now
+// ChangeStatus muda o status do usuário seguindo as transições permitidas.
+// Pedir o status atual não é erro: nada muda e changed é false.
+func (u *User) ChangeStatus(target Status, at time.Time) (changed bool, err error) {
+	if !target.Valid() {
+		return false, ErrInvalidStatus
+	}
+
+	if u.Status == target {
+		return false, nil
+	}
+
+	if !u.Status.CanTransitionTo(target) {
+		return false, &StatusTransitionError{
+			From:    u.Status,
+			To:      target,
+			Allowed: u.Status.AllowedTransitions(),
+		}
+	}
+
+	u.Status = target
+	u.UpdatedAt = at
+
+	return true, nil
+}
+
+// ChangeRole muda o role do usuário. Quem pode pedir a mudança é verificado
+// por CanChangeRole. Pedir o role atual não é erro: nada muda e changed é false.
+func (u *User) ChangeRole(target Role, at time.Time) (changed bool, err error) {
+	if !target.Valid() {
+		return false, ErrInvalidRole
+	}
+
+	if u.Role == target {
+		return false, nil
+	}
+
+	u.Role = target
+	u.UpdatedAt = at
+
+	return true, nil
+}
+
+// RecordLogin registra um login bem-sucedido.
+func (u *User) RecordLogin(at time.Time) {
+	u.LastLoginAt = &at
+	u.LoginCount++
+}
+
+// SoftDelete marca o usuário como deletado no instante informado.
+func (u *User) SoftDelete(at time.Time) {
+	u.DeletedAt = &at
+	u.UpdatedAt = at
 }
 
 // IsDeleted verifica se o usuário foi deletado.
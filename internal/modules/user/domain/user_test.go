@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestNewUserValidatesAndNormalizesEmail(t *testing.T) {
+	now := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	passwords := NewPasswordService(bcrypt.MinCost)
+
+	tests := []struct {
+		name  string
+		email string
+		want  string
+		err   error
+	}{
+		{name: "mixed case and spaces", email: "  Ana@Example.COM ", want: "ana@example.com"},
+		{name: "quoted local part", email: `"ana maria"@example.com`, want: `"ana maria"@example.com`},
+		{name: "long tld", email: "ana@example.photography", want: "ana@example.photography"},
+		{name: "display name", email: "Ana <ana@example.com>", err: ErrInvalidEmail},
+		{name: "missing domain dot", email: "ana@localhost", err: ErrInvalidEmail},
+		{name: "double dot", email: "ana..maria@example.com", err: ErrInvalidEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("Ana", tt.email, testPassword, passwords, now)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NewUser error = %v, want %v", err, tt.err)
+			}
+
+			if err == nil && user.Email != tt.want {
+				t.Errorf("Email = %q, want %q", user.Email, tt.want)
+			}
+		})
+	}
+}
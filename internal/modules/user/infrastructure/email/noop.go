@@ -0,0 +1,32 @@
+package email
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// NoopSender não envia emails; apenas registra no log (útil em desenvolvimento).
+type NoopSender struct {
+	logger *zap.Logger
+}
+
+// NewNoopSender cria um Sender que apenas registra as mensagens.
+func NewNoopSender(logger *zap.Logger) *NoopSender {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &NoopSender{logger: logger}
+}
+
+// Send registra a mensagem no log sem enviá-la.
+func (s *NoopSender) Send(_ context.Context, message *Message) error {
+	s.logger.Info("Email not sent (noop sender)",
+		zap.String("component", "email"),
+		zap.String("to", message.To),
+		zap.String("subject", message.Subject),
+	)
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"io/fs"
+	"os"
+	"strings"
+	textTemplate "text/template"
+)
+
+// defaultTemplates contém os templates padrão embarcados no binário.
+//
+//go:embed templates/*.txt templates/*.html
+var defaultTemplates embed.FS
+
+// Nomes dos templates de email.
+const (
+	templateWelcome           = "welcome"
+	templateEmailVerification = "email_verification"
+	templatePasswordReset     = "password_reset"
+	templatePasswordChanged   = "password_changed"
+	templateAccountLocked     = "account_locked"
+	templateLoginAlert        = "login_alert"
+	templateAccountDeleted    = "account_deleted"
+)
+
+// TemplateData representa os dados disponíveis nos templates.
+type TemplateData struct {
+	AppName   string
+	Name      string
+	Email     string
+	Token     string
+	IPAddress string
+}
+
+// Message representa um email renderizado.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Renderer renderiza os templates de email (texto + HTML).
+// O template de texto deve definir o bloco "subject".
+type Renderer struct {
+	overrides fs.FS
+}
+
+// NewRenderer cria um Renderer. Quando templatesPath é informado, os arquivos
+// desse diretório substituem os templates padrão de mesmo nome.
+func NewRenderer(templatesPath string) *Renderer {
+	renderer := &Renderer{}
+	if templatesPath != "" {
+		renderer.overrides = os.DirFS(templatesPath)
+	}
+
+	return renderer
+}
+
+// Render renderiza o template informado para o destinatário.
+func (r *Renderer) Render(name, to string, data TemplateData) (*Message, error) {
+	textSource, err := r.read(name + ".txt")
+	if err != nil {
+		return nil, err
+	}
+
+	htmlSource, err := r.read(name + ".html")
+	if err != nil {
+		return nil, err
+	}
+
+	textTmpl, err := textTemplate.New(name).Parse(textSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s text template: %w", name, err)
+	}
+
+	htmlTmpl, err := htmlTemplate.New(name).Parse(htmlSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s html template: %w", name, err)
+	}
+
+	var subject, text, html bytes.Buffer
+
+	if err := textTmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+
+	if err := textTmpl.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text body: %w", name, err)
+	}
+
+	if err := htmlTmpl.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s html body: %w", name, err)
+	}
+
+	return &Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// read lê um template, priorizando o diretório de sobrescrita.
+func (r *Renderer) read(file string) (string, error) {
+	if r.overrides != nil {
+		content, err := fs.ReadFile(r.overrides, file)
+		if err == nil {
+			return string(content), nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to read template %s: %w", file, err)
+		}
+	}
+
+	content, err := defaultTemplates.ReadFile("templates/" + file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template %s: %w", file, err)
+	}
+
+	return string(content), nil
+}
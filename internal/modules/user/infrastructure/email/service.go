@@ -0,0 +1,106 @@
+package email
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/application"
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+)
+
+// Sender entrega uma mensagem já renderizada.
+type Sender interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// Service implementa application.EmailService renderizando templates e
+// delegando a entrega a um Sender (SMTP em produção, no-op em desenvolvimento).
+type Service struct {
+	sender   Sender
+	renderer *Renderer
+	logger   *zap.Logger
+	appName  string
+}
+
+// Garantir em tempo de compilação que Service implementa a interface.
+var _ application.EmailService = (*Service)(nil)
+
+// NewService cria um novo serviço de email.
+func NewService(sender Sender, renderer *Renderer, logger *zap.Logger, appName string) *Service {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &Service{
+		sender:   sender,
+		renderer: renderer,
+		logger:   logger,
+		appName:  appName,
+	}
+}
+
+// SendWelcomeEmail envia o email de boas-vindas.
+func (s *Service) SendWelcomeEmail(ctx context.Context, user *domain.User) error {
+	return s.send(ctx, templateWelcome, user, TemplateData{})
+}
+
+// SendEmailVerification envia o código de verificação de email.
+func (s *Service) SendEmailVerification(ctx context.Context, user *domain.User, token string) error {
+	return s.send(ctx, templateEmailVerification, user, TemplateData{Token: token})
+}
+
+// SendPasswordReset envia o código de redefinição de senha.
+func (s *Service) SendPasswordReset(ctx context.Context, user *domain.User, token string) error {
+	return s.send(ctx, templatePasswordReset, user, TemplateData{Token: token})
+}
+
+// SendPasswordChanged avisa que a senha foi alterada.
+func (s *Service) SendPasswordChanged(ctx context.Context, user *domain.User) error {
+	return s.send(ctx, templatePasswordChanged, user, TemplateData{})
+}
+
+// SendAccountLocked avisa que a conta foi bloqueada.
+func (s *Service) SendAccountLocked(ctx context.Context, user *domain.User) error {
+	return s.send(ctx, templateAccountLocked, user, TemplateData{})
+}
+
+// SendLoginAlert avisa sobre um novo login.
+func (s *Service) SendLoginAlert(ctx context.Context, user *domain.User, ipAddress string) error {
+	return s.send(ctx, templateLoginAlert, user, TemplateData{IPAddress: ipAddress})
+}
+
+// SendAccountDeleted confirma a exclusão da conta.
+func (s *Service) SendAccountDeleted(ctx context.Context, user *domain.User) error {
+	return s.send(ctx, templateAccountDeleted, user, TemplateData{})
+}
+
+// send renderiza o template para o usuário e entrega a mensagem.
+func (s *Service) send(ctx context.Context, name string, user *domain.User, data TemplateData) error {
+	data.AppName = s.appName
+	data.Name = user.Name
+	data.Email = user.Email
+
+	message, err := s.renderer.Render(name, user.Email, data)
+	if err != nil {
+		s.logger.Error("Failed to render email",
+			zap.String("template", name),
+			zap.Error(err),
+		)
+
+		return err
+	}
+
+	if err := s.sender.Send(ctx, message); err != nil {
+		s.logger.Error("Failed to send email",
+			zap.String("template", name),
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err),
+		)
+
+		return fmt.Errorf("failed to send %s email: %w", name, err)
+	}
+
+	return nil
+}
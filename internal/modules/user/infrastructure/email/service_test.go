@@ -0,0 +1,171 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+)
+
+// sentMail é uma mensagem recebida pelo servidor SMTP fake, já decodificada.
+type sentMail struct {
+	addr    string
+	from    string
+	to      []string
+	subject string
+	text    string
+	html    string
+}
+
+// smtpSink substitui smtp.SendMail, guardando as mensagens em vez de enviá-las.
+type smtpSink struct {
+	t    *testing.T
+	err  error
+	sent []sentMail
+}
+
+func (s *smtpSink) sendMail(addr string, _ smtp.Auth, from string, to []string, raw []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		s.t.Fatalf("invalid MIME message: %v", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		s.t.Fatalf("decode subject: %v", err)
+	}
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		s.t.Fatalf("parse content type: %v", err)
+	}
+
+	received := sentMail{addr: addr, from: from, to: to, subject: subject}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+
+	for {
+		part, err := parts.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			s.t.Fatalf("read part: %v", err)
+		}
+
+		content, _ := io.ReadAll(part)
+
+		if strings.HasPrefix(part.Header.Get("Content-Type"), "text/html") {
+			received.html = string(content)
+		} else {
+			received.text = string(content)
+		}
+	}
+
+	s.sent = append(s.sent, received)
+
+	return nil
+}
+
+// newSinkService cria um Service que entrega as mensagens ao sink.
+func newSinkService(t *testing.T, templatesPath string) (*Service, *smtpSink) {
+	t.Helper()
+
+	sink := &smtpSink{t: t}
+	sender := NewSMTPSender(SMTPConfig{Host: "smtp.example.com", Port: 2525, From: "no-reply@example.com"})
+	sender.sendMail = sink.sendMail
+
+	return NewService(sender, NewRenderer(templatesPath), nil, "Go Zero"), sink
+}
+
+func TestServiceRendersWelcomeAndPasswordReset(t *testing.T) {
+	service, sink := newSinkService(t, "")
+	user := repositorytest.NewUser("Ana <Admin>", "ana@example.com", 0)
+	ctx := context.Background()
+
+	if err := service.SendWelcomeEmail(ctx, user); err != nil {
+		t.Fatalf("SendWelcomeEmail: %v", err)
+	}
+
+	if err := service.SendPasswordReset(ctx, user, "RESET-123"); err != nil {
+		t.Fatalf("SendPasswordReset: %v", err)
+	}
+
+	if len(sink.sent) != 2 {
+		t.Fatalf("sent %d emails, want 2", len(sink.sent))
+	}
+
+	welcome, reset := sink.sent[0], sink.sent[1]
+
+	if welcome.addr != "smtp.example.com:2525" || welcome.from != "no-reply@example.com" ||
+		len(welcome.to) != 1 || welcome.to[0] != "ana@example.com" {
+		t.Errorf("welcome envelope = %s from %s to %v", welcome.addr, welcome.from, welcome.to)
+	}
+
+	if welcome.subject != "Welcome to Go Zero, Ana <Admin>!" {
+		t.Errorf("welcome subject = %q", welcome.subject)
+	}
+
+	if !strings.Contains(welcome.text, "Hi Ana <Admin>,") || !strings.Contains(welcome.text, "Welcome to Go Zero!") {
+		t.Errorf("welcome text = %q", welcome.text)
+	}
+
+	// O HTML escapa os dados do usuário
+	if !strings.Contains(welcome.html, "Hi Ana &lt;Admin&gt;,") {
+		t.Errorf("welcome html = %q", welcome.html)
+	}
+
+	if reset.subject != "Reset your password" {
+		t.Errorf("reset subject = %q", reset.subject)
+	}
+
+	if !strings.Contains(reset.text, "RESET-123") || !strings.Contains(reset.html, "<strong>RESET-123</strong>") {
+		t.Errorf("reset body does not carry the token: text %q, html %q", reset.text, reset.html)
+	}
+}
+
+func TestServiceUsesTemplateOverrides(t *testing.T) {
+	dir := t.TempDir()
+
+	override := `{{define "subject"}}Bem-vindo, {{.Name}}{{end}}Olá {{.Name}}`
+	if err := os.WriteFile(filepath.Join(dir, "welcome.txt"), []byte(override), 0o600); err != nil {
+		t.Fatalf("write override: %v", err)
+	}
+
+	service, sink := newSinkService(t, dir)
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+
+	if err := service.SendWelcomeEmail(context.Background(), user); err != nil {
+		t.Fatalf("SendWelcomeEmail: %v", err)
+	}
+
+	sent := sink.sent[0]
+
+	// O texto vem da sobrescrita; o HTML, sem arquivo no diretório, usa o padrão
+	if sent.subject != "Bem-vindo, Ana" || sent.text != "Olá Ana" || !strings.Contains(sent.html, "Welcome to Go Zero!") {
+		t.Errorf("sent = %+v", sent)
+	}
+}
+
+func TestServiceReturnsSendErrors(t *testing.T) {
+	service, sink := newSinkService(t, "")
+	sink.err = errors.New("connection refused")
+
+	err := service.SendPasswordChanged(context.Background(), repositorytest.NewUser("Ana", "ana@example.com", 0))
+	if !errors.Is(err, sink.err) {
+		t.Errorf("SendPasswordChanged = %v, want %v", err, sink.err)
+	}
+}
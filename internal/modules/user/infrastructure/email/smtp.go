@@ -0,0 +1,102 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+)
+
+// SMTPConfig representa a configuração do servidor SMTP.
+type SMTPConfig struct {
+	Host     string
+	User     string
+	Password string
+	From     string
+	Port     int
+}
+
+// SMTPSender entrega mensagens via SMTP.
+type SMTPSender struct {
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+	config   SMTPConfig
+}
+
+// NewSMTPSender cria um Sender SMTP.
+func NewSMTPSender(config SMTPConfig) *SMTPSender {
+	return &SMTPSender{
+		sendMail: smtp.SendMail,
+		config:   config,
+	}
+}
+
+// Send envia a mensagem como multipart/alternative (texto + HTML).
+func (s *SMTPSender) Send(ctx context.Context, message *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := buildMIME(s.config.From, message)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.config.User != "" {
+		auth = smtp.PlainAuth("", s.config.User, s.config.Password, s.config.Host)
+	}
+
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+
+	if err := s.sendMail(addr, auth, s.config.From, []string{message.To}, body); err != nil {
+		return fmt.Errorf("smtp send failed: %w", err)
+	}
+
+	return nil
+}
+
+// buildMIME monta a mensagem MIME com as partes de texto e HTML.
+func buildMIME(from string, message *Message) ([]byte, error) {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=UTF-8", content: message.Text},
+		{contentType: "text/html; charset=UTF-8", content: message.HTML},
+	}
+
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build email body: %w", err)
+		}
+
+		if _, err := partWriter.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to build email body: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email body: %w", err)
+	}
+
+	var msg bytes.Buffer
+
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", message.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,729 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/application"
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/pagination"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+	"github.com/devleo-m/go-zero/internal/shared/response"
+	"github.com/devleo-m/go-zero/internal/shared/validation"
+)
+
+// AdminHandler gerencia as rotas HTTP administrativas de usuários.
+type AdminHandler struct {
+	listInactiveUsersUseCase *application.ListInactiveUsersUseCase
+	bulkUpdateStatusUseCase  *application.BulkUpdateStatusUseCase
+	bulkUpdateRoleUseCase    *application.BulkUpdateRoleUseCase
+	bulkDeleteUsersUseCase   *application.BulkDeleteUsersUseCase
+	listByEmailDomainUseCase *application.ListUsersByEmailDomainUseCase
+	mergeUsersUseCase        *application.MergeUsersUseCase
+	setUserStatusUseCase     *application.SetUserStatusUseCase
+	resendEmailUseCase       *application.ResendEmailUseCase
+	impersonateUserUseCase   *application.ImpersonateUserUseCase
+	filterPresetUseCase      *application.FilterPresetUseCase
+	changeRoleUseCase        *application.ChangeRoleUseCase
+}
+
+// NewAdminHandler cria uma nova instância do handler administrativo.
+func NewAdminHandler(
+	listInactiveUsersUseCase *application.ListInactiveUsersUseCase,
+	bulkUpdateStatusUseCase *application.BulkUpdateStatusUseCase,
+	bulkUpdateRoleUseCase *application.BulkUpdateRoleUseCase,
+	bulkDeleteUsersUseCase *application.BulkDeleteUsersUseCase,
+	listByEmailDomainUseCase *application.ListUsersByEmailDomainUseCase,
+	mergeUsersUseCase *application.MergeUsersUseCase,
+	setUserStatusUseCase *application.SetUserStatusUseCase,
+	resendEmailUseCase *application.ResendEmailUseCase,
+	impersonateUserUseCase *application.ImpersonateUserUseCase,
+	filterPresetUseCase *application.FilterPresetUseCase,
+	changeRoleUseCase *application.ChangeRoleUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		listInactiveUsersUseCase: listInactiveUsersUseCase,
+		bulkUpdateStatusUseCase:  bulkUpdateStatusUseCase,
+		bulkUpdateRoleUseCase:    bulkUpdateRoleUseCase,
+		bulkDeleteUsersUseCase:   bulkDeleteUsersUseCase,
+		listByEmailDomainUseCase: listByEmailDomainUseCase,
+		mergeUsersUseCase:        mergeUsersUseCase,
+		setUserStatusUseCase:     setUserStatusUseCase,
+		resendEmailUseCase:       resendEmailUseCase,
+		impersonateUserUseCase:   impersonateUserUseCase,
+		filterPresetUseCase:      filterPresetUseCase,
+		changeRoleUseCase:        changeRoleUseCase,
+	}
+}
+
+// ListInactiveUsers lista usuários sem login há N dias ou que nunca fizeram login.
+func (h *AdminHandler) ListInactiveUsers(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		response.BadRequest(c, "INVALID_DAYS", "days must be a non-negative integer")
+		return
+	}
+
+	params := pagination.ParseFromQuery(c)
+
+	input := application.ListInactiveUsersInput{
+		Days:     days,
+		Page:     params.Page,
+		PageSize: params.Limit,
+	}
+
+	result, err := h.listInactiveUsersUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		if errors.Is(err, pagination.ErrPageTooDeep) {
+			respondPageTooDeep(c)
+			return
+		}
+
+		internalError(c, "LIST_INACTIVE_USERS_FAILED", err)
+
+		return
+	}
+
+	page := pageOrEmpty(result.Result, params.Limit)
+
+	users := make([]AdminUserResponse, len(page.Items))
+	for i, user := range page.Items {
+		users[i] = toAdminUserResponse(user)
+	}
+
+	meta := pageMeta(page).WithRequested(params.RequestedPage, params.RequestedLimit)
+
+	response.Paginated(c, map[string]interface{}{
+		"users": users,
+	}, meta)
+}
+
+// ListUsersByEmailDomain lista os usuários de um domínio de email (?email_domain=example.com).
+func (h *AdminHandler) ListUsersByEmailDomain(c *gin.Context) {
+	emailDomain := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(c.Query("email_domain"))), "@")
+	if err := validation.ValidateDomain(emailDomain); err != nil {
+		response.BadRequest(c, "INVALID_EMAIL_DOMAIN", err.Error())
+		return
+	}
+
+	params := pagination.ParseFromQuery(c)
+
+	input := application.ListUsersByEmailDomainInput{
+		EmailDomain: emailDomain,
+		Page:        params.Page,
+		PageSize:    params.Limit,
+	}
+
+	result, err := h.listByEmailDomainUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		if errors.Is(err, pagination.ErrPageTooDeep) {
+			respondPageTooDeep(c)
+			return
+		}
+
+		internalError(c, "LIST_USERS_BY_EMAIL_DOMAIN_FAILED", err)
+
+		return
+	}
+
+	page := pageOrEmpty(result.Result, params.Limit)
+
+	users := make([]AdminUserResponse, len(page.Items))
+	for i, user := range page.Items {
+		users[i] = toAdminUserResponse(user)
+	}
+
+	meta := pageMeta(page).WithRequested(params.RequestedPage, params.RequestedLimit)
+
+	response.Paginated(c, map[string]interface{}{
+		"users": users,
+	}, meta)
+}
+
+// BulkUpdateStatus altera o status de todos os usuários que satisfazem o filtro.
+func (h *AdminHandler) BulkUpdateStatus(c *gin.Context) {
+	var req BulkUpdateStatusRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	filter, ok := parseBulkFilter(c, req.BulkFilterRequest)
+	if !ok {
+		return
+	}
+
+	input := application.BulkUpdateStatusInput{
+		BulkFilter:   filter,
+		TargetStatus: req.TargetStatus,
+		DryRun:       isDryRun(c, req.BulkFilterRequest),
+	}
+
+	output, err := h.bulkUpdateStatusUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		respondBulkError(c, "BULK_UPDATE_STATUS_FAILED", err)
+		return
+	}
+
+	if output.DryRun {
+		response.Success(c, output, "Dry run: no users were updated")
+		return
+	}
+
+	response.Success(c, output, "Users status updated successfully")
+}
+
+// PreviewBulkUpdateRole lista, sem aplicar, a mudança de role (atual -> novo)
+// de cada usuário que BulkUpdateRole alteraria.
+func (h *AdminHandler) PreviewBulkUpdateRole(c *gin.Context) {
+	input, _, ok := bindBulkUpdateRole(c)
+	if !ok {
+		return
+	}
+
+	h.previewBulkUpdateRole(c, input)
+}
+
+// BulkUpdateRole altera o role de todos os usuários que satisfazem o filtro,
+// em uma transação. Com dry_run, responde a prévia das mudanças.
+func (h *AdminHandler) BulkUpdateRole(c *gin.Context) {
+	input, dryRun, ok := bindBulkUpdateRole(c)
+	if !ok {
+		return
+	}
+
+	if dryRun {
+		h.previewBulkUpdateRole(c, input)
+		return
+	}
+
+	output, err := h.bulkUpdateRoleUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		respondBulkError(c, "BULK_UPDATE_ROLE_FAILED", err)
+		return
+	}
+
+	response.Success(c, output, "Users role updated successfully")
+}
+
+// previewBulkUpdateRole responde a prévia da alteração de role em massa.
+func (h *AdminHandler) previewBulkUpdateRole(c *gin.Context, input application.BulkUpdateRoleInput) {
+	preview, err := h.bulkUpdateRoleUseCase.Preview(c.Request.Context(), input)
+	if err != nil {
+		respondBulkError(c, "PREVIEW_BULK_UPDATE_ROLE_FAILED", err)
+		return
+	}
+
+	response.Success(c, preview, "Preview: no users were updated")
+}
+
+// bindBulkUpdateRole lê a requisição de alteração de role em massa,
+// respondendo o erro quando ela é inválida.
+func bindBulkUpdateRole(c *gin.Context) (input application.BulkUpdateRoleInput, dryRun, ok bool) {
+	var req BulkUpdateRoleRequest
+	if !bindJSON(c, &req) {
+		return input, false, false
+	}
+
+	filter, ok := parseBulkFilter(c, req.BulkFilterRequest)
+	if !ok {
+		return input, false, false
+	}
+
+	input = application.BulkUpdateRoleInput{
+		TargetRole: req.TargetRole,
+		BulkFilter: filter,
+	}
+
+	return input, isDryRun(c, req.BulkFilterRequest), true
+}
+
+// BulkDeleteUsers deleta (soft delete) todos os usuários que satisfazem o filtro.
+func (h *AdminHandler) BulkDeleteUsers(c *gin.Context) {
+	var req BulkDeleteUsersRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	filter, ok := parseBulkFilter(c, req.BulkFilterRequest)
+	if !ok {
+		return
+	}
+
+	input := application.BulkDeleteUsersInput{
+		BulkFilter: filter,
+		DryRun:     isDryRun(c, req.BulkFilterRequest),
+	}
+
+	output, err := h.bulkDeleteUsersUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		respondBulkError(c, "BULK_DELETE_USERS_FAILED", err)
+		return
+	}
+
+	if output.DryRun {
+		response.Success(c, output, "Dry run: no users were deleted")
+		return
+	}
+
+	response.Success(c, output, "Users deleted successfully")
+}
+
+// MergeUsers mescla o usuário da rota (origem) no usuário informado em target_id.
+func (h *AdminHandler) MergeUsers(c *gin.Context) {
+	sourceID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req MergeUsersRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	targetID, err := uuid.Parse(req.TargetID)
+	if err != nil {
+		response.BadRequest(c, "INVALID_ID", "Invalid target user ID")
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	input := application.MergeUsersInput{
+		SourceID: sourceID,
+		TargetID: targetID,
+		DryRun:   req.DryRun || dryRun,
+	}
+
+	output, err := h.mergeUsersUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSelfMerge):
+			respondRejected(c, "SELF_MERGE", err.Error(), err)
+		case errors.Is(err, domain.ErrRoleChangeForbidden):
+			response.Forbidden(c, "MERGE_FORBIDDEN", err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.NotFound(c, "USER_NOT_FOUND", "User not found")
+		default:
+			internalError(c, "MERGE_USERS_FAILED", err)
+		}
+
+		return
+	}
+
+	data := MergeUsersResponse{
+		Target:               toAdminUserResponse(output.Target),
+		PasswordHistoryMoved: output.PasswordHistoryMoved,
+		SourceID:             output.SourceID,
+		TargetID:             output.TargetID,
+		DryRun:               output.DryRun,
+	}
+
+	if output.DryRun {
+		response.Success(c, data, "Dry run: no users were merged")
+		return
+	}
+
+	response.Success(c, data, "Users merged successfully")
+}
+
+// parseBulkFilter converte a requisição em application.BulkFilter,
+// respondendo com erro quando algum ID é inválido.
+func parseBulkFilter(c *gin.Context, req BulkFilterRequest) (application.BulkFilter, bool) {
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+
+	for _, rawID := range req.IDs {
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			response.BadRequest(c, "INVALID_ID", "Invalid user ID: "+rawID)
+			return application.BulkFilter{}, false
+		}
+
+		ids = append(ids, id)
+	}
+
+	return application.BulkFilter{
+		IDs:         ids,
+		Role:        req.Role,
+		Status:      req.Status,
+		EmailDomain: req.EmailDomain,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}, true
+}
+
+// isDryRun indica se a operação em massa deve apenas simular a alteração.
+func isDryRun(c *gin.Context, req BulkFilterRequest) bool {
+	if req.DryRun {
+		return true
+	}
+
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	return dryRun
+}
+
+// SetUserStatus define o status de um usuário seguindo as transições permitidas.
+// Transições recusadas retornam 422 com os status permitidos a partir do atual;
+// alvos que o administrador autenticado não pode gerenciar, 403.
+func (h *AdminHandler) SetUserStatus(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req SetUserStatusRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	output, err := h.setUserStatusUseCase.Execute(c.Request.Context(), application.SetUserStatusInput{
+		UserID: id,
+		Status: req.Status,
+	})
+	if err != nil {
+		var transitionErr *domain.StatusTransitionError
+
+		switch {
+		case errors.As(err, &transitionErr):
+			response.UnprocessableEntity(c, "INVALID_STATUS_TRANSITION", err.Error(), StatusTransitionErrorData{
+				From:    transitionErr.From,
+				To:      transitionErr.To,
+				Allowed: transitionErr.Allowed,
+			})
+		case errors.Is(err, domain.ErrInvalidStatus):
+			response.BadRequest(c, "INVALID_STATUS", err.Error())
+		case errors.Is(err, domain.ErrRoleChangeForbidden):
+			response.Forbidden(c, "STATUS_CHANGE_FORBIDDEN", err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.NotFound(c, "USER_NOT_FOUND", "User not found")
+		default:
+			internalError(c, "SET_USER_STATUS_FAILED", err)
+		}
+
+		return
+	}
+
+	data := SetUserStatusResponse{
+		User:           toAdminUserResponse(output.User),
+		PreviousStatus: output.PreviousStatus,
+		Changed:        output.Changed,
+	}
+
+	if !output.Changed {
+		response.Success(c, data, "User already has this status")
+		return
+	}
+
+	response.Success(c, data, "User status updated successfully")
+}
+
+// ChangeRole muda o role de um usuário. O administrador autenticado não pode
+// mudar o próprio role nem conceder um role igual ou superior ao seu.
+func (h *AdminHandler) ChangeRole(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req ChangeRoleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	output, err := h.changeRoleUseCase.Execute(c.Request.Context(), application.ChangeRoleInput{
+		UserID: id,
+		Role:   req.Role,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRole):
+			response.BadRequest(c, "INVALID_ROLE", err.Error())
+		case errors.Is(err, domain.ErrSelfRoleChange):
+			response.UnprocessableEntity(c, "SELF_ROLE_CHANGE", "You cannot change your own role", nil)
+		case errors.Is(err, domain.ErrRoleChangeForbidden):
+			response.Forbidden(c, "ROLE_CHANGE_FORBIDDEN", err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.NotFound(c, "USER_NOT_FOUND", "User not found")
+		default:
+			internalError(c, "CHANGE_ROLE_FAILED", err)
+		}
+
+		return
+	}
+
+	data := ChangeRoleResponse{
+		User:         toAdminUserResponse(output.User),
+		PreviousRole: output.PreviousRole,
+		Changed:      output.Changed,
+	}
+
+	if !output.Changed {
+		response.Success(c, data, "User already has this role")
+		return
+	}
+
+	response.Success(c, data, "User role updated successfully")
+}
+
+// ResendEmail reenvia ao usuário o email indicado em ?type= (welcome ou activation).
+func (h *AdminHandler) ResendEmail(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	emailType, err := application.ParseEmailType(c.Query("type"))
+	if err != nil {
+		response.BadRequest(c, "INVALID_EMAIL_TYPE", err.Error())
+		return
+	}
+
+	output, err := h.resendEmailUseCase.Execute(c.Request.Context(), application.ResendEmailInput{
+		UserID: id,
+		Type:   emailType,
+	})
+	if err != nil {
+		var limitErr *application.ResendRateLimitedError
+
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.NotFound(c, "USER_NOT_FOUND", "User not found")
+		case errors.Is(err, domain.ErrRoleChangeForbidden):
+			response.Forbidden(c, "RESEND_EMAIL_FORBIDDEN", err.Error())
+		case errors.Is(err, application.ErrEmailNotApplicable):
+			response.Conflict(c, "EMAIL_NOT_APPLICABLE", err.Error())
+		case errors.Is(err, application.ErrEmailTypeUnavailable):
+			response.UnprocessableEntity(c, "EMAIL_TYPE_UNAVAILABLE", err.Error(), nil)
+		case errors.As(err, &limitErr):
+			c.Header("Retry-After", strconv.Itoa(max(1, int(math.Ceil(limitErr.RetryAfter.Seconds())))))
+			response.TooManyRequests(c, "RESEND_RATE_LIMITED", err.Error())
+		default:
+			internalError(c, "RESEND_EMAIL_FAILED", err)
+		}
+
+		return
+	}
+
+	response.Success(c, output, "Email resent successfully")
+}
+
+// Impersonate emite um token de curta duração para o administrador autenticado
+// agir como o usuário do parâmetro :id. O token não pode ser renovado.
+func (h *AdminHandler) Impersonate(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.impersonateUserUseCase.Execute(c.Request.Context(), application.ImpersonateUserInput{UserID: id})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.NotFound(c, "USER_NOT_FOUND", "User not found")
+		case errors.Is(err, application.ErrImpersonatorRequired):
+			response.Unauthorized(c, "AUTHENTICATION_REQUIRED", "Authentication is required")
+		case errors.Is(err, application.ErrSelfImpersonation):
+			// Regra de negócio da personificação, como as do domínio
+			response.UnprocessableEntity(c, "SELF_IMPERSONATION", err.Error(), nil)
+		case errors.Is(err, application.ErrNestedImpersonation):
+			response.Forbidden(c, "NESTED_IMPERSONATION", err.Error())
+		case errors.Is(err, application.ErrAdminImpersonation):
+			response.Forbidden(c, "ADMIN_IMPERSONATION_FORBIDDEN", err.Error())
+		default:
+			internalError(c, "IMPERSONATION_FAILED", err)
+		}
+
+		return
+	}
+
+	response.Success(c, output, "Impersonation token issued")
+}
+
+// ListFilterPresets lista os presets de filtro do administrador autenticado.
+func (h *AdminHandler) ListFilterPresets(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	presets, err := h.filterPresetUseCase.List(c.Request.Context(), adminID)
+	if err != nil {
+		internalError(c, "LIST_FILTER_PRESETS_FAILED", err)
+		return
+	}
+
+	items := make([]FilterPresetResponse, len(presets))
+	for i, preset := range presets {
+		items[i] = toFilterPresetResponse(preset)
+	}
+
+	response.Success(c, gin.H{"presets": items})
+}
+
+// GetFilterPreset retorna o preset :name do administrador autenticado.
+func (h *AdminHandler) GetFilterPreset(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	preset, err := h.filterPresetUseCase.Get(c.Request.Context(), adminID, c.Param("name"))
+	if err != nil {
+		respondFilterPresetError(c, "GET_FILTER_PRESET_FAILED", err)
+		return
+	}
+
+	response.Success(c, toFilterPresetResponse(preset))
+}
+
+// SaveFilterPreset cria o preset :name do administrador autenticado ou
+// substitui os critérios do existente.
+func (h *AdminHandler) SaveFilterPreset(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req SaveFilterPresetRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	output, err := h.filterPresetUseCase.Save(c.Request.Context(), application.SaveFilterPresetInput{
+		AdminID: adminID,
+		Name:    c.Param("name"),
+		Criteria: domain.FilterCriteria{
+			CreatedFrom: req.CreatedFrom,
+			CreatedTo:   req.CreatedTo,
+			Search:      req.Search,
+			Roles:       req.Roles,
+			Statuses:    req.Statuses,
+		},
+	})
+	if err != nil {
+		respondFilterPresetError(c, "SAVE_FILTER_PRESET_FAILED", err)
+		return
+	}
+
+	if output.Created {
+		response.Created(c, toFilterPresetResponse(output.Preset), "Filter preset created successfully")
+		return
+	}
+
+	response.Success(c, toFilterPresetResponse(output.Preset), "Filter preset updated successfully")
+}
+
+// DeleteFilterPreset remove o preset :name do administrador autenticado.
+func (h *AdminHandler) DeleteFilterPreset(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.filterPresetUseCase.Delete(c.Request.Context(), adminID, c.Param("name")); err != nil {
+		respondFilterPresetError(c, "DELETE_FILTER_PRESET_FAILED", err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// ApplyFilterPreset lista os usuários que satisfazem o preset :name do
+// administrador autenticado, paginados como a listagem de usuários.
+func (h *AdminHandler) ApplyFilterPreset(c *gin.Context) {
+	adminID, ok := adminIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	params := pagination.ParseFromQuery(c)
+
+	result, err := h.filterPresetUseCase.Apply(c.Request.Context(), application.ApplyFilterPresetInput{
+		AdminID: adminID,
+		Name:    c.Param("name"),
+		Limit:   params.Limit,
+		Offset:  params.Offset(),
+	})
+	if err != nil {
+		respondFilterPresetError(c, "APPLY_FILTER_PRESET_FAILED", err)
+		return
+	}
+
+	page := pageOrEmpty(result.Result, params.Limit)
+
+	users := make([]AdminUserResponse, len(page.Items))
+	for i, user := range page.Items {
+		users[i] = toAdminUserResponse(user)
+	}
+
+	meta := pageMeta(page).WithRequested(params.RequestedPage, params.RequestedLimit)
+
+	response.Paginated(c, map[string]interface{}{
+		"users": users,
+	}, meta)
+}
+
+// adminIDFromContext retorna o ID do administrador autenticado, dono dos presets.
+func adminIDFromContext(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(requestctx.Actor(c.Request.Context()))
+	if err != nil {
+		response.Unauthorized(c, "AUTHENTICATION_REQUIRED", "Authentication is required")
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// respondFilterPresetError responde com o erro adequado para as operações de presets.
+func respondFilterPresetError(c *gin.Context, errorCode string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrFilterPresetNotFound):
+		response.NotFound(c, "FILTER_PRESET_NOT_FOUND", "Filter preset not found")
+	case errors.Is(err, domain.ErrInvalidFilterPreset):
+		response.BadRequest(c, "INVALID_FILTER_PRESET", err.Error())
+	case errors.Is(err, domain.ErrInvalidFilter):
+		response.BadRequest(c, "INVALID_FILTER", err.Error())
+	case errors.Is(err, domain.ErrInvalidRole):
+		response.BadRequest(c, "INVALID_ROLE", err.Error())
+	case errors.Is(err, domain.ErrInvalidStatus):
+		response.BadRequest(c, "INVALID_STATUS", err.Error())
+	case errors.Is(err, pagination.ErrPageTooDeep):
+		respondPageTooDeep(c)
+	default:
+		internalError(c, errorCode, err)
+	}
+}
+
+// toFilterPresetResponse converte domain.FilterPreset para FilterPresetResponse.
+func toFilterPresetResponse(preset *domain.FilterPreset) FilterPresetResponse {
+	return FilterPresetResponse{
+		Name:      preset.Name,
+		Criteria:  preset.Criteria,
+		CreatedAt: clock.UTC(preset.CreatedAt),
+		UpdatedAt: clock.UTC(preset.UpdatedAt),
+	}
+}
+
+// respondBulkError responde com o erro adequado para as operações em massa.
+func respondBulkError(c *gin.Context, errorCode string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidStatus):
+		response.BadRequest(c, "INVALID_STATUS", err.Error())
+	case errors.Is(err, domain.ErrInvalidRole):
+		response.BadRequest(c, "INVALID_ROLE", err.Error())
+	case errors.Is(err, domain.ErrEmptyBulkFilter):
+		response.BadRequest(c, "EMPTY_FILTER", err.Error())
+	case errors.Is(err, domain.ErrInvalidFilter):
+		response.BadRequest(c, "INVALID_FILTER", err.Error())
+	case errors.Is(err, domain.ErrRoleChangeForbidden):
+		response.Forbidden(c, "ROLE_CHANGE_FORBIDDEN", err.Error())
+	default:
+		internalError(c, errorCode, err)
+	}
+}
@@ -0,0 +1,641 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/application"
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/pagination"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+)
+
+// actingAs simula o AuthMiddleware com user autenticado.
+func actingAs(user *domain.User) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(requestctx.WithActor(c.Request.Context(), user.ID.String()))
+	}
+}
+
+// seedAdmin cria no repositório o administrador que faz as requisições.
+func seedAdmin(t *testing.T, repo domain.Repository) *domain.User {
+	t.Helper()
+
+	admin := repositorytest.NewUser("Admin", "admin@example.com", 9)
+	admin.Role = domain.RoleAdmin
+	repositorytest.Seed(t, repo, admin)
+
+	return admin
+}
+
+func TestListUsersByEmailDomainMatchesExactDomain(t *testing.T) {
+	repo := memory.NewRepository()
+	repositorytest.Seed(t, repo,
+		repositorytest.NewUser("Ana", "ana@example.com", 0),
+		repositorytest.NewUser("Bruno", "bruno@notexample.com", 1),
+		repositorytest.NewUser("Carla", "carla@sub.example.com", 2),
+		repositorytest.NewUser("Davi", "davi@example.com.br", 3),
+		repositorytest.NewUser("Eva", "eva@EXAMPLE.com", 4),
+	)
+
+	handler := &AdminHandler{listByEmailDomainUseCase: application.NewListUsersByEmailDomainUseCase(repo)}
+
+	router := gin.New()
+	router.GET("/admin/users", handler.ListUsersByEmailDomain)
+
+	tests := []struct {
+		name     string
+		query    string
+		want     []string
+		wantCode int
+	}{
+		{name: "exact domain", query: "email_domain=example.com", want: []string{"ana@example.com", "eva@example.com"}},
+		{name: "case and at sign", query: "email_domain=@Example.COM", want: []string{"ana@example.com", "eva@example.com"}},
+		{name: "subdomain only", query: "email_domain=sub.example.com", want: []string{"carla@sub.example.com"}},
+		{name: "no match", query: "email_domain=ample.com", want: []string{}},
+		{name: "missing domain", wantCode: http.StatusBadRequest},
+		{name: "invalid domain", query: "email_domain=%25example", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodGet, "/admin/users?"+tt.query, "")
+
+			wantCode := tt.wantCode
+			if wantCode == 0 {
+				wantCode = http.StatusOK
+			}
+
+			if rec.Code != wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, wantCode, rec.Body.String())
+			}
+
+			var body struct {
+				Data struct {
+					Users []struct {
+						Email string `json:"email"`
+					} `json:"users"`
+				} `json:"data"`
+				errorBody
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if tt.wantCode != 0 {
+				if body.Error != "INVALID_EMAIL_DOMAIN" {
+					t.Errorf("error = %s, want INVALID_EMAIL_DOMAIN", body.Error)
+				}
+
+				return
+			}
+
+			got := make([]string, len(body.Data.Users))
+			for i, user := range body.Data.Users {
+				got[i] = user.Email
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("users = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEndpointsClampOverLimitRequests(t *testing.T) {
+	repo := memory.NewRepository()
+	repositorytest.Seed(t, repo,
+		repositorytest.NewUser("Ana", "ana@example.com", 0),
+		repositorytest.NewUser("Bruno", "bruno@example.com", 1),
+	)
+
+	handler := &Handler{listUsersUseCase: application.NewListUsersUseCase(repo)}
+	admin := &AdminHandler{
+		listInactiveUsersUseCase: application.NewListInactiveUsersUseCase(repo),
+		listByEmailDomainUseCase: application.NewListUsersByEmailDomainUseCase(repo),
+	}
+
+	router := gin.New()
+	router.GET("/users", handler.ListUsers)
+	router.GET("/admin/users/inactive", admin.ListInactiveUsers)
+	router.GET("/admin/users", admin.ListUsersByEmailDomain)
+
+	endpoints := []string{"/users?", "/admin/users/inactive?", "/admin/users?email_domain=example.com&"}
+
+	tests := []struct {
+		name          string
+		query         string
+		wantLimit     int
+		wantRequested int
+		wantAdjusted  bool
+	}{
+		{name: "over the maximum", query: "limit=500", wantLimit: 100, wantRequested: 500, wantAdjusted: true},
+		{name: "below one", query: "limit=0", wantLimit: 10, wantAdjusted: true},
+		{name: "within bounds", query: "limit=50", wantLimit: 50},
+	}
+
+	for _, endpoint := range endpoints {
+		for _, tt := range tests {
+			t.Run(endpoint+tt.name, func(t *testing.T) {
+				rec := serveJSON(router, http.MethodGet, endpoint+tt.query, "")
+
+				// Limites fora do intervalo são ajustados, nunca rejeitados
+				if rec.Code != http.StatusOK {
+					t.Fatalf("status = %d, want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+				}
+
+				var body struct {
+					Meta struct {
+						Limit          int  `json:"limit"`
+						RequestedLimit int  `json:"requested_limit"`
+						Adjusted       bool `json:"adjusted"`
+						Total          int  `json:"total"`
+					} `json:"meta"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("body is not JSON: %v", err)
+				}
+
+				meta := body.Meta
+				if meta.Limit != tt.wantLimit || meta.Adjusted != tt.wantAdjusted || meta.Total != 2 {
+					t.Errorf("meta = %+v, want limit %d adjusted %v total 2", meta, tt.wantLimit, tt.wantAdjusted)
+				}
+
+				if tt.wantRequested != 0 && meta.RequestedLimit != tt.wantRequested {
+					t.Errorf("requested_limit = %d, want %d", meta.RequestedLimit, tt.wantRequested)
+				}
+			})
+		}
+	}
+}
+
+func TestListEndpointsShareConfiguredDefault(t *testing.T) {
+	pagination.Configure(2, 3)
+	t.Cleanup(func() { pagination.Configure(pagination.FallbackDefaultLimit, pagination.FallbackMaxLimit) })
+
+	repo := memory.NewRepository()
+	repositorytest.Seed(t, repo,
+		repositorytest.NewUser("Ana", "ana@example.com", 0),
+		repositorytest.NewUser("Bruno", "bruno@example.com", 1),
+		repositorytest.NewUser("Carla", "carla@example.com", 2),
+		repositorytest.NewUser("Davi", "davi@example.com", 3),
+	)
+
+	handler := &Handler{listUsersUseCase: application.NewListUsersUseCase(repo)}
+	admin := &AdminHandler{
+		listInactiveUsersUseCase: application.NewListInactiveUsersUseCase(repo),
+		listByEmailDomainUseCase: application.NewListUsersByEmailDomainUseCase(repo),
+	}
+
+	router := gin.New()
+	router.GET("/users", handler.ListUsers)
+	router.GET("/admin/users/inactive", admin.ListInactiveUsers)
+	router.GET("/admin/users", admin.ListUsersByEmailDomain)
+
+	endpoints := []string{"/users?", "/admin/users/inactive?", "/admin/users?email_domain=example.com&"}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantLimit int
+	}{
+		{name: "configured default", wantLimit: 2},
+		{name: "configured max", query: "limit=10", wantLimit: 3},
+	}
+
+	for _, endpoint := range endpoints {
+		for _, tt := range tests {
+			t.Run(endpoint+tt.name, func(t *testing.T) {
+				rec := serveJSON(router, http.MethodGet, endpoint+tt.query, "")
+				if rec.Code != http.StatusOK {
+					t.Fatalf("status = %d, want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+				}
+
+				var body struct {
+					Data struct {
+						Users []json.RawMessage `json:"users"`
+					} `json:"data"`
+					Meta struct {
+						Limit int `json:"limit"`
+					} `json:"meta"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("body is not JSON: %v", err)
+				}
+
+				if body.Meta.Limit != tt.wantLimit || len(body.Data.Users) != tt.wantLimit {
+					t.Errorf("limit = %d with %d users, want %d", body.Meta.Limit, len(body.Data.Users), tt.wantLimit)
+				}
+			})
+		}
+	}
+}
+
+func TestAdminHandlersRejectUnknownEnumsAtBind(t *testing.T) {
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, repo, user)
+
+	admin := &AdminHandler{
+		setUserStatusUseCase: application.NewSetUserStatusUseCase(repo),
+		changeRoleUseCase:    application.NewChangeRoleUseCase(repo),
+	}
+
+	router := gin.New()
+	router.PATCH("/admin/users/:id/status", admin.SetUserStatus)
+	router.PATCH("/admin/users/:id/role", admin.ChangeRole)
+
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		wantErr string
+	}{
+		{name: "unknown status", path: "/status", body: `{"status":"banned"}`, wantErr: "INVALID_STATUS"},
+		{name: "status is not a string", path: "/status", body: `{"status":true}`, wantErr: "INVALID_STATUS"},
+		{name: "unknown role", path: "/role", body: `{"role":"root"}`, wantErr: "INVALID_ROLE"},
+		{name: "role in another case", path: "/role", body: `{"role":"Admin"}`, wantErr: "INVALID_ROLE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodPatch, "/admin/users/"+user.ID.String()+tt.path, tt.body)
+
+			var body errorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if rec.Code != http.StatusBadRequest || body.Error != tt.wantErr {
+				t.Errorf("got %d %s, want %d %s", rec.Code, body.Error, http.StatusBadRequest, tt.wantErr)
+			}
+		})
+	}
+
+	stored, err := repo.GetByID(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Status != user.Status || stored.Role != user.Role {
+		t.Errorf("user changed to %s/%s", stored.Status, stored.Role)
+	}
+}
+
+func TestSetUserStatusEndpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        domain.Status
+		body        string
+		wantCode    int
+		wantErr     string
+		wantStatus  domain.Status
+		wantAllowed []domain.Status
+	}{
+		{name: "suspended to active", from: domain.StatusSuspended, body: `{"status":"active"}`, wantCode: http.StatusOK, wantStatus: domain.StatusActive},
+		{name: "same status", from: domain.StatusActive, body: `{"status":"active"}`, wantCode: http.StatusOK, wantStatus: domain.StatusActive},
+		{
+			name:        "back to pending",
+			from:        domain.StatusActive,
+			body:        `{"status":"pending"}`,
+			wantCode:    http.StatusUnprocessableEntity,
+			wantErr:     "INVALID_STATUS_TRANSITION",
+			wantStatus:  domain.StatusActive,
+			wantAllowed: []domain.Status{domain.StatusInactive, domain.StatusSuspended},
+		},
+		{name: "missing status", from: domain.StatusActive, body: `{}`, wantCode: http.StatusBadRequest, wantStatus: domain.StatusActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewRepository()
+			user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+			user.Status = tt.from
+			repositorytest.Seed(t, repo, user)
+
+			admin := &AdminHandler{setUserStatusUseCase: application.NewSetUserStatusUseCase(repo)}
+
+			router := gin.New()
+			router.PUT("/admin/users/:id/status", actingAs(seedAdmin(t, repo)), admin.SetUserStatus)
+
+			rec := serveJSON(router, http.MethodPut, "/admin/users/"+user.ID.String()+"/status", tt.body)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			var body struct {
+				Data struct {
+					Allowed []domain.Status `json:"allowed"`
+				} `json:"data"`
+				errorBody
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if tt.wantErr != "" && body.Error != tt.wantErr {
+				t.Errorf("error = %s, want %s", body.Error, tt.wantErr)
+			}
+
+			if !slices.Equal(body.Data.Allowed, tt.wantAllowed) {
+				t.Errorf("allowed = %v, want %v", body.Data.Allowed, tt.wantAllowed)
+			}
+
+			stored, err := repo.GetByID(context.Background(), user.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+
+			if stored.Status != tt.wantStatus {
+				t.Errorf("stored status = %s, want %s", stored.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestSetUserStatusRequiresHigherRole(t *testing.T) {
+	repo := memory.NewRepository()
+	admin := seedAdmin(t, repo)
+	superAdmin := repositorytest.NewUser("Root", "root@example.com", 0)
+	superAdmin.Role = domain.RoleSuperAdmin
+	repositorytest.Seed(t, repo, superAdmin)
+
+	handler := &AdminHandler{setUserStatusUseCase: application.NewSetUserStatusUseCase(repo)}
+
+	router := gin.New()
+	router.PUT("/admin/users/:id/status", actingAs(admin), handler.SetUserStatus)
+
+	for _, target := range []*domain.User{superAdmin, admin} {
+		rec := serveJSON(router, http.MethodPut, "/admin/users/"+target.ID.String()+"/status", `{"status":"suspended"}`)
+
+		var body errorBody
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("body is not JSON: %v", err)
+		}
+
+		if rec.Code != http.StatusForbidden || body.Error != "STATUS_CHANGE_FORBIDDEN" {
+			t.Errorf("suspend %s: got %d %s, want %d STATUS_CHANGE_FORBIDDEN", target.Role, rec.Code, body.Error, http.StatusForbidden)
+		}
+
+		stored, err := repo.GetByID(context.Background(), target.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+
+		if stored.Status != target.Status {
+			t.Errorf("%s status changed to %s", target.Role, stored.Status)
+		}
+	}
+}
+
+// welcomeOnlyEmails aceita apenas o email de boas-vindas, o único usado no teste.
+type welcomeOnlyEmails struct {
+	application.EmailService
+}
+
+func (welcomeOnlyEmails) SendWelcomeEmail(context.Context, *domain.User) error {
+	return nil
+}
+
+func TestResendEmailEndpoint(t *testing.T) {
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	superAdmin := repositorytest.NewUser("Root", "root@example.com", 1)
+	superAdmin.Role = domain.RoleSuperAdmin
+	repositorytest.Seed(t, repo, user, superAdmin)
+
+	fake := clock.NewFakeClock(repositorytest.BaseTime)
+	uc := application.NewResendEmailUseCase(repo, welcomeOnlyEmails{}).
+		WithRateLimit(cache.NewMemoryCache().WithClock(fake), 1, time.Hour)
+	admin := &AdminHandler{resendEmailUseCase: uc}
+
+	router := gin.New()
+	router.POST("/admin/users/:id/resend-email", actingAs(seedAdmin(t, repo)), admin.ResendEmail)
+
+	// Em sequência: o limite de um reenvio por hora vale a partir do primeiro sucesso
+	tests := []struct {
+		name      string
+		target    *domain.User
+		emailType string
+		wantCode  int
+		wantErr   string
+	}{
+		{
+			name: "higher role target", target: superAdmin, emailType: "welcome",
+			wantCode: http.StatusForbidden, wantErr: "RESEND_EMAIL_FORBIDDEN",
+		},
+		{name: "invalid type", emailType: "newsletter", wantCode: http.StatusBadRequest, wantErr: "INVALID_EMAIL_TYPE"},
+		{name: "missing type", wantCode: http.StatusBadRequest, wantErr: "INVALID_EMAIL_TYPE"},
+		{name: "activation for active user", emailType: "activation", wantCode: http.StatusConflict, wantErr: "EMAIL_NOT_APPLICABLE"},
+		{name: "welcome", emailType: "welcome", wantCode: http.StatusOK},
+		{name: "welcome again", emailType: "welcome", wantCode: http.StatusTooManyRequests, wantErr: "RESEND_RATE_LIMITED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// O segundo reenvio chega com 40 minutos restantes na janela
+			if tt.wantCode == http.StatusTooManyRequests {
+				fake.Advance(20 * time.Minute)
+			}
+
+			target := user
+			if tt.target != nil {
+				target = tt.target
+			}
+
+			path := "/admin/users/" + target.ID.String() + "/resend-email?type=" + tt.emailType
+
+			rec := serveJSON(router, http.MethodPost, path, "")
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			var body errorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if body.Error != tt.wantErr {
+				t.Errorf("error = %s, want %s", body.Error, tt.wantErr)
+			}
+
+			if tt.wantCode == http.StatusTooManyRequests && rec.Header().Get("Retry-After") != "2400" {
+				t.Errorf("Retry-After = %q, want 2400", rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestBusinessRuleVersusInputShapeStatus(t *testing.T) {
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, repo, user)
+
+	admin := &AdminHandler{setUserStatusUseCase: application.NewSetUserStatusUseCase(repo)}
+
+	router := gin.New()
+	router.PUT("/admin/users/:id/status", actingAs(seedAdmin(t, repo)), admin.SetUserStatus)
+
+	tests := []struct {
+		name     string
+		id       string
+		body     string
+		wantCode int
+	}{
+		// Entrada malformada: 400
+		{name: "malformed uuid", id: "not-a-uuid", body: `{"status":"suspended"}`, wantCode: http.StatusBadRequest},
+		{name: "unknown status", id: user.ID.String(), body: `{"status":"archived"}`, wantCode: http.StatusBadRequest},
+		// Entrada bem formada recusada pela regra de negócio: 422
+		{name: "invalid transition", id: user.ID.String(), body: `{"status":"pending"}`, wantCode: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodPut, "/admin/users/"+tt.id+"/status", tt.body)
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRespondRejectedFollowsErrorKind(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		wantCode int
+	}{
+		{name: "reused password", err: domain.ErrPasswordReused, wantCode: http.StatusUnprocessableEntity},
+		{name: "password policy", err: fmt.Errorf("%w: too short", domain.ErrInvalidPassword), wantCode: http.StatusUnprocessableEntity},
+		{name: "self merge", err: domain.ErrSelfMerge, wantCode: http.StatusUnprocessableEntity},
+		{name: "invalid name", err: domain.ErrInvalidName, wantCode: http.StatusBadRequest},
+		{name: "invalid email", err: domain.ErrInvalidEmail, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) { respondRejected(c, "REJECTED", tt.err.Error(), tt.err) })
+
+			if rec := serveJSON(router, http.MethodGet, "/", ""); rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestListEndpointsRejectDeepPages(t *testing.T) {
+	pagination.ConfigureMaxOffset(20)
+	t.Cleanup(func() { pagination.ConfigureMaxOffset(pagination.FallbackMaxOffset) })
+
+	repo := memory.NewRepository()
+	repositorytest.Seed(t, repo, repositorytest.NewUser("Ana", "ana@example.com", 0))
+
+	handler := &Handler{listUsersUseCase: application.NewListUsersUseCase(repo)}
+	admin := &AdminHandler{
+		listInactiveUsersUseCase: application.NewListInactiveUsersUseCase(repo),
+		listByEmailDomainUseCase: application.NewListUsersByEmailDomainUseCase(repo),
+	}
+
+	router := gin.New()
+	router.GET("/users", handler.ListUsers)
+	router.GET("/admin/users/inactive", admin.ListInactiveUsers)
+	router.GET("/admin/users", admin.ListUsersByEmailDomain)
+
+	// Com 10 por página, a página 3 pula exatamente 20 registros
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+	}{
+		{name: "offset at the depth", path: "/users?limit=10&offset=20", wantCode: http.StatusOK},
+		{name: "offset beyond the depth", path: "/users?limit=10&offset=21", wantCode: http.StatusBadRequest},
+		{name: "inactive page at the depth", path: "/admin/users/inactive?limit=10&page=3", wantCode: http.StatusOK},
+		{name: "inactive page beyond the depth", path: "/admin/users/inactive?limit=10&page=4", wantCode: http.StatusBadRequest},
+		{name: "email domain page at the depth", path: "/admin/users?email_domain=example.com&limit=10&page=3", wantCode: http.StatusOK},
+		{name: "email domain page beyond the depth", path: "/admin/users?email_domain=example.com&limit=10&page=4", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodGet, tt.path, "")
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			if tt.wantCode != http.StatusBadRequest {
+				return
+			}
+
+			var body errorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error != "PAGE_TOO_DEEP" {
+				t.Errorf("body = %s, want error PAGE_TOO_DEEP", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestChangeRoleEndpoint(t *testing.T) {
+	repo := memory.NewRepository()
+	admin := repositorytest.NewUser("Admin", "admin@example.com", 0)
+	admin.Role = domain.RoleAdmin
+	user := repositorytest.NewUser("Ana", "ana@example.com", 1)
+	repositorytest.Seed(t, repo, admin, user)
+
+	handler := &AdminHandler{changeRoleUseCase: application.NewChangeRoleUseCase(repo)}
+
+	// Simula o AuthMiddleware com o administrador autenticado
+	authenticated := func(c *gin.Context) {
+		c.Request = c.Request.WithContext(requestctx.WithActor(c.Request.Context(), admin.ID.String()))
+	}
+
+	router := gin.New()
+	router.PATCH("/admin/users/:id/role", authenticated, handler.ChangeRole)
+
+	tests := []struct {
+		name         string
+		id           string
+		body         string
+		wantCode     int
+		wantErr      string
+		wantPrevious domain.Role
+	}{
+		{name: "promote user", id: user.ID.String(), body: `{"role":"moderator"}`, wantCode: http.StatusOK, wantPrevious: domain.RoleUser},
+		{name: "self elevation", id: admin.ID.String(), body: `{"role":"super_admin"}`, wantCode: http.StatusUnprocessableEntity, wantErr: "SELF_ROLE_CHANGE"},
+		{name: "grant own role", id: user.ID.String(), body: `{"role":"admin"}`, wantCode: http.StatusForbidden, wantErr: "ROLE_CHANGE_FORBIDDEN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodPatch, "/admin/users/"+tt.id+"/role", tt.body)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			var body struct {
+				Data struct {
+					PreviousRole domain.Role `json:"previous_role"`
+				} `json:"data"`
+				errorBody
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if body.Error != tt.wantErr || body.Data.PreviousRole != tt.wantPrevious {
+				t.Errorf("error = %q, previous_role = %q, want %q and %q", body.Error, body.Data.PreviousRole, tt.wantErr, tt.wantPrevious)
+			}
+		})
+	}
+}
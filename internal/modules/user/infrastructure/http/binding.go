@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/i18n"
+	"github.com/devleo-m/go-zero/internal/shared/jsonnaming"
+	"github.com/devleo-m/go-zero/internal/shared/response"
+	"github.com/devleo-m/go-zero/internal/shared/validation"
+)
+
+// errEmptyBody indica uma requisição sem corpo (ou só com espaços) em um
+// endpoint que exige um objeto JSON.
+var errEmptyBody = errors.New("request body is required")
+
+// emailAddressTag é a regra de binding que valida emails com validation.ValidateEmail,
+// a mesma regra aplicada pelo domínio.
+const emailAddressTag = "email_address"
+
+func init() {
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		_ = engine.RegisterValidation(emailAddressTag, func(fl validator.FieldLevel) bool {
+			return validation.ValidateEmail(validation.NormalizeEmail(fl.Field().String())) == nil
+		})
+
+		// Os erros de validação usam o nome JSON do campo na convenção configurada
+		engine.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+// bindJSON decodifica o corpo em req e valida todas as regras de binding de
+// uma vez, respondendo as falhas com respondBindError. Retorna false quando a
+// resposta de erro já foi escrita.
+func bindJSON(c *gin.Context, req interface{}) bool {
+	if err := shouldBindJSON(c, req); err != nil {
+		respondBindError(c, err)
+		return false
+	}
+
+	return true
+}
+
+// respondBindError responde a erros de bind: falhas de validação são
+// respondidas juntas, por campo, com VALIDATION_ERROR no idioma do cliente;
+// role e status inválidos (rejeitados por domain.Role/domain.Status durante o
+// unmarshal), com INVALID_ROLE e INVALID_STATUS; JSON malformado, com
+// INVALID_REQUEST.
+func respondBindError(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+
+	switch {
+	case errors.As(err, &validationErrs):
+		response.ValidationError(c, fieldErrors(validationErrs, response.Language(c)))
+	case errors.Is(err, domain.ErrInvalidStatus):
+		response.BadRequest(c, "INVALID_STATUS", err.Error())
+	case errors.Is(err, domain.ErrInvalidRole):
+		response.BadRequest(c, "INVALID_ROLE", err.Error())
+	case errors.Is(err, errEmptyBody):
+		respondEmptyBody(c)
+	default:
+		response.BadRequest(c, "INVALID_REQUEST", err.Error())
+	}
+}
+
+// respondEmptyBody responde ao errEmptyBody com uma mensagem clara, em vez do
+// EOF devolvido pelo decoder.
+func respondEmptyBody(c *gin.Context) {
+	response.BadRequest(c, "EMPTY_BODY", "Request body is required and must be a JSON object")
+}
+
+// normalizer é implementado pelos DTOs que ajustam a entrada (espaços,
+// caixa do email) antes da validação.
+type normalizer interface {
+	Normalize()
+}
+
+// shouldBindJSON decodifica o corpo, normaliza o DTO (normalizer) e então
+// valida as regras de binding, de modo que as regras vejam os valores já
+// ajustados. Aceita também os nomes de campos na convenção de jsonnaming.
+// Corpos ausentes ou vazios retornam errEmptyBody.
+func shouldBindJSON(c *gin.Context, req interface{}) error {
+	if c.Request.Body == nil {
+		return errEmptyBody
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errEmptyBody
+	}
+
+	// Mesmas opções de decodificação do binding.JSON do gin
+	decoder := json.NewDecoder(bytes.NewReader(jsonnaming.RequestBody(body, req)))
+	if binding.EnableDecoderUseNumber {
+		decoder.UseNumber()
+	}
+
+	if binding.EnableDecoderDisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(req); err != nil {
+		return err
+	}
+
+	if n, ok := req.(normalizer); ok {
+		n.Normalize()
+	}
+
+	return binding.Validator.ValidateStruct(req)
+}
+
+// fieldErrors converte os erros do validator em mensagens, no idioma
+// informado, indexadas pelo nome JSON de cada campo.
+func fieldErrors(errs validator.ValidationErrors, language string) map[string]string {
+	fields := make(map[string]string, len(errs))
+
+	for _, fieldErr := range errs {
+		name := fieldErr.Field()
+		if _, exists := fields[name]; !exists {
+			fields[name] = fieldErrorMessage(fieldErr, language)
+		}
+	}
+
+	return fields
+}
+
+// jsonFieldName retorna o nome do campo na tag json, na convenção configurada,
+// ou o nome Go se não houver tag. Campos com json:"-" ficam sem nome.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	if name == "" {
+		return field.Name
+	}
+
+	return jsonnaming.FieldName(name)
+}
+
+// fieldErrorMessage descreve a regra violada de forma legível no idioma informado.
+func fieldErrorMessage(fieldErr validator.FieldError, language string) string {
+	key := "validation.rule"
+
+	switch fieldErr.Tag() {
+	case "required", "min", "max":
+		key = "validation." + fieldErr.Tag()
+	case "email", emailAddressTag:
+		key = "validation.email"
+	}
+
+	return i18n.Translate(language, key, "{param}", fieldErr.Param(), "{tag}", fieldErr.Tag())
+}
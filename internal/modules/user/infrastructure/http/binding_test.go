@@ -0,0 +1,125 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/jsonnaming"
+	"github.com/devleo-m/go-zero/internal/shared/response"
+)
+
+// useNaming configura a convenção de nomes durante o teste e restaura snake_case ao final.
+func useNaming(t *testing.T, strategy jsonnaming.Strategy) {
+	t.Helper()
+
+	jsonnaming.Configure(strategy)
+	t.Cleanup(func() { jsonnaming.Configure(jsonnaming.SnakeCase) })
+}
+
+func TestUserResponseNamingStrategies(t *testing.T) {
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	lastLogin := repositorytest.BaseTime.Add(time.Hour)
+	user.LastLoginAt = &lastLogin
+
+	router := gin.New()
+	router.GET("/users/:id", func(c *gin.Context) {
+		response.Success(c, toUserResponse(user))
+	})
+
+	tests := []struct {
+		strategy jsonnaming.Strategy
+		wantKeys []string
+	}{
+		{
+			strategy: jsonnaming.SnakeCase,
+			wantKeys: []string{"created_at", "email", "id", "last_login_at", "login_count", "name", "role", "status", "updated_at"},
+		},
+		{
+			strategy: jsonnaming.CamelCase,
+			wantKeys: []string{"createdAt", "email", "id", "lastLoginAt", "loginCount", "name", "role", "status", "updatedAt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			useNaming(t, tt.strategy)
+
+			rec := serveJSON(router, http.MethodGet, "/users/"+user.ID.String(), "")
+
+			var body struct {
+				Data map[string]json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			keys := make([]string, 0, len(body.Data))
+			for key := range body.Data {
+				keys = append(keys, key)
+			}
+
+			slices.Sort(keys)
+
+			if !slices.Equal(keys, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+
+			// Só os nomes mudam: os valores são os mesmos nas duas convenções
+			if string(body.Data["email"]) != `"ana@example.com"` {
+				t.Errorf("email = %s", body.Data["email"])
+			}
+		})
+	}
+}
+
+func TestValidationFieldNamesFollowStrategy(t *testing.T) {
+	type request struct {
+		NewPassword string `json:"new_password" binding:"required"`
+		Email       string `json:"email" binding:"required"`
+	}
+
+	tests := []struct {
+		strategy jsonnaming.Strategy
+		want     []string
+	}{
+		{strategy: jsonnaming.SnakeCase, want: []string{"email", "new_password"}},
+		{strategy: jsonnaming.CamelCase, want: []string{"email", "newPassword"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			useNaming(t, tt.strategy)
+
+			// Validator novo: o do gin guarda os nomes da primeira validação de cada tipo
+			validate := validator.New()
+			validate.SetTagName("binding")
+			validate.RegisterTagNameFunc(jsonFieldName)
+
+			var validationErrs validator.ValidationErrors
+			if err := validate.Struct(request{}); !errors.As(err, &validationErrs) {
+				t.Fatalf("Struct = %v, want validation errors", err)
+			}
+
+			fields := fieldErrors(validationErrs, "en")
+
+			got := make([]string, 0, len(fields))
+			for name := range fields {
+				got = append(got, name)
+			}
+
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,41 +1,217 @@
 package http
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/validation"
 )
 
 // UserResponse representa a resposta de um usuário.
 type UserResponse struct {
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Phone     *string   `json:"phone,omitempty"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	Status    string    `json:"status"`
-	ID        uuid.UUID `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Phone       *string    `json:"phone,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	Status      string     `json:"status"`
+	LoginCount  int        `json:"login_count"`
+	ID          uuid.UUID  `json:"id"`
+}
+
+// AdminUserResponse representa um usuário nas respostas administrativas,
+// incluindo quem o criou e quem o alterou por último.
+type AdminUserResponse struct {
+	UserResponse
+	CreatedBy string `json:"created_by"`
+	UpdatedBy string `json:"updated_by"`
 }
 
 // CreateUserRequest representa a requisição de criação de usuário.
 type CreateUserRequest struct {
-	Name     string `json:"name" validate:"required,min=2,max=100"`
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Name     string `json:"name" binding:"required,min=2,max=100"`
+	Email    string `json:"email" binding:"required,email_address"`
+	Password string `json:"password" binding:"required"`
 	Phone    string `json:"phone,omitempty"`
 }
 
+// Normalize ajusta nome, email e telefone antes da validação. A senha é
+// mantida como enviada.
+func (r *CreateUserRequest) Normalize() {
+	r.Name = validation.NormalizeName(r.Name)
+	r.Email = validation.NormalizeEmail(r.Email)
+	r.Phone = strings.TrimSpace(r.Phone)
+}
+
 // UpdateUserRequest representa a requisição de atualização de usuário.
 type UpdateUserRequest struct {
-	Name  string `json:"name" validate:"required,min=2,max=100"`
+	Name  string `json:"name" binding:"required,min=2,max=100"`
 	Phone string `json:"phone,omitempty"`
 }
 
-// ListUsersRequest representa a requisição de listagem de usuários.
-type ListUsersRequest struct {
-	Limit  int `json:"limit" form:"limit" validate:"min=1,max=100"`
-	Offset int `json:"offset" form:"offset" validate:"min=0"`
+// Normalize ajusta nome e telefone antes da validação.
+func (r *UpdateUserRequest) Normalize() {
+	r.Name = validation.NormalizeName(r.Name)
+	r.Phone = strings.TrimSpace(r.Phone)
+}
+
+// ChangePasswordRequest representa a requisição de troca de senha.
+// A força da nova senha é verificada pela política de senhas do domínio.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// GetUsersBatchRequest representa a busca de vários usuários por ID.
+type GetUsersBatchRequest struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
+// GetUsersBatchResponse representa os usuários encontrados, na ordem pedida,
+// e os IDs que não correspondem a nenhum usuário.
+type GetUsersBatchResponse struct {
+	Users   []UserResponse `json:"users"`
+	Missing []uuid.UUID    `json:"missing"`
+}
+
+// SetUserMetadataRequest representa a substituição dos metadados de um usuário.
+type SetUserMetadataRequest struct {
+	Metadata domain.Metadata `json:"metadata" binding:"required"`
+}
+
+// UpdateUserProfileRequest representa a substituição do perfil de um usuário.
+// Os formatos e limites são verificados pelo domínio.
+type UpdateUserProfileRequest struct {
+	AvatarURL string `json:"avatar_url"`
+	Bio       string `json:"bio"`
+	Locale    string `json:"locale"`
+}
+
+// Normalize remove os espaços das pontas; quebras de linha internas da
+// biografia são preservadas.
+func (r *UpdateUserProfileRequest) Normalize() {
+	r.AvatarURL = strings.TrimSpace(r.AvatarURL)
+	r.Bio = strings.TrimSpace(r.Bio)
+	r.Locale = strings.TrimSpace(r.Locale)
+}
+
+// UserProfileResponse representa o perfil de um usuário.
+type UserProfileResponse struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	AvatarURL string    `json:"avatar_url"`
+	Bio       string    `json:"bio"`
+	Locale    string    `json:"locale"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// ResetPasswordRequest representa a redefinição de senha feita por um administrador.
+type ResetPasswordRequest struct {
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// BulkFilterRequest representa os critérios de seleção das operações em massa.
+type BulkFilterRequest struct {
+	CreatedFrom *time.Time    `json:"created_from,omitempty"`
+	CreatedTo   *time.Time    `json:"created_to,omitempty"`
+	Role        domain.Role   `json:"role,omitempty"`
+	Status      domain.Status `json:"status,omitempty"`
+	EmailDomain string        `json:"email_domain,omitempty"`
+	IDs         []string      `json:"ids,omitempty"`
+	// DryRun retorna apenas a quantidade que seria afetada (também aceito como ?dry_run=true).
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// BulkUpdateStatusRequest representa a requisição de alteração de status em massa.
+type BulkUpdateStatusRequest struct {
+	TargetStatus domain.Status `json:"target_status" binding:"required"`
+	BulkFilterRequest
+}
+
+// BulkUpdateRoleRequest representa a requisição de alteração de role em massa.
+// Com dry_run, a resposta é a prévia das mudanças, como em /bulk-role/preview.
+type BulkUpdateRoleRequest struct {
+	TargetRole domain.Role `json:"target_role" binding:"required"`
+	BulkFilterRequest
+}
+
+// BulkDeleteUsersRequest representa a requisição de exclusão em massa.
+type BulkDeleteUsersRequest struct {
+	BulkFilterRequest
+}
+
+// MergeUsersRequest representa a requisição de mesclagem do usuário da rota no destino.
+type MergeUsersRequest struct {
+	TargetID string `json:"target_id" binding:"required"`
+	// DryRun simula a mesclagem sem persistir (também aceito como ?dry_run=true).
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// MergeUsersResponse representa o resultado de uma mesclagem.
+type MergeUsersResponse struct {
+	Target               AdminUserResponse `json:"target"`
+	PasswordHistoryMoved int64             `json:"password_history_moved"`
+	SourceID             uuid.UUID         `json:"source_id"`
+	TargetID             uuid.UUID         `json:"target_id"`
+	DryRun               bool              `json:"dry_run"`
+}
+
+// SetUserStatusRequest representa a requisição de mudança de status de um usuário.
+type SetUserStatusRequest struct {
+	Status domain.Status `json:"status" binding:"required"`
+}
+
+// SetUserStatusResponse representa o resultado de uma mudança de status.
+type SetUserStatusResponse struct {
+	User           AdminUserResponse `json:"user"`
+	PreviousStatus domain.Status     `json:"previous_status"`
+	Changed        bool              `json:"changed"`
+}
+
+// ChangeRoleRequest representa a requisição de mudança de role de um usuário.
+type ChangeRoleRequest struct {
+	Role domain.Role `json:"role" binding:"required"`
+}
+
+// ChangeRoleResponse representa o resultado de uma mudança de role.
+type ChangeRoleResponse struct {
+	User         AdminUserResponse `json:"user"`
+	PreviousRole domain.Role       `json:"previous_role"`
+	Changed      bool              `json:"changed"`
+}
+
+// StatusTransitionErrorData detalha uma transição de status recusada.
+type StatusTransitionErrorData struct {
+	From    domain.Status   `json:"from"`
+	To      domain.Status   `json:"to"`
+	Allowed []domain.Status `json:"allowed"`
+}
+
+// SaveFilterPresetRequest representa a gravação de um preset de filtro.
+// O nome vem da URL; os critérios são verificados pelo domínio.
+type SaveFilterPresetRequest struct {
+	CreatedFrom *time.Time      `json:"created_from,omitempty"`
+	CreatedTo   *time.Time      `json:"created_to,omitempty"`
+	Search      string          `json:"search,omitempty"`
+	Roles       []domain.Role   `json:"roles,omitempty"`
+	Statuses    []domain.Status `json:"statuses,omitempty"`
+}
+
+// Normalize remove os espaços das pontas do termo de busca.
+func (r *SaveFilterPresetRequest) Normalize() {
+	r.Search = strings.TrimSpace(r.Search)
+}
+
+// FilterPresetResponse representa um preset de filtro salvo.
+type FilterPresetResponse struct {
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+	Criteria  domain.FilterCriteria `json:"criteria"`
+	Name      string                `json:"name"`
 }
 
 // ErrorResponse representa uma resposta de erro.
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/shared/jsonnaming"
+)
+
+// userResponseFields lista os campos de UserResponse que podem ser pedidos em ?fields=.
+var userResponseFields = map[string]bool{
+	"id":            true,
+	"name":          true,
+	"email":         true,
+	"phone":         true,
+	"role":          true,
+	"status":        true,
+	"last_login_at": true,
+	"login_count":   true,
+	"created_at":    true,
+	"updated_at":    true,
+}
+
+// fieldsFromQuery lê o parâmetro fields (repetido ou separado por vírgula),
+// aceitando nomes em snake_case ou camelCase.
+// Retorna nil quando nenhum campo é pedido e erro para campos desconhecidos.
+func fieldsFromQuery(c *gin.Context) ([]string, error) {
+	fields := queryValues(c, "fields")
+
+	for i, field := range fields {
+		fields[i] = jsonnaming.SnakeName(field)
+		if !userResponseFields[fields[i]] {
+			return nil, fmt.Errorf("unknown field: %s", field)
+		}
+	}
+
+	return fields, nil
+}
+
+// selectFields restringe a serialização do usuário aos campos pedidos.
+// Sem campos, a resposta completa é retornada.
+func selectFields(user UserResponse, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return user, nil
+	}
+
+	raw, err := json.Marshal(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize user: %w", err)
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil, fmt.Errorf("failed to serialize user: %w", err)
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+
+	for _, field := range fields {
+		// Campos omitidos (ex.: phone nulo) continuam ausentes
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+
+	return selected, nil
+}
@@ -1,24 +1,39 @@
 package http
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/devleo-m/go-zero/internal/infrastructure/http/middleware"
 	"github.com/devleo-m/go-zero/internal/modules/user/application"
 	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/circuitbreaker"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/pagination"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
 	"github.com/devleo-m/go-zero/internal/shared/response"
 	"github.com/devleo-m/go-zero/internal/shared/validation"
 )
 
 // Handler gerencia as rotas HTTP para usuários.
 type Handler struct {
-	createUserUseCase *application.CreateUserUseCase
-	getUserUseCase    *application.GetUserUseCase
-	listUsersUseCase  *application.ListUsersUseCase
-	updateUserUseCase *application.UpdateUserUseCase
-	deleteUserUseCase *application.DeleteUserUseCase
+	createUserUseCase     *application.CreateUserUseCase
+	getUserUseCase        *application.GetUserUseCase
+	listUsersUseCase      *application.ListUsersUseCase
+	updateUserUseCase     *application.UpdateUserUseCase
+	deleteUserUseCase     *application.DeleteUserUseCase
+	changePasswordUseCase *application.ChangePasswordUseCase
+	metadataUseCase       *application.UserMetadataUseCase
+	getUsersBatchUseCase  *application.GetUsersBatchUseCase
+	profileUseCase        *application.UserProfileUseCase
 }
 
 // NewHandler cria uma nova instância do handler.
@@ -28,57 +43,84 @@ func NewHandler(
 	listUsersUseCase *application.ListUsersUseCase,
 	updateUserUseCase *application.UpdateUserUseCase,
 	deleteUserUseCase *application.DeleteUserUseCase,
+	changePasswordUseCase *application.ChangePasswordUseCase,
+	metadataUseCase *application.UserMetadataUseCase,
+	getUsersBatchUseCase *application.GetUsersBatchUseCase,
+	profileUseCase *application.UserProfileUseCase,
 ) *Handler {
 	return &Handler{
-		createUserUseCase: createUserUseCase,
-		getUserUseCase:    getUserUseCase,
-		listUsersUseCase:  listUsersUseCase,
-		updateUserUseCase: updateUserUseCase,
-		deleteUserUseCase: deleteUserUseCase,
+		createUserUseCase:     createUserUseCase,
+		getUserUseCase:        getUserUseCase,
+		listUsersUseCase:      listUsersUseCase,
+		updateUserUseCase:     updateUserUseCase,
+		deleteUserUseCase:     deleteUserUseCase,
+		changePasswordUseCase: changePasswordUseCase,
+		metadataUseCase:       metadataUseCase,
+		getUsersBatchUseCase:  getUsersBatchUseCase,
+		profileUseCase:        profileUseCase,
 	}
 }
 
 // CreateUser cria um novo usuário.
 func (h *Handler) CreateUser(c *gin.Context) {
 	var req CreateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "INVALID_REQUEST", err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	// Apenas sanitização - validação já feita pelo gin binding
+	// Entrada já normalizada e validada pelo binding
 	var phone *string
 	if req.Phone != "" {
 		phone = &req.Phone
 	}
 
 	input := application.CreateUserInput{
-		Name:     validation.SanitizeString(req.Name),
-		Email:    validation.SanitizeString(req.Email),
+		Name:     req.Name,
+		Email:    req.Email,
 		Password: req.Password,
 		Phone:    phone,
 	}
 
 	result, err := h.createUserUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
-		response.BadRequest(c, "CREATE_USER_FAILED", err.Error())
+		if errors.Is(err, domain.ErrEmailAlreadyInUse) {
+			response.Conflict(c, "USER_ALREADY_EXISTS", "A user with this email already exists")
+			return
+		}
+
+		if errors.Is(err, domain.ErrEmailDomainNotAllowed) {
+			respondRejected(c, "EMAIL_DOMAIN_NOT_ALLOWED", "Email domain is not allowed", err)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidPassword) {
+			respondRejected(c, "INVALID_PASSWORD", err.Error(), err)
+			return
+		}
+
+		if errors.Is(err, circuitbreaker.ErrOpen) {
+			internalError(c, "CREATE_USER_FAILED", err)
+			return
+		}
+
+		respondRejected(c, "CREATE_USER_FAILED", err.Error(), err)
+
 		return
 	}
 
 	response.Created(c, toUserResponse(result.User), result.Message)
 }
 
-// GetUser busca um usuário por ID.
+// GetUser busca um usuário por ID. ?fields= restringe os campos serializados.
 func (h *Handler) GetUser(c *gin.Context) {
-	idStr := c.Param("id")
-	if err := validation.ValidateUUID(idStr); err != nil {
-		response.BadRequest(c, "INVALID_ID", err.Error())
+	id, ok := userIDParam(c)
+	if !ok {
 		return
 	}
 
-	id, err := uuid.Parse(idStr)
+	fields, err := fieldsFromQuery(c)
 	if err != nil {
-		response.BadRequest(c, "INVALID_UUID", "Invalid UUID format")
+		response.BadRequest(c, "INVALID_FIELDS", err.Error())
 		return
 	}
 
@@ -86,12 +128,103 @@ func (h *Handler) GetUser(c *gin.Context) {
 
 	result, err := h.getUserUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
-		if err == domain.ErrUserNotFound {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			response.NotFound(c, "USER_NOT_FOUND", "User not found")
+			return
+		}
+
+		if errors.Is(err, application.ErrInvalidLookup) {
+			response.BadRequest(c, "INVALID_LOOKUP", err.Error())
+			return
+		}
+
+		internalError(c, "GET_USER_FAILED", err)
+
+		return
+	}
+
+	data, err := selectFields(toUserResponse(result.User), fields)
+	if err != nil {
+		internalError(c, "GET_USER_FAILED", err)
+		return
+	}
+
+	response.Success(c, data)
+}
+
+// GetUsersBatch busca vários usuários por ID em uma única consulta, preservando
+// a ordem pedida e informando separadamente os IDs não encontrados.
+func (h *Handler) GetUsersBatch(c *gin.Context) {
+	var req GetUsersBatchRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if len(req.IDs) > application.MaxBatchSize {
+		response.BadRequest(c, "BATCH_TOO_LARGE", application.ErrBatchTooLarge.Error())
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+
+	for _, rawID := range req.IDs {
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			response.BadRequest(c, "INVALID_ID", "Invalid user ID: "+rawID)
+			return
+		}
+
+		ids = append(ids, id)
+	}
+
+	result, err := h.getUsersBatchUseCase.Execute(c.Request.Context(), application.GetUsersBatchInput{IDs: ids})
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrEmptyBatch):
+			response.BadRequest(c, "EMPTY_BATCH", err.Error())
+		case errors.Is(err, application.ErrBatchTooLarge):
+			response.BadRequest(c, "BATCH_TOO_LARGE", err.Error())
+		default:
+			internalError(c, "GET_USERS_BATCH_FAILED", err)
+		}
+
+		return
+	}
+
+	users := make([]UserResponse, len(result.Users))
+	for i, user := range result.Users {
+		users[i] = toUserResponse(user)
+	}
+
+	response.Success(c, GetUsersBatchResponse{
+		Users:   users,
+		Missing: result.Missing,
+	})
+}
+
+// GetUserByEmail busca um usuário pelo email informado na query string (?email=).
+func (h *Handler) GetUserByEmail(c *gin.Context) {
+	email := emailFromQuery(c)
+	if err := validation.ValidateEmail(email); err != nil {
+		response.BadRequest(c, "INVALID_EMAIL", err.Error())
+		return
+	}
+
+	input := application.GetUserInput{Email: email}
+
+	result, err := h.getUserUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
 			response.NotFound(c, "USER_NOT_FOUND", "User not found")
 			return
 		}
 
-		response.InternalServerError(c, "GET_USER_FAILED", err.Error())
+		if errors.Is(err, application.ErrInvalidLookup) {
+			response.BadRequest(c, "INVALID_LOOKUP", err.Error())
+			return
+		}
+
+		internalError(c, "GET_USER_FAILED", err)
 
 		return
 	}
@@ -99,92 +232,185 @@ func (h *Handler) GetUser(c *gin.Context) {
 	response.Success(c, toUserResponse(result.User))
 }
 
-// ListUsers lista usuários.
+// emailFromQuery lê o parâmetro email da query string preservando o caractere "+".
+// Na decodificação padrão "+" vira espaço, o que quebraria aliases como "joao+tag@x.com".
+func emailFromQuery(c *gin.Context) string {
+	values, err := url.ParseQuery(strings.ReplaceAll(c.Request.URL.RawQuery, "+", "%2B"))
+	if err != nil {
+		return strings.TrimSpace(c.Query("email"))
+	}
+
+	return strings.TrimSpace(values.Get("email"))
+}
+
+// ListUsers lista usuários. ?fields= restringe os campos serializados de cada usuário.
 func (h *Handler) ListUsers(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
+	limitStr := c.DefaultQuery("limit", strconv.Itoa(pagination.DefaultLimit()))
 	offsetStr := c.DefaultQuery("offset", "0")
 
-	limit, err := strconv.Atoi(limitStr)
+	requestedLimit, err := strconv.Atoi(limitStr)
 	if err != nil {
-		limit = 10
+		requestedLimit = pagination.DefaultLimit()
 	}
 
 	offset, err := strconv.Atoi(offsetStr)
-	if err != nil {
+	if err != nil || offset < 0 {
 		offset = 0
 	}
 
-	// Validar parâmetros de paginação
-	if err := validation.ValidatePagination(offset/limit+1, limit); err != nil {
-		response.BadRequest(c, "INVALID_PAGINATION", err.Error())
+	fields, err := fieldsFromQuery(c)
+	if err != nil {
+		response.BadRequest(c, "INVALID_FIELDS", err.Error())
 		return
 	}
 
+	createdFrom, createdTo, err := createdRangeFromQuery(c)
+	if err != nil {
+		response.BadRequest(c, "INVALID_FILTER", err.Error())
+		return
+	}
+
+	// Ajustar o limite ao intervalo aceito (o ajuste é informado no meta)
+	limit := pagination.ClampLimit(requestedLimit)
+
 	input := application.ListUsersInput{
-		Limit:  limit,
-		Offset: offset,
+		Metadata:    c.QueryMap("metadata"),
+		Roles:       queryValues(c, "role"),
+		Statuses:    queryValues(c, "status"),
+		Search:      c.Query("search"),
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		Limit:       limit,
+		Offset:      offset,
 	}
 
 	result, err := h.listUsersUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
-		response.InternalServerError(c, "LIST_USERS_FAILED", err.Error())
+		switch {
+		case errors.Is(err, domain.ErrInvalidRole):
+			response.BadRequest(c, "INVALID_ROLE", err.Error())
+		case errors.Is(err, domain.ErrInvalidStatus):
+			response.BadRequest(c, "INVALID_STATUS", err.Error())
+		case errors.Is(err, domain.ErrInvalidMetadataKey):
+			response.BadRequest(c, "INVALID_METADATA_KEY", err.Error())
+		case errors.Is(err, domain.ErrInvalidFilter):
+			response.BadRequest(c, "INVALID_FILTER", err.Error())
+		case errors.Is(err, pagination.ErrPageTooDeep):
+			respondPageTooDeep(c)
+		default:
+			internalError(c, "LIST_USERS_FAILED", err)
+		}
+
 		return
 	}
 
-	users := make([]UserResponse, len(result.Users))
-	for i, user := range result.Users {
-		users[i] = toUserResponse(user)
+	page := pageOrEmpty(result.Result, limit)
+
+	users := make([]interface{}, len(page.Items))
+	for i, user := range page.Items {
+		users[i], err = selectFields(toUserResponse(user), fields)
+		if err != nil {
+			internalError(c, "LIST_USERS_FAILED", err)
+			return
+		}
 	}
 
-	// Calculator página corretamente
-	page := (offset / limit) + 1
-	meta := response.NewMeta(page, limit, int64(result.Total))
+	meta := pageMeta(page).WithRequested(page.Page, requestedLimit)
 
 	response.Paginated(c, map[string]interface{}{
 		"users": users,
 	}, meta)
 }
 
+// queryValues lê um parâmetro de query que pode ser repetido ou separado por vírgula.
+func queryValues(c *gin.Context, key string) []string {
+	var values []string
+
+	for _, raw := range c.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+// createdRangeFromQuery lê created_from e created_to, que aceitam data e hora
+// RFC 3339 ou apenas a data (YYYY-MM-DD, em UTC). Uma data sem hora em
+// created_to cobre o dia inteiro. Parâmetros ausentes resultam em nil.
+func createdRangeFromQuery(c *gin.Context) (from, to *time.Time, err error) {
+	parse := func(key string, endOfDay bool, target **time.Time) error {
+		value := strings.TrimSpace(c.Query(key))
+		if value == "" {
+			return nil
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if parsed, err = time.Parse(time.DateOnly, value); err != nil {
+				return fmt.Errorf("%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", key)
+			}
+
+			if endOfDay {
+				parsed = parsed.Add(24*time.Hour - time.Nanosecond)
+			}
+		}
+
+		*target = &parsed
+
+		return nil
+	}
+
+	if err := parse("created_from", false, &from); err != nil {
+		return nil, nil, err
+	}
+
+	if err := parse("created_to", true, &to); err != nil {
+		return nil, nil, err
+	}
+
+	return from, to, nil
+}
+
 // UpdateUser atualiza um usuário.
 func (h *Handler) UpdateUser(c *gin.Context) {
-	idStr := c.Param("id")
-	if err := validation.ValidateUUID(idStr); err != nil {
-		response.BadRequest(c, "INVALID_ID", err.Error())
+	id, ok := userIDParam(c)
+	if !ok {
 		return
 	}
 
 	var req UpdateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		response.BadRequest(c, "INVALID_REQUEST", err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	// Apenas sanitização - validação já feita pelo gin binding
+	// Entrada já normalizada e validada pelo binding
 	var phone *string
 	if req.Phone != "" {
 		phone = &req.Phone
 	}
 
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		response.BadRequest(c, "INVALID_UUID", "Invalid UUID format")
-		return
-	}
-
 	input := application.UpdateUserInput{
 		ID:    id,
-		Name:  validation.SanitizeString(req.Name),
+		Name:  req.Name,
 		Phone: phone,
 	}
 
 	result, err := h.updateUserUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
-		if err == domain.ErrUserNotFound {
+		if errors.Is(err, domain.ErrUserNotFound) {
 			response.NotFound(c, "USER_NOT_FOUND", "User not found")
 			return
 		}
 
-		response.BadRequest(c, "UPDATE_USER_FAILED", err.Error())
+		if errors.Is(err, circuitbreaker.ErrOpen) {
+			internalError(c, "UPDATE_USER_FAILED", err)
+			return
+		}
+
+		respondRejected(c, "UPDATE_USER_FAILED", err.Error(), err)
 
 		return
 	}
@@ -192,17 +418,212 @@ func (h *Handler) UpdateUser(c *gin.Context) {
 	response.Success(c, toUserResponse(result.User), result.Message)
 }
 
-// DeleteUser deleta um usuário.
-func (h *Handler) DeleteUser(c *gin.Context) {
-	idStr := c.Param("id")
-	if err := validation.ValidateUUID(idStr); err != nil {
-		response.BadRequest(c, "INVALID_ID", err.Error())
+// ChangePassword troca a senha do usuário do parâmetro :id, exigindo a senha atual.
+func (h *Handler) ChangePassword(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
 		return
 	}
 
-	id, err := uuid.Parse(idStr)
+	h.changePassword(c, id)
+}
+
+// ChangeOwnPassword troca a senha do usuário autenticado, exigindo a senha atual.
+func (h *Handler) ChangeOwnPassword(c *gin.Context) {
+	id, err := uuid.Parse(requestctx.Actor(c.Request.Context()))
 	if err != nil {
-		response.BadRequest(c, "INVALID_UUID", "Invalid UUID format")
+		response.Unauthorized(c, "AUTHENTICATION_REQUIRED", "Authentication is required")
+		return
+	}
+
+	h.changePassword(c, id)
+}
+
+// ResetPassword redefine a senha do usuário do parâmetro :id sem exigir a
+// senha atual. Deve ser registrado apenas em rotas administrativas.
+func (h *Handler) ResetPassword(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req ResetPasswordRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.changePasswordUseCase.Reset(c.Request.Context(), application.ResetPasswordInput{
+		UserID:      id,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		respondPasswordError(c, "RESET_PASSWORD_FAILED", err)
+		return
+	}
+
+	response.Success(c, nil, result.Message)
+}
+
+// changePassword troca a senha do usuário informado a partir do corpo da requisição.
+func (h *Handler) changePassword(c *gin.Context, id uuid.UUID) {
+	var req ChangePasswordRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	input := application.ChangePasswordInput{
+		UserID:          id,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}
+
+	result, err := h.changePasswordUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		respondPasswordError(c, "CHANGE_PASSWORD_FAILED", err)
+		return
+	}
+
+	response.Success(c, nil, result.Message)
+}
+
+// respondPasswordError responde com o erro adequado para troca e redefinição de senha.
+func respondPasswordError(c *gin.Context, errorCode string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		response.NotFound(c, "USER_NOT_FOUND", "User not found")
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		response.Unauthorized(c, "INVALID_CURRENT_PASSWORD", "Current password is incorrect")
+	case errors.Is(err, domain.ErrRoleChangeForbidden):
+		response.Forbidden(c, "PASSWORD_RESET_FORBIDDEN", err.Error())
+	case errors.Is(err, domain.ErrPasswordReused):
+		respondRejected(c, "PASSWORD_REUSED", "New password must not match a recently used password", err)
+	case errors.Is(err, domain.ErrInvalidPassword):
+		respondRejected(c, "INVALID_PASSWORD", err.Error(), err)
+	default:
+		internalError(c, errorCode, err)
+	}
+}
+
+// GetUserMetadata retorna os metadados de um usuário.
+func (h *Handler) GetUserMetadata(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	metadata, err := h.metadataUseCase.Get(c.Request.Context(), id)
+	if err != nil {
+		respondMetadataError(c, "GET_USER_METADATA_FAILED", err)
+		return
+	}
+
+	response.Success(c, gin.H{"metadata": metadata})
+}
+
+// SetUserMetadata substitui os metadados de um usuário.
+func (h *Handler) SetUserMetadata(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req SetUserMetadataRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	metadata, err := h.metadataUseCase.Set(c.Request.Context(), application.SetUserMetadataInput{
+		UserID:   id,
+		Metadata: req.Metadata,
+	})
+	if err != nil {
+		respondMetadataError(c, "SET_USER_METADATA_FAILED", err)
+		return
+	}
+
+	response.Success(c, gin.H{"metadata": metadata}, "User metadata updated successfully")
+}
+
+// userIDParam retorna o parâmetro :id já validado (ver middleware.UUIDParams),
+// respondendo 400 INVALID_ID quando malformado.
+func userIDParam(c *gin.Context) (uuid.UUID, bool) {
+	return middleware.UUIDParam(c, "id")
+}
+
+// respondMetadataError responde com o erro adequado para as operações de metadados.
+func respondMetadataError(c *gin.Context, errorCode string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		response.NotFound(c, "USER_NOT_FOUND", "User not found")
+	case errors.Is(err, domain.ErrInvalidMetadataKey):
+		response.BadRequest(c, "INVALID_METADATA_KEY", err.Error())
+	case errors.Is(err, domain.ErrMetadataTooLarge):
+		response.BadRequest(c, "METADATA_TOO_LARGE", err.Error())
+	case errors.Is(err, domain.ErrInvalidMetadata):
+		response.BadRequest(c, "INVALID_METADATA", err.Error())
+	default:
+		internalError(c, errorCode, err)
+	}
+}
+
+// GetUserProfile retorna o perfil de um usuário.
+func (h *Handler) GetUserProfile(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.profileUseCase.Get(c.Request.Context(), id)
+	if err != nil {
+		respondProfileError(c, "GET_USER_PROFILE_FAILED", err)
+		return
+	}
+
+	response.Success(c, toUserProfileResponse(profile))
+}
+
+// UpdateUserProfile substitui o perfil de um usuário.
+func (h *Handler) UpdateUserProfile(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateUserProfileRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	profile, err := h.profileUseCase.Update(c.Request.Context(), application.UpdateUserProfileInput{
+		UserID:    id,
+		AvatarURL: req.AvatarURL,
+		Bio:       req.Bio,
+		Locale:    req.Locale,
+	})
+	if err != nil {
+		respondProfileError(c, "UPDATE_USER_PROFILE_FAILED", err)
+		return
+	}
+
+	response.Success(c, toUserProfileResponse(profile), "User profile updated successfully")
+}
+
+// respondProfileError responde com o erro adequado para as operações de perfil.
+func respondProfileError(c *gin.Context, errorCode string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		response.NotFound(c, "USER_NOT_FOUND", "User not found")
+	case errors.Is(err, domain.ErrInvalidProfile):
+		response.BadRequest(c, "INVALID_PROFILE", err.Error())
+	default:
+		internalError(c, errorCode, err)
+	}
+}
+
+// DeleteUser deleta um usuário.
+func (h *Handler) DeleteUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
 		return
 	}
 
@@ -210,12 +631,12 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 
 	result, err := h.deleteUserUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
-		if err == domain.ErrUserNotFound {
+		if errors.Is(err, domain.ErrUserNotFound) {
 			response.NotFound(c, "USER_NOT_FOUND", "User not found")
 			return
 		}
 
-		response.InternalServerError(c, "DELETE_USER_FAILED", err.Error())
+		internalError(c, "DELETE_USER_FAILED", err)
 
 		return
 	}
@@ -223,16 +644,92 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 	response.Success(c, nil, result.Message)
 }
 
-// toUserResponse converte domain.User para UserResponse.
+// respondRejected responde a uma entrada recusada pelo domínio seguindo a
+// política de status da API: violações de regras de negócio
+// (domain.IsBusinessRuleViolation) recebem 422 e os erros de formato da
+// entrada, 400. Os grupos de erros estão documentados em domain/errors.go.
+func respondRejected(c *gin.Context, errorCode, message string, err error) {
+	if domain.IsBusinessRuleViolation(err) {
+		response.UnprocessableEntity(c, errorCode, message, nil)
+		return
+	}
+
+	response.BadRequest(c, errorCode, message)
+}
+
+// respondPageTooDeep recusa páginas além de pagination.MaxOffset, que obrigariam
+// o banco a percorrer e descartar todas as linhas anteriores.
+func respondPageTooDeep(c *gin.Context) {
+	response.BadRequest(c, "PAGE_TOO_DEEP", fmt.Sprintf(
+		"Requested page skips more than %d records; narrow the filters (e.g. created_from/created_to) and paginate within them instead",
+		pagination.MaxOffset()))
+}
+
+// internalError responde a erros inesperados; com o circuito do banco aberto
+// responde 503 para que o cliente possa tentar novamente mais tarde. Se o
+// cliente já desconectou, apenas registra o status 499.
+func internalError(c *gin.Context, errorCode string, err error) {
+	// O cliente desconectou e a consulta foi cancelada: não há a quem responder
+	if middleware.ClientGone(c.Request.Context()) {
+		c.AbortWithStatus(middleware.StatusClientClosedRequest)
+		return
+	}
+
+	if errors.Is(err, circuitbreaker.ErrOpen) {
+		response.ServiceUnavailable(c, "CIRCUIT_BREAKER_OPEN", "Service temporarily unavailable, try again later")
+		return
+	}
+
+	response.InternalServerError(c, errorCode, err.Error())
+}
+
+// pageOrEmpty retorna a página recebida ou, se ela estiver ausente, uma página
+// vazia (página 1, total 0), para que a resposta nunca perca a paginação.
+func pageOrEmpty(page *repository.PaginatedResult[domain.User], pageSize int) *repository.PaginatedResult[domain.User] {
+	if page == nil {
+		return repository.NewPaginatedResult[domain.User](nil, 0, 1, pageSize)
+	}
+
+	return page
+}
+
+// pageMeta converte uma página do repositório no meta de paginação da resposta.
+func pageMeta(page *repository.PaginatedResult[domain.User]) *response.Meta {
+	return response.NewMeta(page.Page, page.PageSize, page.TotalItems)
+}
+
+// toUserResponse converte domain.User para UserResponse, com instantes em UTC.
 func toUserResponse(user *domain.User) UserResponse {
 	return UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Phone:     user.Phone,
-		Role:      user.Role,
-		Status:    user.Status,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:          user.ID,
+		Name:        user.Name,
+		Email:       user.Email,
+		Phone:       user.Phone,
+		Role:        user.Role.String(),
+		Status:      user.Status.String(),
+		LastLoginAt: clock.UTCPtr(user.LastLoginAt),
+		LoginCount:  user.LoginCount,
+		CreatedAt:   clock.UTC(user.CreatedAt),
+		UpdatedAt:   clock.UTC(user.UpdatedAt),
+	}
+}
+
+// toUserProfileResponse converte domain.UserProfile para UserProfileResponse.
+func toUserProfileResponse(profile *domain.UserProfile) UserProfileResponse {
+	return UserProfileResponse{
+		UserID:    profile.UserID,
+		AvatarURL: profile.AvatarURL,
+		Bio:       profile.Bio,
+		Locale:    profile.Locale,
+		UpdatedAt: clock.UTC(profile.UpdatedAt),
+	}
+}
+
+// toAdminUserResponse converte domain.User para AdminUserResponse.
+func toAdminUserResponse(user *domain.User) AdminUserResponse {
+	return AdminUserResponse{
+		UserResponse: toUserResponse(user),
+		CreatedBy:    user.CreatedBy,
+		UpdatedBy:    user.UpdatedBy,
 	}
 }
@@ -0,0 +1,917 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/devleo-m/go-zero/internal/infrastructure/http/middleware"
+	"github.com/devleo-m/go-zero/internal/modules/user/application"
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/memory"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
+	"github.com/devleo-m/go-zero/internal/shared/validation"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// testPasswords usa o custo mínimo do bcrypt para manter os testes rápidos.
+var testPasswords = domain.NewPasswordService(bcrypt.MinCost)
+
+// errorBody é o envelope das respostas de erro.
+type errorBody struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+	Success bool   `json:"success"`
+}
+
+// serveJSON envia body ao router e retorna a resposta gravada.
+func serveJSON(router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestCreateUserDuplicateEmailReturnsConflict(t *testing.T) {
+	repo := memory.NewRepository()
+	handler := &Handler{createUserUseCase: application.NewCreateUserUseCase(repo, testPasswords)}
+
+	router := gin.New()
+	router.POST("/users", handler.CreateUser)
+
+	first := serveJSON(router, http.MethodPost, "/users",
+		`{"name":"Ana","email":"ana@example.com","password":"Senha-Forte-123"}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first create = %d, want %d (%s)", first.Code, http.StatusCreated, first.Body.String())
+	}
+
+	duplicate := serveJSON(router, http.MethodPost, "/users",
+		`{"name":"Outra Ana","email":"ANA@example.com","password":"Outra-Senha-456","phone":"+5511999990000"}`)
+	if duplicate.Code != http.StatusConflict {
+		t.Fatalf("duplicate create = %d, want %d (%s)", duplicate.Code, http.StatusConflict, duplicate.Body.String())
+	}
+
+	var body errorBody
+	if err := json.Unmarshal(duplicate.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+
+	if body.Success || body.Error != "USER_ALREADY_EXISTS" {
+		t.Errorf("body = %+v, want error USER_ALREADY_EXISTS", body)
+	}
+
+	// A resposta menciona apenas o email, sem expor outros campos
+	for _, leaked := range []string{"name", "phone", "password", "Ana"} {
+		if strings.Contains(body.Message, leaked) {
+			t.Errorf("message %q leaks %q", body.Message, leaked)
+		}
+	}
+}
+
+func TestGetUserByEmailQueryPreservesPlusAlias(t *testing.T) {
+	repo := memory.NewRepository()
+	alias := repositorytest.NewUser("Joao", "joao+tag@example.com", 0)
+	repositorytest.Seed(t, repo, alias, repositorytest.NewUser("Joao", "joao@example.com", 1))
+
+	handler := &Handler{getUserUseCase: application.NewGetUserUseCase(repo)}
+
+	router := gin.New()
+	router.GET("/users/by-email", handler.GetUserByEmail)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "raw plus", query: "email=joao+tag@example.com", wantCode: http.StatusOK},
+		{name: "encoded plus", query: "email=joao%2Btag%40example.com", wantCode: http.StatusOK},
+		{name: "invalid email", query: "email=not-an-email", wantCode: http.StatusBadRequest, wantErr: "INVALID_EMAIL"},
+		{name: "missing email", wantCode: http.StatusBadRequest, wantErr: "INVALID_EMAIL"},
+		{name: "unknown alias", query: "email=joao+other@example.com", wantCode: http.StatusNotFound, wantErr: "USER_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodGet, "/users/by-email?"+tt.query, "")
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			var body struct {
+				Data struct {
+					ID string `json:"id"`
+				} `json:"data"`
+				errorBody
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if tt.wantErr != "" {
+				if body.Error != tt.wantErr {
+					t.Errorf("error = %s, want %s", body.Error, tt.wantErr)
+				}
+
+				return
+			}
+
+			if body.Data.ID != alias.ID.String() {
+				t.Errorf("found user %s, want %s", body.Data.ID, alias.ID)
+			}
+		})
+	}
+}
+
+func TestListUsersFiltersByMultipleRolesAndStatuses(t *testing.T) {
+	repo := memory.NewRepository()
+
+	seed := func(name string, offset int, role domain.Role, status domain.Status) {
+		user := repositorytest.NewUser(name, name+"@example.com", offset)
+		user.Role, user.Status = role, status
+		repositorytest.Seed(t, repo, user)
+	}
+
+	seed("admin", 0, domain.RoleAdmin, domain.StatusActive)
+	seed("moderator", 1, domain.RoleModerator, domain.StatusPending)
+	seed("user", 2, domain.RoleUser, domain.StatusActive)
+	seed("super", 3, domain.RoleSuperAdmin, domain.StatusSuspended)
+
+	handler := &Handler{listUsersUseCase: application.NewListUsersUseCase(repo)}
+
+	router := gin.New()
+	router.GET("/users", handler.ListUsers)
+
+	tests := []struct {
+		name     string
+		query    string
+		want     []string
+		wantCode int
+		wantErr  string
+	}{
+		{name: "comma separated roles", query: "role=admin,moderator", want: []string{"admin", "moderator"}},
+		{name: "repeated roles", query: "role=admin&role=moderator", want: []string{"admin", "moderator"}},
+		{name: "single role", query: "role=user", want: []string{"user"}},
+		{name: "two statuses", query: "status=pending,suspended", want: []string{"moderator", "super"}},
+		{name: "roles and statuses", query: "role=admin,user,moderator&status=active", want: []string{"admin", "user"}},
+		{name: "invalid role among valid ones", query: "role=admin,owner", wantCode: http.StatusBadRequest, wantErr: "INVALID_ROLE"},
+		{name: "invalid status", query: "status=active,banned", wantCode: http.StatusBadRequest, wantErr: "INVALID_STATUS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodGet, "/users?"+tt.query, "")
+
+			wantCode := tt.wantCode
+			if wantCode == 0 {
+				wantCode = http.StatusOK
+			}
+
+			if rec.Code != wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, wantCode, rec.Body.String())
+			}
+
+			var body struct {
+				Data struct {
+					Users []struct {
+						Name string `json:"name"`
+					} `json:"users"`
+				} `json:"data"`
+				errorBody
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if tt.wantErr != "" {
+				if body.Error != tt.wantErr {
+					t.Errorf("error = %s, want %s", body.Error, tt.wantErr)
+				}
+
+				return
+			}
+
+			got := make([]string, len(body.Data.Users))
+			for i, user := range body.Data.Users {
+				got[i] = user.Name
+			}
+
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("users = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserResponsesUseUTC(t *testing.T) {
+	// Simula um servidor em outro fuso horário
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	local := time.Local
+	time.Local = saoPaulo
+
+	t.Cleanup(func() { time.Local = local })
+
+	created := time.Date(2025, 1, 6, 9, 0, 0, 0, saoPaulo)
+	lastLogin := time.Date(2025, 1, 7, 22, 30, 0, 0, time.Local)
+
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	user.CreatedAt, user.UpdatedAt, user.LastLoginAt = created, created, &lastLogin
+	repositorytest.Seed(t, repo, user)
+
+	handler := &Handler{getUserUseCase: application.NewGetUserUseCase(repo)}
+
+	router := gin.New()
+	router.GET("/users/:id", handler.GetUser)
+
+	rec := serveJSON(router, http.MethodGet, "/users/"+user.ID.String(), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+
+	want := map[string]string{
+		"created_at":    "2025-01-06T12:00:00Z",
+		"updated_at":    "2025-01-06T12:00:00Z",
+		"last_login_at": "2025-01-08T01:30:00Z",
+	}
+
+	for field, value := range want {
+		if got := body.Data[field]; got != value {
+			t.Errorf("%s = %v, want %s", field, got, value)
+		}
+	}
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, repo, user)
+
+	handler := &Handler{
+		getUserUseCase:   application.NewGetUserUseCase(repo),
+		listUsersUseCase: application.NewListUsersUseCase(repo),
+	}
+
+	router := gin.New()
+	router.GET("/users", handler.ListUsers)
+	router.GET("/users/:id", handler.GetUser)
+
+	tests := []struct {
+		name     string
+		path     string
+		want     []string
+		wantCode int
+	}{
+		{name: "get subset", path: "/users/" + user.ID.String() + "?fields=id,name,email", want: []string{"email", "id", "name"}},
+		{name: "get camelCase field", path: "/users/" + user.ID.String() + "?fields=id&fields=loginCount", want: []string{"id", "login_count"}},
+		{name: "list subset", path: "/users?fields=id,name", want: []string{"id", "name"}},
+		{name: "get unknown field", path: "/users/" + user.ID.String() + "?fields=id,password", wantCode: http.StatusBadRequest},
+		{name: "list unknown field", path: "/users?fields=name,metadata", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodGet, tt.path, "")
+
+			var body struct {
+				Data json.RawMessage `json:"data"`
+				errorBody
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if tt.wantCode != 0 {
+				if rec.Code != tt.wantCode || body.Error != "INVALID_FIELDS" {
+					t.Errorf("response = %d %s, want %d INVALID_FIELDS", rec.Code, body.Error, tt.wantCode)
+				}
+
+				return
+			}
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+			}
+
+			// A listagem traz os usuários em data.users
+			fields := map[string]json.RawMessage{}
+			if strings.HasPrefix(tt.path, "/users?") {
+				var list struct {
+					Users []map[string]json.RawMessage `json:"users"`
+				}
+				if err := json.Unmarshal(body.Data, &list); err != nil || len(list.Users) != 1 {
+					t.Fatalf("users = %s (%v)", body.Data, err)
+				}
+
+				fields = list.Users[0]
+			} else if err := json.Unmarshal(body.Data, &fields); err != nil {
+				t.Fatalf("data is not an object: %v", err)
+			}
+
+			got := make([]string, 0, len(fields))
+			for field := range fields {
+				got = append(got, field)
+			}
+
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// noPasswordHistory aceita qualquer nova senha e não guarda histórico.
+type noPasswordHistory struct{}
+
+func (noPasswordHistory) Recent(context.Context, uuid.UUID, int) ([]string, error) {
+	return nil, nil
+}
+
+func (noPasswordHistory) Add(context.Context, uuid.UUID, string, int) error {
+	return nil
+}
+
+func TestPasswordPolicyIsTheSameOverHTTPAndUseCase(t *testing.T) {
+	passwords := domain.NewPasswordService(bcrypt.MinCost).
+		WithPolicy(validation.PasswordPolicy{MinLength: 10, RequiredClasses: 3})
+
+	tests := []struct {
+		password string
+		accepted bool
+	}{
+		{password: "Senha-Forte-123", accepted: true},
+		{password: "senha-forte123", accepted: true},
+		{password: "senhaforte123", accepted: false},
+		{password: "senhafortissima", accepted: false},
+		{password: "Ab1-curta", accepted: false},
+		{password: "Çãé-senha-longa", accepted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			if accepted := passwords.CheckPolicy(tt.password) == nil; accepted != tt.accepted {
+				t.Fatalf("policy accepted = %v, want %v", accepted, tt.accepted)
+			}
+
+			repo := memory.NewRepository()
+			createUser := application.NewCreateUserUseCase(repo, passwords)
+
+			_, err := createUser.Execute(context.Background(), application.CreateUserInput{
+				Name: "Direto", Email: "direto@example.com", Password: tt.password,
+			})
+			if accepted := err == nil; accepted != tt.accepted {
+				t.Errorf("use case accepted = %v (%v), want %v", accepted, err, tt.accepted)
+			}
+
+			owner := repositorytest.NewUser("Ana", "ana@example.com", 0)
+			owner.Password, _ = passwords.Hash("Senha-Atual-123")
+			repositorytest.Seed(t, repo, owner)
+
+			handler := &Handler{
+				createUserUseCase:     createUser,
+				changePasswordUseCase: application.NewChangePasswordUseCase(repo, noPasswordHistory{}, passwords, 0),
+			}
+
+			router := gin.New()
+			router.POST("/users", handler.CreateUser)
+			router.PUT("/users/:id/password", handler.ChangePassword)
+
+			requests := map[string]*httptest.ResponseRecorder{
+				"create": serveJSON(router, http.MethodPost, "/users",
+					`{"name":"Bruno","email":"bruno@example.com","password":"`+tt.password+`"}`),
+				"change password": serveJSON(router, http.MethodPut, "/users/"+owner.ID.String()+"/password",
+					`{"current_password":"Senha-Atual-123","new_password":"`+tt.password+`"}`),
+			}
+
+			for route, rec := range requests {
+				var body errorBody
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("%s: body is not JSON: %v", route, err)
+				}
+
+				accepted := rec.Code < http.StatusBadRequest
+				if accepted != tt.accepted || (!accepted && body.Error != "INVALID_PASSWORD") {
+					t.Errorf("%s: got %d %s, want accepted %v", route, rec.Code, body.Error, tt.accepted)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateUserReportsEveryInvalidField(t *testing.T) {
+	repo := memory.NewRepository()
+	handler := &Handler{createUserUseCase: application.NewCreateUserUseCase(repo, testPasswords)}
+
+	router := gin.New()
+	router.POST("/users", handler.CreateUser)
+
+	rec := serveJSON(router, http.MethodPost, "/users", `{"name":"A","email":"not-an-email","password":""}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d (%s)", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+
+	var body struct {
+		Data struct {
+			Errors map[string]string `json:"errors"`
+		} `json:"data"`
+		errorBody
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+
+	if body.Error != "VALIDATION_ERROR" {
+		t.Errorf("error = %s, want VALIDATION_ERROR", body.Error)
+	}
+
+	// Os três campos são informados juntos, cada um com sua mensagem
+	for _, field := range []string{"name", "email", "password"} {
+		if body.Data.Errors[field] == "" {
+			t.Errorf("errors = %v, missing %s", body.Data.Errors, field)
+		}
+	}
+
+	if len(body.Data.Errors) != 3 {
+		t.Errorf("got %d field errors, want 3: %v", len(body.Data.Errors), body.Data.Errors)
+	}
+
+	malformed := serveJSON(router, http.MethodPost, "/users", `{"name":`)
+	if err := json.Unmarshal(malformed.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+
+	if malformed.Code != http.StatusBadRequest || body.Error != "INVALID_REQUEST" {
+		t.Errorf("malformed body = %d %s, want %d INVALID_REQUEST", malformed.Code, body.Error, http.StatusBadRequest)
+	}
+}
+
+func TestSelfPasswordChangeAndAdminReset(t *testing.T) {
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	user.Password, _ = testPasswords.Hash("Senha-Atual-123")
+	admin := repositorytest.NewUser("Admin", "admin@example.com", 1)
+	admin.Role = domain.RoleAdmin
+	repositorytest.Seed(t, repo, user, admin)
+
+	handler := &Handler{
+		changePasswordUseCase: application.NewChangePasswordUseCase(repo, noPasswordHistory{}, testPasswords, 0),
+	}
+
+	// Simula o AuthMiddleware: o ator vem do token, não da URL
+	actor := func(id string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Request = c.Request.WithContext(requestctx.WithActor(c.Request.Context(), id))
+		}
+	}
+
+	router := gin.New()
+	router.POST("/auth/change-password", actor(user.ID.String()), handler.ChangeOwnPassword)
+	router.POST("/anonymous/change-password", handler.ChangeOwnPassword)
+	router.POST("/admin/users/:id/password-reset", actor(admin.ID.String()), handler.ResetPassword)
+	router.POST("/self/users/:id/password-reset", actor(user.ID.String()), handler.ResetPassword)
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		wantCode int
+		wantErr  string
+		// wantPassword é a senha aceita depois da requisição
+		wantPassword string
+	}{
+		{
+			name: "self change without current password", path: "/auth/change-password",
+			body:     `{"new_password":"Nova-Senha-456"}`,
+			wantCode: http.StatusBadRequest, wantErr: "VALIDATION_ERROR", wantPassword: "Senha-Atual-123",
+		},
+		{
+			name: "self change with wrong current password", path: "/auth/change-password",
+			body:     `{"current_password":"Senha-Errada-1","new_password":"Nova-Senha-456"}`,
+			wantCode: http.StatusUnauthorized, wantErr: "INVALID_CURRENT_PASSWORD", wantPassword: "Senha-Atual-123",
+		},
+		{
+			name: "unauthenticated self change", path: "/anonymous/change-password",
+			body:     `{"current_password":"Senha-Atual-123","new_password":"Nova-Senha-456"}`,
+			wantCode: http.StatusUnauthorized, wantErr: "AUTHENTICATION_REQUIRED", wantPassword: "Senha-Atual-123",
+		},
+		{
+			name: "self change", path: "/auth/change-password",
+			body:     `{"current_password":"Senha-Atual-123","new_password":"Nova-Senha-456"}`,
+			wantCode: http.StatusOK, wantPassword: "Nova-Senha-456",
+		},
+		{
+			name: "reset own password", path: "/self/users/" + user.ID.String() + "/password-reset",
+			body:     `{"new_password":"Senha-Redefinida-789"}`,
+			wantCode: http.StatusForbidden, wantErr: "PASSWORD_RESET_FORBIDDEN", wantPassword: "Nova-Senha-456",
+		},
+		{
+			name: "admin reset bypasses current password", path: "/admin/users/" + user.ID.String() + "/password-reset",
+			body:     `{"new_password":"Senha-Redefinida-789"}`,
+			wantCode: http.StatusOK, wantPassword: "Senha-Redefinida-789",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodPost, tt.path, tt.body)
+
+			var body errorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if rec.Code != tt.wantCode || body.Error != tt.wantErr {
+				t.Fatalf("got %d %q, want %d %q (%s)", rec.Code, body.Error, tt.wantCode, tt.wantErr, rec.Body.String())
+			}
+
+			stored, err := repo.GetByID(context.Background(), user.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+
+			if stored.ValidatePassword(tt.wantPassword) != nil {
+				t.Errorf("stored password is not %s", tt.wantPassword)
+			}
+		})
+	}
+}
+
+func TestGetUsersBatchEndpoint(t *testing.T) {
+	repo := memory.NewRepository()
+	ana := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	bruno := repositorytest.NewUser("Bruno", "bruno@example.com", 1)
+	repositorytest.Seed(t, repo, ana, bruno)
+
+	handler := &Handler{getUsersBatchUseCase: application.NewGetUsersBatchUseCase(repo)}
+
+	router := gin.New()
+	router.POST("/users/batch", handler.GetUsersBatch)
+
+	missing := uuid.New()
+
+	oversized := make([]string, application.MaxBatchSize+1)
+	for i := range oversized {
+		oversized[i] = uuid.NewString()
+	}
+
+	idsBody := func(ids ...string) string {
+		body, _ := json.Marshal(map[string][]string{"ids": ids})
+		return string(body)
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    int
+		wantErr     string
+		wantEmails  []string
+		wantMissing []uuid.UUID
+	}{
+		{
+			name:        "existing and missing ids",
+			body:        idsBody(bruno.ID.String(), missing.String(), ana.ID.String()),
+			wantCode:    http.StatusOK,
+			wantEmails:  []string{"bruno@example.com", "ana@example.com"},
+			wantMissing: []uuid.UUID{missing},
+		},
+		{name: "invalid id", body: idsBody(ana.ID.String(), "not-a-uuid"), wantCode: http.StatusBadRequest, wantErr: "INVALID_ID"},
+		{name: "empty batch", body: `{"ids":[]}`, wantCode: http.StatusBadRequest, wantErr: "EMPTY_BATCH"},
+		{name: "over the cap", body: idsBody(oversized...), wantCode: http.StatusBadRequest, wantErr: "BATCH_TOO_LARGE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveJSON(router, http.MethodPost, "/users/batch", tt.body)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+
+			var body struct {
+				Data struct {
+					Users []struct {
+						Email string `json:"email"`
+					} `json:"users"`
+					Missing []uuid.UUID `json:"missing"`
+				} `json:"data"`
+				errorBody
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if body.Error != tt.wantErr {
+				t.Errorf("error = %s, want %s", body.Error, tt.wantErr)
+			}
+
+			emails := make([]string, len(body.Data.Users))
+			for i, user := range body.Data.Users {
+				emails[i] = user.Email
+			}
+
+			if !slices.Equal(emails, tt.wantEmails) {
+				t.Errorf("emails = %v, want %v", emails, tt.wantEmails)
+			}
+
+			if !slices.Equal(body.Data.Missing, tt.wantMissing) {
+				t.Errorf("missing = %v, want %v", body.Data.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestValidationMessagesInPortuguese(t *testing.T) {
+	handler := &Handler{createUserUseCase: application.NewCreateUserUseCase(memory.NewRepository(), testPasswords)}
+
+	router := gin.New()
+	router.POST("/users", handler.CreateUser)
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"A","email":"not-an-email"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var body struct {
+		Data struct {
+			Errors map[string]string `json:"errors"`
+		} `json:"data"`
+		errorBody
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest || body.Message != "Falha na validação" {
+		t.Errorf("got %d %q, want %d %q", rec.Code, body.Message, http.StatusBadRequest, "Falha na validação")
+	}
+
+	want := map[string]string{
+		"name":     "deve ter pelo menos 2 caracteres",
+		"email":    "deve ser um email válido",
+		"password": "é obrigatório",
+	}
+
+	for field, message := range want {
+		if got := body.Data.Errors[field]; got != message {
+			t.Errorf("errors[%s] = %q, want %q", field, got, message)
+		}
+	}
+}
+
+func TestPasswordChangeValidationMessagesInPortuguese(t *testing.T) {
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, repo, user)
+
+	handler := &Handler{
+		changePasswordUseCase: application.NewChangePasswordUseCase(repo, noPasswordHistory{}, testPasswords, 0),
+	}
+
+	router := gin.New()
+	router.POST("/users/:id/change-password", handler.ChangePassword)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/"+user.ID.String()+"/change-password", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "pt-BR")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var body struct {
+		Data struct {
+			Errors map[string]string `json:"errors"`
+		} `json:"data"`
+		errorBody
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest || body.Error != "VALIDATION_ERROR" || body.Message != "Falha na validação" {
+		t.Errorf("got %d %s %q, want %d VALIDATION_ERROR %q", rec.Code, body.Error, body.Message, http.StatusBadRequest, "Falha na validação")
+	}
+
+	want := map[string]string{
+		"current_password": "é obrigatório",
+		"new_password":     "é obrigatório",
+	}
+
+	if !maps.Equal(body.Data.Errors, want) {
+		t.Errorf("errors = %v, want %v", body.Data.Errors, want)
+	}
+}
+
+func TestCreateAndUpdateTrimPaddedInput(t *testing.T) {
+	repo := memory.NewRepository()
+	handler := &Handler{
+		createUserUseCase: application.NewCreateUserUseCase(repo, testPasswords),
+		updateUserUseCase: application.NewUpdateUserUseCase(repo),
+	}
+
+	router := gin.New()
+	router.POST("/users", handler.CreateUser)
+	router.PUT("/users/:id", handler.UpdateUser)
+
+	created := serveJSON(router, http.MethodPost, "/users",
+		`{"name":"  Ana    Maria ","email":"  Ana@Example.COM  ","password":"Senha-Forte-123","phone":" +5511999999999 "}`)
+	if created.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, want %d (%s)", created.Code, http.StatusCreated, created.Body.String())
+	}
+
+	stored, err := repo.GetByEmail(context.Background(), "ana@example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+
+	if stored.Name != "Ana Maria" || stored.Email != "ana@example.com" || stored.Phone == nil || *stored.Phone != "+5511999999999" {
+		t.Errorf("stored name %q, email %q, phone %v", stored.Name, stored.Email, stored.Phone)
+	}
+
+	updated := serveJSON(router, http.MethodPut, "/users/"+stored.ID.String(), `{"name":"\tAna  Souza\n"}`)
+	if updated.Code != http.StatusOK {
+		t.Fatalf("update status = %d, want %d (%s)", updated.Code, http.StatusOK, updated.Body.String())
+	}
+
+	if stored, _ = repo.GetByID(context.Background(), stored.ID); stored.Name != "Ana Souza" {
+		t.Errorf("updated name = %q, want %q", stored.Name, "Ana Souza")
+	}
+
+	// A validação vê o valor já normalizado: só espaços não contam para o mínimo
+	rejected := serveJSON(router, http.MethodPut, "/users/"+stored.ID.String(), `{"name":"  A  "}`)
+	if rejected.Code != http.StatusBadRequest {
+		t.Errorf("padded one-letter name = %d, want %d", rejected.Code, http.StatusBadRequest)
+	}
+}
+
+func TestListUsersEmptyResultPagination(t *testing.T) {
+	handler := &Handler{listUsersUseCase: application.NewListUsersUseCase(memory.NewRepository())}
+
+	router := gin.New()
+	router.GET("/users", handler.ListUsers)
+
+	rec := serveJSON(router, http.MethodGet, "/users?limit=10", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	// Ponteiros distinguem um campo zerado de um campo ausente
+	var body struct {
+		Meta struct {
+			Page       *int   `json:"page"`
+			Limit      *int   `json:"limit"`
+			Total      *int64 `json:"total"`
+			TotalPages *int   `json:"total_pages"`
+			HasNext    *bool  `json:"has_next"`
+			HasPrev    *bool  `json:"has_prev"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+
+	meta := body.Meta
+	if meta.Page == nil || meta.Limit == nil || meta.Total == nil || meta.TotalPages == nil || meta.HasNext == nil || meta.HasPrev == nil {
+		t.Fatalf("meta is missing fields: %s", rec.Body.String())
+	}
+
+	if *meta.Page != 1 || *meta.Limit != 10 || *meta.Total != 0 || *meta.TotalPages != 0 || *meta.HasNext || *meta.HasPrev {
+		t.Errorf("meta = %s, want page 1, limit 10, total 0 and no next/prev", rec.Body.String())
+	}
+}
+
+func TestPageOrEmptyGuardsNil(t *testing.T) {
+	page := pageOrEmpty(nil, 20)
+
+	if page.Page != 1 || page.PageSize != 20 || page.TotalItems != 0 || len(page.Items) != 0 {
+		t.Errorf("pageOrEmpty(nil) = %+v", page)
+	}
+
+	meta := pageMeta(page)
+	if meta.Page != 1 || meta.Total != 0 || meta.HasNext || meta.HasPrev {
+		t.Errorf("pageMeta = %+v", meta)
+	}
+}
+
+func TestEmptyBodyGetsClearMessage(t *testing.T) {
+	repo := memory.NewRepository()
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, repo, user)
+
+	handler := &Handler{
+		createUserUseCase:     application.NewCreateUserUseCase(repo, testPasswords),
+		changePasswordUseCase: application.NewChangePasswordUseCase(repo, noPasswordHistory{}, testPasswords, 0),
+	}
+
+	router := gin.New()
+	router.POST("/users", handler.CreateUser)
+	router.PUT("/users/:id/password", handler.ChangePassword)
+
+	passwordPath := "/users/" + user.ID.String() + "/password"
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{name: "create without body", path: "/users"},
+		{name: "create with whitespace body", path: "/users", body: " \n\t "},
+		{name: "change password without body", path: passwordPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := http.MethodPost
+			if tt.path == passwordPath {
+				method = http.MethodPut
+			}
+
+			rec := serveJSON(router, method, tt.path, tt.body)
+
+			var body errorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			// Nada de "EOF": a mensagem diz o que falta
+			if rec.Code != http.StatusBadRequest || body.Error != "EMPTY_BODY" || !strings.Contains(body.Message, "body is required") {
+				t.Errorf("got %d %s %q, want %d EMPTY_BODY", rec.Code, body.Error, body.Message, http.StatusBadRequest)
+			}
+		})
+	}
+
+	// Um objeto vazio tem corpo: cai na validação dos campos
+	rec := serveJSON(router, http.MethodPost, "/users", `{}`)
+
+	var body errorBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error != "VALIDATION_ERROR" {
+		t.Errorf("empty object = %d %s, want VALIDATION_ERROR", rec.Code, body.Error)
+	}
+}
+
+func TestInternalErrorAfterClientDisconnect(t *testing.T) {
+	tests := []struct {
+		name     string
+		cancel   bool
+		wantCode int
+	}{
+		{name: "client still connected", wantCode: http.StatusInternalServerError},
+		{name: "client disconnected", cancel: true, wantCode: middleware.StatusClientClosedRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			router := gin.New()
+			router.GET("/users", func(c *gin.Context) {
+				if tt.cancel {
+					cancel()
+				}
+
+				// A consulta cancelada chega ao handler como um erro qualquer
+				internalError(c, "LIST_USERS_FAILED", context.Canceled)
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil).WithContext(ctx))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			if tt.cancel && rec.Body.Len() != 0 {
+				t.Errorf("wrote a body to a client that left: %s", rec.Body.String())
+			}
+		})
+	}
+}
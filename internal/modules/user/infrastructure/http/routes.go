@@ -10,11 +10,13 @@ func SetupRoutes(router *gin.Engine, handler *Handler) {
 	{
 		users := v1.Group("/users")
 		{
-			users.POST("", handler.CreateUser)       // POST /api/v1/users
-			users.GET("", handler.ListUsers)         // GET /api/v1/users
-			users.GET("/:id", handler.GetUser)       // GET /api/v1/users/:id
-			users.PUT("/:id", handler.UpdateUser)    // PUT /api/v1/users/:id
-			users.DELETE("/:id", handler.DeleteUser) // DELETE /api/v1/users/:id
+			users.POST("", handler.CreateUser)             // POST /api/v1/users
+			users.GET("", handler.ListUsers)               // GET /api/v1/users
+			users.GET("/by-email", handler.GetUserByEmail) // GET /api/v1/users/by-email?email=
+			users.POST("/batch", handler.GetUsersBatch)    // POST /api/v1/users/batch
+			users.GET("/:id", handler.GetUser)             // GET /api/v1/users/:id
+			users.PUT("/:id", handler.UpdateUser)          // PUT /api/v1/users/:id
+			users.DELETE("/:id", handler.DeleteUser)       // DELETE /api/v1/users/:id
 		}
 	}
 }
@@ -0,0 +1,280 @@
+// Package memory implementa os repositórios do módulo de usuários em memória,
+// para testes dos casos de uso sem banco de dados.
+package memory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+// Garantir em tempo de compilação que Repository implementa as interfaces.
+var (
+	_ domain.Repository                  = (*Repository)(nil)
+	_ repository.Repository[domain.User] = (*Repository)(nil)
+)
+
+// Repository implementa domain.Repository em memória. As operações genéricas
+// vêm de repository.MemoryRepository; como na tabela users, o email é único
+// inclusive entre usuários deletados e é comparado na forma canônica.
+type Repository struct {
+	*repository.MemoryRepository[domain.User]
+	clock clock.Clock
+	// writes serializa as escritas que verificam a unicidade do email
+	writes sync.Mutex
+}
+
+// NewRepository cria um repositório em memória vazio.
+func NewRepository() *Repository {
+	return &Repository{
+		MemoryRepository: repository.NewMemoryRepository[domain.User]().
+			WithNotFoundError(domain.ErrUserNotFound),
+		clock: clock.RealClock{},
+	}
+}
+
+// WithClock define o relógio usado nos timestamps, no soft delete e nos
+// cortes de inatividade.
+func (r *Repository) WithClock(c clock.Clock) *Repository {
+	r.clock = clock.OrReal(c)
+	r.MemoryRepository.WithClock(c)
+
+	return r
+}
+
+// Create cria o usuário, retornando domain.ErrEmailAlreadyInUse se o email já
+// pertencer a outro usuário.
+func (r *Repository) Create(ctx context.Context, user *domain.User) error {
+	r.writes.Lock()
+	defer r.writes.Unlock()
+
+	taken, err := r.emailTaken(ctx, user.Email, uuid.Nil)
+	if err != nil {
+		return err
+	}
+
+	if taken {
+		return domain.ErrEmailAlreadyInUse
+	}
+
+	return r.MemoryRepository.Create(ctx, user)
+}
+
+// Update atualiza o usuário, retornando domain.ErrEmailAlreadyInUse se o novo
+// email pertencer a outro usuário.
+func (r *Repository) Update(ctx context.Context, user *domain.User) error {
+	r.writes.Lock()
+	defer r.writes.Unlock()
+
+	taken, err := r.emailTaken(ctx, user.Email, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if taken {
+		return domain.ErrEmailAlreadyInUse
+	}
+
+	return r.MemoryRepository.Update(ctx, user)
+}
+
+// FindOrCreate insere o usuário construído por build ou, se o email já estiver
+// em uso, retorna o usuário existente, como o repositório postgres.
+func (r *Repository) FindOrCreate(
+	ctx context.Context,
+	email string,
+	build func() *domain.User,
+) (*domain.User, bool, error) {
+	r.writes.Lock()
+	defer r.writes.Unlock()
+
+	taken, err := r.emailTaken(ctx, email, uuid.Nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !taken {
+		user := build()
+		if err := r.MemoryRepository.Create(ctx, user); err != nil {
+			return nil, false, err
+		}
+
+		return user, true, nil
+	}
+
+	existing, err := r.GetByEmail(ctx, email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		// O email pertence a um usuário deletado
+		return nil, false, domain.ErrEmailAlreadyInUse
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return existing, false, nil
+}
+
+// GetByID busca um usuário não deletado por ID.
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.FindOne(ctx, repository.NewQueryBuilder().WhereEqual("id", id).Build())
+}
+
+// GetByEmail busca um usuário não deletado pelo email canônico.
+func (r *Repository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.FindOne(ctx, repository.NewQueryBuilder().WhereEqual("email", domain.NormalizeEmail(email)).Build())
+}
+
+// Merge mescla source em target como o repositório postgres, sem histórico de
+// senhas: a origem recebe domain.MergedEmail e é removida com soft delete e,
+// com dryRun, nada é gravado.
+func (r *Repository) Merge(
+	ctx context.Context,
+	sourceID, targetID uuid.UUID,
+	dryRun bool,
+) (*domain.MergeResult, error) {
+	if sourceID == targetID {
+		return nil, domain.ErrSelfMerge
+	}
+
+	r.writes.Lock()
+	defer r.writes.Unlock()
+
+	source, err := r.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := r.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Os metadados são compartilhados com o registro armazenado
+	target.Metadata = maps.Clone(target.Metadata)
+	target.MergeFrom(source, r.clock.Now())
+
+	result := &domain.MergeResult{SourceID: sourceID, TargetID: targetID, Target: target}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := r.MemoryRepository.Update(ctx, target); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.UpdateMany(ctx, repository.NewQueryBuilder().WhereEqual("id", sourceID).Build(), map[string]interface{}{
+		"email":      domain.MergedEmail(sourceID),
+		"deleted_at": r.clock.Now().UTC(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// List lista usuários não deletados, do mais recente para o mais antigo.
+func (r *Repository) List(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	return r.FindMany(ctx, repository.QueryFilter{Limit: limit, Offset: offset})
+}
+
+// FindUsersByLastLogin busca, paginados, os usuários cujo último login é
+// anterior a N dias ou que nunca fizeram login, na ordem do repositório
+// postgres: quem nunca entrou primeiro, depois o login mais antigo.
+func (r *Repository) FindUsersByLastLogin(
+	ctx context.Context,
+	days, page, pageSize int,
+) (*repository.PaginatedResult[domain.User], error) {
+	cutoff := r.clock.Now().AddDate(0, 0, -days)
+
+	return r.findPage(ctx, page, pageSize, func(user *domain.User) bool {
+		return user.LastLoginAt == nil || user.LastLoginAt.Before(cutoff)
+	}, func(a, b *domain.User) int {
+		switch {
+		case a.LastLoginAt == nil && b.LastLoginAt != nil:
+			return -1
+		case a.LastLoginAt != nil && b.LastLoginAt == nil:
+			return 1
+		case a.LastLoginAt != nil:
+			if result := a.LastLoginAt.Compare(*b.LastLoginAt); result != 0 {
+				return result
+			}
+		}
+
+		return compareCreated(a, b)
+	})
+}
+
+// FindUsersByEmailDomain busca, paginados, os usuários cujo email pertence
+// exatamente ao domínio informado, do mais antigo para o mais recente.
+func (r *Repository) FindUsersByEmailDomain(
+	ctx context.Context,
+	emailDomain string,
+	page, pageSize int,
+) (*repository.PaginatedResult[domain.User], error) {
+	emailDomain = strings.ToLower(emailDomain)
+
+	return r.findPage(ctx, page, pageSize, func(user *domain.User) bool {
+		_, userDomain, _ := strings.Cut(user.Email, "@")
+
+		return strings.ToLower(userDomain) == emailDomain
+	}, compareCreated)
+}
+
+// findPage pagina os usuários não deletados que satisfazem match, na ordem de compare.
+func (r *Repository) findPage(
+	ctx context.Context,
+	page, pageSize int,
+	match func(*domain.User) bool,
+	compare func(a, b *domain.User) int,
+) (*repository.PaginatedResult[domain.User], error) {
+	pageFilter := repository.QueryFilter{Page: page, PageSize: pageSize}
+	if err := pageFilter.CheckDepth(); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = pageFilter.NormalizedPage()
+
+	users, err := r.FindMany(ctx, repository.QueryFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	users = slices.DeleteFunc(users, func(user *domain.User) bool { return !match(user) })
+	slices.SortStableFunc(users, compare)
+
+	total := len(users)
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+
+	return repository.NewPaginatedResult(users[start:end], int64(total), page, pageSize), nil
+}
+
+// emailTaken informa se o email canônico pertence a outro usuário, deletado ou não.
+func (r *Repository) emailTaken(ctx context.Context, email string, except uuid.UUID) (bool, error) {
+	filter := repository.NewQueryBuilder().
+		WhereEqual("email", domain.NormalizeEmail(email)).
+		Where("id", repository.OpNotEqual, except).
+		IncludeDeleted().
+		Build()
+
+	return r.Exists(ctx, filter)
+}
+
+// compareCreated ordena por criação (mais antigo primeiro) e desempata pelo ID.
+func compareCreated(a, b *domain.User) int {
+	if result := a.CreatedAt.Compare(b.CreatedAt); result != 0 {
+		return result
+	}
+
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+)
+
+func TestRepositoryContract(t *testing.T) {
+	repositorytest.Run(t, func(*testing.T) domain.Repository {
+		return NewRepository()
+	})
+}
@@ -0,0 +1,116 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/application"
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+)
+
+// Sender envia uma mensagem para um destinatário (número de telefone ou ID de dispositivo).
+type Sender interface {
+	Send(ctx context.Context, to, message string) error
+}
+
+// Config representa a configuração do Dispatcher.
+type Config struct {
+	// SMS e Push são opcionais; o SMS tem prioridade quando ambos estão definidos.
+	SMS  Sender
+	Push Sender
+	// Events lista os eventos que geram notificação.
+	Events []string
+}
+
+// Dispatcher implementa application.NotificationService enviando SMS ou push
+// para os eventos de segurança configurados.
+type Dispatcher struct {
+	sms    Sender
+	push   Sender
+	events map[application.SecurityEvent]bool
+}
+
+// Garantir em tempo de compilação que Dispatcher implementa a interface.
+var _ application.NotificationService = (*Dispatcher)(nil)
+
+// NewDispatcher cria um novo Dispatcher.
+func NewDispatcher(config Config) *Dispatcher {
+	events := make(map[application.SecurityEvent]bool, len(config.Events))
+	for _, event := range config.Events {
+		events[application.SecurityEvent(event)] = true
+	}
+
+	return &Dispatcher{
+		sms:    config.SMS,
+		push:   config.Push,
+		events: events,
+	}
+}
+
+// NotifySecurityEvent envia a notificação do evento quando ele está habilitado
+// e o usuário possui telefone cadastrado.
+func (d *Dispatcher) NotifySecurityEvent(
+	ctx context.Context,
+	user *domain.User,
+	event application.SecurityEvent,
+) error {
+	if !d.events[event] || user == nil || user.Phone == nil || *user.Phone == "" {
+		return nil
+	}
+
+	message := messageFor(event)
+
+	switch {
+	case d.sms != nil:
+		if err := d.sms.Send(ctx, *user.Phone, message); err != nil {
+			return fmt.Errorf("failed to send sms notification: %w", err)
+		}
+	case d.push != nil:
+		if err := d.push.Send(ctx, user.ID.String(), message); err != nil {
+			return fmt.Errorf("failed to send push notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// messageFor retorna o texto da notificação de um evento.
+func messageFor(event application.SecurityEvent) string {
+	switch event {
+	case application.SecurityEventNewDeviceLogin:
+		return "A new device signed in to your account. If this wasn't you, reset your password."
+	case application.SecurityEventPasswordChanged:
+		return "Your password was changed. If this wasn't you, contact support immediately."
+	case application.SecurityEventRoleElevated:
+		return "Your account permissions were changed."
+	case application.SecurityEventStatusChanged:
+		return "Your account status was changed. If you did not expect this, contact support."
+	default:
+		return "There was a security-related change on your account."
+	}
+}
+
+// LogSender é um Sender que apenas registra as mensagens no log (útil em desenvolvimento).
+type LogSender struct {
+	logger  *zap.Logger
+	channel string
+}
+
+// NewLogSender cria um Sender que registra as mensagens no log.
+func NewLogSender(logger *zap.Logger, channel string) *LogSender {
+	return &LogSender{logger: logger, channel: channel}
+}
+
+// Send registra a mensagem no log.
+func (s *LogSender) Send(_ context.Context, to, message string) error {
+	s.logger.Info("Notification sent",
+		zap.String("component", "notification"),
+		zap.String("channel", s.channel),
+		zap.String("to", to),
+		zap.String("message", message),
+	)
+
+	return nil
+}
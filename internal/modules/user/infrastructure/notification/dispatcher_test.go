@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/application"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+)
+
+// recordingSender registra os destinatários das mensagens enviadas.
+type recordingSender struct {
+	err error
+	to  []string
+}
+
+func (s *recordingSender) Send(_ context.Context, to, _ string) error {
+	s.to = append(s.to, to)
+
+	return s.err
+}
+
+func TestDispatcherNotifySecurityEvent(t *testing.T) {
+	phone := "+5511999990000"
+
+	withPhone := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	withPhone.Phone = &phone
+	withoutPhone := repositorytest.NewUser("Bruno", "bruno@example.com", 1)
+
+	events := []string{string(application.SecurityEventPasswordChanged)}
+
+	tests := []struct {
+		name     string
+		smsErr   error
+		event    application.SecurityEvent
+		withSMS  bool
+		wantSMS  int
+		wantPush int
+		wantErr  bool
+		noPhone  bool
+	}{
+		{name: "configured event goes by sms", event: application.SecurityEventPasswordChanged, withSMS: true, wantSMS: 1},
+		{name: "push when sms is not configured", event: application.SecurityEventPasswordChanged, wantPush: 1},
+		{name: "event not configured", event: application.SecurityEventRoleElevated, withSMS: true},
+		{name: "user without phone", event: application.SecurityEventPasswordChanged, withSMS: true, noPhone: true},
+		{
+			name:    "sender failure",
+			event:   application.SecurityEventPasswordChanged,
+			withSMS: true,
+			smsErr:  errors.New("gateway down"),
+			wantSMS: 1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sms := &recordingSender{err: tt.smsErr}
+			push := &recordingSender{}
+
+			config := Config{Push: push, Events: events}
+			if tt.withSMS {
+				config.SMS = sms
+			}
+
+			user := withPhone
+			if tt.noPhone {
+				user = withoutPhone
+			}
+
+			err := NewDispatcher(config).NotifySecurityEvent(context.Background(), user, tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NotifySecurityEvent error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(sms.to) != tt.wantSMS || len(push.to) != tt.wantPush {
+				t.Errorf("sent %d sms and %d push, want %d and %d", len(sms.to), len(push.to), tt.wantSMS, tt.wantPush)
+			}
+
+			if tt.wantSMS > 0 && sms.to[0] != phone {
+				t.Errorf("sms sent to %s, want %s", sms.to[0], phone)
+			}
+
+			if tt.wantPush > 0 && push.to[0] != user.ID.String() {
+				t.Errorf("push sent to %s, want %s", push.to[0], user.ID)
+			}
+		})
+	}
+}
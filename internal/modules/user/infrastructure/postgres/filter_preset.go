@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// Garantir em tempo de compilação que FilterPresetRepository implementa a interface.
+var _ domain.FilterPresetRepository = (*FilterPresetRepository)(nil)
+
+// filterCriteria mapeia os critérios de um preset para uma coluna JSONB.
+type filterCriteria domain.FilterCriteria
+
+// Value serializa os critérios para gravação.
+func (c filterCriteria) Value() (driver.Value, error) {
+	raw, err := json.Marshal(domain.FilterCriteria(c))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode jsonb: %w", err)
+	}
+
+	return string(raw), nil
+}
+
+// Scan lê o JSONB retornado pelo driver.
+func (c *filterCriteria) Scan(value interface{}) error {
+	var raw []byte
+
+	switch v := value.(type) {
+	case nil:
+		*c = filterCriteria{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported jsonb value type %T", value)
+	}
+
+	var decoded domain.FilterCriteria
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Errorf("failed to decode jsonb: %w", err)
+	}
+
+	*c = filterCriteria(decoded)
+
+	return nil
+}
+
+// FilterPresetModel representa o modelo GORM de um preset de filtro.
+type FilterPresetModel struct {
+	CreatedAt time.Time      `gorm:"not null"`
+	UpdatedAt time.Time      `gorm:"not null"`
+	Name      string         `gorm:"size:64;not null;uniqueIndex:uq_user_filter_presets_admin_name"`
+	Criteria  filterCriteria `gorm:"type:jsonb;not null;default:'{}'"`
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key"`
+	AdminID   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:uq_user_filter_presets_admin_name"`
+}
+
+// TableName define o nome da tabela.
+func (FilterPresetModel) TableName() string {
+	return "user_filter_presets"
+}
+
+// toDomain converte o modelo para a entidade de domínio.
+func (m *FilterPresetModel) toDomain() *domain.FilterPreset {
+	return &domain.FilterPreset{
+		ID:        m.ID,
+		AdminID:   m.AdminID,
+		Name:      m.Name,
+		Criteria:  domain.FilterCriteria(m.Criteria),
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// FilterPresetRepository implementa domain.FilterPresetRepository usando GORM.
+type FilterPresetRepository struct {
+	clock clock.Clock
+	db    *gorm.DB
+}
+
+// NewFilterPresetRepository cria uma nova instância do repositório.
+func NewFilterPresetRepository(db *gorm.DB) *FilterPresetRepository {
+	return &FilterPresetRepository{clock: clock.RealClock{}, db: db}
+}
+
+// WithClock define o relógio usado nas datas gravadas (útil em testes).
+func (r *FilterPresetRepository) WithClock(c clock.Clock) *FilterPresetRepository {
+	r.clock = clock.OrReal(c)
+
+	return r
+}
+
+// List retorna os presets do administrador ordenados por nome.
+func (r *FilterPresetRepository) List(ctx context.Context, adminID uuid.UUID) ([]*domain.FilterPreset, error) {
+	var models []FilterPresetModel
+
+	if err := r.db.WithContext(ctx).
+		Where("admin_id = ?", adminID).
+		Order("name").
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to list filter presets: %w", err)
+	}
+
+	presets := make([]*domain.FilterPreset, len(models))
+	for i := range models {
+		presets[i] = models[i].toDomain()
+	}
+
+	return presets, nil
+}
+
+// Get busca um preset do administrador pelo nome.
+func (r *FilterPresetRepository) Get(ctx context.Context, adminID uuid.UUID, name string) (*domain.FilterPreset, error) {
+	var model FilterPresetModel
+
+	if err := r.db.WithContext(ctx).
+		Where("admin_id = ? AND name = ?", adminID, name).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrFilterPresetNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get filter preset: %w", err)
+	}
+
+	return model.toDomain(), nil
+}
+
+// Save insere o preset ou, se o administrador já tiver um com o mesmo nome,
+// substitui seus critérios em um único comando. O RETURNING traz o ID e a
+// criação da linha gravada, que diferem dos informados quando ela já existia.
+func (r *FilterPresetRepository) Save(ctx context.Context, preset *domain.FilterPreset) (bool, error) {
+	now := clock.UTC(r.clock.Now())
+	model := &FilterPresetModel{
+		ID:        preset.ID,
+		AdminID:   preset.AdminID,
+		Name:      preset.Name,
+		Criteria:  filterCriteria(preset.Criteria),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := r.db.WithContext(ctx).
+		Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "admin_id"}, {Name: "name"}},
+				DoUpdates: clause.AssignmentColumns([]string{"criteria", "updated_at"}),
+			},
+			clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "created_at"}}},
+		).
+		Create(model).Error; err != nil {
+		return false, fmt.Errorf("failed to save filter preset: %w", err)
+	}
+
+	created := model.ID == preset.ID
+
+	preset.ID = model.ID
+	preset.CreatedAt = model.CreatedAt
+	preset.UpdatedAt = now
+
+	return created, nil
+}
+
+// Delete remove um preset do administrador pelo nome.
+func (r *FilterPresetRepository) Delete(ctx context.Context, adminID uuid.UUID, name string) error {
+	result := r.db.WithContext(ctx).
+		Where("admin_id = ? AND name = ?", adminID, name).
+		Delete(&FilterPresetModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete filter preset: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrFilterPresetNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,632 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/modules/user/infrastructure/repositorytest"
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+// newTestDB conecta ao banco de TEST_DATABASE_URL em um schema temporário,
+// criado com as tabelas do módulo e removido ao fim do teste.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+
+		if sqlDB, err := admin.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	db, err := gorm.Open(postgres.Open(withSearchPath(dsn, schema)), &gorm.Config{
+		Logger:         logger.Discard,
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("connect to schema: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	if err := db.AutoMigrate(&UserModel{}, &PasswordHistoryModel{}, &UserProfileModel{}, &FilterPresetModel{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	// Mesmo índice da migração 000007, que o AutoMigrate não cria
+	if err := db.Exec(
+		"CREATE UNIQUE INDEX idx_users_email_lower ON users (LOWER(email)) WHERE deleted_at IS NULL",
+	).Error; err != nil {
+		t.Fatalf("create email index: %v", err)
+	}
+
+	return db
+}
+
+// withSearchPath acrescenta o search_path ao DSN, em formato URL ou chave=valor.
+func withSearchPath(dsn, schema string) string {
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+
+		return dsn + separator + "search_path=" + schema
+	}
+
+	return fmt.Sprintf("%s search_path=%s", dsn, schema)
+}
+
+func TestRepositoryContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) domain.Repository {
+		return NewRepository(newTestDB(t))
+	})
+}
+
+func TestRepositoryUsesClock(t *testing.T) {
+	ctx := context.Background()
+	frozen := repositorytest.BaseTime.AddDate(0, 0, 30)
+	repo := NewRepository(newTestDB(t)).WithClock(clock.NewFakeClock(frozen))
+
+	// Com o relógio congelado, o corte de 10 dias cai entre os dois logins
+	recent := frozen.AddDate(0, 0, -5)
+	stale := frozen.AddDate(0, 0, -20)
+
+	active := repositorytest.NewUser("Ativo", "active@example.com", 0)
+	active.LastLoginAt = &recent
+	inactive := repositorytest.NewUser("Inativo", "inactive@example.com", 1)
+	inactive.LastLoginAt = &stale
+	repositorytest.Seed(t, repo, active, inactive)
+
+	result, err := repo.FindUsersByLastLogin(ctx, 10, 1, 10)
+	if err != nil {
+		t.Fatalf("FindUsersByLastLogin: %v", err)
+	}
+
+	if len(result.Items) != 1 || result.Items[0].ID != inactive.ID {
+		t.Fatalf("got %d inactive users, want only %s", len(result.Items), inactive.ID)
+	}
+
+	if err := repo.Delete(ctx, active.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	deleted, err := repo.FindOne(ctx, repository.NewQueryBuilder().WhereEqual("id", active.ID).IncludeDeleted().Build())
+	if err != nil {
+		t.Fatalf("FindOne: %v", err)
+	}
+
+	if deleted.DeletedAt == nil || !deleted.DeletedAt.Equal(frozen) {
+		t.Errorf("deleted_at = %v, want %s", deleted.DeletedAt, frozen)
+	}
+}
+
+func TestFindOrCreateCaseInsensitiveConflict(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+
+	// Registro legado gravado antes da normalização dos emails
+	legacy := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	legacy.Email = "Ana@Example.com"
+
+	if err := db.Create(toModel(legacy)).Error; err != nil {
+		t.Fatalf("create legacy user: %v", err)
+	}
+
+	// O conflito é no índice LOWER(email), fora do ON CONFLICT (email)
+	_, _, err := repo.FindOrCreate(context.Background(), "ana@example.com", func() *domain.User {
+		return repositorytest.NewUser("Ana", "ana@example.com", 1)
+	})
+	if !errors.Is(err, domain.ErrEmailAlreadyInUse) {
+		t.Errorf("FindOrCreate error = %v, want %v", err, domain.ErrEmailAlreadyInUse)
+	}
+}
+
+func TestPaginateCachesCount(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// Conta as consultas de total executadas pelo GORM
+	counts := 0
+	if err := db.Callback().Query().After("gorm:query").Register("test:count_queries", func(tx *gorm.DB) {
+		if strings.Contains(strings.ToLower(tx.Statement.SQL.String()), "count(") {
+			counts++
+		}
+	}); err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	fake := clock.NewFakeClock(repositorytest.BaseTime)
+	repo := NewRepository(db).WithCountCache(cache.NewMemoryCache().WithClock(fake), time.Minute)
+
+	users := make([]*domain.User, 5)
+	for i := range users {
+		users[i] = repositorytest.NewUser(fmt.Sprintf("Usuário %d", i), fmt.Sprintf("user%d@example.com", i), i)
+	}
+
+	repositorytest.Seed(t, repo, users...)
+
+	paginate := func(filter repository.QueryFilter) {
+		t.Helper()
+
+		result, err := repo.Paginate(ctx, filter)
+		if err != nil {
+			t.Fatalf("Paginate: %v", err)
+		}
+
+		if result.TotalItems != 5 && !filter.IncludeDeleted {
+			t.Errorf("TotalItems = %d, want 5", result.TotalItems)
+		}
+	}
+
+	for page := 1; page <= 3; page++ {
+		paginate(repository.QueryFilter{Page: page, PageSize: 2})
+	}
+
+	if counts != 1 {
+		t.Fatalf("count ran %d times across three pages, want 1", counts)
+	}
+
+	paginate(repository.QueryFilter{Page: 1, PageSize: 2, SkipCountCache: true})
+
+	if counts != 2 {
+		t.Errorf("SkipCountCache did not bypass the cache: %d counts", counts)
+	}
+
+	// O escopo de soft delete faz parte da chave
+	paginate(repository.QueryFilter{Page: 1, PageSize: 2, IncludeDeleted: true})
+
+	if counts != 3 {
+		t.Errorf("IncludeDeleted reused the scoped total: %d counts", counts)
+	}
+
+	fake.Advance(time.Minute + time.Second)
+	paginate(repository.QueryFilter{Page: 2, PageSize: 2})
+
+	if counts != 4 {
+		t.Errorf("count not recomputed after the TTL: %d counts", counts)
+	}
+}
+
+func TestGroupByRoleRunsOnPostgres(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository(newTestDB(t))
+
+	admin := repositorytest.NewUser("Admin", "admin@example.com", 0)
+	admin.Role = domain.RoleAdmin
+	moderator := repositorytest.NewUser("Moderadora", "moderator@example.com", 1)
+	moderator.Role = domain.RoleModerator
+	removed := repositorytest.NewUser("Removido", "removed@example.com", 4)
+	removed.Role = domain.RoleModerator
+	repositorytest.Seed(t, repo, admin, moderator, removed,
+		repositorytest.NewUser("Ana", "ana@example.com", 2),
+		repositorytest.NewUser("Bruno", "bruno@example.com", 3),
+	)
+
+	if err := repo.Delete(ctx, removed.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	// A ordenação do filtro usa uma coluna fora do agrupamento e precisa ser ignorada
+	filter := repository.NewQueryBuilder().
+		Where("email", repository.OpLike, "%@example.com").
+		OrderBy("name ASC").
+		Build()
+
+	groups, err := repo.GroupBy(ctx, "role", filter)
+	if err != nil {
+		t.Fatalf("GroupBy(role): %v", err)
+	}
+
+	got := make([]string, len(groups))
+	for i, group := range groups {
+		got[i] = fmt.Sprintf("%v=%d", group.Group, group.Count)
+	}
+
+	if len(got) != 3 || got[0] != "user=2" || !slices.Contains(got, "admin=1") || !slices.Contains(got, "moderator=1") {
+		t.Errorf("GroupBy(role) = %v, want user=2 first, then admin=1 and moderator=1", got)
+	}
+}
+
+func TestPasswordHistoryPrunesToKeep(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	fake := clock.NewFakeClock(repositorytest.BaseTime)
+	history := NewPasswordHistoryRepository(db).WithClock(fake)
+
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, NewRepository(db), user)
+
+	for _, hash := range []string{"hash-1", "hash-2", "hash-3", "hash-4"} {
+		if err := history.Add(ctx, user.ID, hash, 2); err != nil {
+			t.Fatalf("Add(%s): %v", hash, err)
+		}
+
+		fake.Advance(time.Minute)
+	}
+
+	hashes, err := history.Recent(ctx, user.ID, 10)
+	if err != nil {
+		t.Fatalf("Recent: %v", err)
+	}
+
+	if fmt.Sprint(hashes) != "[hash-4 hash-3]" {
+		t.Errorf("Recent = %v, want [hash-4 hash-3]", hashes)
+	}
+}
+
+func TestRepositoryReadsTimestampsInUTC(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository(newTestDB(t))
+
+	brt := time.FixedZone("BRT", -3*60*60)
+	lastLogin := time.Date(2025, 1, 7, 22, 30, 0, 0, brt)
+
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	user.CreatedAt = time.Date(2025, 1, 6, 9, 0, 0, 0, brt)
+	user.UpdatedAt = user.CreatedAt
+	user.LastLoginAt = &lastLogin
+	repositorytest.Seed(t, repo, user)
+
+	found, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	for name, value := range map[string]time.Time{
+		"created_at":    found.CreatedAt,
+		"updated_at":    found.UpdatedAt,
+		"last_login_at": *found.LastLoginAt,
+	} {
+		if value.Location() != time.UTC {
+			t.Errorf("%s = %v, want UTC", name, value)
+		}
+	}
+
+	if !found.CreatedAt.Equal(user.CreatedAt) || !found.LastLoginAt.Equal(lastLogin) {
+		t.Errorf("timestamps changed instant: created %v, last login %v", found.CreatedAt, found.LastLoginAt)
+	}
+}
+
+func TestFindManyQueryGuard(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	capped := NewRepository(db).WithQueryGuard(repository.QueryGuard{MaxRows: 2}).WithLogger(zap.New(core))
+	repositorytest.Seed(t, capped,
+		repositorytest.NewUser("Ana", "ana@example.com", 0),
+		repositorytest.NewUser("Bruno", "bruno@example.com", 1),
+		repositorytest.NewUser("Carla", "carla@example.com", 2),
+	)
+
+	users, err := capped.FindMany(ctx, repository.QueryFilter{})
+	if err != nil {
+		t.Fatalf("FindMany: %v", err)
+	}
+
+	if len(users) != 2 || logs.FilterMessage("Unbounded user query capped").Len() != 1 {
+		t.Errorf("got %d users and %d warnings, want 2 and 1", len(users), logs.Len())
+	}
+
+	strict := NewRepository(db).WithQueryGuard(repository.QueryGuard{Strict: true})
+
+	if _, err := strict.FindMany(ctx, repository.QueryFilter{}); !errors.Is(err, repository.ErrUnboundedQuery) {
+		t.Errorf("strict FindMany = %v, want %v", err, repository.ErrUnboundedQuery)
+	}
+
+	if users, err := strict.FindMany(ctx, repository.QueryFilter{Limit: 10}); err != nil || len(users) != 3 {
+		t.Errorf("strict FindMany with limit = %d users, %v", len(users), err)
+	}
+}
+
+func TestMergeMovesPasswordHistory(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	history := NewPasswordHistoryRepository(db)
+
+	source := repositorytest.NewUser("Ana", "ana.dup@example.com", 0)
+	target := repositorytest.NewUser("Ana", "ana@example.com", 1)
+	repositorytest.Seed(t, repo, source, target)
+
+	for _, hash := range []string{"hash-1", "hash-2"} {
+		if err := history.Add(ctx, source.ID, hash, 5); err != nil {
+			t.Fatalf("Add(%s): %v", hash, err)
+		}
+	}
+
+	// A simulação desfaz a transação inteira
+	preview, err := repo.Merge(ctx, source.ID, target.ID, true)
+	if err != nil {
+		t.Fatalf("Merge dry run: %v", err)
+	}
+
+	if preview.PasswordHistoryMoved != 2 {
+		t.Errorf("dry run PasswordHistoryMoved = %d, want 2", preview.PasswordHistoryMoved)
+	}
+
+	if _, err := repo.GetByID(ctx, source.ID); err != nil {
+		t.Fatalf("GetByID(source) after dry run: %v", err)
+	}
+
+	if _, err := repo.Merge(ctx, source.ID, target.ID, false); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, source.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("GetByID(source) = %v, want %v", err, domain.ErrUserNotFound)
+	}
+
+	hashes, err := history.Recent(ctx, target.ID, 10)
+	if err != nil {
+		t.Fatalf("Recent: %v", err)
+	}
+
+	if len(hashes) != 2 {
+		t.Errorf("target history = %v, want the 2 source hashes", hashes)
+	}
+
+	if _, err := repo.Merge(ctx, target.ID, target.ID, false); !errors.Is(err, domain.ErrSelfMerge) {
+		t.Errorf("self Merge = %v, want %v", err, domain.ErrSelfMerge)
+	}
+}
+
+func TestRepositoryPersistsActors(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository(newTestDB(t))
+
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	user.CreatedBy, user.UpdatedBy = "admin-1", "admin-1"
+	repositorytest.Seed(t, repo, user)
+
+	user.UpdatedBy = "admin-2"
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.CreatedBy != "admin-1" || stored.UpdatedBy != "admin-2" {
+		t.Errorf("created by %q, updated by %q, want admin-1 and admin-2", stored.CreatedBy, stored.UpdatedBy)
+	}
+}
+
+func TestCountMatchesFilteredPage(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository(newTestDB(t))
+
+	users := make([]*domain.User, 100)
+	for i := range users {
+		users[i] = repositorytest.NewUser(fmt.Sprintf("user-%03d", i), fmt.Sprintf("user%03d@example.com", i), i)
+		if i%4 == 0 {
+			users[i].Role = domain.RoleAdmin
+		}
+	}
+
+	repositorytest.Seed(t, repo, users...)
+
+	filter := domain.RoleSpecification(domain.RoleAdmin).ToQueryFilter()
+	filter.Limit = 10
+
+	page, err := repo.FindMany(ctx, filter)
+	if err != nil {
+		t.Fatalf("FindMany: %v", err)
+	}
+
+	// O total ignora limit e offset, mas não as condições
+	total, err := repo.Count(ctx, filter)
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+
+	if len(page) != 10 || total != 25 {
+		t.Errorf("got %d users of %d, want 10 of 25", len(page), total)
+	}
+}
+
+func TestFindManyByMetadata(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository(newTestDB(t))
+
+	ana := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	ana.Metadata = domain.Metadata{"plan": "pro", "seats": 3}
+	bruno := repositorytest.NewUser("Bruno", "bruno@example.com", 1)
+	bruno.Metadata = domain.Metadata{"plan": "free"}
+	carla := repositorytest.NewUser("Carla", "carla@example.com", 2)
+	repositorytest.Seed(t, repo, ana, bruno, carla)
+
+	stored, err := repo.GetByID(ctx, ana.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	// Números voltam do JSONB como float64
+	if stored.Metadata["plan"] != "pro" || stored.Metadata["seats"] != float64(3) {
+		t.Errorf("stored metadata = %v", stored.Metadata)
+	}
+
+	tests := []struct {
+		name   string
+		filter repository.QueryFilter
+		want   []string
+	}{
+		{name: "contains value", filter: domain.MetadataSpecification("plan", "pro").ToQueryFilter(), want: []string{"Ana"}},
+		{name: "has key", filter: repository.NewQueryBuilder().WhereJSONHasKey("metadata", "plan").Build(), want: []string{"Ana", "Bruno"}},
+		{name: "missing key", filter: repository.NewQueryBuilder().WhereJSONHasKey("metadata", "team").Build(), want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Limit = 10
+
+			users, err := repo.FindMany(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("FindMany: %v", err)
+			}
+
+			got := make([]string, len(users))
+			for i, user := range users {
+				got[i] = user.Name
+			}
+
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("users = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileUpsert(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	fake := clock.NewFakeClock(repositorytest.BaseTime)
+	profiles := NewProfileRepository(db).WithClock(fake)
+
+	user := repositorytest.NewUser("Ana", "ana@example.com", 0)
+	repositorytest.Seed(t, NewRepository(db), user)
+
+	if _, err := profiles.Get(ctx, user.ID); !errors.Is(err, domain.ErrProfileNotFound) {
+		t.Fatalf("Get before upsert = %v, want %v", err, domain.ErrProfileNotFound)
+	}
+
+	if err := profiles.Upsert(ctx, domain.NewUserProfile(user.ID, fake.Now())); err != nil {
+		t.Fatalf("Upsert empty profile: %v", err)
+	}
+
+	// O segundo upsert substitui os campos do mesmo registro
+	fake.Advance(time.Hour)
+
+	profile := &domain.UserProfile{UserID: user.ID, AvatarURL: "https://cdn.example.com/ana.png", Bio: "Olá", Locale: "pt-BR"}
+	if err := profiles.Upsert(ctx, profile); err != nil {
+		t.Fatalf("Upsert filled profile: %v", err)
+	}
+
+	stored, err := profiles.Get(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if stored.AvatarURL != profile.AvatarURL || stored.Bio != "Olá" || stored.Locale != "pt-BR" {
+		t.Errorf("stored = %+v, want %+v", stored, profile)
+	}
+
+	if !stored.UpdatedAt.Equal(fake.Now()) {
+		t.Errorf("UpdatedAt = %s, want %s", stored.UpdatedAt, fake.Now())
+	}
+
+	var count int64
+	if err := db.Model(&UserProfileModel{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+		t.Fatalf("count profiles: %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("got %d profile rows, want 1", count)
+	}
+}
+
+func TestFilterPresetRepository(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(repositorytest.BaseTime)
+	presets := NewFilterPresetRepository(newTestDB(t)).WithClock(fake)
+	adminID, otherAdminID := uuid.New(), uuid.New()
+
+	preset, err := domain.NewFilterPreset(adminID, "suspended", domain.FilterCriteria{
+		Statuses: []domain.Status{domain.StatusSuspended},
+	}, fake.Now())
+	if err != nil {
+		t.Fatalf("NewFilterPreset: %v", err)
+	}
+
+	if created, err := presets.Save(ctx, preset); err != nil || !created {
+		t.Fatalf("Save = %v, %v, want created", created, err)
+	}
+
+	// Mesmo nome: os critérios são substituídos no registro original
+	fake.Advance(time.Hour)
+
+	replacement, _ := domain.NewFilterPreset(adminID, "suspended", domain.FilterCriteria{
+		Statuses: []domain.Status{domain.StatusSuspended},
+		Search:   "ana",
+	}, fake.Now())
+
+	if created, err := presets.Save(ctx, replacement); err != nil || created {
+		t.Fatalf("Save replacement = %v, %v, want replaced", created, err)
+	}
+
+	if replacement.ID != preset.ID || !replacement.CreatedAt.Equal(preset.CreatedAt) {
+		t.Errorf("replacement id %s created at %v, want %s at %v",
+			replacement.ID, replacement.CreatedAt, preset.ID, preset.CreatedAt)
+	}
+
+	stored, err := presets.Get(ctx, adminID, "suspended")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if stored.Criteria.Search != "ana" || !slices.Equal(stored.Criteria.Statuses, []domain.Status{domain.StatusSuspended}) {
+		t.Errorf("stored criteria = %+v", stored.Criteria)
+	}
+
+	if _, err := presets.Get(ctx, otherAdminID, "suspended"); !errors.Is(err, domain.ErrFilterPresetNotFound) {
+		t.Errorf("Get by another admin = %v, want %v", err, domain.ErrFilterPresetNotFound)
+	}
+
+	if err := presets.Delete(ctx, adminID, "suspended"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if err := presets.Delete(ctx, adminID, "suspended"); !errors.Is(err, domain.ErrFilterPresetNotFound) {
+		t.Errorf("second Delete = %v, want %v", err, domain.ErrFilterPresetNotFound)
+	}
+
+	if list, err := presets.List(ctx, adminID); err != nil || len(list) != 0 {
+		t.Errorf("List after delete = %d presets, %v", len(list), err)
+	}
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonMap mapeia um objeto JSON para uma coluna JSONB.
+type jsonMap map[string]interface{}
+
+// Value serializa o mapa para gravação; nil é gravado como objeto vazio.
+func (m jsonMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode jsonb: %w", err)
+	}
+
+	return string(raw), nil
+}
+
+// Scan lê o JSONB retornado pelo driver.
+func (m *jsonMap) Scan(value interface{}) error {
+	var raw []byte
+
+	switch v := value.(type) {
+	case nil:
+		*m = jsonMap{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported jsonb value type %T", value)
+	}
+
+	decoded := jsonMap{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Errorf("failed to decode jsonb: %w", err)
+	}
+
+	*m = decoded
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// errMergeDryRun desfaz a transação de uma mesclagem simulada.
+var errMergeDryRun = errors.New("merge dry run")
+
+// Merge mescla o usuário source em target dentro de uma transação.
+// A origem é removida com soft delete e recebe domain.MergedEmail, o que
+// libera seu email: a restrição única de email também cobre usuários deletados.
+func (r *Repository) Merge(
+	ctx context.Context,
+	sourceID, targetID uuid.UUID,
+	dryRun bool,
+) (*domain.MergeResult, error) {
+	if sourceID == targetID {
+		return nil, domain.ErrSelfMerge
+	}
+
+	result := &domain.MergeResult{SourceID: sourceID, TargetID: targetID}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		source, err := lockUser(tx, sourceID)
+		if err != nil {
+			return err
+		}
+
+		target, err := lockUser(tx, targetID)
+		if err != nil {
+			return err
+		}
+
+		now := clock.UTC(r.clock.Now())
+		target.MergeFrom(source, now)
+
+		if err := tx.Save(toModel(target)).Error; err != nil {
+			return fmt.Errorf("failed to update target user: %w", err)
+		}
+
+		moved := tx.Model(&PasswordHistoryModel{}).
+			Where("user_id = ?", sourceID).
+			Update("user_id", targetID)
+		if moved.Error != nil {
+			return fmt.Errorf("failed to reassign password history: %w", moved.Error)
+		}
+
+		if err := tx.Model(&UserModel{}).
+			Where("id = ?", sourceID).
+			Updates(map[string]interface{}{
+				"email":      domain.MergedEmail(sourceID),
+				"deleted_at": now,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to delete source user: %w", err)
+		}
+
+		result.Target = target
+		result.PasswordHistoryMoved = moved.RowsAffected
+
+		if dryRun {
+			return errMergeDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errMergeDryRun) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// lockUser busca um usuário não deletado bloqueando a linha até o fim da transação.
+func lockUser(tx *gorm.DB, id uuid.UUID) (*domain.User, error) {
+	var model UserModel
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+
+	return toDomain(&model), nil
+}
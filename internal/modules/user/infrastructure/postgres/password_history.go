@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// Garantir em tempo de compilação que PasswordHistoryRepository implementa a interface.
+var _ domain.PasswordHistoryRepository = (*PasswordHistoryRepository)(nil)
+
+// PasswordHistoryModel representa o modelo GORM de uma entrada do histórico de senhas.
+type PasswordHistoryModel struct {
+	CreatedAt    time.Time `gorm:"not null"`
+	PasswordHash string    `gorm:"size:255;not null"`
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
+}
+
+// TableName define o nome da tabela.
+func (PasswordHistoryModel) TableName() string {
+	return "password_history"
+}
+
+// PasswordHistoryRepository implementa domain.PasswordHistoryRepository usando GORM.
+type PasswordHistoryRepository struct {
+	clock clock.Clock
+	db    *gorm.DB
+}
+
+// NewPasswordHistoryRepository cria uma nova instância do repositório.
+func NewPasswordHistoryRepository(db *gorm.DB) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{clock: clock.RealClock{}, db: db}
+}
+
+// WithClock define o relógio usado nas datas gravadas (útil em testes).
+func (r *PasswordHistoryRepository) WithClock(c clock.Clock) *PasswordHistoryRepository {
+	r.clock = clock.OrReal(c)
+
+	return r
+}
+
+// Recent retorna os hashes mais recentes do usuário.
+func (r *PasswordHistoryRepository) Recent(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	var hashes []string
+
+	if err := r.db.WithContext(ctx).
+		Model(&PasswordHistoryModel{}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Pluck("password_hash", &hashes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get password history: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// Add registra um hash e remove as entradas além das keep mais recentes, na mesma transação.
+func (r *PasswordHistoryRepository) Add(
+	ctx context.Context,
+	userID uuid.UUID,
+	passwordHash string,
+	keep int,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entry := &PasswordHistoryModel{
+			UserID:       userID,
+			PasswordHash: passwordHash,
+			CreatedAt:    clock.UTC(r.clock.Now()),
+		}
+
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to add password history: %w", err)
+		}
+
+		keepIDs := tx.Model(&PasswordHistoryModel{}).
+			Select("id").
+			Where("user_id = ?", userID).
+			Order("created_at DESC").
+			Limit(keep)
+
+		if err := tx.
+			Where("user_id = ? AND id NOT IN (?)", userID, keepIDs).
+			Delete(&PasswordHistoryModel{}).Error; err != nil {
+			return fmt.Errorf("failed to prune password history: %w", err)
+		}
+
+		return nil
+	})
+}
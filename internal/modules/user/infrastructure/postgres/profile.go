@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// Garantir em tempo de compilação que ProfileRepository implementa a interface.
+var _ domain.ProfileRepository = (*ProfileRepository)(nil)
+
+// UserProfileModel representa o modelo GORM do perfil de um usuário.
+type UserProfileModel struct {
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+	AvatarURL string    `gorm:"size:2048;not null;default:''"`
+	Bio       string    `gorm:"size:500;not null;default:''"`
+	Locale    string    `gorm:"size:16;not null;default:''"`
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key"`
+}
+
+// TableName define o nome da tabela.
+func (UserProfileModel) TableName() string {
+	return "user_profiles"
+}
+
+// ProfileRepository implementa domain.ProfileRepository usando GORM.
+type ProfileRepository struct {
+	clock clock.Clock
+	db    *gorm.DB
+}
+
+// NewProfileRepository cria uma nova instância do repositório.
+func NewProfileRepository(db *gorm.DB) *ProfileRepository {
+	return &ProfileRepository{clock: clock.RealClock{}, db: db}
+}
+
+// WithClock define o relógio usado nas datas gravadas (útil em testes).
+func (r *ProfileRepository) WithClock(c clock.Clock) *ProfileRepository {
+	r.clock = clock.OrReal(c)
+
+	return r
+}
+
+// Get busca o perfil do usuário.
+func (r *ProfileRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.UserProfile, error) {
+	var model UserProfileModel
+
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrProfileNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+
+	return &domain.UserProfile{
+		UserID:    model.UserID,
+		AvatarURL: model.AvatarURL,
+		Bio:       model.Bio,
+		Locale:    model.Locale,
+		UpdatedAt: model.UpdatedAt,
+	}, nil
+}
+
+// Upsert insere o perfil ou, se o usuário já tiver um, substitui seus campos
+// em um único comando, sem corrida entre verificar e inserir.
+func (r *ProfileRepository) Upsert(ctx context.Context, profile *domain.UserProfile) error {
+	now := clock.UTC(r.clock.Now())
+	model := &UserProfileModel{
+		UserID:    profile.UserID,
+		AvatarURL: profile.AvatarURL,
+		Bio:       profile.Bio,
+		Locale:    profile.Locale,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"avatar_url", "bio", "locale", "updated_at"}),
+		}).
+		Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save user profile: %w", err)
+	}
+
+	profile.UpdatedAt = now
+
+	return nil
+}
@@ -2,23 +2,85 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/cache"
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+	"github.com/devleo-m/go-zero/internal/shared/requestctx"
 )
 
+// Garantir em tempo de compilação que Repository implementa as interfaces.
+var (
+	_ domain.Repository                  = (*Repository)(nil)
+	_ repository.Repository[domain.User] = (*Repository)(nil)
+)
+
+// countCachePrefix é o prefixo das chaves de cache de totais de usuários.
+const countCachePrefix = "users"
+
 // Repository implementa domain.Repository usando GORM.
 type Repository struct {
-	db *gorm.DB
+	clock         clock.Clock
+	db            *gorm.DB
+	countCache    cache.Service
+	logger        *zap.Logger
+	guard         repository.QueryGuard
+	countCacheTTL time.Duration
 }
 
 // NewRepository cria uma nova instância do repositório.
 func NewRepository(db *gorm.DB) *Repository {
-	return &Repository{db: db}
+	return &Repository{
+		clock:  clock.RealClock{},
+		db:     db,
+		logger: zap.NewNop(),
+	}
+}
+
+// WithClock define o relógio usado nas datas de exclusão e no corte de
+// FindUsersByLastLogin (útil em testes).
+func (r *Repository) WithClock(c clock.Clock) *Repository {
+	r.clock = clock.OrReal(c)
+
+	return r
+}
+
+// WithLogger define o logger usado pelo repositório.
+func (r *Repository) WithLogger(logger *zap.Logger) *Repository {
+	if logger != nil {
+		r.logger = logger
+	}
+
+	return r
+}
+
+// WithQueryGuard define o limite de segurança aplicado a FindMany sem paginação.
+func (r *Repository) WithQueryGuard(guard repository.QueryGuard) *Repository {
+	r.guard = guard
+
+	return r
+}
+
+// WithCountCache habilita o cache do total de registros usado em Paginate.
+// Um ttl <= 0 mantém o cache desabilitado.
+func (r *Repository) WithCountCache(countCache cache.Service, ttl time.Duration) *Repository {
+	if ttl > 0 {
+		r.countCache = countCache
+		r.countCacheTTL = ttl
+	}
+
+	return r
 }
 
 // Create cria um novo usuário.
@@ -26,6 +88,10 @@ func (r *Repository) Create(ctx context.Context, user *domain.User) error {
 	model := toModel(user)
 
 	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrEmailAlreadyInUse
+		}
+
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -35,6 +101,49 @@ func (r *Repository) Create(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// FindOrCreate cria o usuário de forma atômica, apoiando-se na restrição única
+// de email: em caso de conflito nada é inserido e o usuário existente é retornado.
+// Isso elimina a corrida entre verificar a existência e inserir.
+func (r *Repository) FindOrCreate(
+	ctx context.Context,
+	email string,
+	build func() *domain.User,
+) (*domain.User, bool, error) {
+	user := build()
+	model := toModel(user)
+
+	// Só o conflito na coluna email é absorvido; qualquer outra violação de
+	// unicidade (ex.: o índice LOWER(email) dos usuários ativos) vira erro
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "email"}}, DoNothing: true}).
+		Create(model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return nil, false, domain.ErrEmailAlreadyInUse
+		}
+
+		return nil, false, fmt.Errorf("failed to create user: %w", result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		user.ID = model.ID
+
+		return user, true, nil
+	}
+
+	existing, err := r.GetByEmail(ctx, email)
+	if err != nil {
+		// O conflito pode ter sido com um usuário deletado que ainda ocupa o email
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, false, domain.ErrEmailAlreadyInUse
+		}
+
+		return nil, false, err
+	}
+
+	return existing, false, nil
+}
+
 // GetByID busca um usuário por ID (excluindo deletados).
 func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	var model UserModel
@@ -42,7 +151,7 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, e
 	if err := r.db.WithContext(ctx).
 		Where("id = ? AND deleted_at IS NULL", id).
 		First(&model).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
 
@@ -52,14 +161,14 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, e
 	return toDomain(&model), nil
 }
 
-// GetByEmail busca um usuário por email (excluindo deletados).
+// GetByEmail busca um usuário por email sem diferenciar maiúsculas/minúsculas (excluindo deletados).
 func (r *Repository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var model UserModel
 
 	if err := r.db.WithContext(ctx).
-		Where("email = ? AND deleted_at IS NULL", email).
+		Where("LOWER(email) = ? AND deleted_at IS NULL", domain.NormalizeEmail(email)).
 		First(&model).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
 
@@ -75,34 +184,239 @@ func (r *Repository) List(ctx context.Context, limit, offset int) ([]*domain.Use
 
 	if err := r.db.WithContext(ctx).
 		Where("deleted_at IS NULL").
+		Order(repository.DefaultOrder).
+		Order(repository.TiebreakerOrder).
 		Limit(limit).
 		Offset(offset).
 		Find(&models).Error; err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
 
-	users := make([]*domain.User, len(models))
-	for i, model := range models {
-		users[i] = toDomain(&model)
+	return toDomainList(models), nil
+}
+
+// FindOne busca o primeiro usuário que satisfaz o filtro.
+func (r *Repository) FindOne(ctx context.Context, filter repository.QueryFilter) (*domain.User, error) {
+	var model UserModel
+
+	query, err := repository.ApplyFilter(r.db.WithContext(ctx).Model(&UserModel{}), filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user query: %w", err)
+	}
+
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return toDomain(&model), nil
+}
+
+// FindMany busca os usuários que satisfazem o filtro. Filtros sem limite nem
+// paginação passam pelo QueryGuard, que impõe um limite ou rejeita a consulta.
+func (r *Repository) FindMany(ctx context.Context, filter repository.QueryFilter) ([]*domain.User, error) {
+	var models []UserModel
+
+	if err := filter.CheckDepth(); err != nil {
+		return nil, err
+	}
+
+	filter, capped, err := r.guard.Bound(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	if capped {
+		r.logger.Warn("Unbounded user query capped",
+			append(requestctx.LogFields(ctx), zap.Int("limit", filter.Limit))...,
+		)
+	}
+
+	query, err := repository.ApplyFilter(r.db.WithContext(ctx).Model(&UserModel{}), filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user query: %w", err)
+	}
+
+	if err := repository.ApplyPagination(query, filter).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
 	}
 
-	return users, nil
+	return toDomainList(models), nil
 }
 
-// Count conta o total de usuários (excluindo deletados).
-func (r *Repository) Count(ctx context.Context) (int64, error) {
+// Count conta os usuários que satisfazem o filtro.
+func (r *Repository) Count(ctx context.Context, filter repository.QueryFilter) (int64, error) {
 	var count int64
 
-	err := r.db.WithContext(ctx).Model(&UserModel{}).
-		Where("deleted_at IS NULL").
-		Count(&count).Error
+	query, err := repository.ApplyFilter(r.db.WithContext(ctx).Model(&UserModel{}), filter)
 	if err != nil {
+		return 0, fmt.Errorf("failed to build user query: %w", err)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count users: %w", err)
 	}
 
 	return count, nil
 }
 
+// Exists verifica se existe algum usuário que satisfaz o filtro.
+func (r *Repository) Exists(ctx context.Context, filter repository.QueryFilter) (bool, error) {
+	count, err := r.Count(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Paginate retorna uma página de usuários que satisfazem o filtro.
+func (r *Repository) Paginate(
+	ctx context.Context,
+	filter repository.QueryFilter,
+) (*repository.PaginatedResult[domain.User], error) {
+	page, pageSize := filter.NormalizedPage()
+	filter.Page = page
+	filter.PageSize = pageSize
+
+	// Recusar páginas profundas antes de contar, que também custa uma consulta
+	if err := filter.CheckDepth(); err != nil {
+		return nil, err
+	}
+
+	total, err := r.cachedCount(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := r.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.NewPaginatedResult(users, total, page, pageSize), nil
+}
+
+// GroupBy conta os usuários agrupados pelo campo informado (ex.: "role").
+func (r *Repository) GroupBy(
+	ctx context.Context,
+	field string,
+	filter repository.QueryFilter,
+) ([]repository.GroupCount, error) {
+	groups, err := repository.GroupCounts(r.db.WithContext(ctx).Model(&UserModel{}), field, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to group users: %w", err)
+	}
+
+	return groups, nil
+}
+
+// cachedCount retorna o total do filtro, reutilizando o valor em cache quando possível.
+func (r *Repository) cachedCount(ctx context.Context, filter repository.QueryFilter) (int64, error) {
+	if r.countCache == nil || filter.SkipCountCache {
+		return r.Count(ctx, filter)
+	}
+
+	key, err := filter.CountCacheKey(countCachePrefix)
+	if err != nil {
+		return r.Count(ctx, filter)
+	}
+
+	if cached, err := r.countCache.Get(ctx, key); err == nil {
+		if total, err := strconv.ParseInt(cached, 10, 64); err == nil {
+			return total, nil
+		}
+	}
+
+	total, err := r.Count(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	// Falhas ao gravar no cache não devem impedir a consulta
+	_ = r.countCache.Set(ctx, key, strconv.FormatInt(total, 10), r.countCacheTTL)
+
+	return total, nil
+}
+
+// FindUsersByLastLogin busca, paginados, os usuários cujo último login é
+// anterior a N dias ou que nunca fizeram login (excluindo deletados).
+func (r *Repository) FindUsersByLastLogin(
+	ctx context.Context,
+	days, page, pageSize int,
+) (*repository.PaginatedResult[domain.User], error) {
+	pageFilter := repository.QueryFilter{Page: page, PageSize: pageSize}
+	if err := pageFilter.CheckDepth(); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = pageFilter.NormalizedPage()
+	cutoff := r.clock.Now().AddDate(0, 0, -days)
+
+	query := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("deleted_at IS NULL").
+		Where("(last_login_at IS NULL OR last_login_at < ?)", cutoff).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count inactive users: %w", err)
+	}
+
+	var models []UserModel
+	if err := query.
+		Order("last_login_at ASC NULLS FIRST").
+		Order("created_at ASC").
+		Order(repository.TiebreakerOrder).
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find inactive users: %w", err)
+	}
+
+	return repository.NewPaginatedResult(toDomainList(models), total, page, pageSize), nil
+}
+
+// FindUsersByEmailDomain busca, paginados, os usuários cujo email pertence
+// exatamente ao domínio informado (sem casar subdomínios ou sufixos parecidos).
+func (r *Repository) FindUsersByEmailDomain(
+	ctx context.Context,
+	emailDomain string,
+	page, pageSize int,
+) (*repository.PaginatedResult[domain.User], error) {
+	pageFilter := repository.QueryFilter{Page: page, PageSize: pageSize}
+	if err := pageFilter.CheckDepth(); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = pageFilter.NormalizedPage()
+
+	query := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("deleted_at IS NULL").
+		Where("LOWER(SPLIT_PART(email, '@', 2)) = ?", strings.ToLower(emailDomain)).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count users by email domain: %w", err)
+	}
+
+	var models []UserModel
+	if err := query.
+		Order("created_at ASC").
+		Order(repository.TiebreakerOrder).
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find users by email domain: %w", err)
+	}
+
+	return repository.NewPaginatedResult(toDomainList(models), total, page, pageSize), nil
+}
+
 // Update atualiza um usuário.
 func (r *Repository) Update(ctx context.Context, user *domain.User) error {
 	model := toModel(user)
@@ -114,9 +428,40 @@ func (r *Repository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// UpdateMany aplica as alterações a todos os usuários que satisfazem o filtro,
+// dentro de uma transação, e retorna a quantidade de registros afetados.
+func (r *Repository) UpdateMany(
+	ctx context.Context,
+	filter repository.QueryFilter,
+	updates map[string]interface{},
+) (int64, error) {
+	var affected int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query, err := repository.ApplyFilter(tx.Model(&UserModel{}), filter)
+		if err != nil {
+			return fmt.Errorf("failed to build user query: %w", err)
+		}
+
+		result := query.Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update users: %w", result.Error)
+		}
+
+		affected = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return affected, nil
+}
+
 // Delete deleta um usuário (soft delete).
 func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
-	now := time.Now()
+	now := clock.UTC(r.clock.Now())
 
 	err := r.db.WithContext(ctx).Model(&UserModel{}).
 		Where("id = ?", id).
@@ -128,24 +473,37 @@ func (r *Repository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// toModel converte domain.User para UserModel.
+// DeleteMany deleta (soft delete), dentro de uma transação, todos os usuários
+// que satisfazem o filtro e retorna a quantidade de registros afetados.
+func (r *Repository) DeleteMany(ctx context.Context, filter repository.QueryFilter) (int64, error) {
+	return r.UpdateMany(ctx, filter, map[string]interface{}{
+		"deleted_at": clock.UTC(r.clock.Now()),
+	})
+}
+
+// toModel converte domain.User para UserModel, gravando os instantes em UTC.
 func toModel(user *domain.User) *UserModel {
 	model := &UserModel{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Password:  user.Password,
-		Phone:     user.Phone,
-		Role:      user.Role,
-		Status:    user.Status,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:          user.ID,
+		Name:        user.Name,
+		Email:       user.Email,
+		Password:    user.Password,
+		Phone:       user.Phone,
+		Role:        user.Role.String(),
+		Status:      user.Status.String(),
+		CreatedBy:   user.CreatedBy,
+		UpdatedBy:   user.UpdatedBy,
+		LastLoginAt: clock.UTCPtr(user.LastLoginAt),
+		LoginCount:  user.LoginCount,
+		Metadata:    jsonMap(user.Metadata),
+		CreatedAt:   clock.UTC(user.CreatedAt),
+		UpdatedAt:   clock.UTC(user.UpdatedAt),
 	}
 
 	// Converter DeletedAt corretamente
 	if user.DeletedAt != nil {
 		model.DeletedAt = gorm.DeletedAt{
-			Time:  *user.DeletedAt,
+			Time:  clock.UTC(*user.DeletedAt),
 			Valid: true,
 		}
 	}
@@ -153,23 +511,38 @@ func toModel(user *domain.User) *UserModel {
 	return model
 }
 
-// toDomain converte UserModel para domain.User.
+// toDomain converte UserModel para domain.User, lendo os instantes em UTC.
 func toDomain(model *UserModel) *domain.User {
 	var deletedAt *time.Time
 	if model.DeletedAt.Valid {
-		deletedAt = &model.DeletedAt.Time
+		deletedAt = clock.UTCPtr(&model.DeletedAt.Time)
 	}
 
 	return &domain.User{
-		ID:        model.ID,
-		Name:      model.Name,
-		Email:     model.Email,
-		Password:  model.Password,
-		Phone:     model.Phone,
-		Role:      model.Role,
-		Status:    model.Status,
-		CreatedAt: model.CreatedAt,
-		UpdatedAt: model.UpdatedAt,
-		DeletedAt: deletedAt,
+		ID:          model.ID,
+		Name:        model.Name,
+		Email:       model.Email,
+		Password:    model.Password,
+		Phone:       model.Phone,
+		Role:        domain.Role(model.Role),
+		Status:      domain.Status(model.Status),
+		CreatedBy:   model.CreatedBy,
+		UpdatedBy:   model.UpdatedBy,
+		LastLoginAt: clock.UTCPtr(model.LastLoginAt),
+		LoginCount:  model.LoginCount,
+		Metadata:    domain.Metadata(model.Metadata),
+		CreatedAt:   clock.UTC(model.CreatedAt),
+		UpdatedAt:   clock.UTC(model.UpdatedAt),
+		DeletedAt:   deletedAt,
 	}
 }
+
+// toDomainList converte uma lista de UserModel para domain.User.
+func toDomainList(models []UserModel) []*domain.User {
+	users := make([]*domain.User, len(models))
+	for i := range models {
+		users[i] = toDomain(&models[i])
+	}
+
+	return users
+}
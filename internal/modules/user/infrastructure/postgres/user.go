@@ -9,16 +9,21 @@ import (
 
 // UserModel representa o modelo GORM para User.
 type UserModel struct {
-	CreatedAt time.Time      `gorm:"not null"`
-	UpdatedAt time.Time      `gorm:"not null"`
-	Phone     *string        `gorm:"size:20"`
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-	Name      string         `gorm:"size:100;not null"`
-	Email     string         `gorm:"size:254;uniqueIndex;not null"`
-	Password  string         `gorm:"size:255;not null"`
-	Role      string         `gorm:"size:20;not null;default:'user'"`
-	Status    string         `gorm:"size:20;not null;default:'active'"`
-	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt   time.Time      `gorm:"not null"`
+	UpdatedAt   time.Time      `gorm:"not null"`
+	Phone       *string        `gorm:"size:20"`
+	LastLoginAt *time.Time     `gorm:"index"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Metadata    jsonMap        `gorm:"type:jsonb;not null;default:'{}'"`
+	Name        string         `gorm:"size:100;not null"`
+	Email       string         `gorm:"size:254;uniqueIndex;not null"`
+	Password    string         `gorm:"size:255;not null"`
+	Role        string         `gorm:"size:20;not null;default:'user'"`
+	Status      string         `gorm:"size:20;not null;default:'active'"`
+	CreatedBy   string         `gorm:"size:64;not null;default:'system'"`
+	UpdatedBy   string         `gorm:"size:64;not null;default:'system'"`
+	LoginCount  int            `gorm:"not null;default:0"`
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 }
 
 // TableName define o nome da tabela.
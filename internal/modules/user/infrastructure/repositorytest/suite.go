@@ -0,0 +1,544 @@
+// Package repositorytest reúne a suíte de contrato de domain.Repository,
+// executada contra a implementação em memória e, com a tag integration,
+// contra o repositório postgres.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/modules/user/domain"
+	"github.com/devleo-m/go-zero/internal/shared/repository"
+)
+
+// Factory cria um repositório vazio e isolado para um teste.
+type Factory func(t *testing.T) domain.Repository
+
+// BaseTime é o instante de criação do primeiro usuário de NewUser; os
+// seguintes são criados um minuto depois do anterior.
+var BaseTime = time.Date(2025, time.January, 6, 12, 0, 0, 0, time.UTC)
+
+// NewUser cria um usuário ativo, sem hash real de senha, criado em
+// BaseTime + offset minutos.
+func NewUser(name, email string, offset int) *domain.User {
+	createdAt := BaseTime.Add(time.Duration(offset) * time.Minute)
+
+	return &domain.User{
+		ID:        uuid.New(),
+		Name:      name,
+		Email:     domain.NormalizeEmail(email),
+		Password:  "not-a-real-hash",
+		Role:      domain.RoleUser,
+		Status:    domain.StatusActive,
+		Metadata:  domain.Metadata{},
+		CreatedBy: domain.SystemActor,
+		UpdatedBy: domain.SystemActor,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+// Seed cria os usuários no repositório, falhando o teste em caso de erro.
+func Seed(t *testing.T, repo domain.Repository, users ...*domain.User) {
+	t.Helper()
+
+	for _, user := range users {
+		if err := repo.Create(context.Background(), user); err != nil {
+			t.Fatalf("seed %s: %v", user.Email, err)
+		}
+	}
+}
+
+// Run executa a suíte de contrato contra os repositórios criados por newRepo.
+func Run(t *testing.T, newRepo Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo domain.Repository)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"DuplicateEmail", testDuplicateEmail},
+		{"NotFound", testNotFound},
+		{"FindManyWithSpecification", testFindManyWithSpecification},
+		{"PaginateFilteredTotal", testPaginateFilteredTotal},
+		{"UpdateAndUpdateMany", testUpdateAndUpdateMany},
+		{"SoftDelete", testSoftDelete},
+		{"ExistsIncludingDeleted", testExistsIncludingDeleted},
+		{"GroupBy", testGroupBy},
+		{"FindOrCreate", testFindOrCreate},
+		{"FindOrCreateConcurrent", testFindOrCreateConcurrent},
+		{"FindUsersByEmailDomain", testFindUsersByEmailDomain},
+		{"FindUsersByLastLogin", testFindUsersByLastLogin},
+		{"Merge", testMerge},
+		{"StableOrderOnTies", testStableOrderOnTies},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+func testCreateAndGet(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	user := NewUser("Ana", "ana@example.com", 0)
+	Seed(t, repo, user)
+
+	byID, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if byID.Email != "ana@example.com" || byID.Name != "Ana" {
+		t.Errorf("GetByID = %+v", byID)
+	}
+
+	if !byID.CreatedAt.Equal(user.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want %v", byID.CreatedAt, user.CreatedAt)
+	}
+
+	byEmail, err := repo.GetByEmail(ctx, "  ANA@Example.com ")
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+
+	if byEmail.ID != user.ID {
+		t.Errorf("GetByEmail ID = %s, want %s", byEmail.ID, user.ID)
+	}
+}
+
+func testDuplicateEmail(t *testing.T, repo domain.Repository) {
+	Seed(t, repo, NewUser("Ana", "ana@example.com", 0))
+
+	duplicate := NewUser("Outra Ana", "ana@example.com", 1)
+	duplicate.Email = "ANA@example.com"
+
+	if err := repo.Create(context.Background(), duplicate); !errors.Is(err, domain.ErrEmailAlreadyInUse) {
+		t.Fatalf("Create duplicate error = %v, want ErrEmailAlreadyInUse", err)
+	}
+}
+
+func testNotFound(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("GetByID error = %v, want ErrUserNotFound", err)
+	}
+
+	if _, err := repo.GetByEmail(ctx, "nobody@example.com"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("GetByEmail error = %v, want ErrUserNotFound", err)
+	}
+
+	filter := domain.RoleSpecification(domain.RoleAdmin).ToQueryFilter()
+	if _, err := repo.FindOne(ctx, filter); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("FindOne error = %v, want ErrUserNotFound", err)
+	}
+
+	users, err := repo.FindMany(ctx, repository.QueryFilter{Limit: 10})
+	if err != nil || len(users) != 0 {
+		t.Errorf("FindMany = %d users, %v; want an empty list", len(users), err)
+	}
+}
+
+func testFindManyWithSpecification(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+
+	admin := NewUser("Admin", "admin@example.com", 0)
+	admin.Role = domain.RoleAdmin
+	suspended := NewUser("Suspenso", "suspended@example.com", 1)
+	suspended.Status = domain.StatusSuspended
+	Seed(t, repo, admin, suspended, NewUser("Bruno", "bruno@example.com", 2))
+
+	spec := domain.ActiveSpecification().And(domain.RoleSpecification(domain.RoleUser, domain.RoleAdmin))
+	filter := spec.ToQueryFilter()
+	filter.OrderBy = []string{"email ASC"}
+	filter.Limit = 10
+
+	users, err := repo.FindMany(ctx, filter)
+	if err != nil {
+		t.Fatalf("FindMany: %v", err)
+	}
+
+	if got := emails(users); fmt.Sprint(got) != "[admin@example.com bruno@example.com]" {
+		t.Errorf("FindMany = %v", got)
+	}
+
+	count, err := repo.Count(ctx, spec.ToQueryFilter())
+	if err != nil || count != 2 {
+		t.Errorf("Count = %d, %v; want 2", count, err)
+	}
+
+	exists, err := repo.Exists(ctx, domain.StatusSpecification(domain.StatusSuspended).ToQueryFilter())
+	if err != nil || !exists {
+		t.Errorf("Exists(suspended) = %v, %v; want true", exists, err)
+	}
+
+	found, err := repo.FindOne(ctx, domain.SearchSpecification("BRU").ToQueryFilter())
+	if err != nil || found.Email != "bruno@example.com" {
+		t.Errorf("FindOne(search) = %v, %v", found, err)
+	}
+}
+
+func testPaginateFilteredTotal(t *testing.T, repo domain.Repository) {
+	for i := range 7 {
+		user := NewUser(fmt.Sprintf("User %d", i), fmt.Sprintf("user%d@example.com", i), i)
+		if i%2 == 0 {
+			user.Status = domain.StatusInactive
+		}
+
+		Seed(t, repo, user)
+	}
+
+	filter := domain.StatusSpecification(domain.StatusInactive).ToQueryFilter()
+	filter.Page, filter.PageSize = 2, 3
+
+	page, err := repo.Paginate(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+
+	if page.TotalItems != 4 || page.TotalPages != 2 || page.HasNext || !page.HasPrev {
+		t.Errorf("Paginate meta = total %d, pages %d, next %v, prev %v",
+			page.TotalItems, page.TotalPages, page.HasNext, page.HasPrev)
+	}
+
+	// Ordenação padrão: mais recente primeiro, então a 2ª página tem o mais antigo
+	if got := emails(page.Items); fmt.Sprint(got) != "[user0@example.com]" {
+		t.Errorf("Paginate items = %v", got)
+	}
+}
+
+func testUpdateAndUpdateMany(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	ana := NewUser("Ana", "ana@example.com", 0)
+	Seed(t, repo, ana, NewUser("Bruno", "bruno@example.com", 1), NewUser("Carla", "carla@other.com", 2))
+
+	ana.Name = "Ana Maria"
+	if err := repo.Update(ctx, ana); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	updated, err := repo.GetByID(ctx, ana.ID)
+	if err != nil || updated.Name != "Ana Maria" {
+		t.Errorf("GetByID after Update = %v, %v", updated, err)
+	}
+
+	filter := repository.NewQueryBuilder().Where("email", repository.OpLike, "%@example.com").Build()
+
+	affected, err := repo.UpdateMany(ctx, filter, map[string]interface{}{"status": domain.StatusSuspended.String()})
+	if err != nil || affected != 2 {
+		t.Fatalf("UpdateMany = %d, %v; want 2", affected, err)
+	}
+
+	count, err := repo.Count(ctx, domain.StatusSpecification(domain.StatusSuspended).ToQueryFilter())
+	if err != nil || count != 2 {
+		t.Errorf("suspended count = %d, %v; want 2", count, err)
+	}
+}
+
+func testSoftDelete(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	ana := NewUser("Ana", "ana@example.com", 0)
+	Seed(t, repo, ana, NewUser("Bruno", "bruno@example.com", 1), NewUser("Carla", "carla@example.com", 2))
+
+	if err := repo.Delete(ctx, ana.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, ana.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("GetByID after Delete error = %v, want ErrUserNotFound", err)
+	}
+
+	byEmail := repository.NewQueryBuilder().WhereEqual("email", "ana@example.com")
+
+	exists, err := repo.Exists(ctx, byEmail.Build())
+	if err != nil || exists {
+		t.Errorf("Exists without IncludeDeleted = %v, %v; want false", exists, err)
+	}
+
+	exists, err = repo.Exists(ctx, byEmail.IncludeDeleted().Build())
+	if err != nil || !exists {
+		t.Errorf("Exists with IncludeDeleted = %v, %v; want true", exists, err)
+	}
+
+	deleted, err := repo.FindOne(ctx, repository.NewQueryBuilder().WhereEqual("id", ana.ID).IncludeDeleted().Build())
+	if err != nil || !deleted.IsDeleted() {
+		t.Errorf("FindOne with IncludeDeleted = %v, %v; want the deleted user", deleted, err)
+	}
+
+	affected, err := repo.DeleteMany(ctx, domain.SearchSpecification("bruno").ToQueryFilter())
+	if err != nil || affected != 1 {
+		t.Errorf("DeleteMany = %d, %v; want 1", affected, err)
+	}
+
+	remaining, err := repo.Count(ctx, repository.QueryFilter{})
+	if err != nil || remaining != 1 {
+		t.Errorf("Count after deletes = %d, %v; want 1", remaining, err)
+	}
+}
+
+func testExistsIncludingDeleted(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	deleted := NewUser("Removido", "deleted@example.com", 1)
+	Seed(t, repo, NewUser("Ativo", "active@example.com", 0), deleted)
+
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	// Exists com e sem IncludeDeleted distingue quem pode ser restaurado de quem
+	// precisa ser criado
+	tests := []struct {
+		email       string
+		exists      bool
+		withDeleted bool
+	}{
+		{email: "active@example.com", exists: true, withDeleted: true},
+		{email: "deleted@example.com", exists: false, withDeleted: true},
+		{email: "absent@example.com", exists: false, withDeleted: false},
+	}
+
+	for _, tt := range tests {
+		filter := repository.NewQueryBuilder().WhereEqual("email", tt.email)
+
+		exists, err := repo.Exists(ctx, filter.Build())
+		if err != nil || exists != tt.exists {
+			t.Errorf("Exists(%s) = %v, %v; want %v", tt.email, exists, err, tt.exists)
+		}
+
+		withDeleted, err := repo.Exists(ctx, filter.IncludeDeleted().Build())
+		if err != nil || withDeleted != tt.withDeleted {
+			t.Errorf("Exists(%s, IncludeDeleted) = %v, %v; want %v", tt.email, withDeleted, err, tt.withDeleted)
+		}
+	}
+}
+
+func testGroupBy(t *testing.T, repo domain.Repository) {
+	admin := NewUser("Admin", "admin@example.com", 0)
+	admin.Role = domain.RoleAdmin
+	Seed(t, repo, admin, NewUser("Ana", "ana@example.com", 1), NewUser("Bruno", "bruno@example.com", 2))
+
+	groups, err := repo.GroupBy(context.Background(), "role", repository.QueryFilter{})
+	if err != nil {
+		t.Fatalf("GroupBy: %v", err)
+	}
+
+	counts := map[string]int64{}
+	for _, group := range groups {
+		counts[fmt.Sprint(group.Group)] = group.Count
+	}
+
+	if len(counts) != 2 || counts["user"] != 2 || counts["admin"] != 1 {
+		t.Errorf("GroupBy(role) = %v", counts)
+	}
+
+	if groups[0].Count != 2 {
+		t.Errorf("largest group first: got %v", groups)
+	}
+}
+
+func testFindOrCreate(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	build := func() *domain.User { return NewUser("Ana", "ana@example.com", 0) }
+
+	created, wasCreated, err := repo.FindOrCreate(ctx, "ana@example.com", build)
+	if err != nil || !wasCreated {
+		t.Fatalf("first FindOrCreate = %v, %v; want created", wasCreated, err)
+	}
+
+	found, wasCreated, err := repo.FindOrCreate(ctx, "ana@example.com", build)
+	if err != nil || wasCreated || found.ID != created.ID {
+		t.Fatalf("second FindOrCreate = %v, created %v, %v; want the existing user", found, wasCreated, err)
+	}
+
+	// O email de um usuário deletado continua ocupado
+	if err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, _, err := repo.FindOrCreate(ctx, "ana@example.com", build); !errors.Is(err, domain.ErrEmailAlreadyInUse) {
+		t.Errorf("FindOrCreate over a deleted user error = %v, want ErrEmailAlreadyInUse", err)
+	}
+}
+
+func testFindOrCreateConcurrent(t *testing.T, repo domain.Repository) {
+	const attempts = 8
+
+	var (
+		wg      sync.WaitGroup
+		mutex   sync.Mutex
+		created int
+		ids     = map[uuid.UUID]bool{}
+		start   = make(chan struct{})
+	)
+
+	for i := range attempts {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			<-start
+
+			user, wasCreated, err := repo.FindOrCreate(context.Background(), "ana@example.com", func() *domain.User {
+				return NewUser(fmt.Sprintf("Ana %d", i), "ana@example.com", i)
+			})
+			if err != nil {
+				t.Errorf("FindOrCreate: %v", err)
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			ids[user.ID] = true
+			if wasCreated {
+				created++
+			}
+		}()
+	}
+
+	// Liberar todas as goroutines juntas aumenta a chance de corrida
+	close(start)
+	wg.Wait()
+
+	if created != 1 || len(ids) != 1 {
+		t.Errorf("created %d users with %d distinct ids, want exactly one", created, len(ids))
+	}
+}
+
+func testFindUsersByEmailDomain(t *testing.T, repo domain.Repository) {
+	Seed(t, repo,
+		NewUser("Ana", "ana@example.com", 0),
+		NewUser("Bruno", "bruno@notexample.com", 1),
+		NewUser("Carla", "carla@sub.example.com", 2),
+		NewUser("Davi", "davi@EXAMPLE.com", 3),
+	)
+
+	page, err := repo.FindUsersByEmailDomain(context.Background(), "Example.com", 1, 10)
+	if err != nil {
+		t.Fatalf("FindUsersByEmailDomain: %v", err)
+	}
+
+	if got := emails(page.Items); fmt.Sprint(got) != "[ana@example.com davi@example.com]" || page.TotalItems != 2 {
+		t.Errorf("FindUsersByEmailDomain = %v (total %d)", got, page.TotalItems)
+	}
+}
+
+func testFindUsersByLastLogin(t *testing.T, repo domain.Repository) {
+	recent := time.Now().UTC().Add(-time.Hour)
+	old := time.Now().UTC().AddDate(0, 0, -60)
+	older := time.Now().UTC().AddDate(0, 0, -90)
+
+	active := NewUser("Ativo", "active@example.com", 0)
+	active.LastLoginAt = &recent
+	stale := NewUser("Antigo", "stale@example.com", 1)
+	stale.LastLoginAt = &old
+	staler := NewUser("Mais antigo", "staler@example.com", 2)
+	staler.LastLoginAt = &older
+	Seed(t, repo, active, stale, staler, NewUser("Nunca", "never@example.com", 3))
+
+	page, err := repo.FindUsersByLastLogin(context.Background(), 30, 1, 10)
+	if err != nil {
+		t.Fatalf("FindUsersByLastLogin: %v", err)
+	}
+
+	want := "[never@example.com staler@example.com stale@example.com]"
+	if got := emails(page.Items); fmt.Sprint(got) != want || page.TotalItems != 3 {
+		t.Errorf("FindUsersByLastLogin = %v (total %d), want %s", got, page.TotalItems, want)
+	}
+}
+
+func testMerge(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+	source := NewUser("Origem", "source@example.com", 0)
+	source.LoginCount = 3
+	source.Metadata = domain.Metadata{"plan": "pro"}
+	target := NewUser("Destino", "target@example.com", 1)
+	target.LoginCount = 2
+	Seed(t, repo, source, target)
+
+	preview, err := repo.Merge(ctx, source.ID, target.ID, true)
+	if err != nil || preview.Target.LoginCount != 5 {
+		t.Fatalf("dry-run Merge = %+v, %v", preview, err)
+	}
+
+	if _, err := repo.GetByID(ctx, source.ID); err != nil {
+		t.Fatalf("dry-run Merge removed the source: %v", err)
+	}
+
+	unchanged, err := repo.GetByID(ctx, target.ID)
+	if err != nil || unchanged.LoginCount != 2 || len(unchanged.Metadata) != 0 {
+		t.Fatalf("dry-run Merge changed the target: %+v, %v", unchanged, err)
+	}
+
+	if _, err := repo.Merge(ctx, source.ID, target.ID, false); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	merged, err := repo.GetByID(ctx, target.ID)
+	if err != nil || merged.LoginCount != 5 || merged.Metadata["plan"] != "pro" {
+		t.Errorf("merged target = %+v, %v", merged, err)
+	}
+
+	if _, err := repo.GetByID(ctx, source.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("source after Merge error = %v, want ErrUserNotFound", err)
+	}
+
+	// O email da origem fica livre para um novo cadastro
+	if err := repo.Create(ctx, NewUser("Nova", source.Email, 2)); err != nil {
+		t.Errorf("Create with the merged source email: %v", err)
+	}
+
+	if _, err := repo.Merge(ctx, target.ID, target.ID, false); !errors.Is(err, domain.ErrSelfMerge) {
+		t.Errorf("self Merge error = %v, want ErrSelfMerge", err)
+	}
+}
+
+// emails retorna os emails dos usuários, na ordem recebida.
+func emails(users []*domain.User) []string {
+	result := make([]string, len(users))
+	for i, user := range users {
+		result[i] = user.Email
+	}
+
+	return result
+}
+
+func testStableOrderOnTies(t *testing.T, repo domain.Repository) {
+	ctx := context.Background()
+
+	// Todos com o mesmo created_at: o desempate por id mantém as páginas disjuntas
+	for i := range 5 {
+		Seed(t, repo, NewUser(fmt.Sprintf("Tie %d", i), fmt.Sprintf("tie%d@example.com", i), 0))
+	}
+
+	seen := map[uuid.UUID]bool{}
+
+	for page := 1; page <= 3; page++ {
+		users, err := repo.FindMany(ctx, repository.QueryFilter{Page: page, PageSize: 2})
+		if err != nil {
+			t.Fatalf("FindMany page %d: %v", page, err)
+		}
+
+		for _, user := range users {
+			if seen[user.ID] {
+				t.Errorf("user %s appears on more than one page", user.Email)
+			}
+
+			seen[user.ID] = true
+		}
+	}
+
+	if len(seen) != 5 {
+		t.Errorf("pages returned %d distinct users, want 5", len(seen))
+	}
+}
@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCacheMiss indica que a chave não existe ou expirou.
+var ErrCacheMiss = errors.New("cache miss")
+
+// Service define as operações de cache usadas pela aplicação.
+type Service interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	// Increment incrementa atomicamente o contador da chave e retorna o novo
+	// valor e o tempo que falta para a chave expirar (zero quando não expira).
+	// O ttl é aplicado apenas quando a chave é criada (janela fixa).
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, time.Duration, error)
+}
@@ -0,0 +1,24 @@
+package cache
+
+import "fmt"
+
+// FailureMode define como um recurso se comporta quando o cache está indisponível.
+type FailureMode string
+
+// Modos de falha suportados.
+const (
+	// FailOpen permite a operação, registrando um aviso (disponibilidade primeiro).
+	FailOpen FailureMode = "open"
+	// FailClosed recusa a operação (proteção primeiro).
+	FailClosed FailureMode = "closed"
+)
+
+// ParseFailureMode converte o valor de configuração em FailureMode.
+func ParseFailureMode(value string) (FailureMode, error) {
+	switch mode := FailureMode(value); mode {
+	case FailOpen, FailClosed:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid cache failure mode: %q", value)
+	}
+}
@@ -0,0 +1,25 @@
+package cache
+
+import "testing"
+
+func TestParseFailureMode(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    FailureMode
+		wantErr bool
+	}{
+		{value: "open", want: FailOpen},
+		{value: "closed", want: FailClosed},
+		{value: "", wantErr: true},
+		{value: "OPEN", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseFailureMode(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseFailureMode(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
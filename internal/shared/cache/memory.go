@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// memoryItem representa um valor armazenado em memória.
+type memoryItem struct {
+	expiresAt time.Time
+	value     string
+}
+
+// expired verifica se o item expirou.
+func (i memoryItem) expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !now.Before(i.expiresAt)
+}
+
+// sweepInterval é o intervalo mínimo entre varreduras de chaves expiradas.
+const sweepInterval = time.Minute
+
+// MemoryCache implementa Service em memória, com expiração por TTL.
+// Chaves expiradas são removidas ao serem lidas e, periodicamente, por uma
+// varredura feita durante as escritas, para que chaves nunca mais lidas (ex.:
+// contadores de rate limit de clientes que não voltaram) não se acumulem.
+type MemoryCache struct {
+	lastSweep time.Time
+	clock     clock.Clock
+	items     map[string]memoryItem
+	mutex     sync.RWMutex
+}
+
+// NewMemoryCache cria um novo cache em memória.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		clock: clock.RealClock{},
+		items: make(map[string]memoryItem),
+	}
+}
+
+// WithClock define o relógio usado para calcular expirações (útil em testes).
+func (m *MemoryCache) WithClock(c clock.Clock) *MemoryCache {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.clock = clock.OrReal(c)
+
+	return m
+}
+
+// Get retorna o valor de uma chave ou ErrCacheMiss.
+func (m *MemoryCache) Get(_ context.Context, key string) (string, error) {
+	m.mutex.RLock()
+	item, ok := m.items[key]
+	now := m.clock.Now()
+	m.mutex.RUnlock()
+
+	if !ok {
+		return "", ErrCacheMiss
+	}
+
+	if item.expired(now) {
+		m.mutex.Lock()
+		// Verificar novamente: a chave pode ter sido regravada nesse intervalo
+		if current, exists := m.items[key]; exists && current.expired(now) {
+			delete(m.items, key)
+		}
+		m.mutex.Unlock()
+
+		return "", ErrCacheMiss
+	}
+
+	return item.value, nil
+}
+
+// Set armazena um valor; ttl <= 0 significa sem expiração.
+func (m *MemoryCache) Set(_ context.Context, key, value string, ttl time.Duration) error {
+	m.mutex.Lock()
+	now := m.clock.Now()
+	m.sweep(now)
+
+	item := memoryItem{value: value}
+	if ttl > 0 {
+		item.expiresAt = now.Add(ttl)
+	}
+
+	m.items[key] = item
+	m.mutex.Unlock()
+
+	return nil
+}
+
+// Delete remove uma chave.
+func (m *MemoryCache) Delete(_ context.Context, key string) error {
+	m.mutex.Lock()
+	delete(m.items, key)
+	m.mutex.Unlock()
+
+	return nil
+}
+
+// Increment incrementa o contador da chave; ttl <= 0 significa sem expiração.
+func (m *MemoryCache) Increment(_ context.Context, key string, ttl time.Duration) (int64, time.Duration, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := m.clock.Now()
+	m.sweep(now)
+
+	item, ok := m.items[key]
+	if !ok || item.expired(now) {
+		item = memoryItem{value: "0"}
+		if ttl > 0 {
+			item.expiresAt = now.Add(ttl)
+		}
+	}
+
+	current, err := strconv.ParseInt(item.value, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cache value for %q is not an integer: %w", key, err)
+	}
+
+	current++
+	item.value = strconv.FormatInt(current, 10)
+	m.items[key] = item
+
+	var remaining time.Duration
+	if !item.expiresAt.IsZero() {
+		remaining = item.expiresAt.Sub(now)
+	}
+
+	return current, remaining, nil
+}
+
+// sweep remove as chaves expiradas, no máximo uma vez por sweepInterval.
+// Deve ser chamado com o lock de escrita.
+func (m *MemoryCache) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, item := range m.items {
+		if item.expired(now) {
+			delete(m.items, key)
+		}
+	}
+
+	m.lastSweep = now
+}
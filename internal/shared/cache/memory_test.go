@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+func newTestCache() (*MemoryCache, *clock.FakeClock) {
+	fake := clock.NewFakeClock(time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC))
+
+	return NewMemoryCache().WithClock(fake), fake
+}
+
+func TestMemoryCacheIncrementFixedWindow(t *testing.T) {
+	ctx := context.Background()
+	store, fake := newTestCache()
+
+	count, ttl, err := store.Increment(ctx, "counter", time.Minute)
+	if err != nil || count != 1 || ttl != time.Minute {
+		t.Fatalf("first Increment = (%d, %s, %v), want (1, 1m0s, nil)", count, ttl, err)
+	}
+
+	// O ttl só vale na criação: a janela não se estende a cada incremento
+	fake.Advance(40 * time.Second)
+
+	count, ttl, err = store.Increment(ctx, "counter", time.Minute)
+	if err != nil || count != 2 || ttl != 20*time.Second {
+		t.Fatalf("second Increment = (%d, %s, %v), want (2, 20s, nil)", count, ttl, err)
+	}
+
+	fake.Advance(20 * time.Second)
+
+	if _, err := store.Get(ctx, "counter"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get after expiry = %v, want %v", err, ErrCacheMiss)
+	}
+
+	count, ttl, err = store.Increment(ctx, "counter", time.Minute)
+	if err != nil || count != 1 || ttl != time.Minute {
+		t.Errorf("Increment after expiry = (%d, %s, %v), want (1, 1m0s, nil)", count, ttl, err)
+	}
+}
+
+func TestMemoryCacheIncrementWithoutExpiry(t *testing.T) {
+	store, _ := newTestCache()
+
+	count, ttl, err := store.Increment(context.Background(), "counter", 0)
+	if err != nil || count != 1 || ttl != 0 {
+		t.Errorf("Increment = (%d, %s, %v), want (1, 0s, nil)", count, ttl, err)
+	}
+}
+
+func TestMemoryCacheIncrementRejectsNonInteger(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestCache()
+
+	if err := store.Set(ctx, "counter", "abc", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if _, _, err := store.Increment(ctx, "counter", time.Minute); err == nil {
+		t.Error("Increment of a non-integer value succeeded")
+	}
+}
+
+func TestMemoryCacheSweepsExpiredKeysOnWrite(t *testing.T) {
+	ctx := context.Background()
+	store, fake := newTestCache()
+
+	for _, key := range []string{"a", "b", "c"} {
+		if _, _, err := store.Increment(ctx, key, time.Second); err != nil {
+			t.Fatalf("Increment %s: %v", key, err)
+		}
+	}
+
+	if err := store.Set(ctx, "persistent", "value", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// As chaves expiradas nunca são lidas; a próxima escrita após o
+	// intervalo de varredura as remove
+	fake.Advance(sweepInterval)
+
+	if err := store.Set(ctx, "fresh", "value", time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if len(store.items) != 2 {
+		t.Errorf("items = %v, want only persistent and fresh", store.items)
+	}
+}
@@ -0,0 +1,171 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// ErrOpen indica que o circuito está aberto e a chamada não foi executada.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State representa o estado do circuito.
+type State string
+
+// Estados possíveis do circuito.
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+// Valores padrão da configuração.
+const (
+	DefaultFailureThreshold = 5
+	DefaultCooldown         = 30 * time.Second
+)
+
+// Config representa a configuração do Breaker.
+type Config struct {
+	Clock clock.Clock
+	// IsFailure decide se um erro conta como falha; nil considera todo erro não nulo.
+	IsFailure func(err error) bool
+	// IsNeutral identifica erros que não dizem nada sobre a saúde da
+	// dependência (ex.: cancelamento pelo chamador): não contam como falha
+	// nem como sucesso. nil não trata nenhum erro como neutro.
+	IsNeutral func(err error) bool
+	// FailureThreshold é o número de falhas consecutivas que abre o circuito.
+	FailureThreshold int
+	// Cooldown é o tempo em que o circuito fica aberto antes de liberar uma sondagem.
+	Cooldown time.Duration
+}
+
+// Breaker implementa um circuit breaker: após N falhas consecutivas o circuito
+// abre e as chamadas falham imediatamente com ErrOpen; passado o cooldown, uma
+// única chamada de sondagem é liberada (half-open) e o resultado dela decide
+// se o circuito fecha ou volta a abrir.
+type Breaker struct {
+	openedAt         time.Time
+	clock            clock.Clock
+	isFailure        func(err error) bool
+	isNeutral        func(err error) bool
+	state            State
+	failures         int
+	failureThreshold int
+	cooldown         time.Duration
+	probing          bool
+	mutex            sync.Mutex
+}
+
+// New cria um novo Breaker.
+func New(config Config) *Breaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultFailureThreshold
+	}
+
+	if config.Cooldown <= 0 {
+		config.Cooldown = DefaultCooldown
+	}
+
+	if config.IsFailure == nil {
+		config.IsFailure = func(err error) bool { return err != nil }
+	}
+
+	if config.IsNeutral == nil {
+		config.IsNeutral = func(error) bool { return false }
+	}
+
+	return &Breaker{
+		clock:            clock.OrReal(config.Clock),
+		isFailure:        config.IsFailure,
+		isNeutral:        config.IsNeutral,
+		state:            StateClosed,
+		failureThreshold: config.FailureThreshold,
+		cooldown:         config.Cooldown,
+	}
+}
+
+// Allow indica se uma chamada pode ser executada. Quando retorna nil, o
+// chamador deve informar o resultado com Record.
+func (b *Breaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.cooldown {
+			return ErrOpen
+		}
+
+		// Cooldown encerrado: liberar uma única sondagem
+		b.state = StateHalfOpen
+		b.probing = true
+
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+
+		b.probing = true
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record registra o resultado de uma chamada liberada por Allow.
+func (b *Breaker) Record(err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if err != nil && b.isNeutral(err) {
+		// A sondagem não foi conclusiva: a próxima chamada sonda novamente
+		b.probing = false
+
+		return
+	}
+
+	if !b.isFailure(err) {
+		b.state = StateClosed
+		b.failures = 0
+		b.probing = false
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == StateHalfOpen || b.failures >= b.failureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.clock.Now()
+		b.probing = false
+	}
+}
+
+// Execute executa fn se o circuito permitir e registra o resultado.
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.Record(err)
+
+	return err
+}
+
+// State retorna o estado atual do circuito.
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == StateOpen && b.clock.Now().Sub(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+
+	return b.state
+}
@@ -0,0 +1,115 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+var (
+	errFailure = errors.New("connection refused")
+	errNeutral = errors.New("canceled by caller")
+)
+
+func newTestBreaker() (*Breaker, *clock.FakeClock) {
+	fake := clock.NewFakeClock(time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC))
+
+	return New(Config{
+		Clock:            fake,
+		FailureThreshold: 3,
+		Cooldown:         time.Minute,
+		IsNeutral:        func(err error) bool { return errors.Is(err, errNeutral) },
+	}), fake
+}
+
+// fail executa n chamadas que retornam err.
+func fail(breaker *Breaker, n int, err error) {
+	for range n {
+		_ = breaker.Execute(func() error { return err })
+	}
+}
+
+func TestBreakerOpensAfterThresholdAndRecovers(t *testing.T) {
+	breaker, fake := newTestBreaker()
+
+	fail(breaker, 2, errFailure)
+
+	if state := breaker.State(); state != StateClosed {
+		t.Fatalf("state after 2 failures = %s, want %s", state, StateClosed)
+	}
+
+	fail(breaker, 1, errFailure)
+
+	if state := breaker.State(); state != StateOpen {
+		t.Fatalf("state after 3 failures = %s, want %s", state, StateOpen)
+	}
+
+	called := false
+	if err := breaker.Execute(func() error { called = true; return nil }); !errors.Is(err, ErrOpen) || called {
+		t.Fatalf("Execute while open = %v (called %v), want %v without calling", err, called, ErrOpen)
+	}
+
+	fake.Advance(time.Minute)
+
+	if state := breaker.State(); state != StateHalfOpen {
+		t.Fatalf("state after cooldown = %s, want %s", state, StateHalfOpen)
+	}
+
+	if err := breaker.Execute(func() error { return nil }); err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+
+	if state := breaker.State(); state != StateClosed {
+		t.Errorf("state after successful probe = %s, want %s", state, StateClosed)
+	}
+}
+
+func TestBreakerFailedProbeReopens(t *testing.T) {
+	breaker, fake := newTestBreaker()
+
+	fail(breaker, 3, errFailure)
+	fake.Advance(time.Minute)
+	fail(breaker, 1, errFailure)
+
+	if state := breaker.State(); state != StateOpen {
+		t.Errorf("state after failed probe = %s, want %s", state, StateOpen)
+	}
+}
+
+func TestBreakerIgnoresNeutralErrors(t *testing.T) {
+	breaker, fake := newTestBreaker()
+
+	// Erros neutros não abrem o circuito...
+	fail(breaker, 5, errNeutral)
+
+	if state := breaker.State(); state != StateClosed {
+		t.Fatalf("state after neutral errors = %s, want %s", state, StateClosed)
+	}
+
+	// ...nem zeram a contagem de falhas consecutivas
+	fail(breaker, 2, errFailure)
+	fail(breaker, 1, errNeutral)
+	fail(breaker, 1, errFailure)
+
+	if state := breaker.State(); state != StateOpen {
+		t.Fatalf("state after failures around a neutral error = %s, want %s", state, StateOpen)
+	}
+
+	// Uma sondagem neutra não fecha o circuito, mas libera a próxima sondagem
+	fake.Advance(time.Minute)
+	fail(breaker, 1, errNeutral)
+
+	if state := breaker.State(); state != StateHalfOpen {
+		t.Fatalf("state after neutral probe = %s, want %s", state, StateHalfOpen)
+	}
+
+	if err := breaker.Execute(func() error { return nil }); err != nil {
+		t.Fatalf("second probe: %v", err)
+	}
+
+	if state := breaker.State(); state != StateClosed {
+		t.Errorf("state after successful probe = %s, want %s", state, StateClosed)
+	}
+}
@@ -0,0 +1,80 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstrai a leitura do tempo atual para permitir testes determinísticos.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock implementa Clock usando o relógio do sistema.
+type RealClock struct{}
+
+// Now retorna o tempo atual do sistema.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock implementa Clock com um tempo controlado manualmente.
+type FakeClock struct {
+	now   time.Time
+	mutex sync.RWMutex
+}
+
+// NewFakeClock cria um relógio fixo no instante informado.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now retorna o instante atual do relógio fake.
+func (f *FakeClock) Now() time.Time {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.now
+}
+
+// Set define o instante atual do relógio fake.
+func (f *FakeClock) Set(now time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.now = now
+}
+
+// Advance avança o relógio fake pela duração informada.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.now = f.now.Add(d)
+}
+
+// OrReal retorna o relógio informado ou o relógio real quando nil.
+func OrReal(c Clock) Clock {
+	if c == nil {
+		return RealClock{}
+	}
+
+	return c
+}
+
+// UTC normaliza o instante para UTC, para que respostas e gravações não
+// dependam do fuso do servidor ou da configuração do driver.
+func UTC(t time.Time) time.Time {
+	return t.UTC()
+}
+
+// UTCPtr normaliza um instante opcional para UTC; nil permanece nil.
+func UTCPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	utc := t.UTC()
+
+	return &utc
+}
@@ -0,0 +1,44 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUTC(t *testing.T) {
+	instant := time.Date(2025, 1, 6, 9, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	got := UTC(instant)
+	if got.Location() != time.UTC || !got.Equal(instant) {
+		t.Errorf("UTC(%v) = %v, want the same instant in UTC", instant, got)
+	}
+
+	if ptr := UTCPtr(&instant); ptr == nil || ptr.Location() != time.UTC || !ptr.Equal(instant) {
+		t.Errorf("UTCPtr(%v) = %v, want the same instant in UTC", instant, ptr)
+	}
+
+	if ptr := UTCPtr(nil); ptr != nil {
+		t.Errorf("UTCPtr(nil) = %v, want nil", ptr)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	fake := NewFakeClock(start)
+
+	fake.Advance(90 * time.Second)
+
+	if got := fake.Now(); !got.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("Now after Advance = %v", got)
+	}
+
+	fake.Set(start)
+
+	if got := fake.Now(); !got.Equal(start) {
+		t.Errorf("Now after Set = %v", got)
+	}
+
+	if _, ok := OrReal(nil).(RealClock); !ok {
+		t.Error("OrReal(nil) is not the real clock")
+	}
+}
@@ -0,0 +1,75 @@
+// Package featureflag mantém as flags de funcionalidade da aplicação.
+//
+// Os valores iniciais vêm da configuração e podem ser alterados em tempo de
+// execução; quem depende de uma flag deve consultá-la a cada uso, e não
+// guardar o valor, para que a alteração tenha efeito imediato.
+package featureflag
+
+import (
+	"errors"
+	"sync"
+)
+
+// Flags conhecidas pela aplicação.
+const (
+	// UserRegistration controla o auto-cadastro público (POST /api/v1/users).
+	UserRegistration = "user_registration"
+	// MaintenanceMode recusa as requisições de escrita com 503 enquanto ativa.
+	MaintenanceMode = "maintenance_mode"
+)
+
+// ErrUnknownFlag indica uma flag que não foi registrada no Store.
+var ErrUnknownFlag = errors.New("unknown feature flag")
+
+// Store guarda o estado das flags conhecidas. É seguro para uso concorrente.
+type Store struct {
+	mu    sync.RWMutex
+	flags map[string]bool
+}
+
+// NewStore cria um Store com as flags informadas e seus valores iniciais.
+// Apenas essas flags podem ser alteradas depois.
+func NewStore(defaults map[string]bool) *Store {
+	flags := make(map[string]bool, len(defaults))
+	for name, enabled := range defaults {
+		flags[name] = enabled
+	}
+
+	return &Store{flags: flags}
+}
+
+// Enabled indica se a flag está ativa. Flags desconhecidas são consideradas inativas.
+func (s *Store) Enabled(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.flags[name]
+}
+
+// Set altera o valor de uma flag, retornando o valor anterior.
+func (s *Store) Set(name string, enabled bool) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.flags[name]
+	if !ok {
+		return false, ErrUnknownFlag
+	}
+
+	s.flags[name] = enabled
+
+	return previous, nil
+}
+
+// All retorna uma cópia do estado atual de todas as flags.
+func (s *Store) All() map[string]bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	flags := make(map[string]bool, len(s.flags))
+	for name, enabled := range s.flags {
+		flags[name] = enabled
+	}
+
+	return flags
+}
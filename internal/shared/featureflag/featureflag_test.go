@@ -0,0 +1,31 @@
+package featureflag
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(map[string]bool{UserRegistration: true})
+
+	if !store.Enabled(UserRegistration) || store.Enabled("unknown") {
+		t.Fatalf("initial flags = %v", store.All())
+	}
+
+	previous, err := store.Set(UserRegistration, false)
+	if err != nil || !previous || store.Enabled(UserRegistration) {
+		t.Errorf("Set = %v, %v; enabled %v", previous, err, store.Enabled(UserRegistration))
+	}
+
+	if _, err := store.Set("unknown", true); !errors.Is(err, ErrUnknownFlag) {
+		t.Errorf("Set(unknown) = %v, want %v", err, ErrUnknownFlag)
+	}
+
+	// All retorna uma cópia: alterá-la não muda o Store
+	all := store.All()
+	all[UserRegistration] = true
+
+	if store.Enabled(UserRegistration) || len(store.All()) != 1 {
+		t.Errorf("store changed through All: %v", store.All())
+	}
+}
@@ -0,0 +1,71 @@
+package i18n
+
+// catalogs guarda as mensagens de cada idioma. As mensagens de erro são
+// indexadas pelo código de erro da API; o catálogo em inglês não as repete,
+// porque os handlers já escrevem a mensagem original em inglês. As mensagens
+// de validação são indexadas por "validation." seguido da regra violada.
+var catalogs = map[string]map[string]string{
+	English: {
+		"validation.required": "is required",
+		"validation.email":    "must be a valid email",
+		"validation.min":      "must be at least {param} characters long",
+		"validation.max":      "must be at most {param} characters long",
+		"validation.rule":     "failed the {tag} rule",
+	},
+	Portuguese: {
+		"validation.required": "é obrigatório",
+		"validation.email":    "deve ser um email válido",
+		"validation.min":      "deve ter pelo menos {param} caracteres",
+		"validation.max":      "deve ter no máximo {param} caracteres",
+		"validation.rule":     "não atende à regra {tag}",
+
+		"ADMIN_IMPERSONATION_FORBIDDEN": "Não é possível personificar um administrador",
+		"AUTHENTICATION_REQUIRED":       "Autenticação obrigatória",
+		"BATCH_TOO_LARGE":               "O lote excede a quantidade máxima de IDs",
+		"CIRCUIT_BREAKER_OPEN":          "Serviço temporariamente indisponível, tente novamente mais tarde",
+		"EMAIL_DOMAIN_NOT_ALLOWED":      "O domínio do email não é permitido",
+		"EMAIL_NOT_APPLICABLE":          "Este tipo de email não se aplica ao status do usuário",
+		"EMAIL_TYPE_UNAVAILABLE":        "Este tipo de email não está disponível",
+		"EMPTY_BATCH":                   "Informe ao menos um ID",
+		"EMPTY_BODY":                    "O corpo da requisição é obrigatório e deve ser um objeto JSON",
+		"EMPTY_FILTER":                  "A operação em lote exige ao menos um filtro",
+		"FILTER_PRESET_NOT_FOUND":       "Preset de filtro não encontrado",
+		"INVALID_CURRENT_PASSWORD":      "A senha atual está incorreta",
+		"INVALID_DAYS":                  "days deve ser um número inteiro não negativo",
+		"INVALID_EMAIL":                 "Email inválido",
+		"INVALID_EMAIL_DOMAIN":          "Domínio de email inválido",
+		"INVALID_EMAIL_TYPE":            "Tipo de email inválido (use welcome ou activation)",
+		"INVALID_FIELDS":                "Parâmetro fields inválido",
+		"INVALID_FILTER":                "Filtro inválido",
+		"INVALID_FILTER_PRESET":         "Preset de filtro inválido",
+		"INVALID_ID":                    "ID inválido",
+		"INVALID_LOG_LEVEL":             "Nível de log inválido",
+		"INVALID_LOOKUP":                "Busca inválida",
+		"INVALID_METADATA":              "Metadados inválidos",
+		"INVALID_METADATA_KEY":          "Chave de metadados inválida",
+		"INVALID_PASSWORD":              "A senha não atende à política de senhas",
+		"INVALID_PROFILE":               "Perfil inválido",
+		"INVALID_REQUEST":               "Requisição inválida",
+		"INVALID_ROLE":                  "Papel inválido",
+		"INVALID_STATUS":                "Status inválido",
+		"INVALID_STATUS_TRANSITION":     "Transição de status não permitida",
+		"INVALID_UUID":                  "Formato de UUID inválido",
+		"METADATA_TOO_LARGE":            "Os metadados excedem os limites de tamanho",
+		"NESTED_IMPERSONATION":          "Não é possível personificar outro usuário durante uma personificação",
+		"PAGE_TOO_DEEP":                 "Página muito profunda; restrinja os filtros (por exemplo, created_from/created_to) e pagine dentro deles",
+		"PASSWORD_REUSED":               "A nova senha não pode repetir uma senha usada recentemente",
+		"RESEND_RATE_LIMITED":           "Muitos reenvios de email para este usuário, tente novamente mais tarde",
+		"ROLE_CHANGE_FORBIDDEN":         "Você não tem permissão para mudar o papel deste usuário",
+		"ROUTE_NOT_FOUND":               "Rota não encontrada",
+		"SELF_IMPERSONATION":            "Não é possível personificar a si mesmo",
+		"SELF_MERGE":                    "Não é possível mesclar um usuário com ele mesmo",
+		"SELF_ROLE_CHANGE":              "Não é possível mudar o próprio papel",
+		"TIMEOUT":                       "A requisição demorou demais para ser concluída",
+		"UNKNOWN_FEATURE_FLAG":          "Feature flag desconhecida",
+		"UNSUPPORTED_API_VERSION":       "Versão da API não suportada",
+		"UNSUPPORTED_MEDIA_TYPE":        "O Content-Type deve ser application/json",
+		"USER_ALREADY_EXISTS":           "Já existe um usuário com este email",
+		"USER_NOT_FOUND":                "Usuário não encontrado",
+		"VALIDATION_ERROR":              "Falha na validação",
+	},
+}
@@ -0,0 +1,83 @@
+// Package i18n traduz as mensagens da API para o idioma pedido pelo cliente.
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Idiomas com catálogo de mensagens.
+const (
+	English    = "en"
+	Portuguese = "pt"
+)
+
+// DefaultLanguage é o idioma usado quando o cliente não aceita nenhum idioma suportado.
+const DefaultLanguage = English
+
+// FromAcceptLanguage escolhe o idioma suportado de maior peso no cabeçalho
+// Accept-Language, comparando apenas o idioma principal (pt-BR casa com pt).
+// Sem correspondência, retorna DefaultLanguage.
+func FromAcceptLanguage(header string) string {
+	best, bestWeight := DefaultLanguage, 0.0
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+
+		if _, ok := catalogs[primary]; !ok {
+			continue
+		}
+
+		// Em empate vale a ordem do cabeçalho
+		if weight := languageWeight(params); weight > bestWeight {
+			best, bestWeight = primary, weight
+		}
+	}
+
+	return best
+}
+
+// languageWeight lê o peso q dos parâmetros de um idioma (1 se ausente).
+func languageWeight(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.TrimSpace(key) != "q" {
+			continue
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0
+		}
+
+		return weight
+	}
+
+	return 1
+}
+
+// Lookup retorna a mensagem de key no catálogo do idioma, sem recorrer a outro idioma.
+func Lookup(language, key string) (string, bool) {
+	message, ok := catalogs[language][key]
+
+	return message, ok
+}
+
+// Translate retorna a mensagem de key no idioma, recorrendo ao inglês quando
+// não há tradução e à própria key em último caso. replacements são pares
+// placeholder/valor, como em strings.NewReplacer.
+func Translate(language, key string, replacements ...string) string {
+	message, ok := Lookup(language, key)
+	if !ok {
+		if message, ok = Lookup(English, key); !ok {
+			message = key
+		}
+	}
+
+	if len(replacements) == 0 {
+		return message
+	}
+
+	return strings.NewReplacer(replacements...).Replace(message)
+}
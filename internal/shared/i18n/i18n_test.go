@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: English},
+		{header: "pt-BR", want: Portuguese},
+		{header: "PT-br,pt;q=0.9", want: Portuguese},
+		{header: "fr-FR, pt;q=0.8, en;q=0.5", want: Portuguese},
+		{header: "en-US,en;q=0.9,pt-BR;q=0.8", want: English},
+		{header: "pt;q=0, en;q=0.1", want: English},
+		{header: "fr, de", want: English},
+		{header: "en, pt", want: English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := FromAcceptLanguage(tt.header); got != tt.want {
+				t.Errorf("FromAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		key      string
+		want     string
+	}{
+		{name: "portuguese error", language: Portuguese, key: "USER_NOT_FOUND", want: "Usuário não encontrado"},
+		{name: "portuguese rule with param", language: Portuguese, key: "validation.min", want: "deve ter pelo menos 8 caracteres"},
+		{name: "english rule with param", language: English, key: "validation.min", want: "must be at least 8 characters long"},
+		{name: "falls back to english", language: "fr", key: "validation.required", want: "is required"},
+		{name: "falls back to the key", language: Portuguese, key: "UNKNOWN_CODE", want: "UNKNOWN_CODE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.language, tt.key, "{param}", "8"); got != tt.want {
+				t.Errorf("Translate(%s, %s) = %q, want %q", tt.language, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogsCoverTheSameValidationRules(t *testing.T) {
+	for key := range catalogs[English] {
+		if _, ok := catalogs[Portuguese][key]; !ok {
+			t.Errorf("Portuguese catalog is missing %s", key)
+		}
+	}
+
+	for key := range catalogs[Portuguese] {
+		if strings.HasPrefix(key, "validation.") {
+			if _, ok := catalogs[English][key]; !ok {
+				t.Errorf("English catalog is missing %s", key)
+			}
+		}
+	}
+}
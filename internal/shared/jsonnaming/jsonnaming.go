@@ -0,0 +1,394 @@
+// Package jsonnaming define a convenção de nomes dos campos JSON da API.
+//
+// Os tipos de resposta declaram suas tags json em snake_case. Com CamelCase
+// configurado (Configure), Convert reescreve os nomes dos campos de structs e
+// as chaves de mapas de resposta (gin.H e mapas sem nome de tipo) antes da
+// serialização. Mapas de tipo nomeado, como domain.Metadata, carregam chaves
+// definidas pelo cliente e são preservados.
+package jsonnaming
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Strategy é uma convenção de nomes de campos JSON.
+type Strategy string
+
+// Convenções suportadas.
+const (
+	SnakeCase Strategy = "snake"
+	CamelCase Strategy = "camel"
+)
+
+// ErrUnknownStrategy indica uma convenção de nomes desconhecida.
+var ErrUnknownStrategy = errors.New("unknown JSON naming strategy")
+
+var current atomic.Value
+
+func init() {
+	Configure(SnakeCase)
+}
+
+// Parse converte o texto em Strategy; vazio resulta em SnakeCase.
+func Parse(value string) (Strategy, error) {
+	switch strategy := Strategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case "", SnakeCase:
+		return SnakeCase, nil
+	case CamelCase:
+		return CamelCase, nil
+	default:
+		return "", fmt.Errorf("%w: %q (expected snake or camel)", ErrUnknownStrategy, value)
+	}
+}
+
+// Configure define a convenção usada por toda a API. Deve ser chamada na
+// inicialização, antes das primeiras requisições: o validator guarda os nomes
+// dos campos de cada tipo na primeira validação.
+func Configure(strategy Strategy) {
+	if strategy != CamelCase {
+		strategy = SnakeCase
+	}
+
+	current.Store(strategy)
+}
+
+// Current retorna a convenção configurada.
+func Current() Strategy {
+	return current.Load().(Strategy)
+}
+
+// FieldName converte um nome em snake_case para a convenção configurada.
+func FieldName(name string) string {
+	if Current() != CamelCase || !strings.Contains(name, "_") {
+		return name
+	}
+
+	parts := strings.Split(name, "_")
+
+	var builder strings.Builder
+
+	builder.WriteString(parts[0])
+
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+
+		builder.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return builder.String()
+}
+
+// Convert prepara value para serialização com a convenção configurada.
+// Em SnakeCase, value é retornado sem alterações.
+func Convert(value interface{}) interface{} {
+	if Current() != CamelCase || value == nil {
+		return value
+	}
+
+	return convert(reflect.ValueOf(value))
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	ginHType          = reflect.TypeOf(gin.H{})
+)
+
+// convert percorre o valor reescrevendo nomes de campos e chaves de mapas.
+func convert(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+
+	// Tipos com serialização própria (time.Time, uuid.UUID...) seguem como estão
+	if value.Type().Implements(jsonMarshalerType) || value.Type().Implements(textMarshalerType) {
+		return value.Interface()
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+
+		return convert(value.Elem())
+	case reflect.Struct:
+		return convertStruct(value)
+	case reflect.Map:
+		return convertMap(value)
+	case reflect.Slice:
+		if value.IsNil() || value.Type().Elem().Kind() == reflect.Uint8 {
+			return value.Interface()
+		}
+
+		fallthrough
+	case reflect.Array:
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			items[i] = convert(value.Index(i))
+		}
+
+		return items
+	default:
+		return value.Interface()
+	}
+}
+
+// convertMap reescreve as chaves de mapas de resposta e converte os valores.
+func convertMap(value reflect.Value) interface{} {
+	if value.IsNil() {
+		return nil
+	}
+
+	renameKeys := value.Type().Key().Kind() == reflect.String &&
+		(value.Type().Name() == "" || value.Type() == ginHType)
+
+	converted := make(map[string]interface{}, value.Len())
+
+	iter := value.MapRange()
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key().Interface())
+		if renameKeys {
+			key = FieldName(key)
+		}
+
+		converted[key] = convert(iter.Value())
+	}
+
+	return converted
+}
+
+// convertStruct converte uma struct em um objeto que preserva a ordem dos
+// campos, seguindo as regras de tags do encoding/json (nome, omitempty e "-").
+func convertStruct(value reflect.Value) interface{} {
+	var fields object
+
+	valueType := value.Type()
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		fieldValue := value.Field(i)
+
+		// Structs embutidas sem nome na tag têm os campos promovidos
+		if field.Anonymous && name == "" {
+			embedded := fieldValue
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				if inner, ok := convertStruct(embedded).(object); ok {
+					fields = append(fields, inner...)
+				}
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if strings.Contains(","+options+",", ",omitempty,") && isEmptyValue(fieldValue) {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, objectField{name: FieldName(name), value: convert(fieldValue)})
+	}
+
+	return fields
+}
+
+// isEmptyValue reproduz a regra de omitempty do encoding/json: structs nunca
+// são consideradas vazias.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return value.IsZero()
+	default:
+		return false
+	}
+}
+
+// objectField é um campo de object.
+type objectField struct {
+	value interface{}
+	name  string
+}
+
+// object é um objeto JSON que preserva a ordem de declaração dos campos.
+type object []objectField
+
+// MarshalJSON serializa os campos na ordem em que foram declarados.
+func (o object) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteByte('{')
+
+	for i, field := range o {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// SnakeName converte um nome em camelCase para snake_case; nomes já em
+// snake_case não mudam.
+func SnakeName(name string) string {
+	var builder strings.Builder
+
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				builder.WriteByte('_')
+			}
+
+			r += 'a' - 'A'
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// RequestBody renomeia as chaves de primeiro nível do corpo JSON escritas na
+// convenção configurada para os nomes das tags json de target, incluindo os
+// campos promovidos de structs embutidas, para que a decodificação aceite os
+// mesmos nomes que a API emite. Objetos aninhados são preservados. Em SnakeCase, ou se o corpo não for um objeto, body é
+// retornado sem alterações.
+func RequestBody(body []byte, target interface{}) []byte {
+	if Current() != CamelCase {
+		return body
+	}
+
+	targetType := reflect.TypeOf(target)
+	for targetType != nil && targetType.Kind() == reflect.Pointer {
+		targetType = targetType.Elem()
+	}
+
+	if targetType == nil || targetType.Kind() != reflect.Struct {
+		return body
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+
+	renamed := false
+
+	for _, name := range tagNames(targetType) {
+		external := FieldName(name)
+		if value, ok := fields[external]; ok && external != name {
+			if _, exists := fields[name]; !exists {
+				fields[name] = value
+			}
+
+			delete(fields, external)
+
+			renamed = true
+		}
+	}
+
+	if !renamed {
+		return body
+	}
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+
+	return normalized
+}
+
+// tagNames retorna os nomes das tags json dos campos de structType, descendo
+// em structs embutidas sem nome na tag como convertStruct.
+func tagNames(structType reflect.Type) []string {
+	var names []string
+
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				names = append(names, tagNames(embedded)...)
+
+				continue
+			}
+		}
+
+		if name == "" || !field.IsExported() {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,177 @@
+package jsonnaming
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// useStrategy configura a convenção durante o teste e restaura snake_case ao final.
+func useStrategy(t *testing.T, strategy Strategy) {
+	t.Helper()
+
+	Configure(strategy)
+	t.Cleanup(func() { Configure(SnakeCase) })
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Strategy
+		wantErr error
+	}{
+		{value: "", want: SnakeCase},
+		{value: "snake", want: SnakeCase},
+		{value: " Camel ", want: CamelCase},
+		{value: "kebab", wantErr: ErrUnknownStrategy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("Parse(%q) = %q, %v; want %q, %v", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFieldNameAndSnakeName(t *testing.T) {
+	useStrategy(t, CamelCase)
+
+	tests := []struct {
+		snake string
+		camel string
+	}{
+		{snake: "id", camel: "id"},
+		{snake: "created_at", camel: "createdAt"},
+		{snake: "last_login_at", camel: "lastLoginAt"},
+	}
+
+	for _, tt := range tests {
+		if got := FieldName(tt.snake); got != tt.camel {
+			t.Errorf("FieldName(%q) = %q, want %q", tt.snake, got, tt.camel)
+		}
+
+		if got := SnakeName(tt.camel); got != tt.snake {
+			t.Errorf("SnakeName(%q) = %q, want %q", tt.camel, got, tt.snake)
+		}
+	}
+}
+
+// labels é um mapa de tipo nomeado, cujas chaves pertencem ao cliente.
+type labels map[string]string
+
+type inner struct {
+	ZipCode string `json:"zip_code"`
+}
+
+type sample struct {
+	inner
+	Labels    labels `json:"labels"`
+	Extra     gin.H  `json:"extra"`
+	Nickname  string `json:"nick_name,omitempty"`
+	Secret    string `json:"-"`
+	FullName  string `json:"full_name"`
+	Addresses []inner
+}
+
+func TestConvert(t *testing.T) {
+	value := sample{
+		inner:     inner{ZipCode: "01000-000"},
+		Labels:    labels{"cost_center": "42"},
+		Extra:     gin.H{"page_size": 10},
+		Secret:    "hidden",
+		FullName:  "Ana Maria",
+		Addresses: []inner{{ZipCode: "02000-000"}},
+	}
+
+	tests := []struct {
+		strategy Strategy
+		want     string
+	}{
+		{
+			strategy: SnakeCase,
+			want:     `{"zip_code":"01000-000","labels":{"cost_center":"42"},"extra":{"page_size":10},"full_name":"Ana Maria","Addresses":[{"zip_code":"02000-000"}]}`,
+		},
+		{
+			strategy: CamelCase,
+			want:     `{"zipCode":"01000-000","labels":{"cost_center":"42"},"extra":{"pageSize":10},"fullName":"Ana Maria","Addresses":[{"zipCode":"02000-000"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			useStrategy(t, tt.strategy)
+
+			got, err := json.Marshal(Convert(value))
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("Convert = %s\nwant      %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestBody(t *testing.T) {
+	type request struct {
+		FullName string `json:"full_name"`
+		Email    string `json:"email"`
+	}
+
+	tests := []struct {
+		name     string
+		strategy Strategy
+		body     string
+		want     request
+	}{
+		{name: "snake body in snake mode", strategy: SnakeCase, body: `{"full_name":"Ana","email":"a@b.com"}`, want: request{FullName: "Ana", Email: "a@b.com"}},
+		{name: "camel body in camel mode", strategy: CamelCase, body: `{"fullName":"Ana","email":"a@b.com"}`, want: request{FullName: "Ana", Email: "a@b.com"}},
+		{name: "snake body still accepted in camel mode", strategy: CamelCase, body: `{"full_name":"Ana"}`, want: request{FullName: "Ana"}},
+		{name: "camel body ignored in snake mode", strategy: SnakeCase, body: `{"fullName":"Ana"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useStrategy(t, tt.strategy)
+
+			var got request
+			if err := json.Unmarshal(RequestBody([]byte(tt.body), &got), &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("decoded = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestBodyEmbeddedFields(t *testing.T) {
+	useStrategy(t, CamelCase)
+
+	type filter struct {
+		EmailDomain string `json:"email_domain,omitempty"`
+		DryRun      bool   `json:"dry_run"`
+	}
+
+	type request struct {
+		filter
+		TargetStatus string `json:"target_status"`
+	}
+
+	var got request
+	if err := json.Unmarshal(RequestBody([]byte(`{"targetStatus":"suspended","dryRun":true,"emailDomain":"acme.com"}`), &got), &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	want := request{filter: filter{EmailDomain: "acme.com", DryRun: true}, TargetStatus: "suspended"}
+	if got != want {
+		t.Errorf("decoded = %+v, want %+v", got, want)
+	}
+}
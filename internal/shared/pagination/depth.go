@@ -0,0 +1,41 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// FallbackMaxOffset é a profundidade máxima usada quando ConfigureMaxOffset não é chamado.
+const FallbackMaxOffset = 10000
+
+// ErrPageTooDeep indica uma página cujo offset passa da profundidade máxima.
+var ErrPageTooDeep = errors.New("page too deep")
+
+var maxOffset atomic.Int64
+
+func init() {
+	ConfigureMaxOffset(FallbackMaxOffset)
+}
+
+// ConfigureMaxOffset define quantos registros uma listagem pode pular (offset).
+// Páginas mais profundas obrigam o Postgres a ler e descartar todas as linhas
+// anteriores; valores < 1 removem o limite.
+func ConfigureMaxOffset(value int) {
+	maxOffset.Store(int64(max(value, 0)))
+}
+
+// MaxOffset retorna a profundidade máxima configurada (0 quando não há limite).
+func MaxOffset() int {
+	return int(maxOffset.Load())
+}
+
+// CheckOffset retorna ErrPageTooDeep quando offset passa da profundidade máxima.
+func CheckOffset(offset int) error {
+	limit := MaxOffset()
+	if limit > 0 && offset > limit {
+		return fmt.Errorf("%w: offset %d exceeds the maximum of %d", ErrPageTooDeep, offset, limit)
+	}
+
+	return nil
+}
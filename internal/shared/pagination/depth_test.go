@@ -0,0 +1,39 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+)
+
+// configureMaxOffset aplica a profundidade durante o teste e restaura o fallback ao final.
+func configureMaxOffset(t *testing.T, value int) {
+	t.Helper()
+
+	ConfigureMaxOffset(value)
+	t.Cleanup(func() { ConfigureMaxOffset(FallbackMaxOffset) })
+}
+
+func TestCheckOffset(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxOffset int
+		offset    int
+		want      error
+	}{
+		{name: "deep page within the limit", maxOffset: 1000, offset: 1000},
+		{name: "beyond the limit", maxOffset: 1000, offset: 1001, want: ErrPageTooDeep},
+		{name: "fallback limit", maxOffset: FallbackMaxOffset, offset: FallbackMaxOffset + 1, want: ErrPageTooDeep},
+		{name: "disabled", maxOffset: 0, offset: 1_000_000},
+		{name: "negative disables", maxOffset: -5, offset: 1_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configureMaxOffset(t, tt.maxOffset)
+
+			if err := CheckOffset(tt.offset); !errors.Is(err, tt.want) {
+				t.Errorf("CheckOffset(%d) = %v, want %v", tt.offset, err, tt.want)
+			}
+		})
+	}
+}
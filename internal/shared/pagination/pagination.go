@@ -1,17 +1,69 @@
+// Package pagination centraliza os limites de paginação das listagens.
+//
+// O tamanho de página efetivo é resolvido nesta ordem: valor pedido na
+// requisição, padrão configurado (Configure) quando ausente ou inválido e,
+// por fim, o teto configurado, que nunca é ultrapassado.
 package pagination
 
 import (
 	"strconv"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Limites usados quando Configure não é chamado ou recebe valores inválidos.
+const (
+	FallbackDefaultLimit = 10
+	FallbackMaxLimit     = 100
+)
+
+var (
+	defaultLimit atomic.Int64
+	maxLimit     atomic.Int64
+)
+
+func init() {
+	Configure(FallbackDefaultLimit, FallbackMaxLimit)
+}
+
+// Configure define o tamanho de página padrão e o máximo de todas as listagens.
+// Valores < 1 usam os fallbacks, e o padrão nunca excede o máximo.
+func Configure(defaultValue, maxValue int) {
+	if maxValue < 1 {
+		maxValue = FallbackMaxLimit
+	}
+
+	if defaultValue < 1 {
+		defaultValue = FallbackDefaultLimit
+	}
+
+	defaultValue = min(defaultValue, maxValue)
+
+	maxLimit.Store(int64(maxValue))
+	defaultLimit.Store(int64(defaultValue))
+}
+
+// DefaultLimit retorna o tamanho de página padrão configurado.
+func DefaultLimit() int {
+	return int(defaultLimit.Load())
+}
+
+// MaxLimit retorna o tamanho de página máximo configurado.
+func MaxLimit() int {
+	return int(maxLimit.Load())
+}
+
 // Params representa os parâmetros de paginação.
+// Valores fora dos limites são ajustados (nunca rejeitados); RequestedPage e
+// RequestedLimit guardam o que o cliente pediu para que o ajuste seja informado.
 type Params struct {
-	Sort  string
-	Order string
-	Page  int
-	Limit int
+	Sort           string
+	Order          string
+	Page           int
+	Limit          int
+	RequestedPage  int
+	RequestedLimit int
 }
 
 // Result representa o resultado paginado.
@@ -27,34 +79,38 @@ type Result struct {
 
 // ParseFromQuery extrai parâmetros de paginação da query string.
 func ParseFromQuery(c *gin.Context) *Params {
-	page := parseInt(c.Query("page"), 1)
-	limit := parseInt(c.Query("limit"), 10)
+	requestedPage := parseInt(c.Query("page"), 1)
+	requestedLimit := parseInt(c.Query("limit"), DefaultLimit())
 	sort := c.Query("sort")
 	order := c.Query("order")
 
 	// Validações básicas
+	page := requestedPage
 	if page < 1 {
 		page = 1
 	}
 
-	if limit < 1 {
-		limit = 10
-	}
-
-	if limit > 100 {
-		limit = 100
-	}
-
 	if order != "asc" && order != "desc" {
 		order = "asc"
 	}
 
 	return &Params{
-		Page:  page,
-		Limit: limit,
-		Sort:  sort,
-		Order: order,
+		Page:           page,
+		Limit:          ClampLimit(requestedLimit),
+		Sort:           sort,
+		Order:          order,
+		RequestedPage:  requestedPage,
+		RequestedLimit: requestedLimit,
+	}
+}
+
+// ClampLimit ajusta o limite ao intervalo aceito (padrão quando < 1, MaxLimit no máximo).
+func ClampLimit(limit int) int {
+	if limit < 1 {
+		return DefaultLimit()
 	}
+
+	return min(limit, MaxLimit())
 }
 
 // Offset calcula o offset baseado na página e limite.
@@ -104,8 +160,8 @@ func ValidateParams(params *Params) error {
 		return &ValidationError{Field: "limit", Message: "Limit must be greater than 0"}
 	}
 
-	if params.Limit > 100 {
-		return &ValidationError{Field: "limit", Message: "Limit must be at most 100"}
+	if params.Limit > MaxLimit() {
+		return &ValidationError{Field: "limit", Message: "Limit must be at most " + strconv.Itoa(MaxLimit())}
 	}
 
 	if params.Order != "" && params.Order != "asc" && params.Order != "desc" {
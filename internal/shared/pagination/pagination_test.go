@@ -0,0 +1,58 @@
+package pagination
+
+import "testing"
+
+// configure aplica os limites durante o teste e restaura os fallbacks ao final.
+func configure(t *testing.T, defaultValue, maxValue int) {
+	t.Helper()
+
+	Configure(defaultValue, maxValue)
+	t.Cleanup(func() { Configure(FallbackDefaultLimit, FallbackMaxLimit) })
+}
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultIn   int
+		maxIn       int
+		wantDefault int
+		wantMax     int
+	}{
+		{name: "configured values", defaultIn: 25, maxIn: 200, wantDefault: 25, wantMax: 200},
+		{name: "invalid values use fallbacks", defaultIn: 0, maxIn: -1, wantDefault: FallbackDefaultLimit, wantMax: FallbackMaxLimit},
+		{name: "default capped at max", defaultIn: 50, maxIn: 20, wantDefault: 20, wantMax: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configure(t, tt.defaultIn, tt.maxIn)
+
+			if DefaultLimit() != tt.wantDefault || MaxLimit() != tt.wantMax {
+				t.Errorf("limits = %d/%d, want %d/%d", DefaultLimit(), MaxLimit(), tt.wantDefault, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestClampLimitResolutionOrder(t *testing.T) {
+	configure(t, 25, 200)
+
+	tests := []struct {
+		name      string
+		requested int
+		want      int
+	}{
+		{name: "request value", requested: 40, want: 40},
+		{name: "missing uses configured default", requested: 0, want: 25},
+		{name: "negative uses configured default", requested: -5, want: 25},
+		{name: "hard cap", requested: 500, want: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClampLimit(tt.requested); got != tt.want {
+				t.Errorf("ClampLimit(%d) = %d, want %d", tt.requested, got, tt.want)
+			}
+		})
+	}
+}
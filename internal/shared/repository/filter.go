@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/devleo-m/go-zero/internal/shared/pagination"
+)
+
+// Operator representa um operador de comparação em uma condição.
+type Operator string
+
+// Operadores suportados pelas condições de filtro.
+const (
+	OpEqual          Operator = "="
+	OpNotEqual       Operator = "<>"
+	OpGreater        Operator = ">"
+	OpGreaterOrEqual Operator = ">="
+	OpLess           Operator = "<"
+	OpLessOrEqual    Operator = "<="
+	OpLike           Operator = "LIKE"
+	OpILike          Operator = "ILIKE"
+	OpIn             Operator = "IN"
+	OpNotIn          Operator = "NOT IN"
+	OpIsNull         Operator = "IS NULL"
+	OpIsNotNull      Operator = "IS NOT NULL"
+	// OpJSONContains testa se a coluna JSONB contém o documento do valor (@>).
+	OpJSONContains Operator = "@>"
+	// OpJSONHasKey testa se a coluna JSONB possui a chave do valor no primeiro nível.
+	OpJSONHasKey Operator = "HAS KEY"
+)
+
+// Erros de validação de filtros.
+var (
+	ErrInvalidField    = errors.New("invalid filter field")
+	ErrInvalidOperator = errors.New("invalid filter operator")
+	ErrInvalidOrder    = errors.New("invalid filter order")
+	ErrInvalidValue    = errors.New("invalid filter value")
+)
+
+var (
+	// fieldRegex restringe nomes de campos a identificadores SQL simples.
+	fieldRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.]*$`)
+
+	// orderRegex restringe ordenações a "campo" ou "campo ASC|DESC".
+	orderRegex = regexp.MustCompile(`(?i)^[a-zA-Z_][a-zA-Z0-9_.]*( (ASC|DESC))?$`)
+)
+
+// validOperators lista os operadores aceitos nas condições.
+var validOperators = map[Operator]bool{
+	OpEqual:          true,
+	OpNotEqual:       true,
+	OpGreater:        true,
+	OpGreaterOrEqual: true,
+	OpLess:           true,
+	OpLessOrEqual:    true,
+	OpLike:           true,
+	OpILike:          true,
+	OpIn:             true,
+	OpNotIn:          true,
+	OpIsNull:         true,
+	OpIsNotNull:      true,
+	OpJSONContains:   true,
+	OpJSONHasKey:     true,
+}
+
+// Condition representa uma condição de filtro (campo, operador e valor).
+// Condições compostas agrupam outras em All (AND) ou Any (OR); nesse caso
+// Field e Operator são ignorados. Not nega a condição.
+type Condition struct {
+	Value    interface{}
+	Field    string
+	Operator Operator
+	All      []Condition `json:",omitempty"`
+	Any      []Condition `json:",omitempty"`
+	Not      bool        `json:",omitempty"`
+}
+
+// IsGroup indica se a condição agrupa outras condições.
+func (c Condition) IsGroup() bool {
+	return c.All != nil || c.Any != nil
+}
+
+// Ordenação padrão das consultas paginadas e o desempate que a torna estável:
+// registros com o mesmo created_at (ou o mesmo valor em OrderBy) mantêm a
+// posição entre páginas, sem aparecer duas vezes nem ser pulados.
+const (
+	DefaultOrder    = "created_at DESC"
+	TiebreakerOrder = "id ASC"
+)
+
+// QueryFilter representa os critérios de uma consulta genérica.
+type QueryFilter struct {
+	Conditions     []Condition
+	OrderBy        []string
+	Limit          int
+	Offset         int
+	Page           int
+	PageSize       int
+	IncludeDeleted bool
+	// SkipCountCache força o recálculo do total em Paginate, ignorando o cache.
+	SkipCountCache bool
+}
+
+// Validate verifica se campos, operadores e ordenações do filtro são seguros.
+func (f QueryFilter) Validate() error {
+	for _, condition := range f.Conditions {
+		if err := validateCondition(condition); err != nil {
+			return err
+		}
+	}
+
+	for _, order := range f.OrderBy {
+		if !orderRegex.MatchString(order) {
+			return ErrInvalidOrder
+		}
+	}
+
+	return nil
+}
+
+// validateCondition valida a condição e, recursivamente, as condições agrupadas.
+func validateCondition(condition Condition) error {
+	if condition.IsGroup() {
+		// Concat copia: um append em All escreveria na capacidade livre do slice do chamador
+		for _, nested := range slices.Concat(condition.All, condition.Any) {
+			if err := validateCondition(nested); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if !fieldRegex.MatchString(condition.Field) {
+		return ErrInvalidField
+	}
+
+	if !validOperators[condition.Operator] {
+		return ErrInvalidOperator
+	}
+
+	switch condition.Operator {
+	case OpJSONContains:
+		if _, err := json.Marshal(condition.Value); err != nil {
+			return ErrInvalidValue
+		}
+	case OpJSONHasKey:
+		if key, ok := condition.Value.(string); !ok || key == "" {
+			return ErrInvalidValue
+		}
+	}
+
+	return nil
+}
+
+// CountCacheKey gera uma chave de cache estável para o total de registros do
+// filtro. A chave considera apenas as condições e o escopo de soft delete,
+// de modo que páginas diferentes da mesma consulta compartilham o total.
+func (f QueryFilter) CountCacheKey(prefix string) (string, error) {
+	payload, err := json.Marshal(struct {
+		Conditions     []Condition `json:"conditions"`
+		IncludeDeleted bool        `json:"include_deleted"`
+	}{
+		Conditions:     f.Conditions,
+		IncludeDeleted: f.IncludeDeleted,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build count cache key: %w", err)
+	}
+
+	hash := sha256.Sum256(payload)
+
+	return prefix + ":count:" + hex.EncodeToString(hash[:]), nil
+}
+
+// HasPagination indica se o filtro define paginação por página.
+func (f QueryFilter) HasPagination() bool {
+	return f.Page > 0 || f.PageSize > 0
+}
+
+// NormalizedPage retorna a página e o tamanho de página com valores padrão aplicados.
+func (f QueryFilter) NormalizedPage() (page, pageSize int) {
+	page = f.Page
+	if page < 1 {
+		page = 1
+	}
+
+	// Mesmos limites das listagens HTTP (ver pacote pagination)
+	pageSize = pagination.ClampLimit(f.PageSize)
+
+	return page, pageSize
+}
+
+// PageOrder retorna a ordenação usada ao paginar: OrderBy (ou DefaultOrder,
+// quando vazio) seguida de TiebreakerOrder, a menos que id já faça parte dela.
+func (f QueryFilter) PageOrder() []string {
+	orders := slices.Clone(f.OrderBy)
+	if len(orders) == 0 {
+		orders = append(orders, DefaultOrder)
+	}
+
+	for _, order := range orders {
+		if field, _, _ := strings.Cut(order, " "); strings.EqualFold(field, "id") {
+			return orders
+		}
+	}
+
+	return append(orders, TiebreakerOrder)
+}
+
+// CheckDepth verifica se o offset efetivo do filtro (por página ou explícito)
+// respeita a profundidade máxima, retornando pagination.ErrPageTooDeep.
+func (f QueryFilter) CheckDepth() error {
+	if f.HasPagination() {
+		page, pageSize := f.NormalizedPage()
+
+		return pagination.CheckOffset((page - 1) * pageSize)
+	}
+
+	return pagination.CheckOffset(f.Offset)
+}
+
+// QueryBuilder constrói um QueryFilter de forma fluente.
+type QueryBuilder struct {
+	filter QueryFilter
+}
+
+// NewQueryBuilder cria um novo construtor de filtros.
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// Where adiciona uma condição ao filtro.
+func (b *QueryBuilder) Where(field string, operator Operator, value interface{}) *QueryBuilder {
+	b.filter.Conditions = append(b.filter.Conditions, Condition{
+		Field:    field,
+		Operator: operator,
+		Value:    value,
+	})
+
+	return b
+}
+
+// WhereEqual adiciona uma condição de igualdade.
+func (b *QueryBuilder) WhereEqual(field string, value interface{}) *QueryBuilder {
+	return b.Where(field, OpEqual, value)
+}
+
+// WhereIn adiciona uma condição IN.
+func (b *QueryBuilder) WhereIn(field string, values interface{}) *QueryBuilder {
+	return b.Where(field, OpIn, values)
+}
+
+// WhereJSONContains adiciona uma condição de contenção JSONB, ex.:
+// WhereJSONContains("metadata", map[string]interface{}{"plan": "pro"}).
+func (b *QueryBuilder) WhereJSONContains(field string, value interface{}) *QueryBuilder {
+	return b.Where(field, OpJSONContains, value)
+}
+
+// WhereJSONHasKey adiciona uma condição de existência de chave JSONB.
+func (b *QueryBuilder) WhereJSONHasKey(field, key string) *QueryBuilder {
+	return b.Where(field, OpJSONHasKey, key)
+}
+
+// OrderBy adiciona uma ordenação (ex.: "created_at DESC").
+func (b *QueryBuilder) OrderBy(order string) *QueryBuilder {
+	b.filter.OrderBy = append(b.filter.OrderBy, order)
+
+	return b
+}
+
+// Limit define o limite de registros.
+func (b *QueryBuilder) Limit(limit int) *QueryBuilder {
+	b.filter.Limit = limit
+
+	return b
+}
+
+// Offset define o deslocamento de registros.
+func (b *QueryBuilder) Offset(offset int) *QueryBuilder {
+	b.filter.Offset = offset
+
+	return b
+}
+
+// Page define a página e o tamanho de página.
+func (b *QueryBuilder) Page(page, pageSize int) *QueryBuilder {
+	b.filter.Page = page
+	b.filter.PageSize = pageSize
+
+	return b
+}
+
+// IncludeDeleted inclui registros removidos (soft delete) na consulta.
+func (b *QueryBuilder) IncludeDeleted() *QueryBuilder {
+	b.filter.IncludeDeleted = true
+
+	return b
+}
+
+// Build retorna o filtro construído.
+func (b *QueryBuilder) Build() QueryFilter {
+	return b.filter
+}
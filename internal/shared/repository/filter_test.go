@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestValidateDoesNotWriteIntoCallerSlices(t *testing.T) {
+	sentinel := Condition{Field: "sentinel", Operator: OpEqual, Value: 1}
+
+	// All tem capacidade livre logo após o último elemento
+	all := make([]Condition, 1, 2)
+	all[0] = Condition{Field: "name", Operator: OpEqual, Value: "Ana"}
+	all[:2][1] = sentinel
+
+	filter := QueryFilter{Conditions: []Condition{{
+		All: all,
+		Any: []Condition{{Field: "email", Operator: OpLike, Value: "%@example.com"}},
+	}}}
+
+	if err := filter.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	if spare := all[:2][1]; spare.Field != sentinel.Field {
+		t.Errorf("Validate overwrote the caller's spare capacity with %+v", spare)
+	}
+}
+
+func TestValidateNestedGroups(t *testing.T) {
+	valid := Condition{Field: "name", Operator: OpEqual, Value: "Ana"}
+	invalid := Condition{Field: "name; DROP TABLE users", Operator: OpEqual, Value: "Ana"}
+
+	tests := []struct {
+		want      error
+		name      string
+		condition Condition
+	}{
+		{name: "valid group", condition: Condition{All: []Condition{valid}, Any: []Condition{valid}}},
+		{name: "invalid field in All", condition: Condition{All: []Condition{valid, invalid}}, want: ErrInvalidField},
+		{name: "invalid field in Any", condition: Condition{All: []Condition{valid}, Any: []Condition{invalid}}, want: ErrInvalidField},
+		{
+			name:      "invalid operator nested",
+			condition: Condition{Any: []Condition{{All: []Condition{{Field: "name", Operator: "~"}}}}},
+			want:      ErrInvalidOperator,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := QueryFilter{Conditions: []Condition{tt.condition}}.Validate()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Validate = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountCacheKey(t *testing.T) {
+	base := QueryFilter{Conditions: []Condition{{Field: "role", Operator: OpEqual, Value: "admin"}}}
+
+	key := func(filter QueryFilter) string {
+		t.Helper()
+
+		k, err := filter.CountCacheKey("users")
+		if err != nil {
+			t.Fatalf("CountCacheKey: %v", err)
+		}
+
+		return k
+	}
+
+	paged := base
+	paged.Page, paged.PageSize, paged.OrderBy = 3, 20, []string{"name ASC"}
+
+	if key(base) != key(paged) {
+		t.Error("pages of the same query produce different keys")
+	}
+
+	deleted := base
+	deleted.IncludeDeleted = true
+
+	if key(base) == key(deleted) {
+		t.Error("key ignores the soft-delete scope")
+	}
+
+	other := QueryFilter{Conditions: []Condition{{Field: "role", Operator: OpEqual, Value: "user"}}}
+	if key(base) == key(other) {
+		t.Error("different conditions produce the same key")
+	}
+}
+
+func TestPageOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		orderBy []string
+		want    []string
+	}{
+		{name: "default order", want: []string{DefaultOrder, TiebreakerOrder}},
+		{name: "custom order", orderBy: []string{"name ASC"}, want: []string{"name ASC", TiebreakerOrder}},
+		{name: "id already ordered", orderBy: []string{"name ASC", "id DESC"}, want: []string{"name ASC", "id DESC"}},
+		{name: "id without direction", orderBy: []string{"ID"}, want: []string{"ID"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := QueryFilter{OrderBy: tt.orderBy}
+
+			if got := filter.PageOrder(); !slices.Equal(got, tt.want) {
+				t.Errorf("PageOrder = %v, want %v", got, tt.want)
+			}
+
+			// O filtro do chamador não é alterado
+			if !slices.Equal(filter.OrderBy, tt.orderBy) {
+				t.Errorf("OrderBy changed to %v", filter.OrderBy)
+			}
+		})
+	}
+}
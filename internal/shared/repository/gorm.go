@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ApplyFilter aplica as condições, o escopo de soft delete e a ordenação do filtro.
+// Limite e paginação não são aplicados; use ApplyPagination para isso.
+func ApplyFilter(db *gorm.DB, filter QueryFilter) (*gorm.DB, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	// O soft delete do GORM (gorm.DeletedAt) só é removido com Unscoped
+	if filter.IncludeDeleted {
+		db = db.Unscoped()
+	} else {
+		db = db.Where("deleted_at IS NULL")
+	}
+
+	for _, condition := range filter.Conditions {
+		db = applyCondition(db, condition)
+	}
+
+	for _, order := range filter.OrderBy {
+		db = db.Order(order)
+	}
+
+	return db, nil
+}
+
+// ApplyPagination aplica limite/offset ou página/tamanho de página do filtro.
+// Consultas limitadas recebem também o restante de PageOrder (a ordenação
+// padrão e o desempate por id), já que ApplyFilter aplica apenas OrderBy.
+func ApplyPagination(db *gorm.DB, filter QueryFilter) *gorm.DB {
+	if filter.HasPagination() || filter.Limit > 0 || filter.Offset > 0 {
+		for _, order := range filter.PageOrder()[len(filter.OrderBy):] {
+			db = db.Order(order)
+		}
+	}
+
+	if filter.HasPagination() {
+		page, pageSize := filter.NormalizedPage()
+
+		return db.Limit(pageSize).Offset((page - 1) * pageSize)
+	}
+
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		db = db.Offset(filter.Offset)
+	}
+
+	return db
+}
+
+// GroupCounts conta os registros por valor do campo informado, respeitando as
+// condições do filtro. Apenas a coluna agrupada e o agregado são selecionados,
+// o que mantém a consulta válida no Postgres.
+func GroupCounts(db *gorm.DB, field string, filter QueryFilter) ([]GroupCount, error) {
+	if !fieldRegex.MatchString(field) {
+		return nil, ErrInvalidField
+	}
+
+	// Ordenações do filtro podem referenciar colunas fora do agrupamento
+	filter.OrderBy = nil
+
+	query, err := ApplyFilter(db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Group interface{} `gorm:"column:group_value"`
+		Count int64       `gorm:"column:count"`
+	}
+
+	if err := query.
+		Select(fmt.Sprintf("%s AS group_value, COUNT(*) AS count", field)).
+		Group(field).
+		Order("count DESC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	groups := make([]GroupCount, len(rows))
+	for i, row := range rows {
+		groups[i] = GroupCount{Group: row.Group, Count: row.Count}
+	}
+
+	return groups, nil
+}
+
+// applyCondition aplica uma condição individual à consulta.
+func applyCondition(db *gorm.DB, condition Condition) *gorm.DB {
+	sql, args := conditionSQL(condition)
+
+	return db.Where(sql, args...)
+}
+
+// conditionSQL monta o SQL e os argumentos de uma condição, incluindo grupos
+// AND/OR e negação. Os campos já foram validados por QueryFilter.Validate.
+func conditionSQL(condition Condition) (string, []interface{}) {
+	var (
+		sql  string
+		args []interface{}
+	)
+
+	switch {
+	case condition.IsGroup():
+		sql, args = groupSQL(condition)
+	case condition.Operator == OpIsNull || condition.Operator == OpIsNotNull:
+		sql = fmt.Sprintf("%s %s", condition.Field, condition.Operator)
+	case condition.Operator == OpJSONContains:
+		// O valor já foi validado como serializável por QueryFilter.Validate
+		document, _ := json.Marshal(condition.Value)
+		sql = fmt.Sprintf("%s @> ?::jsonb", condition.Field)
+		args = []interface{}{string(document)}
+	case condition.Operator == OpJSONHasKey:
+		// "->" evita o operador "?" do Postgres, que conflita com os placeholders
+		sql = fmt.Sprintf("(%s -> ?::text) IS NOT NULL", condition.Field)
+		args = []interface{}{condition.Value}
+	case condition.Operator == OpIn || condition.Operator == OpNotIn:
+		sql = fmt.Sprintf("%s %s (?)", condition.Field, condition.Operator)
+		args = []interface{}{condition.Value}
+	default:
+		sql = fmt.Sprintf("%s %s ?", condition.Field, condition.Operator)
+		args = []interface{}{condition.Value}
+	}
+
+	if condition.Not {
+		sql = "NOT (" + sql + ")"
+	}
+
+	return sql, args
+}
+
+// groupSQL combina as condições de um grupo com AND (All) ou OR (Any).
+// Um grupo AND vazio é sempre verdadeiro e um grupo OR vazio, sempre falso.
+func groupSQL(condition Condition) (string, []interface{}) {
+	nested, separator, empty := condition.All, " AND ", "TRUE"
+	if condition.Any != nil {
+		nested, separator, empty = condition.Any, " OR ", "FALSE"
+	}
+
+	if len(nested) == 0 {
+		return empty, nil
+	}
+
+	parts := make([]string, len(nested))
+
+	var args []interface{}
+
+	for i, c := range nested {
+		sql, nestedArgs := conditionSQL(c)
+		parts[i] = "(" + sql + ")"
+		args = append(args, nestedArgs...)
+	}
+
+	return strings.Join(parts, separator), args
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// gormEntity usa gorm.DeletedAt, como os modelos do repositório postgres.
+type gormEntity struct {
+	CreatedAt time.Time
+	DeletedAt gorm.DeletedAt
+	Name      string
+	ID        uuid.UUID
+}
+
+// dryRunDB abre um *gorm.DB do Postgres que apenas monta o SQL, sem conexão.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("open dry-run db: %v", err)
+	}
+
+	return db
+}
+
+// findSQL retorna o SELECT gerado para o filtro, com ApplyFilter e ApplyPagination.
+func findSQL(t *testing.T, filter QueryFilter) string {
+	t.Helper()
+
+	query, err := ApplyFilter(dryRunDB(t).Model(&gormEntity{}), filter)
+	if err != nil {
+		t.Fatalf("ApplyFilter: %v", err)
+	}
+
+	var rows []gormEntity
+
+	return ApplyPagination(query, filter).Find(&rows).Statement.SQL.String()
+}
+
+func TestApplyFilterSoftDeleteScope(t *testing.T) {
+	active := findSQL(t, QueryFilter{})
+	if !strings.Contains(active, "deleted_at IS NULL") {
+		t.Errorf("default query does not exclude deleted rows: %s", active)
+	}
+
+	withDeleted := findSQL(t, QueryFilter{IncludeDeleted: true})
+	if strings.Contains(withDeleted, "deleted_at") {
+		t.Errorf("IncludeDeleted query still filters deleted rows: %s", withDeleted)
+	}
+}
+
+func TestApplyFilterJSONOperators(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		wantSQL   string
+		wantArg   interface{}
+	}{
+		{
+			name:      "contains",
+			condition: Condition{Field: "name", Operator: OpJSONContains, Value: map[string]interface{}{"plan": "pro"}},
+			wantSQL:   "name @> $1::jsonb",
+			wantArg:   `{"plan":"pro"}`,
+		},
+		{
+			name:      "has key",
+			condition: Condition{Field: "name", Operator: OpJSONHasKey, Value: "plan"},
+			wantSQL:   "(name -> $1::text) IS NOT NULL",
+			wantArg:   "plan",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := ApplyFilter(dryRunDB(t).Model(&gormEntity{}), QueryFilter{Conditions: []Condition{tt.condition}})
+			if err != nil {
+				t.Fatalf("ApplyFilter: %v", err)
+			}
+
+			var rows []gormEntity
+
+			statement := query.Find(&rows).Statement
+			if sql := statement.SQL.String(); !strings.Contains(sql, tt.wantSQL) {
+				t.Errorf("SQL = %s, want it to contain %s", sql, tt.wantSQL)
+			}
+
+			if len(statement.Vars) == 0 || statement.Vars[0] != tt.wantArg {
+				t.Errorf("vars = %v, want %v first", statement.Vars, tt.wantArg)
+			}
+		})
+	}
+}
+
+func TestApplyPaginationBreaksTiesByID(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter QueryFilter
+		want   string
+	}{
+		{name: "page", filter: QueryFilter{Page: 2, PageSize: 10}, want: "ORDER BY created_at DESC,id ASC LIMIT"},
+		{name: "limit", filter: QueryFilter{Limit: 5}, want: "ORDER BY created_at DESC,id ASC LIMIT"},
+		{name: "custom order", filter: QueryFilter{OrderBy: []string{"name ASC"}, Limit: 5}, want: "ORDER BY name ASC,id ASC LIMIT"},
+		{name: "id already ordered", filter: QueryFilter{OrderBy: []string{"id DESC"}, Limit: 5}, want: "ORDER BY id DESC LIMIT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if sql := findSQL(t, tt.filter); !strings.Contains(sql, tt.want) {
+				t.Errorf("SQL = %s, want %q", sql, tt.want)
+			}
+		})
+	}
+
+	// Sem paginação, a ordem não importa e nada é acrescentado
+	if sql := findSQL(t, QueryFilter{}); strings.Contains(sql, "ORDER BY") {
+		t.Errorf("unpaginated SQL = %s, want no ORDER BY", sql)
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import "errors"
+
+// DefaultMaxRows é o limite aplicado a consultas sem limite nem paginação.
+const DefaultMaxRows = 1000
+
+// ErrUnboundedQuery indica uma consulta sem limite nem paginação em modo estrito.
+var ErrUnboundedQuery = errors.New("query has no limit or page size")
+
+// QueryGuard protege FindMany contra consultas que carregariam a tabela inteira.
+type QueryGuard struct {
+	// MaxRows é o limite aplicado quando o filtro não define Limit nem paginação;
+	// valores <= 0 usam DefaultMaxRows.
+	MaxRows int
+	// Strict rejeita consultas sem limite em vez de aplicar MaxRows.
+	Strict bool
+}
+
+// Bound retorna o filtro com o limite de segurança aplicado quando necessário.
+// O booleano indica se o limite foi imposto pelo guard.
+func (g QueryGuard) Bound(filter QueryFilter) (QueryFilter, bool, error) {
+	if filter.Limit > 0 || filter.HasPagination() {
+		return filter, false, nil
+	}
+
+	if g.Strict {
+		return filter, false, ErrUnboundedQuery
+	}
+
+	filter.Limit = g.MaxRows
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultMaxRows
+	}
+
+	return filter, true, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQueryGuardBound(t *testing.T) {
+	tests := []struct {
+		name       string
+		guard      QueryGuard
+		filter     QueryFilter
+		wantLimit  int
+		wantCapped bool
+		wantErr    error
+	}{
+		{name: "default cap", filter: QueryFilter{}, wantLimit: DefaultMaxRows, wantCapped: true},
+		{name: "configured cap", guard: QueryGuard{MaxRows: 50}, wantLimit: 50, wantCapped: true},
+		{name: "explicit limit", guard: QueryGuard{Strict: true}, filter: QueryFilter{Limit: 5000}, wantLimit: 5000},
+		{name: "paginated", guard: QueryGuard{Strict: true}, filter: QueryFilter{Page: 2, PageSize: 20}},
+		{name: "strict rejects unbounded", guard: QueryGuard{Strict: true}, wantErr: ErrUnboundedQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, capped, err := tt.guard.Bound(tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Bound error = %v, want %v", err, tt.wantErr)
+			}
+
+			if filter.Limit != tt.wantLimit || capped != tt.wantCapped {
+				t.Errorf("Bound = limit %d, capped %v; want %d, %v", filter.Limit, capped, tt.wantLimit, tt.wantCapped)
+			}
+		})
+	}
+}
@@ -0,0 +1,858 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+)
+
+// ErrNotFound é o erro padrão de MemoryRepository quando nenhum registro
+// satisfaz uma busca por um único registro.
+var ErrNotFound = errors.New("record not found")
+
+// MemoryRepository implementa Repository[T] em memória, para testes de casos
+// de uso e execuções sem banco. T deve ser uma struct com o campo ID
+// (uuid.UUID); um campo DeletedAt (*time.Time) habilita o soft delete, e os
+// campos CreatedAt/UpdatedAt (time.Time) são preenchidos como o GORM faria.
+//
+// Os campos das condições seguem a convenção de colunas do GORM (LastLoginAt
+// vira last_login_at), e consultas limitadas sem OrderBy usam DefaultOrder. As
+// entidades são copiadas na entrada e na saída, de modo que alterar o valor
+// retornado não altera o repositório; mapas e ponteiros continuam compartilhados.
+type MemoryRepository[T any] struct {
+	clock    clock.Clock
+	notFound error
+	columns  map[string]int
+	items    []*T
+	mutex    sync.RWMutex
+}
+
+// Garantir em tempo de compilação que MemoryRepository implementa Repository.
+var _ Repository[struct{ ID uuid.UUID }] = (*MemoryRepository[struct{ ID uuid.UUID }])(nil)
+
+// NewMemoryRepository cria um repositório em memória vazio. Entra em panic se
+// T não for uma struct com o campo ID do tipo uuid.UUID.
+func NewMemoryRepository[T any]() *MemoryRepository[T] {
+	entityType := reflect.TypeOf((*T)(nil)).Elem()
+	if entityType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("repository: %s is not a struct", entityType))
+	}
+
+	if field, ok := entityType.FieldByName("ID"); !ok || field.Type != reflect.TypeOf(uuid.UUID{}) {
+		panic(fmt.Sprintf("repository: %s has no ID field of type uuid.UUID", entityType))
+	}
+
+	naming := schema.NamingStrategy{}
+	columns := make(map[string]int, entityType.NumField())
+
+	for i := range entityType.NumField() {
+		if field := entityType.Field(i); field.IsExported() {
+			columns[naming.ColumnName("", field.Name)] = i
+		}
+	}
+
+	return &MemoryRepository[T]{
+		clock:    clock.RealClock{},
+		notFound: ErrNotFound,
+		columns:  columns,
+	}
+}
+
+// WithClock define o relógio usado nos timestamps e no soft delete.
+func (r *MemoryRepository[T]) WithClock(c clock.Clock) *MemoryRepository[T] {
+	r.clock = clock.OrReal(c)
+
+	return r
+}
+
+// WithNotFoundError define o erro retornado por FindOne quando nada é
+// encontrado, para reproduzir o contrato do repositório real (ex.:
+// domain.ErrUserNotFound).
+func (r *MemoryRepository[T]) WithNotFoundError(err error) *MemoryRepository[T] {
+	if err != nil {
+		r.notFound = err
+	}
+
+	return r
+}
+
+// Create armazena uma cópia da entidade. Um ID zerado recebe um novo UUID e
+// CreatedAt/UpdatedAt zerados recebem o instante atual.
+func (r *MemoryRepository[T]) Create(_ context.Context, entity *T) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	value := reflect.ValueOf(entity).Elem()
+
+	id := value.FieldByName("ID")
+	if id.Interface().(uuid.UUID) == uuid.Nil {
+		id.Set(reflect.ValueOf(uuid.New()))
+	}
+
+	if r.indexOf(id.Interface().(uuid.UUID)) >= 0 {
+		return fmt.Errorf("failed to create record: duplicate id %s", id.Interface())
+	}
+
+	now := r.clock.Now().UTC()
+	r.setTimeIfZero(value, "created_at", now)
+	r.setTimeIfZero(value, "updated_at", now)
+
+	stored := *entity
+	r.items = append(r.items, &stored)
+
+	return nil
+}
+
+// FindOne retorna o primeiro registro que satisfaz o filtro, ordenado por
+// OrderBy e pelo ID, como o First do GORM.
+func (r *MemoryRepository[T]) FindOne(_ context.Context, filter QueryFilter) (*T, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	matched, err := r.match(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.sort(matched, append(slices.Clone(filter.OrderBy), "id")); err != nil {
+		return nil, err
+	}
+
+	if len(matched) == 0 {
+		return nil, r.notFound
+	}
+
+	found := *matched[0]
+
+	return &found, nil
+}
+
+// FindMany retorna, ordenados e paginados como em ApplyPagination, os
+// registros que satisfazem o filtro.
+func (r *MemoryRepository[T]) FindMany(_ context.Context, filter QueryFilter) ([]*T, error) {
+	if err := filter.CheckDepth(); err != nil {
+		return nil, err
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	matched, err := r.match(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := filter.OrderBy
+	if filter.HasPagination() || filter.Limit > 0 || filter.Offset > 0 {
+		orders = filter.PageOrder()
+	}
+
+	if err := r.sort(matched, orders); err != nil {
+		return nil, err
+	}
+
+	matched = paginate(matched, filter)
+
+	result := make([]*T, len(matched))
+	for i, item := range matched {
+		clone := *item
+		result[i] = &clone
+	}
+
+	return result, nil
+}
+
+// Update substitui o registro de mesmo ID, ou o cria, como o Save do GORM.
+// UpdatedAt recebe o instante atual.
+func (r *MemoryRepository[T]) Update(_ context.Context, entity *T) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stored := *entity
+	value := reflect.ValueOf(&stored).Elem()
+	r.setTime(value, "updated_at", r.clock.Now().UTC())
+
+	index := r.indexOf(value.FieldByName("ID").Interface().(uuid.UUID))
+	if index < 0 {
+		r.items = append(r.items, &stored)
+
+		return nil
+	}
+
+	r.items[index] = &stored
+
+	return nil
+}
+
+// UpdateMany aplica as alterações (coluna → valor) aos registros que
+// satisfazem o filtro e retorna quantos foram afetados. Como no GORM,
+// updated_at recebe o instante atual quando não faz parte das alterações.
+func (r *MemoryRepository[T]) UpdateMany(
+	_ context.Context,
+	filter QueryFilter,
+	updates map[string]interface{},
+) (int64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	matched, err := r.match(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	for column := range updates {
+		if _, ok := r.columns[column]; !ok {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidField, column)
+		}
+	}
+
+	now := r.clock.Now().UTC()
+
+	for _, item := range matched {
+		updated := *item
+		value := reflect.ValueOf(&updated).Elem()
+
+		for column, newValue := range updates {
+			if err := assign(value.Field(r.columns[column]), newValue); err != nil {
+				return 0, fmt.Errorf("failed to update %s: %w", column, err)
+			}
+		}
+
+		if _, ok := updates["updated_at"]; !ok {
+			r.setTime(value, "updated_at", now)
+		}
+
+		// Só grava depois de todas as atribuições, sem deixar o registro pela metade
+		*item = updated
+	}
+
+	return int64(len(matched)), nil
+}
+
+// Delete remove o registro: soft delete quando T tem DeletedAt, definitivo
+// caso contrário. Um ID inexistente não é erro.
+func (r *MemoryRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.DeleteMany(ctx, QueryFilter{
+		Conditions: []Condition{{Field: "id", Operator: OpEqual, Value: id}},
+	})
+
+	return err
+}
+
+// DeleteMany remove os registros que satisfazem o filtro e retorna quantos
+// foram afetados, com soft delete quando T tem DeletedAt.
+func (r *MemoryRepository[T]) DeleteMany(ctx context.Context, filter QueryFilter) (int64, error) {
+	if _, ok := r.columns["deleted_at"]; ok {
+		return r.UpdateMany(ctx, filter, map[string]interface{}{"deleted_at": r.clock.Now().UTC()})
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	matched, err := r.match(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	r.items = slices.DeleteFunc(r.items, func(item *T) bool {
+		return slices.Contains(matched, item)
+	})
+
+	return int64(len(matched)), nil
+}
+
+// Count conta os registros que satisfazem as condições do filtro, ignorando a paginação.
+func (r *MemoryRepository[T]) Count(_ context.Context, filter QueryFilter) (int64, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	matched, err := r.match(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(matched)), nil
+}
+
+// Exists verifica se algum registro satisfaz o filtro.
+func (r *MemoryRepository[T]) Exists(ctx context.Context, filter QueryFilter) (bool, error) {
+	count, err := r.Count(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Paginate retorna uma página dos registros que satisfazem o filtro.
+func (r *MemoryRepository[T]) Paginate(ctx context.Context, filter QueryFilter) (*PaginatedResult[T], error) {
+	page, pageSize := filter.NormalizedPage()
+	filter.Page = page
+	filter.PageSize = pageSize
+
+	if err := filter.CheckDepth(); err != nil {
+		return nil, err
+	}
+
+	total, err := r.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := r.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPaginatedResult(items, total, page, pageSize), nil
+}
+
+// GroupBy conta os registros por valor do campo, do grupo maior para o menor.
+// Valores de tipos nomeados sobre string (ex.: papéis) são agrupados como string.
+func (r *MemoryRepository[T]) GroupBy(_ context.Context, field string, filter QueryFilter) ([]GroupCount, error) {
+	index, ok := r.columns[field]
+	if !ok || !fieldRegex.MatchString(field) {
+		return nil, ErrInvalidField
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	matched, err := r.match(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []GroupCount
+
+	for _, item := range matched {
+		group := normalize(reflect.ValueOf(item).Elem().Field(index))
+
+		position := slices.IndexFunc(groups, func(g GroupCount) bool { return g.Group == group })
+		if position < 0 {
+			groups = append(groups, GroupCount{Group: group})
+			position = len(groups) - 1
+		}
+
+		groups[position].Count++
+	}
+
+	slices.SortStableFunc(groups, func(a, b GroupCount) int {
+		return int(b.Count - a.Count)
+	})
+
+	return groups, nil
+}
+
+// match retorna os registros que satisfazem as condições e o escopo de soft
+// delete do filtro, na ordem de inserção. Deve ser chamado com o mutex travado.
+func (r *MemoryRepository[T]) match(filter QueryFilter) ([]*T, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	deletedAt, softDelete := r.columns["deleted_at"]
+
+	var matched []*T
+
+	for _, item := range r.items {
+		value := reflect.ValueOf(item).Elem()
+
+		if softDelete && !filter.IncludeDeleted && !value.Field(deletedAt).IsZero() {
+			continue
+		}
+
+		ok, err := r.matchAll(value, filter.Conditions)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+
+	return matched, nil
+}
+
+// matchAll informa se a entidade satisfaz todas as condições.
+func (r *MemoryRepository[T]) matchAll(value reflect.Value, conditions []Condition) (bool, error) {
+	for _, condition := range conditions {
+		ok, err := r.matchCondition(value, condition)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// matchCondition avalia uma condição, incluindo grupos e negação. Como no
+// SQL, comparações com um campo nulo nunca são verdadeiras, mesmo negadas.
+func (r *MemoryRepository[T]) matchCondition(value reflect.Value, condition Condition) (bool, error) {
+	if condition.IsGroup() {
+		var ok bool
+
+		var err error
+
+		if condition.Any != nil {
+			ok, err = r.matchAny(value, condition.Any)
+		} else {
+			ok, err = r.matchAll(value, condition.All)
+		}
+
+		return ok != condition.Not, err
+	}
+
+	index, found := r.columns[condition.Field]
+	if !found {
+		return false, fmt.Errorf("%w: %s", ErrInvalidField, condition.Field)
+	}
+
+	field := value.Field(index)
+
+	switch condition.Operator {
+	case OpIsNull:
+		return isNull(field) != condition.Not, nil
+	case OpIsNotNull:
+		return !isNull(field) != condition.Not, nil
+	}
+
+	if isNull(field) {
+		return false, nil
+	}
+
+	ok, err := evaluate(normalize(field), condition.Operator, condition.Value)
+
+	return ok != condition.Not, err
+}
+
+// matchAny informa se a entidade satisfaz ao menos uma das condições.
+func (r *MemoryRepository[T]) matchAny(value reflect.Value, conditions []Condition) (bool, error) {
+	for _, condition := range conditions {
+		ok, err := r.matchCondition(value, condition)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// sort ordena os registros pelas ordenações informadas ("campo [ASC|DESC]").
+// Como no Postgres, nulos ficam por último em ASC e primeiro em DESC.
+func (r *MemoryRepository[T]) sort(items []*T, orders []string) error {
+	type order struct {
+		index      int
+		descending bool
+	}
+
+	parsed := make([]order, len(orders))
+
+	for i, raw := range orders {
+		field, direction, _ := strings.Cut(raw, " ")
+
+		index, ok := r.columns[field]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidOrder, raw)
+		}
+
+		parsed[i] = order{index: index, descending: strings.EqualFold(direction, "DESC")}
+	}
+
+	var sortErr error
+
+	slices.SortStableFunc(items, func(a, b *T) int {
+		left, right := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+
+		for _, o := range parsed {
+			result, err := compareFields(left.Field(o.index), right.Field(o.index))
+			if err != nil {
+				sortErr = err
+				return 0
+			}
+
+			if o.descending {
+				result = -result
+			}
+
+			if result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	})
+
+	return sortErr
+}
+
+// indexOf retorna a posição do registro com o ID informado, ou -1.
+func (r *MemoryRepository[T]) indexOf(id uuid.UUID) int {
+	return slices.IndexFunc(r.items, func(item *T) bool {
+		return reflect.ValueOf(item).Elem().FieldByName("ID").Interface().(uuid.UUID) == id
+	})
+}
+
+// setTime define o campo de tempo da coluna, se T o possuir.
+func (r *MemoryRepository[T]) setTime(value reflect.Value, column string, at time.Time) {
+	if index, ok := r.columns[column]; ok {
+		_ = assign(value.Field(index), at)
+	}
+}
+
+// setTimeIfZero define o campo de tempo da coluna quando ele está zerado.
+func (r *MemoryRepository[T]) setTimeIfZero(value reflect.Value, column string, at time.Time) {
+	if index, ok := r.columns[column]; ok && value.Field(index).IsZero() {
+		_ = assign(value.Field(index), at)
+	}
+}
+
+// paginate aplica página/tamanho de página ou limite/offset, como ApplyPagination.
+func paginate[T any](items []*T, filter QueryFilter) []*T {
+	offset, limit := filter.Offset, filter.Limit
+	if filter.HasPagination() {
+		page, pageSize := filter.NormalizedPage()
+		offset, limit = (page-1)*pageSize, pageSize
+	}
+
+	if offset >= len(items) {
+		return nil
+	}
+
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	return items
+}
+
+// isNull informa se o campo equivale a NULL (ponteiro, mapa ou slice nil).
+func isNull(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return field.IsNil()
+	default:
+		return false
+	}
+}
+
+// normalize converte o valor para uma forma comparável: ponteiros são
+// seguidos, tipos sobre string viram string, inteiros e floats viram float64
+// e UUIDs viram texto. Tempos e mapas são mantidos.
+func normalize(value reflect.Value) interface{} {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	if id, ok := value.Interface().(uuid.UUID); ok {
+		return id.String()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	default:
+		return value.Interface()
+	}
+}
+
+// compareFields compara dois campos para ordenação, com nulos no fim.
+func compareFields(a, b reflect.Value) (int, error) {
+	left, right := normalize(a), normalize(b)
+
+	switch {
+	case left == nil && right == nil:
+		return 0, nil
+	case left == nil:
+		return 1, nil
+	case right == nil:
+		return -1, nil
+	}
+
+	return compare(left, right)
+}
+
+// compare compara valores já convertidos por comparable.
+func compare(left, right interface{}) (int, error) {
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	case float64:
+		if r, ok := right.(float64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		}
+	case time.Time:
+		if r, ok := right.(time.Time); ok {
+			return l.Compare(r), nil
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			switch {
+			case l == r:
+				return 0, nil
+			case r:
+				return -1, nil
+			default:
+				return 1, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("%w: cannot compare %T with %T", ErrInvalidValue, left, right)
+}
+
+// evaluate aplica o operador entre o valor do campo e o valor da condição.
+func evaluate(field interface{}, operator Operator, value interface{}) (bool, error) {
+	switch operator {
+	case OpIn, OpNotIn:
+		found, err := contains(field, value)
+
+		return found == (operator == OpIn), err
+	case OpLike, OpILike:
+		text, ok := field.(string)
+		pattern, isString := value.(string)
+
+		if !ok || !isString {
+			return false, fmt.Errorf("%w: %s requires text", ErrInvalidValue, operator)
+		}
+
+		return likeRegexp(pattern, operator == OpILike).MatchString(text), nil
+	case OpJSONContains:
+		return jsonContains(field, value)
+	case OpJSONHasKey:
+		document, ok := field.(map[string]interface{})
+		if !ok {
+			document = toJSONMap(field)
+		}
+
+		_, found := document[value.(string)]
+
+		return found, nil
+	}
+
+	result, err := compare(field, normalize(reflect.ValueOf(value)))
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case OpEqual:
+		return result == 0, nil
+	case OpNotEqual:
+		return result != 0, nil
+	case OpGreater:
+		return result > 0, nil
+	case OpGreaterOrEqual:
+		return result >= 0, nil
+	case OpLess:
+		return result < 0, nil
+	case OpLessOrEqual:
+		return result <= 0, nil
+	default:
+		return false, ErrInvalidOperator
+	}
+}
+
+// contains informa se o valor do campo está na lista de values.
+func contains(field, values interface{}) (bool, error) {
+	list := reflect.ValueOf(values)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return false, fmt.Errorf("%w: IN requires a list", ErrInvalidValue)
+	}
+
+	for i := range list.Len() {
+		result, err := compare(field, normalize(list.Index(i)))
+		if err != nil {
+			return false, err
+		}
+
+		if result == 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// likeRegexp converte um padrão LIKE (%, _ e escapes com \) em expressão regular.
+func likeRegexp(pattern string, insensitive bool) *regexp.Regexp {
+	var builder strings.Builder
+
+	if insensitive {
+		builder.WriteString("(?is)")
+	} else {
+		builder.WriteString("(?s)")
+	}
+
+	builder.WriteByte('^')
+
+	for i := 0; i < len(pattern); i++ {
+		switch char := pattern[i]; {
+		case char == '\\' && i+1 < len(pattern):
+			i++
+			builder.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		case char == '%':
+			builder.WriteString(".*")
+		case char == '_':
+			builder.WriteByte('.')
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	builder.WriteByte('$')
+
+	return regexp.MustCompile(builder.String())
+}
+
+// jsonContains reproduz o operador @> do JSONB: objetos contêm as chaves do
+// documento com valores contidos, listas contêm cada elemento do documento e
+// escalares precisam ser iguais.
+func jsonContains(field, document interface{}) (bool, error) {
+	left, err := decodeJSON(field)
+	if err != nil {
+		return false, err
+	}
+
+	right, err := decodeJSON(document)
+	if err != nil {
+		return false, err
+	}
+
+	return jsonContainsValue(left, right), nil
+}
+
+// decodeJSON serializa e decodifica o valor, obtendo a mesma representação
+// (mapas, listas, float64...) para o campo e o documento comparados.
+func decodeJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+
+	return decoded, nil
+}
+
+// jsonContainsValue compara documentos JSON já decodificados.
+func jsonContainsValue(left, right interface{}) bool {
+	switch r := right.(type) {
+	case map[string]interface{}:
+		l, ok := left.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, value := range r {
+			nested, found := l[key]
+			if !found || !jsonContainsValue(nested, value) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		l, ok := left.([]interface{})
+		if !ok {
+			return false
+		}
+
+		for _, value := range r {
+			if !slices.ContainsFunc(l, func(candidate interface{}) bool {
+				return jsonContainsValue(candidate, value)
+			}) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(left, right)
+	}
+}
+
+// toJSONMap converte um mapa de tipo nomeado (ex.: metadados) em
+// map[string]interface{}; outros valores resultam em um mapa vazio.
+func toJSONMap(value interface{}) map[string]interface{} {
+	field := reflect.ValueOf(value)
+	if field.Kind() != reflect.Map || field.Type().Key().Kind() != reflect.String {
+		return map[string]interface{}{}
+	}
+
+	document := make(map[string]interface{}, field.Len())
+	for _, key := range field.MapKeys() {
+		document[key.String()] = field.MapIndex(key).Interface()
+	}
+
+	return document
+}
+
+// assign atribui value ao campo, convertendo tipos compatíveis e criando o
+// ponteiro quando o campo é opcional. nil zera o campo.
+func assign(field reflect.Value, value interface{}) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	source := reflect.ValueOf(value)
+
+	if field.Kind() == reflect.Pointer && source.Kind() != reflect.Pointer {
+		target := reflect.New(field.Type().Elem())
+		if err := assign(target.Elem(), value); err != nil {
+			return err
+		}
+
+		field.Set(target)
+
+		return nil
+	}
+
+	switch {
+	case source.Type().AssignableTo(field.Type()):
+		field.Set(source)
+	case source.Type().ConvertibleTo(field.Type()):
+		field.Set(source.Convert(field.Type()))
+	default:
+		return fmt.Errorf("%w: cannot assign %T to %s", ErrInvalidValue, value, field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/devleo-m/go-zero/internal/shared/clock"
+	"github.com/devleo-m/go-zero/internal/shared/pagination"
+)
+
+type testKind string
+
+type testEntity struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+	SeenAt    *time.Time
+	Tags      map[string]interface{}
+	Name      string
+	Kind      testKind
+	Score     int
+	ID        uuid.UUID
+}
+
+var testBase = time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+
+// seedEntities cria entidades com os nomes informados, criadas com um minuto
+// de diferença e score igual à posição.
+func seedEntities(t *testing.T, repo *MemoryRepository[testEntity], names ...string) []*testEntity {
+	t.Helper()
+
+	entities := make([]*testEntity, len(names))
+
+	for i, name := range names {
+		entities[i] = &testEntity{
+			Name:      name,
+			Kind:      "basic",
+			Score:     i,
+			CreatedAt: testBase.Add(time.Duration(i) * time.Minute),
+		}
+
+		if err := repo.Create(context.Background(), entities[i]); err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+	}
+
+	return entities
+}
+
+func names(entities []*testEntity) string {
+	result := make([]string, len(entities))
+	for i, entity := range entities {
+		result[i] = entity.Name
+	}
+
+	return fmt.Sprint(result)
+}
+
+func TestMemoryRepositoryOperators(t *testing.T) {
+	repo := NewMemoryRepository[testEntity]()
+	entities := seedEntities(t, repo, "alpha", "beta", "gamma_1", "gammax1", "delta")
+
+	seen := testBase.Add(time.Hour)
+	entities[1].SeenAt = &seen
+	entities[1].Tags = map[string]interface{}{"plan": "pro", "seats": 3}
+
+	if err := repo.Update(context.Background(), entities[1]); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		condition Condition
+		want      string
+	}{
+		{"equal", Condition{Field: "name", Operator: OpEqual, Value: "beta"}, "[beta]"},
+		{"not equal", Condition{Field: "score", Operator: OpNotEqual, Value: 0}, "[beta gamma_1 gammax1 delta]"},
+		{"greater", Condition{Field: "score", Operator: OpGreater, Value: 2}, "[gammax1 delta]"},
+		{"less or equal", Condition{Field: "created_at", Operator: OpLessOrEqual, Value: testBase.Add(time.Minute)}, "[alpha beta]"},
+		{"in", Condition{Field: "name", Operator: OpIn, Value: []string{"alpha", "delta"}}, "[alpha delta]"},
+		{"not in", Condition{Field: "kind", Operator: OpNotIn, Value: []string{"basic"}}, "[]"},
+		{"like escapes wildcards", Condition{Field: "name", Operator: OpLike, Value: `gamma\_%`}, "[gamma_1]"},
+		{"like wildcard", Condition{Field: "name", Operator: OpLike, Value: "gamma_1"}, "[gamma_1 gammax1]"},
+		{"ilike", Condition{Field: "name", Operator: OpILike, Value: "%ELT%"}, "[delta]"},
+		{"is null", Condition{Field: "seen_at", Operator: OpIsNull}, "[alpha gamma_1 gammax1 delta]"},
+		{"is not null", Condition{Field: "seen_at", Operator: OpIsNotNull}, "[beta]"},
+		{"null never compares", Condition{Field: "seen_at", Operator: OpLess, Value: seen.Add(time.Hour), Not: true}, "[]"},
+		{"json contains", Condition{Field: "tags", Operator: OpJSONContains, Value: map[string]interface{}{"seats": 3}}, "[beta]"},
+		{"json has key", Condition{Field: "tags", Operator: OpJSONHasKey, Value: "plan"}, "[beta]"},
+		{"not", Condition{Field: "score", Operator: OpLess, Value: 3, Not: true}, "[gammax1 delta]"},
+		{"any", Condition{Any: []Condition{
+			{Field: "name", Operator: OpEqual, Value: "alpha"},
+			{Field: "score", Operator: OpGreaterOrEqual, Value: 4},
+		}}, "[alpha delta]"},
+		{"empty any", Condition{Any: []Condition{}}, "[]"},
+		{"empty all", Condition{All: []Condition{}}, "[alpha beta gamma_1 gammax1 delta]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.FindMany(context.Background(), QueryFilter{Conditions: []Condition{tt.condition}})
+			if err != nil {
+				t.Fatalf("FindMany: %v", err)
+			}
+
+			if names(got) != tt.want {
+				t.Errorf("FindMany = %s, want %s", names(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryRepositoryRejectsInvalidFilters(t *testing.T) {
+	repo := NewMemoryRepository[testEntity]()
+	seedEntities(t, repo, "alpha")
+
+	tests := []struct {
+		name   string
+		filter QueryFilter
+		want   error
+	}{
+		{"unknown field", QueryFilter{Conditions: []Condition{{Field: "missing", Operator: OpEqual, Value: 1}}}, ErrInvalidField},
+		{"unsafe field", QueryFilter{Conditions: []Condition{{Field: "name; DROP", Operator: OpEqual, Value: 1}}}, ErrInvalidField},
+		{"unknown operator", QueryFilter{Conditions: []Condition{{Field: "name", Operator: "~", Value: 1}}}, ErrInvalidOperator},
+		{"mismatched value", QueryFilter{Conditions: []Condition{{Field: "score", Operator: OpEqual, Value: "one"}}}, ErrInvalidValue},
+		{"json key is not a string", QueryFilter{Conditions: []Condition{{Field: "tags", Operator: OpJSONHasKey, Value: 1}}}, ErrInvalidValue},
+		{"json document not serializable", QueryFilter{Conditions: []Condition{{Field: "tags", Operator: OpJSONContains, Value: func() {}}}}, ErrInvalidValue},
+		{"unknown order", QueryFilter{OrderBy: []string{"missing ASC"}}, ErrInvalidOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := repo.FindMany(context.Background(), tt.filter); !errors.Is(err, tt.want) {
+				t.Errorf("FindMany error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryRepositoryOrderingAndPagination(t *testing.T) {
+	repo := NewMemoryRepository[testEntity]()
+	entities := seedEntities(t, repo, "a", "b", "c", "d", "e")
+
+	seen := testBase
+	entities[3].SeenAt = &seen
+
+	if err := repo.Update(context.Background(), entities[3]); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter QueryFilter
+		want   string
+	}{
+		{"insertion order without limit", QueryFilter{}, "[a b c d e]"},
+		{"default order when paginated", QueryFilter{Page: 1, PageSize: 2}, "[e d]"},
+		{"second page", QueryFilter{Page: 2, PageSize: 2}, "[c b]"},
+		{"last page", QueryFilter{Page: 3, PageSize: 2}, "[a]"},
+		{"limit and offset", QueryFilter{OrderBy: []string{"name ASC"}, Limit: 2, Offset: 1}, "[b c]"},
+		{"nulls last ascending", QueryFilter{OrderBy: []string{"seen_at ASC", "name DESC"}}, "[d e c b a]"},
+		{"nulls first descending", QueryFilter{OrderBy: []string{"seen_at DESC", "name ASC"}}, "[a b c e d]"},
+		{"offset past the end", QueryFilter{Limit: 2, Offset: 10}, "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.FindMany(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("FindMany: %v", err)
+			}
+
+			if names(got) != tt.want {
+				t.Errorf("FindMany = %s, want %s", names(got), tt.want)
+			}
+		})
+	}
+
+	page, err := repo.Paginate(context.Background(), QueryFilter{Page: 2, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+
+	if page.TotalItems != 5 || page.TotalPages != 3 || !page.HasNext || !page.HasPrev {
+		t.Errorf("Paginate = %+v", page)
+	}
+}
+
+func TestMemoryRepositorySoftDelete(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(testBase.Add(24 * time.Hour))
+	repo := NewMemoryRepository[testEntity]().WithClock(fake)
+	entities := seedEntities(t, repo, "alpha", "beta")
+
+	if err := repo.Delete(ctx, entities[0].ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	byID := NewQueryBuilder().WhereEqual("id", entities[0].ID)
+
+	if _, err := repo.FindOne(ctx, byID.Build()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindOne deleted error = %v, want ErrNotFound", err)
+	}
+
+	deleted, err := repo.FindOne(ctx, byID.IncludeDeleted().Build())
+	if err != nil {
+		t.Fatalf("FindOne with IncludeDeleted: %v", err)
+	}
+
+	if deleted.DeletedAt == nil || !deleted.DeletedAt.Equal(fake.Now()) {
+		t.Errorf("DeletedAt = %v, want %v", deleted.DeletedAt, fake.Now())
+	}
+
+	count, err := repo.Count(ctx, QueryFilter{IncludeDeleted: true})
+	if err != nil || count != 2 {
+		t.Errorf("Count with IncludeDeleted = %d, %v; want 2", count, err)
+	}
+
+	count, err = repo.Count(ctx, QueryFilter{})
+	if err != nil || count != 1 {
+		t.Errorf("Count = %d, %v; want 1", count, err)
+	}
+}
+
+func TestMemoryRepositoryHardDeleteWithoutDeletedAt(t *testing.T) {
+	type plain struct {
+		ID   uuid.UUID
+		Name string
+	}
+
+	ctx := context.Background()
+	repo := NewMemoryRepository[plain]()
+	entity := &plain{Name: "alpha"}
+
+	if err := repo.Create(ctx, entity); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if entity.ID == uuid.Nil {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	if err := repo.Delete(ctx, entity.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	count, err := repo.Count(ctx, QueryFilter{IncludeDeleted: true})
+	if err != nil || count != 0 {
+		t.Errorf("Count after hard delete = %d, %v; want 0", count, err)
+	}
+}
+
+func TestMemoryRepositoryUpdateMany(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFakeClock(testBase.Add(time.Hour))
+	repo := NewMemoryRepository[testEntity]().WithClock(fake)
+	seedEntities(t, repo, "alpha", "beta", "gamma")
+
+	seen := testBase.Add(2 * time.Hour)
+	filter := NewQueryBuilder().Where("score", OpGreaterOrEqual, 1).Build()
+
+	affected, err := repo.UpdateMany(ctx, filter, map[string]interface{}{"kind": "premium", "seen_at": seen})
+	if err != nil || affected != 2 {
+		t.Fatalf("UpdateMany = %d, %v; want 2", affected, err)
+	}
+
+	updated, err := repo.FindMany(ctx, NewQueryBuilder().WhereEqual("kind", "premium").Build())
+	if err != nil {
+		t.Fatalf("FindMany: %v", err)
+	}
+
+	if names(updated) != "[beta gamma]" {
+		t.Errorf("updated = %s", names(updated))
+	}
+
+	for _, entity := range updated {
+		if entity.SeenAt == nil || !entity.SeenAt.Equal(seen) || !entity.UpdatedAt.Equal(fake.Now()) {
+			t.Errorf("%s: seen_at %v, updated_at %v", entity.Name, entity.SeenAt, entity.UpdatedAt)
+		}
+	}
+
+	if _, err := repo.UpdateMany(ctx, filter, map[string]interface{}{"missing": 1}); !errors.Is(err, ErrInvalidField) {
+		t.Errorf("UpdateMany unknown column error = %v, want ErrInvalidField", err)
+	}
+}
+
+func TestMemoryRepositoryReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository[testEntity]()
+	entities := seedEntities(t, repo, "alpha")
+
+	entities[0].Name = "changed after create"
+
+	found, err := repo.FindOne(ctx, QueryFilter{})
+	if err != nil {
+		t.Fatalf("FindOne: %v", err)
+	}
+
+	found.Name = "changed after find"
+
+	again, err := repo.FindOne(ctx, QueryFilter{})
+	if err != nil || again.Name != "alpha" {
+		t.Errorf("stored name = %v, %v; want alpha", again, err)
+	}
+}
+
+func TestMemoryRepositoryGroupBy(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository[testEntity]()
+	entities := seedEntities(t, repo, "alpha", "beta", "gamma")
+
+	entities[2].Kind = "premium"
+	if err := repo.Update(ctx, entities[2]); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	groups, err := repo.GroupBy(ctx, "kind", QueryFilter{})
+	if err != nil {
+		t.Fatalf("GroupBy: %v", err)
+	}
+
+	want := []GroupCount{{Group: "basic", Count: 2}, {Group: "premium", Count: 1}}
+	if fmt.Sprint(groups) != fmt.Sprint(want) {
+		t.Errorf("GroupBy = %v, want %v", groups, want)
+	}
+
+	if _, err := repo.GroupBy(ctx, "missing", QueryFilter{}); !errors.Is(err, ErrInvalidField) {
+		t.Errorf("GroupBy unknown field error = %v, want ErrInvalidField", err)
+	}
+}
+
+func TestMemoryRepositoryRejectsDeepPages(t *testing.T) {
+	pagination.ConfigureMaxOffset(4)
+	t.Cleanup(func() { pagination.ConfigureMaxOffset(pagination.FallbackMaxOffset) })
+
+	repo := NewMemoryRepository[testEntity]()
+	seedEntities(t, repo, "a", "b", "c", "d", "e")
+
+	tests := []struct {
+		name   string
+		filter QueryFilter
+		want   error
+	}{
+		// A página 3 de 2 pula exatamente 4 registros
+		{name: "deepest allowed page", filter: QueryFilter{Page: 3, PageSize: 2}},
+		{name: "page beyond the depth", filter: QueryFilter{Page: 4, PageSize: 2}, want: pagination.ErrPageTooDeep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.Paginate(context.Background(), tt.filter)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Paginate = %v, want %v", err, tt.want)
+			}
+
+			if tt.want == nil && names(page.Items) != "[a]" {
+				t.Errorf("Paginate items = %s, want [a]", names(page.Items))
+			}
+		})
+	}
+
+	// Offsets explícitos seguem o mesmo limite
+	if _, err := repo.FindMany(context.Background(), QueryFilter{Limit: 1, Offset: 5}); !errors.Is(err, pagination.ErrPageTooDeep) {
+		t.Errorf("FindMany beyond the depth = %v, want %v", err, pagination.ErrPageTooDeep)
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository define as operações genéricas de persistência para uma entidade T.
+type Repository[T any] interface {
+	Create(ctx context.Context, entity *T) error
+	FindOne(ctx context.Context, filter QueryFilter) (*T, error)
+	FindMany(ctx context.Context, filter QueryFilter) ([]*T, error)
+	Update(ctx context.Context, entity *T) error
+	UpdateMany(ctx context.Context, filter QueryFilter, updates map[string]interface{}) (int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteMany(ctx context.Context, filter QueryFilter) (int64, error)
+	// Count conta os registros que satisfazem as condições do filtro; a paginação
+	// é ignorada, então o mesmo filtro usado na busca da página dá o total filtrado.
+	Count(ctx context.Context, filter QueryFilter) (int64, error)
+	Exists(ctx context.Context, filter QueryFilter) (bool, error)
+	Paginate(ctx context.Context, filter QueryFilter) (*PaginatedResult[T], error)
+	GroupBy(ctx context.Context, field string, filter QueryFilter) ([]GroupCount, error)
+}
+
+// GroupCount representa a quantidade de registros de um grupo.
+type GroupCount struct {
+	Group interface{} `json:"group"`
+	Count int64       `json:"count"`
+}
+
+// PaginatedResult representa uma página de resultados.
+type PaginatedResult[T any] struct {
+	Items      []*T  `json:"items"`
+	TotalItems int64 `json:"total_items"`
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+	HasPrev    bool  `json:"has_prev"`
+}
+
+// NewPaginatedResult cria um resultado paginado a partir dos itens e do total.
+func NewPaginatedResult[T any](items []*T, total int64, page, pageSize int) *PaginatedResult[T] {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = int(total) / pageSize
+		if int(total)%pageSize > 0 {
+			totalPages++
+		}
+	}
+
+	if items == nil {
+		items = []*T{}
+	}
+
+	return &PaginatedResult[T]{
+		Items:      items,
+		TotalItems: total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+// ProbeLimit retorna o limite de busca para paginar sem contagem: uma linha
+// além do tamanho da página, usada apenas para saber se há próxima página.
+func ProbeLimit(pageSize int) int {
+	return pageSize + 1
+}
+
+// NewProbedResult cria um resultado paginado quando o total é desconhecido.
+// items deve vir de uma busca com ProbeLimit(pageSize); a linha excedente
+// indica HasNext e é descartada. TotalItems e TotalPages ficam zerados, e uma
+// página exatamente cheia no fim do conjunto reporta HasNext false.
+func NewProbedResult[T any](items []*T, page, pageSize int) *PaginatedResult[T] {
+	hasNext := pageSize > 0 && len(items) > pageSize
+	if hasNext {
+		items = items[:pageSize]
+	}
+
+	if items == nil {
+		items = []*T{}
+	}
+
+	return &PaginatedResult[T]{
+		Items:    items,
+		Page:     page,
+		PageSize: pageSize,
+		HasNext:  hasNext,
+		HasPrev:  page > 1,
+	}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+)
+
+// probePage simula uma busca de ProbeLimit(pageSize) linhas a partir da página.
+func probePage(dataset []*int, page, pageSize int) *PaginatedResult[int] {
+	offset := min((page-1)*pageSize, len(dataset))
+	end := min(offset+ProbeLimit(pageSize), len(dataset))
+
+	return NewProbedResult(dataset[offset:end], page, pageSize)
+}
+
+func TestNewProbedResultPageBoundaries(t *testing.T) {
+	tests := []struct {
+		size      int
+		pageSize  int
+		wantPages int
+	}{
+		{size: 0, pageSize: 10, wantPages: 1},
+		{size: 9, pageSize: 10, wantPages: 1},
+		{size: 10, pageSize: 10, wantPages: 1},
+		{size: 11, pageSize: 10, wantPages: 2},
+		{size: 20, pageSize: 10, wantPages: 2},
+		{size: 30, pageSize: 10, wantPages: 3},
+		{size: 3, pageSize: 1, wantPages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d items by %d", tt.size, tt.pageSize), func(t *testing.T) {
+			dataset := make([]*int, tt.size)
+			for i := range dataset {
+				dataset[i] = &i
+			}
+
+			seen := 0
+
+			for page := 1; ; page++ {
+				result := probePage(dataset, page, tt.pageSize)
+
+				if len(result.Items) > tt.pageSize {
+					t.Fatalf("page %d has %d items, want at most %d", page, len(result.Items), tt.pageSize)
+				}
+
+				// Sem contagem, o total nunca é informado
+				if result.TotalItems != 0 || result.TotalPages != 0 {
+					t.Errorf("page %d totals = %d/%d, want 0/0", page, result.TotalItems, result.TotalPages)
+				}
+
+				if result.HasPrev != (page > 1) {
+					t.Errorf("page %d HasPrev = %v", page, result.HasPrev)
+				}
+
+				seen += len(result.Items)
+
+				if !result.HasNext {
+					if page != tt.wantPages {
+						t.Errorf("last page = %d, want %d", page, tt.wantPages)
+					}
+
+					break
+				}
+
+				if page > tt.wantPages {
+					t.Fatalf("HasNext still true on page %d", page)
+				}
+			}
+
+			if seen != tt.size {
+				t.Errorf("saw %d items, want %d", seen, tt.size)
+			}
+		})
+	}
+}
+
+func TestNewProbedResultEmptyItems(t *testing.T) {
+	result := NewProbedResult[int](nil, 1, 10)
+
+	if result.Items == nil || len(result.Items) != 0 || result.HasNext {
+		t.Errorf("result = %+v, want empty non-nil items without next page", result)
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+// Specification representa um critério de seleção combinável sobre entidades
+// do tipo T, convertido em QueryFilter para consumo pelos repositórios.
+type Specification[T any] interface {
+	// Condition retorna a condição equivalente à especificação.
+	Condition() Condition
+	And(other Specification[T]) Specification[T]
+	Or(other Specification[T]) Specification[T]
+	Not() Specification[T]
+	// ToQueryFilter retorna um filtro sem paginação contendo a especificação.
+	ToQueryFilter() QueryFilter
+}
+
+// conditionSpecification implementa Specification sobre uma Condition.
+type conditionSpecification[T any] struct {
+	condition Condition
+}
+
+// NewSpecification cria uma especificação de uma única condição.
+func NewSpecification[T any](field string, operator Operator, value interface{}) Specification[T] {
+	return conditionSpecification[T]{condition: Condition{
+		Field:    field,
+		Operator: operator,
+		Value:    value,
+	}}
+}
+
+// AllSpecification cria uma especificação satisfeita por qualquer registro,
+// útil como ponto de partida para combinações opcionais.
+func AllSpecification[T any]() Specification[T] {
+	return conditionSpecification[T]{condition: Condition{All: []Condition{}}}
+}
+
+// Condition retorna a condição equivalente à especificação.
+func (s conditionSpecification[T]) Condition() Condition {
+	return s.condition
+}
+
+// And combina as especificações exigindo que ambas sejam satisfeitas.
+func (s conditionSpecification[T]) And(other Specification[T]) Specification[T] {
+	// Agrupamentos AND consecutivos são achatados para manter o SQL simples
+	if s.condition.All != nil && !s.condition.Not {
+		all := append(append([]Condition{}, s.condition.All...), other.Condition())
+
+		return conditionSpecification[T]{condition: Condition{All: all}}
+	}
+
+	return conditionSpecification[T]{condition: Condition{
+		All: []Condition{s.condition, other.Condition()},
+	}}
+}
+
+// Or combina as especificações exigindo que ao menos uma seja satisfeita.
+func (s conditionSpecification[T]) Or(other Specification[T]) Specification[T] {
+	return conditionSpecification[T]{condition: Condition{
+		Any: []Condition{s.condition, other.Condition()},
+	}}
+}
+
+// Not nega a especificação.
+func (s conditionSpecification[T]) Not() Specification[T] {
+	negated := s.condition
+	negated.Not = !negated.Not
+
+	return conditionSpecification[T]{condition: negated}
+}
+
+// ToQueryFilter retorna um filtro sem paginação contendo a especificação.
+// Um agrupamento AND na raiz vira condições independentes do filtro.
+func (s conditionSpecification[T]) ToQueryFilter() QueryFilter {
+	if s.condition.All != nil && !s.condition.Not {
+		return QueryFilter{Conditions: append([]Condition{}, s.condition.All...)}
+	}
+
+	return QueryFilter{Conditions: []Condition{s.condition}}
+}
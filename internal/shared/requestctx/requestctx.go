@@ -0,0 +1,102 @@
+package requestctx
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// contextKey é o tipo das chaves armazenadas no context.Context por este pacote.
+type contextKey string
+
+const (
+	requestIDKey    contextKey = "request_id"
+	traceIDKey      contextKey = "trace_id"
+	actorKey        contextKey = "actor"
+	impersonatorKey contextKey = "impersonator"
+)
+
+// WithRequestID retorna um contexto derivado contendo o request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID extrai o request ID do contexto (vazio se ausente).
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestID, _ := ctx.Value(requestIDKey).(string)
+
+	return requestID
+}
+
+// WithTraceID retorna um contexto derivado contendo o trace ID.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// TraceID extrai o trace ID do contexto (vazio se ausente).
+func TraceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	traceID, _ := ctx.Value(traceIDKey).(string)
+
+	return traceID
+}
+
+// WithActor retorna um contexto derivado contendo o ator autenticado (ID do usuário).
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor extrai o ator autenticado do contexto (vazio se ausente).
+func Actor(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	actor, _ := ctx.Value(actorKey).(string)
+
+	return actor
+}
+
+// WithImpersonator retorna um contexto derivado contendo o ID do administrador
+// que personifica o ator.
+func WithImpersonator(ctx context.Context, impersonator string) context.Context {
+	return context.WithValue(ctx, impersonatorKey, impersonator)
+}
+
+// Impersonator extrai o administrador que personifica o ator (vazio se ausente).
+func Impersonator(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	impersonator, _ := ctx.Value(impersonatorKey).(string)
+
+	return impersonator
+}
+
+// LogFields retorna os campos de log de correlação presentes no contexto.
+func LogFields(ctx context.Context) []zap.Field {
+	fields := make([]zap.Field, 0, 3)
+
+	if requestID := RequestID(ctx); requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+
+	if traceID := TraceID(ctx); traceID != "" {
+		fields = append(fields, zap.String("trace_id", traceID))
+	}
+
+	// Durante uma personificação, todo log identifica o administrador responsável
+	if impersonator := Impersonator(ctx); impersonator != "" {
+		fields = append(fields, zap.String("impersonator_id", impersonator))
+	}
+
+	return fields
+}
@@ -0,0 +1,29 @@
+package response
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/shared/i18n"
+)
+
+// Language retorna o idioma das mensagens negociado pelo cabeçalho Accept-Language.
+func Language(c *gin.Context) string {
+	return i18n.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+}
+
+// localize troca a mensagem pela tradução do código de erro no idioma do
+// cliente. Sem tradução, a mensagem original em inglês é mantida.
+func localize(c *gin.Context, errorCode, message string) string {
+	c.Writer.Header().Add("Vary", "Accept-Language")
+
+	language := Language(c)
+
+	translated, ok := i18n.Lookup(language, errorCode)
+	if !ok {
+		return message
+	}
+
+	c.Header("Content-Language", language)
+
+	return translated
+}
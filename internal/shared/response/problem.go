@@ -0,0 +1,70 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType é o media type de documentos RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix é a base das URIs de type; cada código de erro vira um
+// caminho próprio (ex.: USER_NOT_FOUND -> /problems/user-not-found).
+const problemTypePrefix = "/problems/"
+
+// Problem representa um documento de erro no formato RFC 7807.
+// Code, Errors e Data são membros de extensão com o código de erro da API, os
+// detalhes de validação por campo e dados adicionais do erro.
+type Problem struct {
+	Data     interface{}       `json:"data,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     string            `json:"code"`
+	Status   int               `json:"status"`
+}
+
+// ProblemType retorna a URI de type correspondente a um código de erro da API.
+func ProblemType(errorCode string) string {
+	return problemTypePrefix + strings.ReplaceAll(strings.ToLower(errorCode), "_", "-")
+}
+
+// WantsProblem indica se o cliente pediu erros em RFC 7807 no cabeçalho Accept.
+func WantsProblem(c *gin.Context) bool {
+	for _, accepted := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(accepted, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), ProblemContentType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeProblem escreve um documento RFC 7807 com o content type adequado.
+func writeProblem(
+	c *gin.Context,
+	statusCode int,
+	errorCode, message string,
+	errors map[string]string,
+	data interface{},
+) {
+	problem := Problem{
+		Type:     ProblemType(errorCode),
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Detail:   message,
+		Instance: c.Request.URL.Path,
+		Code:     errorCode,
+		Errors:   errors,
+		Data:     data,
+	}
+
+	// O renderizador JSON do gin preserva um Content-Type já definido
+	c.Header("Content-Type", ProblemContentType)
+	JSON(c, statusCode, problem)
+}
@@ -0,0 +1,171 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestErrorContentNegotiation(t *testing.T) {
+	router := gin.New()
+	router.POST("/users", func(c *gin.Context) {
+		ValidationError(c, map[string]string{"email": "Email is invalid"})
+	})
+	router.GET("/users/:id", func(c *gin.Context) {
+		NotFound(c, "USER_NOT_FOUND", "User not found")
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		accept      string
+		wantCode    int
+		wantType    string
+		wantProblem bool
+	}{
+		{
+			name: "validation error as problem", method: http.MethodPost, path: "/users", accept: ProblemContentType,
+			wantCode: http.StatusBadRequest, wantType: "/problems/validation-error", wantProblem: true,
+		},
+		{
+			name: "not found as problem", method: http.MethodGet, path: "/users/42", accept: "application/json, application/problem+json;q=0.9",
+			wantCode: http.StatusNotFound, wantType: "/problems/user-not-found", wantProblem: true,
+		},
+		{name: "default envelope", method: http.MethodGet, path: "/users/42", wantCode: http.StatusNotFound},
+		{name: "plain json keeps the envelope", method: http.MethodPost, path: "/users", accept: "application/json", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Accept", tt.accept)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			contentType := rec.Header().Get("Content-Type")
+
+			if !tt.wantProblem {
+				if contentType == ProblemContentType || body["success"] != false {
+					t.Errorf("got %s %v, want the default envelope", contentType, body)
+				}
+
+				return
+			}
+
+			if contentType != ProblemContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, ProblemContentType)
+			}
+
+			if body["type"] != tt.wantType || body["title"] != http.StatusText(tt.wantCode) ||
+				body["status"] != float64(tt.wantCode) || body["instance"] != tt.path || body["detail"] == "" {
+				t.Errorf("problem = %v", body)
+			}
+
+			if _, ok := body["success"]; ok {
+				t.Errorf("problem carries the envelope: %v", body)
+			}
+		})
+	}
+}
+
+func TestValidationProblemCarriesFieldErrors(t *testing.T) {
+	router := gin.New()
+	router.POST("/users", func(c *gin.Context) {
+		ValidationError(c, map[string]string{"email": "Email is invalid"})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	req.Header.Set("Accept", ProblemContentType)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var problem Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not a problem: %v", err)
+	}
+
+	if problem.Code != "VALIDATION_ERROR" || problem.Errors["email"] != "Email is invalid" {
+		t.Errorf("problem = %+v", problem)
+	}
+}
+
+func TestErrorMessagesFollowAcceptLanguage(t *testing.T) {
+	router := gin.New()
+	router.GET("/users/:id", func(c *gin.Context) {
+		NotFound(c, "USER_NOT_FOUND", "User not found")
+	})
+	router.GET("/untranslated", func(c *gin.Context) {
+		BadRequest(c, "SOMETHING_NEW", "Something new went wrong")
+	})
+
+	tests := []struct {
+		name         string
+		path         string
+		language     string
+		accept       string
+		wantMessage  string
+		wantLanguage string
+	}{
+		{name: "portuguese", path: "/users/42", language: "pt-BR", wantMessage: "Usuário não encontrado", wantLanguage: "pt"},
+		{name: "portuguese problem", path: "/users/42", language: "pt-BR", accept: ProblemContentType, wantMessage: "Usuário não encontrado", wantLanguage: "pt"},
+		{name: "english", path: "/users/42", language: "en-US", wantMessage: "User not found"},
+		{name: "unsupported language", path: "/users/42", language: "fr", wantMessage: "User not found"},
+		{name: "code without translation", path: "/untranslated", language: "pt-BR", wantMessage: "Something new went wrong"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Accept-Language", tt.language)
+			req.Header.Set("Accept", tt.accept)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			var body struct {
+				Message string `json:"message"`
+				Detail  string `json:"detail"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			// Em problem+json a mensagem vai em detail
+			message := body.Message
+			if tt.accept == ProblemContentType {
+				message = body.Detail
+			}
+
+			if message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", message, tt.wantMessage)
+			}
+
+			if got := rec.Header().Get("Content-Language"); got != tt.wantLanguage {
+				t.Errorf("Content-Language = %q, want %q", got, tt.wantLanguage)
+			}
+
+			if rec.Header().Get("Vary") != "Accept-Language" {
+				t.Errorf("Vary = %q, want Accept-Language", rec.Header().Get("Vary"))
+			}
+		})
+	}
+}
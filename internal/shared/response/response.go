@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/devleo-m/go-zero/internal/shared/jsonnaming"
 )
 
 type Response struct {
@@ -14,11 +16,25 @@ type Response struct {
 	Success bool        `json:"success"`
 }
 
+// Meta descreve a paginação de uma listagem. Os campos principais são sempre
+// emitidos, inclusive zerados, para que uma página vazia continue paginada.
 type Meta struct {
-	Page       int   `json:"page,omitempty"`
-	Limit      int   `json:"limit,omitempty"`
-	Total      int64 `json:"total,omitempty"`
-	TotalPages int   `json:"total_pages,omitempty"`
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+	HasPrev    bool  `json:"has_prev"`
+	// RequestedPage e RequestedLimit são informados quando a paginação pedida foi ajustada.
+	RequestedPage  int  `json:"requested_page,omitempty"`
+	RequestedLimit int  `json:"requested_limit,omitempty"`
+	Adjusted       bool `json:"adjusted,omitempty"`
+}
+
+// JSON escreve obj com a convenção de nomes de campos configurada em jsonnaming.
+// Respostas escritas fora deste pacote devem usá-lo no lugar de c.JSON.
+func JSON(c *gin.Context, statusCode int, obj interface{}) {
+	c.JSON(statusCode, jsonnaming.Convert(obj))
 }
 
 // Success retorna uma resposta de sucesso.
@@ -28,7 +44,7 @@ func Success(c *gin.Context, data interface{}, message ...string) {
 		msg = message[0]
 	}
 
-	c.JSON(http.StatusOK, Response{
+	JSON(c, http.StatusOK, Response{
 		Success: true,
 		Message: msg,
 		Data:    data,
@@ -42,7 +58,7 @@ func Created(c *gin.Context, data interface{}, message ...string) {
 		msg = message[0]
 	}
 
-	c.JSON(http.StatusCreated, Response{
+	JSON(c, http.StatusCreated, Response{
 		Success: true,
 		Message: msg,
 		Data:    data,
@@ -56,18 +72,45 @@ func NoContent(c *gin.Context, message ...string) {
 		msg = message[0]
 	}
 
-	c.JSON(http.StatusNoContent, Response{
+	JSON(c, http.StatusNoContent, Response{
 		Success: true,
 		Message: msg,
 	})
 }
 
-// Error retorna uma resposta de erro.
+// Error retorna uma resposta de erro. Quando o cliente aceita
+// application/problem+json, o erro é emitido no formato RFC 7807.
+// A mensagem é traduzida pelo código de erro conforme o Accept-Language.
 func Error(c *gin.Context, statusCode int, errorCode, message string) {
-	c.JSON(statusCode, Response{
+	message = localize(c, errorCode, message)
+
+	if WantsProblem(c) {
+		writeProblem(c, statusCode, errorCode, message, nil, nil)
+		return
+	}
+
+	JSON(c, statusCode, Response{
+		Success: false,
+		Error:   errorCode,
+		Message: message,
+	})
+}
+
+// ErrorWithData retorna uma resposta de erro acompanhada de dados que ajudam o
+// cliente a corrigir a requisição (ex.: os valores permitidos).
+func ErrorWithData(c *gin.Context, statusCode int, errorCode, message string, data interface{}) {
+	message = localize(c, errorCode, message)
+
+	if WantsProblem(c) {
+		writeProblem(c, statusCode, errorCode, message, nil, data)
+		return
+	}
+
+	JSON(c, statusCode, Response{
 		Success: false,
 		Error:   errorCode,
 		Message: message,
+		Data:    data,
 	})
 }
 
@@ -96,11 +139,26 @@ func Conflict(c *gin.Context, errorCode, message string) {
 	Error(c, http.StatusConflict, errorCode, message)
 }
 
+// UnprocessableEntity retorna uma resposta de requisição válida que não pode ser aplicada.
+func UnprocessableEntity(c *gin.Context, errorCode, message string, data interface{}) {
+	ErrorWithData(c, http.StatusUnprocessableEntity, errorCode, message, data)
+}
+
+// TooManyRequests retorna uma resposta de limite excedido.
+func TooManyRequests(c *gin.Context, errorCode, message string) {
+	Error(c, http.StatusTooManyRequests, errorCode, message)
+}
+
 // InternalServerError retorna uma resposta de erro interno do servidor.
 func InternalServerError(c *gin.Context, errorCode, message string) {
 	Error(c, http.StatusInternalServerError, errorCode, message)
 }
 
+// ServiceUnavailable retorna uma resposta de serviço indisponível.
+func ServiceUnavailable(c *gin.Context, errorCode, message string) {
+	Error(c, http.StatusServiceUnavailable, errorCode, message)
+}
+
 // Paginated retorna uma resposta paginada.
 func Paginated(c *gin.Context, data interface{}, meta *Meta, message ...string) {
 	msg := ""
@@ -108,7 +166,7 @@ func Paginated(c *gin.Context, data interface{}, meta *Meta, message ...string)
 		msg = message[0]
 	}
 
-	c.JSON(http.StatusOK, Response{
+	JSON(c, http.StatusOK, Response{
 		Success: true,
 		Message: msg,
 		Data:    data,
@@ -117,20 +175,43 @@ func Paginated(c *gin.Context, data interface{}, meta *Meta, message ...string)
 }
 
 // ValidationError retorna uma resposta de erro de validação.
+// As mensagens por campo devem chegar já no idioma de Language(c).
 func ValidationError(c *gin.Context, errors map[string]string) {
-	c.JSON(http.StatusBadRequest, Response{
+	message := localize(c, "VALIDATION_ERROR", "Validation failed")
+
+	if WantsProblem(c) {
+		writeProblem(c, http.StatusBadRequest, "VALIDATION_ERROR", message, errors, nil)
+		return
+	}
+
+	JSON(c, http.StatusBadRequest, Response{
 		Success: false,
 		Error:   "VALIDATION_ERROR",
-		Message: "Validation failed",
+		Message: message,
 		Data:    gin.H{"errors": errors},
 	})
 }
 
+// WithRequested registra a paginação pedida pelo cliente quando ela difere da efetiva.
+func (m *Meta) WithRequested(page, limit int) *Meta {
+	if page != m.Page || limit != m.Limit {
+		m.RequestedPage = page
+		m.RequestedLimit = limit
+		m.Adjusted = true
+	}
+
+	return m
+}
+
 // NewMeta cria uma nova estrutura de meta para paginação.
+// Um limit < 1 resulta em zero páginas em vez de divisão por zero.
 func NewMeta(page, limit int, total int64) *Meta {
-	totalPages := int(total) / limit
-	if int(total)%limit > 0 {
-		totalPages++
+	totalPages := 0
+	if limit > 0 {
+		totalPages = int(total) / limit
+		if int(total)%limit > 0 {
+			totalPages++
+		}
 	}
 
 	return &Meta{
@@ -138,5 +219,7 @@ func NewMeta(page, limit int, total int64) *Meta {
 		Limit:      limit,
 		Total:      total,
 		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
 	}
 }
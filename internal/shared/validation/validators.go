@@ -1,16 +1,20 @@
 package validation
 
 import (
+	"math/bits"
+	"net/mail"
 	"regexp"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 var (
-	emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	phoneRegex = regexp.MustCompile(`^\+?[1-9]\d{1,14}$`)
 	uuidRegex  = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	// domainRegex aceita nomes de domínio como "example.com" ou "mail.example.co.uk".
+	domainRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,}$`)
 )
 
 // ValidationError representa um erro de validação.
@@ -23,90 +27,151 @@ func (e ValidationError) Error() string {
 	return e.Message
 }
 
-// ValidateEmail valida um endereço de email.
+// Limites de tamanho de um endereço de email (RFC 5321).
+const (
+	maxEmailLength    = 254
+	maxEmailLocalPart = 64
+)
+
+// ValidateEmail valida um endereço de email com net/mail, aceitando apenas o
+// endereço simples (sem nome de exibição, comentários ou <>). Partes locais
+// entre aspas são aceitas; o domínio precisa ser um nome DNS válido.
 func ValidateEmail(email string) error {
 	if email == "" {
 		return ValidationError{Field: "email", Message: "Email is required"}
 	}
 
-	if !emailRegex.MatchString(email) {
-		return ValidationError{Field: "email", Message: "Invalid email format"}
+	if len(email) > maxEmailLength {
+		return ValidationError{Field: "email", Message: "Email must be at most 254 characters long"}
 	}
 
-	return nil
-}
+	invalid := ValidationError{Field: "email", Message: "Invalid email format"}
 
-// ValidatePassword valida uma senha.
-func ValidatePassword(password string) error {
-	if err := validatePasswordLength(password); err != nil {
-		return err
+	if strings.ContainsAny(email, "<>") {
+		return invalid
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" {
+		return invalid
+	}
+
+	at := strings.LastIndex(address.Address, "@")
+	if at < 1 || at > maxEmailLocalPart {
+		return invalid
 	}
 
-	return validatePasswordComplexity(password)
+	if ValidateDomain(address.Address[at+1:]) != nil {
+		return invalid
+	}
+
+	return nil
+}
+
+// NormalizeEmail retorna a forma canônica de um email (sem espaços e em minúsculas).
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
 
-// validatePasswordLength valida o comprimento da senha.
-func validatePasswordLength(password string) error {
-	if password == "" {
-		return ValidationError{Field: "password", Message: "Password is required"}
+// ValidateDomain valida um nome de domínio (ex.: a parte após o @ de um email).
+func ValidateDomain(domain string) error {
+	if domain == "" {
+		return ValidationError{Field: "domain", Message: "Domain is required"}
 	}
 
-	if len(password) < 8 {
-		return ValidationError{Field: "password", Message: "Password must be at least 8 characters long"}
+	if len(domain) > 253 || !domainRegex.MatchString(domain) {
+		return ValidationError{Field: "domain", Message: "Invalid domain format"}
 	}
 
 	return nil
 }
 
-// validatePasswordComplexity valida a complexidade da senha.
-func validatePasswordComplexity(password string) error {
-	requirements := checkPasswordRequirements(password)
+// Classes de caracteres consideradas pela política de senha.
+const (
+	passwordClassUpper = 1 << iota
+	passwordClassLower
+	passwordClassDigit
+	passwordClassSpecial
+)
+
+// passwordClassCount é a quantidade de classes de caracteres existentes.
+const passwordClassCount = 4
+
+// PasswordPolicy define as regras de força de senha. É a única fonte dessas
+// regras: a camada HTTP e o domínio consultam a mesma política.
+type PasswordPolicy struct {
+	// MinLength é o comprimento mínimo da senha.
+	MinLength int
+	// RequiredClasses é quantas classes distintas (maiúsculas, minúsculas,
+	// dígitos e especiais) a senha deve conter, de 0 a 4.
+	RequiredClasses int
+}
+
+// DefaultPasswordPolicy retorna a política padrão: 8 caracteres e 3 classes.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: 8, RequiredClasses: 3}
+}
 
-	if !requirements.hasUpper {
-		return ValidationError{Field: "password", Message: "Password must contain at least one uppercase letter"}
+// Normalized retorna a política com valores fora do intervalo ajustados.
+func (p PasswordPolicy) Normalized() PasswordPolicy {
+	if p.MinLength < 1 {
+		p.MinLength = 1
 	}
 
-	if !requirements.hasLower {
-		return ValidationError{Field: "password", Message: "Password must contain at least one lowercase letter"}
+	p.RequiredClasses = max(0, min(p.RequiredClasses, passwordClassCount))
+
+	return p
+}
+
+// Validate verifica se a senha atende à política.
+func (p PasswordPolicy) Validate(password string) error {
+	p = p.Normalized()
+
+	if password == "" {
+		return ValidationError{Field: "password", Message: "Password is required"}
 	}
 
-	if !requirements.hasDigit {
-		return ValidationError{Field: "password", Message: "Password must contain at least one digit"}
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return ValidationError{
+			Field:   "password",
+			Message: "Password must be at least " + strconv.Itoa(p.MinLength) + " characters long",
+		}
 	}
 
-	if !requirements.hasSpecial {
-		return ValidationError{Field: "password", Message: "Password must contain at least one special character"}
+	if bits.OnesCount(uint(passwordClasses(password))) < p.RequiredClasses {
+		return ValidationError{
+			Field: "password",
+			Message: "Password must contain at least " + strconv.Itoa(p.RequiredClasses) +
+				" of: uppercase letters, lowercase letters, digits, special characters",
+		}
 	}
 
 	return nil
 }
 
-// passwordRequirements armazena os requisitos de uma senha.
-type passwordRequirements struct {
-	hasUpper   bool
-	hasLower   bool
-	hasDigit   bool
-	hasSpecial bool
+// ValidatePassword valida uma senha com a política padrão.
+func ValidatePassword(password string) error {
+	return DefaultPasswordPolicy().Validate(password)
 }
 
-// checkPasswordRequirements verifica se a senha atende aos requisitos.
-func checkPasswordRequirements(password string) passwordRequirements {
-	var reqs passwordRequirements
+// passwordClasses retorna as classes de caracteres presentes na senha.
+func passwordClasses(password string) int {
+	var classes int
 
 	for _, char := range password {
 		switch {
 		case unicode.IsUpper(char):
-			reqs.hasUpper = true
+			classes |= passwordClassUpper
 		case unicode.IsLower(char):
-			reqs.hasLower = true
+			classes |= passwordClassLower
 		case unicode.IsDigit(char):
-			reqs.hasDigit = true
+			classes |= passwordClassDigit
 		case unicode.IsPunct(char) || unicode.IsSymbol(char):
-			reqs.hasSpecial = true
+			classes |= passwordClassSpecial
 		}
 	}
 
-	return reqs
+	return classes
 }
 
 // ValidatePhone valida um número de telefone.
@@ -152,45 +217,17 @@ func ValidateName(name string) error {
 	return nil
 }
 
-// ValidateRole valida um role.
-func ValidateRole(role string) error {
-	validRoles := []string{"user", "admin", "moderator", "super_admin"}
-
-	if role == "" {
-		return ValidationError{Field: "role", Message: "Role is required"}
-	}
-
-	for _, validRole := range validRoles {
-		if role == validRole {
-			return nil
-		}
-	}
-
-	return ValidationError{Field: "role", Message: "Invalid role"}
-}
-
-// ValidateStatus valida um status.
-func ValidateStatus(status string) error {
-	validStatuses := []string{"active", "inactive", "pending", "suspended"}
-
-	if status == "" {
-		return ValidationError{Field: "status", Message: "Status is required"}
-	}
-
-	for _, validStatus := range validStatuses {
-		if status == validStatus {
-			return nil
-		}
-	}
-
-	return ValidationError{Field: "status", Message: "Invalid status"}
-}
-
 // SanitizeString limpa e sanitiza uma string.
 func SanitizeString(input string) string {
 	return strings.TrimSpace(input)
 }
 
+// NormalizeName remove os espaços das pontas e reduz cada sequência interna
+// de espaços a um único espaço.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
 // ValidatePagination valida parâmetros de paginação.
 func ValidatePagination(page, limit int) error {
 	if page < 1 {
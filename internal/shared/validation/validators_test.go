@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		wantErr  bool
+	}{
+		{name: "default accepts three classes", policy: DefaultPasswordPolicy(), password: "senha-forte1"},
+		{name: "default rejects two classes", policy: DefaultPasswordPolicy(), password: "senhafraca1", wantErr: true},
+		{name: "default rejects short", policy: DefaultPasswordPolicy(), password: "Ab1!", wantErr: true},
+		{name: "empty", policy: DefaultPasswordPolicy(), wantErr: true},
+		{name: "length counts runes", policy: PasswordPolicy{MinLength: 6}, password: "ãéîõüç"},
+		{name: "all four classes", policy: PasswordPolicy{MinLength: 8, RequiredClasses: 4}, password: "Senha-Forte1"},
+		{name: "missing fourth class", policy: PasswordPolicy{MinLength: 8, RequiredClasses: 4}, password: "SenhaForte1", wantErr: true},
+		{name: "classes above four are capped", policy: PasswordPolicy{MinLength: 8, RequiredClasses: 9}, password: "Senha-Forte1"},
+		{name: "no class requirement", policy: PasswordPolicy{MinLength: 8}, password: "aaaaaaaa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(tt.password); (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) = %v, want error %v", tt.password, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	tests := []struct {
+		email   string
+		wantErr bool
+	}{
+		// Válidos, ainda que incomuns
+		{email: "user@example.com"},
+		{email: "first.last+tag@example.com"},
+		{email: `"john doe"@example.com`},
+		{email: `"user@local"@example.com`},
+		{email: "o'brien@example.ie"},
+		{email: "user@mail.example.co.uk"},
+		{email: "user@example.photography"},
+		{email: "user_name-1@sub-domain.example.io"},
+		{email: strings.Repeat("a", 64) + "@example.com"},
+
+		// Inválidos
+		{email: "", wantErr: true},
+		{email: "plainaddress", wantErr: true},
+		{email: "@example.com", wantErr: true},
+		{email: "user@", wantErr: true},
+		{email: "user@@example.com", wantErr: true},
+		{email: "user@localhost", wantErr: true},
+		{email: "user@example", wantErr: true},
+		{email: "user@-example.com", wantErr: true},
+		{email: "user@example..com", wantErr: true},
+		{email: ".user@example.com", wantErr: true},
+		{email: "user.@example.com", wantErr: true},
+		{email: "us..er@example.com", wantErr: true},
+		{email: "user name@example.com", wantErr: true},
+		{email: "John <john@example.com>", wantErr: true},
+		{email: "<john@example.com>", wantErr: true},
+		{email: "john@example.com (comment)", wantErr: true},
+		{email: "user@[192.168.0.1]", wantErr: true},
+		{email: strings.Repeat("a", 65) + "@example.com", wantErr: true},
+		{email: "user@" + strings.Repeat("a", 250) + ".com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			if err := ValidateEmail(tt.email); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEmail(%q) = %v, want error %v", tt.email, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{email: "User@Example.COM", want: "user@example.com"},
+		{email: "  user@example.com\t", want: "user@example.com"},
+		{email: "user@example.com", want: "user@example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeEmail(tt.email); got != tt.want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+		}
+
+		// A forma normalizada continua válida
+		if err := ValidateEmail(NormalizeEmail(tt.email)); err != nil {
+			t.Errorf("ValidateEmail(NormalizeEmail(%q)) = %v", tt.email, err)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "  Ana   Maria  ", want: "Ana Maria"},
+		{name: "Ana\t\nMaria", want: "Ana Maria"},
+		{name: "Ana Maria", want: "Ana Maria"},
+		{name: "   ", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeName(tt.name); got != tt.want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}